@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/yourpwnguy/strix/internal/elf/model"
+	"github.com/yourpwnguy/strix/internal/elf/plt"
+	"github.com/yourpwnguy/strix/internal/elf/render"
+	"github.com/yourpwnguy/strix/pkg/elf"
+	"github.com/yourpwnguy/strix/pkg/elf/types"
+)
+
+// addrOffset makes the addr command treat its value as a file offset.
+var addrOffset bool
+
+// errRelocatableAddress is returned for address lookups in relocatable objects, whose
+// sections are not yet placed at addresses.
+var errRelocatableAddress = errors.New("relocatable objects have no virtual addresses, look up a file offset with --offset")
+
+// addrCmd translates between virtual addresses and file offsets and names what lives there.
+var addrCmd = &cobra.Command{
+	Use:   "addr [file] [value]",
+	Short: "Translate a virtual address (or file offset) and find its section, segment and symbol",
+	Example: `strix addr /bin/ls 0x61d0
+strix addr --offset /bin/ls 0x318
+strix addr /usr/lib/x86_64-linux-gnu/libc.so.6 0x29d90`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		value, err := strconv.ParseUint(args[1], 0, 64)
+		if err != nil {
+			fail(cmd, args[0], fmt.Errorf("invalid value %q, expected a decimal or 0x prefixed number", args[1]))
+			return
+		}
+
+		elfParser, err := openParser(args[0])
+		if err != nil {
+			fail(cmd, args[0], err)
+			return
+		}
+		defer elfParser.Close()
+
+		f, err := elfParser.File()
+		if err != nil {
+			fail(cmd, args[0], err)
+			return
+		}
+
+		syms, err := addrSymbols(elfParser)
+		if err != nil {
+			fail(cmd, args[0], err)
+			return
+		}
+
+		if addrOffset {
+			if value >= uint64(len(elfParser.Data())) {
+				fail(cmd, args[0], fmt.Errorf("offset %#x is past the end of the file (%#x bytes)", value, len(elfParser.Data())))
+				return
+			}
+			emit(cmd, args[0], model.NewOffsetLocation(value, len(elfParser.Data()), f.Segments, f.Sections, f.SectionNames(), syms), render.Options{})
+			return
+		}
+
+		if f.Header.E_type == types.ET_REL {
+			fail(cmd, args[0], errRelocatableAddress)
+			return
+		}
+
+		emit(cmd, args[0], model.NewAddressLocation(value, f.Segments, f.Sections, f.SectionNames(), syms), render.Options{})
+	},
+}
+
+func init() {
+	addrCmd.Flags().BoolVar(&addrOffset, "offset", false, "treat the value as a file offset and translate it to a virtual address")
+	addDemangleFlag(addrCmd)
+}
+
+// addrSymbols gathers the candidates for the nearest symbol: the static symbol table when
+// present, the dynamic one so that stripped binaries still resolve their exports, and the
+// name@plt symbols of the PLT stubs on machines whose stubs are decoded.
+func addrSymbols(p *elf.Parser) ([]types.Symbol, error) {
+	symtab, err := p.Symbols()
+	if err != nil {
+		return nil, err
+	}
+
+	dynsym, err := p.DynamicSymbols()
+	if err != nil {
+		return nil, err
+	}
+
+	stubs, err := plt.Analyze(p)
+	if err != nil && !errors.Is(err, plt.ErrUnsupported) {
+		return nil, err
+	}
+
+	syms := make([]types.Symbol, 0, len(symtab)+len(dynsym))
+	syms = append(syms, symtab...)
+	syms = append(syms, dynsym...)
+	if stubs != nil {
+		syms = append(syms, stubs.Symbols()...)
+	}
+	return syms, nil
+}
@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/yourpwnguy/strix/internal/elf/checksec"
+	"github.com/yourpwnguy/strix/internal/elf/model"
+	"github.com/yourpwnguy/strix/internal/elf/render"
+)
+
+// checksecVerbose switches checksec from the one line summary to the per check explanation.
+var checksecVerbose bool
+
+// checksecCmd reports the exploit mitigations of one or more ELF files.
+// Files that fail to parse are reported and skipped, the rest are still checked.
+var checksecCmd = &cobra.Command{
+	Use:   "checksec [file...]",
+	Short: "Report security mitigations (RELRO, NX, PIE, canary, FORTIFY, CET) of ELF files",
+	Example: `strix checksec /bin/ls /usr/bin/*
+strix checksec -v /usr/lib/x86_64-linux-gnu/libc.so.6`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		results := make([]*model.Envelope, 0, len(args))
+		for _, path := range args {
+			report, err := analyzeFile(path)
+			if err != nil {
+				results = append(results, model.NewErrorEnvelope(cmd.Name(), path, err))
+				exitCode = 1
+				continue
+			}
+			results = append(results, model.NewEnvelope(cmd.Name(), path, model.NewChecksec(report)))
+		}
+
+		output(render.Options{List: true, Verbose: checksecVerbose}, results...)
+	},
+}
+
+// analyzeFile opens a single file and runs the mitigation checks on it.
+func analyzeFile(path string) (*checksec.Report, error) {
+	elfParser, err := openParser(path)
+	if err != nil {
+		return nil, err
+	}
+	defer elfParser.Close()
+
+	return checksec.Analyze(elfParser)
+}
+
+func init() {
+	checksecCmd.Flags().BoolVarP(&checksecVerbose, "verbose", "v", false, "explain the result of every check")
+}
@@ -1 +1,32 @@
 package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/yourpwnguy/strix/internal/ui"
+	"github.com/yourpwnguy/strix/pkg/elf"
+)
+
+// errNoArgument is returned when the file argument is blank.
+var errNoArgument = errors.New("Provide an argument !")
+
+// openParser validates the file argument and memory maps the ELF file behind it.
+// The caller owns the returned parser and must Close it.
+func openParser(path string) (*elf.Parser, error) {
+	if strings.TrimSpace(path) == "" {
+		return nil, errNoArgument
+	}
+
+	return elf.Open(path)
+}
+
+// printError reports an error on stderr behind the usual [ERR] prefix.
+func printError(err error) {
+	fmt.Fprintf(os.Stderr, "%s %s\n",
+		ui.ErrPrefix,
+		ui.Red.Sprint(err),
+	)
+}
@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/yourpwnguy/strix/internal/elf/disasm"
+	"github.com/yourpwnguy/strix/internal/elf/model"
+	"github.com/yourpwnguy/strix/internal/elf/render"
+)
+
+// disasmOpts holds the code selection of the disasm command.
+var disasmOpts struct {
+	section string
+	symbol  string
+	addr    uint64
+	length  uint64
+	intel   bool
+}
+
+// disasmCmd disassembles the code of a file.
+var disasmCmd = &cobra.Command{
+	Use:   "disasm [file]",
+	Short: "Disassemble a section, a symbol or an address range (x86-64, AArch64, RV64)",
+	Long: `Disassemble every executable section, or the section, symbol or address range selected.
+Calls and jumps are labelled with the symbol or name@plt stub they land on, and RIP relative
+operands with the address they resolve to, the symbol or GOT slot found there and the string
+stored there in read-only data. On AArch64 and RISC-V, the addresses ADRP and AUIPC pairs
+build are labelled the same way. Instructions are colored by class: calls, jumps, returns,
+traps, stack operations, comparisons and nops. The decoder is picked from the machine of
+the file.
+
+On stripped binaries, code is split at the dynamic symbols that remain and named after its
+section otherwise. Without section headers, the executable PT_LOAD segments are decoded.`,
+	Example: `strix disasm /bin/ls
+strix disasm -s .plt.sec /bin/ls
+strix disasm --symbol main ./build/app
+strix disasm --addr 0x4a20 -n 64 --intel /bin/ls
+strix disasm --symbol main.main ./app-arm64`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		flags := cmd.Flags()
+		if flags.Changed("length") && !flags.Changed("addr") {
+			fail(cmd, args[0], fmt.Errorf("--length only applies to --addr"))
+			return
+		}
+
+		elfParser, err := openParser(args[0])
+		if err != nil {
+			fail(cmd, args[0], err)
+			return
+		}
+		defer elfParser.Close()
+
+		sel := disasm.Selection{
+			Section:   disasmOpts.section,
+			Symbol:    disasmOpts.symbol,
+			ByAddress: flags.Changed("addr"),
+			Address:   disasmOpts.addr,
+			Length:    disasmOpts.length,
+			Syntax:    disasm.SyntaxATT,
+		}
+		if disasmOpts.intel {
+			sel.Syntax = disasm.SyntaxIntel
+		}
+
+		report, err := disasm.Analyze(elfParser, sel)
+		if err != nil {
+			fail(cmd, args[0], err)
+			return
+		}
+
+		emit(cmd, args[0], model.NewDisassembly(report), render.Options{})
+	},
+}
+
+func init() {
+	flags := disasmCmd.Flags()
+	flags.StringVarP(&disasmOpts.section, "section", "s", "", "disassemble the section with this name")
+	flags.StringVar(&disasmOpts.symbol, "symbol", "", "disassemble the symbol with this name (name@plt for PLT stubs)")
+	flags.Uint64Var(&disasmOpts.addr, "addr", 0, "disassemble from this virtual address (to the end of its symbol or section without --length)")
+	flags.Uint64VarP(&disasmOpts.length, "length", "n", 0, "number of bytes to disassemble from --addr")
+	flags.BoolVar(&disasmOpts.intel, "intel", false, "use Intel syntax instead of AT&T (x86-64 only)")
+	disasmCmd.MarkFlagsMutuallyExclusive("section", "symbol", "addr")
+	addDemangleFlag(disasmCmd)
+}
@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/yourpwnguy/strix/internal/elf/model"
+	"github.com/yourpwnguy/strix/internal/elf/render"
+	"github.com/yourpwnguy/strix/pkg/elf/types"
+)
+
+// dynamicCmd displays the dynamic section with colored output.
+// The dynamic section drives the runtime linker (NEEDED libraries, RUNPATH, flags, tables).
+var dynamicCmd = &cobra.Command{
+	Use:     "dynamic [file]",
+	Short:   "Display the dynamic section from an ELF file",
+	Example: "strix dynamic /bin/ls",
+	Args:    cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		elfParser, err := openParser(args[0])
+		if err != nil {
+			fail(cmd, args[0], err)
+			return
+		}
+		defer elfParser.Close()
+
+		f, err := elfParser.File()
+		if err != nil {
+			fail(cmd, args[0], err)
+			return
+		}
+
+		entries, err := elfParser.DynamicEntries()
+		if err != nil {
+			fail(cmd, args[0], err)
+			return
+		}
+
+		if len(entries) == 0 {
+			fail(cmd, args[0], fmt.Errorf("no dynamic section in %s", args[0]))
+			return
+		}
+
+		// Location of the table, as the loader sees it
+		var offset uint64
+		if sh := f.SectionByType(types.SHT_DYNAMIC); sh != nil {
+			offset = sh.Sh_offset
+		}
+		for i := range f.Segments {
+			if f.Segments[i].P_type == types.PT_DYNAMIC {
+				offset = f.Segments[i].P_offset
+				break
+			}
+		}
+
+		emit(cmd, args[0], model.NewDynamic(offset, entries), render.Options{})
+	},
+}
@@ -1,15 +1,9 @@
 package cmd
 
 import (
-	"fmt"
-	"os"
-	"strings"
-
 	"github.com/spf13/cobra"
-	"github.com/yourpwnguy/strix/internal/elf/format"
-	"github.com/yourpwnguy/strix/internal/elf/parser"
-	"github.com/yourpwnguy/strix/internal/reader"
-	"github.com/yourpwnguy/strix/internal/ui"
+	"github.com/yourpwnguy/strix/internal/elf/model"
+	"github.com/yourpwnguy/strix/internal/elf/render"
 )
 
 // infoCmd displays ELF headers with colored output.
@@ -19,42 +13,20 @@ var infoCmd = &cobra.Command{
 	Example: "strix ehdr /bin/ls",
 	Args:    cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
-		if strings.TrimSpace(args[0]) == "" {
-			fmt.Fprintf(os.Stderr, "%s %s\n",
-				ui.ErrPrefix,
-				ui.Red.Sprint("Provide an argument !"),
-			)
-			return
-		}
-
-		elfParser := parser.NewParser(&reader.MmapReader{})
-
-		if err := elfParser.Load(args[0]); err != nil {
-			fmt.Fprintf(os.Stderr, "%s\n",
-				err,
-			)
+		elfParser, err := openParser(args[0])
+		if err != nil {
+			fail(cmd, args[0], err)
 			return
 		}
-
 		defer elfParser.Close()
 
-		// Getting initiliased ELF Header
-		hdr, err := elfParser.ELFHeader()
+		// Class-neutral view of the ELF file
+		f, err := elfParser.File()
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "%s\n",
-				err,
-			)
+			fail(cmd, args[0], err)
 			return
 		}
 
-		phdr, err := elfParser.ProgramHeaders()
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "%s\n",
-				err,
-			)
-		}
-
-		// Pretty Print the ELF Header
-		format.PrintELFHeader(hdr, phdr)
+		emit(cmd, args[0], model.NewHeader(f.Header, f.Segments, elfParser.Data()), render.Options{})
 	},
 }
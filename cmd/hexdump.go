@@ -0,0 +1,156 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/yourpwnguy/strix/internal/elf/model"
+	"github.com/yourpwnguy/strix/internal/elf/render"
+	"github.com/yourpwnguy/strix/pkg/elf"
+	"github.com/yourpwnguy/strix/pkg/elf/types"
+)
+
+// hexdumpOptions holds the range selection of the hexdump command.
+type hexdumpOptions struct {
+	section string
+	segment int
+	offset  uint64
+	addr    uint64
+	length  uint64
+	virtual bool
+}
+
+var hexdumpOpts hexdumpOptions
+
+// hexdumpCmd dumps the raw bytes of a section, a segment, a file range or an address range.
+// The bytes are read straight from the mapped file, nothing is copied.
+var hexdumpCmd = &cobra.Command{
+	Use:   "hexdump [file]",
+	Short: "Dump the raw bytes of a section, segment, file offset range or address range",
+	Example: `strix hexdump -s .rodata /bin/ls
+strix hexdump -p 1 /bin/ls
+strix hexdump --offset 0x318 -n 28 /bin/ls
+strix hexdump --addr 0x61d0 -n 64 -V /bin/ls`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		elfParser, err := openParser(args[0])
+		if err != nil {
+			fail(cmd, args[0], err)
+			return
+		}
+		defer elfParser.Close()
+
+		f, err := elfParser.File()
+		if err != nil {
+			fail(cmd, args[0], err)
+			return
+		}
+
+		dump, err := hexdumpOpts.dump(cmd, elfParser, f)
+		if err != nil {
+			fail(cmd, args[0], err)
+			return
+		}
+
+		emit(cmd, args[0], dump, render.Options{Virtual: hexdumpOpts.virtual})
+	},
+}
+
+func init() {
+	flags := hexdumpCmd.Flags()
+	flags.StringVarP(&hexdumpOpts.section, "section", "s", "", "dump the section with this name")
+	flags.IntVarP(&hexdumpOpts.segment, "segment", "p", 0, "dump the file contents of the segment at this index")
+	flags.Uint64Var(&hexdumpOpts.offset, "offset", 0, "dump from this file offset (to the end of the file without --length)")
+	flags.Uint64Var(&hexdumpOpts.addr, "addr", 0, "dump from this virtual address (to the end of its PT_LOAD segment without --length)")
+	flags.Uint64VarP(&hexdumpOpts.length, "length", "n", 0, "number of bytes to dump")
+	flags.BoolVarP(&hexdumpOpts.virtual, "virtual", "V", false, "label rows with virtual addresses instead of file offsets")
+	hexdumpCmd.MarkFlagsMutuallyExclusive("section", "segment", "offset", "addr")
+	hexdumpCmd.MarkFlagsOneRequired("section", "segment", "offset", "addr")
+}
+
+// dump resolves the selected range into file bytes. A length caps sections and segments,
+// and must stay inside the file (or the PT_LOAD segment, for addresses) otherwise.
+func (o *hexdumpOptions) dump(cmd *cobra.Command, p *elf.Parser, f *elf.File) (*model.HexDump, error) {
+	flags := cmd.Flags()
+
+	var source string
+	var offset, size uint64
+
+	switch {
+	case flags.Changed("section"):
+		sh := f.SectionByName(o.section)
+		if sh == nil {
+			return nil, fmt.Errorf("no section named %s", o.section)
+		}
+		if sh.Sh_type == types.SHT_NOBITS {
+			return nil, fmt.Errorf("section %s has no file contents (NOBITS)", o.section)
+		}
+
+		source = fmt.Sprintf("section '%s'", o.section)
+		offset, size = sh.Sh_offset, sh.Sh_size
+		if flags.Changed("length") {
+			size = min(size, o.length)
+		}
+
+	case flags.Changed("segment"):
+		if o.segment < 0 || o.segment >= len(f.Segments) {
+			return nil, fmt.Errorf("no segment %d, the file has %d", o.segment, len(f.Segments))
+		}
+		ph := &f.Segments[o.segment]
+
+		source = fmt.Sprintf("segment %d (%s)", o.segment, types.GetPType(ph.P_type))
+		offset, size = ph.P_offset, ph.P_filesz
+		if flags.Changed("length") {
+			size = min(size, o.length)
+		}
+
+	case flags.Changed("offset"):
+		source = "file range"
+		offset = o.offset
+		if offset <= uint64(len(p.Data())) {
+			size = uint64(len(p.Data())) - offset
+		}
+		if flags.Changed("length") {
+			size = o.length
+		}
+
+	case flags.Changed("addr"):
+		ph := loadSegment(f.Segments, o.addr)
+		if ph == nil {
+			return nil, fmt.Errorf("address %#x is not backed by the file contents of a PT_LOAD segment", o.addr)
+		}
+
+		source = "address range"
+		offset = ph.P_offset + (o.addr - ph.P_vaddr)
+		size = ph.P_filesz - (o.addr - ph.P_vaddr)
+		if flags.Changed("length") {
+			if o.length > size {
+				return nil, fmt.Errorf("range %#x-%#x runs past the file contents of its PT_LOAD segment", o.addr, o.addr+o.length)
+			}
+			size = o.length
+		}
+	}
+
+	data, err := p.Bytes(source, offset, size)
+	if err != nil {
+		return nil, err
+	}
+
+	address, mapped := p.OffsetToVaddr(offset)
+	if o.virtual && !mapped {
+		return nil, fmt.Errorf("%s is not mapped by a PT_LOAD segment, it has no virtual address", source)
+	}
+
+	return model.NewHexDump(source, offset, address, mapped, data), nil
+}
+
+// loadSegment returns the PT_LOAD segment whose file contents hold addr, or nil if there is none.
+func loadSegment(phdr []types.Elf64_Phdr, addr uint64) *types.Elf64_Phdr {
+	for i := range phdr {
+		ph := &phdr[i]
+		if ph.P_type == types.PT_LOAD && addr >= ph.P_vaddr && addr-ph.P_vaddr < ph.P_filesz {
+			return ph
+		}
+	}
+	return nil
+}
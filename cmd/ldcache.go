@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/yourpwnguy/strix/internal/elf/model"
+	"github.com/yourpwnguy/strix/internal/elf/render"
+	"github.com/yourpwnguy/strix/internal/ldcache"
+)
+
+// defaultLdCache is where the loader reads its cache from.
+const defaultLdCache = "/etc/ld.so.cache"
+
+// ldcacheOptions holds the filters of the ldcache command.
+type ldcacheOptions struct {
+	sysroot string
+	name    string
+	arch    string
+}
+
+var ldcacheOpts ldcacheOptions
+
+// ldcacheCmd lists the loader cache, like ldconfig -p but for any cache file.
+var ldcacheCmd = &cobra.Command{
+	Use:   "ldcache [cache file]",
+	Short: "Display the glibc loader cache (/etc/ld.so.cache)",
+	Long: `Display the library name to file mappings of a glibc loader cache, as written by
+ldconfig. Both the old ld.so-1.7.0 format and the glibc-ld.so.cache1.1 format are read,
+including entries in glibc-hwcaps subdirectories.`,
+	Example: `strix ldcache
+strix ldcache -n '^libssl'
+strix ldcache --arch x86-64 --sysroot /mnt/rootfs`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		path := filepath.Join(ldcacheOpts.sysroot, defaultLdCache)
+		if len(args) > 0 {
+			path = args[0]
+		}
+
+		name, err := regexp.Compile(ldcacheOpts.name)
+		if err != nil {
+			fail(cmd, path, err)
+			return
+		}
+
+		cache, err := ldcache.Open(path)
+		if err != nil {
+			fail(cmd, path, err)
+			return
+		}
+
+		keep := func(e *ldcache.Entry) bool {
+			if !name.MatchString(e.Name) {
+				return false
+			}
+			switch arch := ldcacheOpts.arch; {
+			case arch == "":
+				return true
+			case strings.EqualFold(arch, "none"):
+				return ldcache.ArchName(e.Flags) == ""
+			default:
+				return strings.EqualFold(arch, ldcache.ArchName(e.Flags))
+			}
+		}
+
+		emit(cmd, path, model.NewLoaderCache(cache, keep), render.Options{})
+	},
+}
+
+func init() {
+	flags := ldcacheCmd.Flags()
+	flags.StringVar(&ldcacheOpts.sysroot, "sysroot", "", "read the cache of this root filesystem")
+	flags.StringVarP(&ldcacheOpts.name, "name", "n", "", "only show libraries whose name matches this regular expression")
+	flags.StringVar(&ldcacheOpts.arch, "arch", "", "only show entries for this architecture flag (e.g. x86-64, AArch64, hard-float, none)")
+}
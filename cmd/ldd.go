@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/yourpwnguy/strix/internal/elf/ldd"
+	"github.com/yourpwnguy/strix/internal/elf/model"
+	"github.com/yourpwnguy/strix/internal/elf/render"
+	"github.com/yourpwnguy/strix/internal/ldcache"
+)
+
+// lddOptions holds the filesystem and environment the ldd command emulates.
+type lddOptions struct {
+	sysroot     string
+	libraryPath string
+	cache       string
+	noCache     bool
+}
+
+var lddOpts lddOptions
+
+// lddCmd resolves the shared library dependencies of a file the way the loader would,
+// without running it. Missing libraries make strix exit with status 1.
+var lddCmd = &cobra.Command{
+	Use:   "ldd [file]",
+	Short: "Resolve shared library dependencies without running the file",
+	Long: `Resolve the DT_NEEDED libraries of a file recursively, following the search order of the
+glibc loader: DT_RPATH (when there is no DT_RUNPATH), LD_LIBRARY_PATH, DT_RUNPATH,
+/etc/ld.so.cache, then the default directories. $ORIGIN, $LIB and $PLATFORM are expanded,
+and libraries built for another class or machine are skipped. The file is never executed,
+so this is safe on untrusted binaries.
+
+strix exits with status 1 when a library cannot be found.`,
+	Example: `strix ldd /bin/ls
+strix ldd --sysroot /mnt/rootfs /mnt/rootfs/usr/bin/ssh
+strix ldd --library-path ./build/lib ./build/app`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		libraryPath := lddOpts.libraryPath
+		if !cmd.Flags().Changed("library-path") && lddOpts.sysroot == "" {
+			libraryPath = os.Getenv("LD_LIBRARY_PATH")
+		}
+
+		resolver := &ldd.Resolver{
+			Sysroot:     lddOpts.sysroot,
+			LibraryPath: libraryPath,
+		}
+
+		cache, err := lddOpts.loadCache(cmd.Flags().Changed("cache"))
+		if err != nil {
+			fail(cmd, args[0], err)
+			return
+		}
+		if cache != nil {
+			resolver.Cache = cache
+		}
+
+		report, err := resolver.Resolve(args[0])
+		if err != nil {
+			fail(cmd, args[0], err)
+			return
+		}
+
+		if report.Missing > 0 {
+			exitCode = 1
+		}
+		emit(cmd, args[0], model.NewDependencies(report, lddOpts.sysroot), render.Options{})
+	},
+}
+
+func init() {
+	flags := lddCmd.Flags()
+	flags.StringVar(&lddOpts.sysroot, "sysroot", "", "resolve libraries inside this root filesystem")
+	flags.StringVar(&lddOpts.libraryPath, "library-path", "", "LD_LIBRARY_PATH to emulate (defaults to the environment without --sysroot)")
+	flags.StringVar(&lddOpts.cache, "cache", "", "loader cache to use instead of /etc/ld.so.cache in the sysroot")
+	flags.BoolVar(&lddOpts.noCache, "no-cache", false, "skip the loader cache step of the search")
+	lddCmd.MarkFlagsMutuallyExclusive("cache", "no-cache")
+}
+
+// loadCache opens the loader cache the search goes through. The default cache may be
+// missing, the loader then skips that step, while a cache given with --cache must exist.
+func (o *lddOptions) loadCache(explicit bool) (*ldcache.Cache, error) {
+	if o.noCache {
+		return nil, nil
+	}
+
+	path := o.cache
+	if !explicit {
+		path = filepath.Join(o.sysroot, defaultLdCache)
+	}
+
+	cache, err := ldcache.Open(path)
+	if err != nil && !explicit && errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	return cache, err
+}
@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"errors"
+
+	"github.com/spf13/cobra"
+	"github.com/yourpwnguy/strix/internal/elf/model"
+	"github.com/yourpwnguy/strix/internal/elf/render"
+	"github.com/yourpwnguy/strix/internal/elf/symhash"
+)
+
+// lookupCmd replays the hash table lookup of a symbol and reports how well the hash tables
+// spread their symbols. A symbol that does not resolve makes strix exit with status 1.
+var lookupCmd = &cobra.Command{
+	Use:   "lookup [file] [symbol]",
+	Short: "Look a symbol up through DT_GNU_HASH and DT_HASH like the dynamic linker",
+	Long: `Look a dynamic symbol up the way the dynamic linker does: the GNU hash bloom filter, the
+bucket, then the chain until a symbol matches by name, type, binding and version. Both
+DT_GNU_HASH and DT_HASH are searched, the loader only uses DT_GNU_HASH when both exist.
+
+A version is given as name@VERSION and behaves like dlvsym, a plain name like dlsym, which
+takes the default version. Without a symbol only the bucket occupancy of each table is shown,
+long chains slow down every lookup.
+
+strix exits with status 1 when the symbol is not found.`,
+	Example: `strix lookup /usr/lib/x86_64-linux-gnu/libc.so.6 printf
+strix lookup /usr/lib/x86_64-linux-gnu/libc.so.6 memcpy@GLIBC_2.2.5
+strix lookup ./build/libfoo.so`,
+	Args: cobra.RangeArgs(1, 2),
+	Run: func(cmd *cobra.Command, args []string) {
+		symbol := ""
+		if len(args) == 2 {
+			symbol = args[1]
+			if name, _ := symhash.SplitName(symbol); name == "" {
+				fail(cmd, args[0], errors.New("empty symbol name"))
+				exitCode = 2
+				return
+			}
+		}
+
+		elfParser, err := openParser(args[0])
+		if err != nil {
+			fail(cmd, args[0], err)
+			return
+		}
+		defer elfParser.Close()
+
+		report, err := symhash.Analyze(elfParser, symbol)
+		if err != nil {
+			fail(cmd, args[0], err)
+			return
+		}
+
+		view := model.NewSymbolLookup(report)
+		if symbol != "" && !view.Found {
+			exitCode = 1
+		}
+		emit(cmd, args[0], view, render.Options{})
+	},
+}
+
+func init() {
+	addDemangleFlag(lookupCmd)
+}
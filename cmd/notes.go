@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/yourpwnguy/strix/internal/elf/model"
+	"github.com/yourpwnguy/strix/internal/elf/render"
+)
+
+// notesCmd displays the notes from SHT_NOTE sections and PT_NOTE segments with colored output.
+// This covers build IDs, ABI tags, GNU properties, package metadata and core file notes.
+var notesCmd = &cobra.Command{
+	Use:   "notes [file]",
+	Short: "Display notes (build ID, ABI tag, GNU properties, core notes) from an ELF file",
+	Example: `strix notes /bin/ls
+strix notes core.1234`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		elfParser, err := openParser(args[0])
+		if err != nil {
+			fail(cmd, args[0], err)
+			return
+		}
+		defer elfParser.Close()
+
+		ehdr, err := elfParser.ELFHeader()
+		if err != nil {
+			fail(cmd, args[0], err)
+			return
+		}
+
+		notes, err := elfParser.Notes()
+		if err != nil {
+			fail(cmd, args[0], err)
+			return
+		}
+
+		if len(notes) == 0 {
+			fail(cmd, args[0], fmt.Errorf("no notes in %s", args[0]))
+			return
+		}
+
+		emit(cmd, args[0], model.NewNotes(ehdr, notes), render.Options{})
+	},
+}
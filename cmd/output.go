@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/yourpwnguy/strix/internal/demangle"
+	"github.com/yourpwnguy/strix/internal/elf/model"
+	"github.com/yourpwnguy/strix/internal/elf/render"
+)
+
+// outputFormat is the value of the global --output flag.
+var outputFormat string
+
+// demangleNames is the value of the --demangle flag of the commands that print symbol names.
+var demangleNames bool
+
+// addDemangleFlag registers --demangle on a command that prints symbol names.
+func addDemangleFlag(cmd *cobra.Command) {
+	cmd.Flags().BoolVarP(&demangleNames, "demangle", "C", false, "demangle C++ and Rust symbol names")
+}
+
+// symbolName is the form of a symbol name the user asked for.
+func symbolName(name string) string {
+	if demangleNames {
+		return demangle.Name(name)
+	}
+	return name
+}
+
+// validateOutput rejects unknown --output values before any command runs.
+func validateOutput(cmd *cobra.Command, args []string) error {
+	_, err := render.New(outputFormat, render.Options{})
+	return err
+}
+
+// output renders the results of a command, one envelope per input file.
+// Views with symbol names are demangled first when --demangle is set.
+func output(opts render.Options, results ...*model.Envelope) {
+	if demangleNames {
+		for _, env := range results {
+			if d, ok := env.Data.(model.Demangler); ok {
+				d.Demangle(demangle.Name)
+			}
+		}
+	}
+
+	r, err := render.New(outputFormat, opts)
+	if err != nil {
+		printError(err)
+		exitCode = max(exitCode, 1)
+		return
+	}
+
+	if err := r.Render(os.Stdout, results); err != nil {
+		printError(err)
+		exitCode = max(exitCode, 1)
+	}
+}
+
+// emit renders the view cmd built for a single file.
+func emit(cmd *cobra.Command, path string, data any, opts render.Options) {
+	output(opts, model.NewEnvelope(cmd.Name(), path, data))
+}
+
+// fail renders the failure of cmd on a single file and makes strix exit with status 1, or
+// with the higher status the command already set.
+func fail(cmd *cobra.Command, path string, err error) {
+	exitCode = max(exitCode, 1)
+	output(render.Options{}, model.NewErrorEnvelope(cmd.Name(), path, err))
+}
@@ -1,15 +1,9 @@
 package cmd
 
 import (
-	"fmt"
-	"os"
-	"strings"
-
 	"github.com/spf13/cobra"
-	"github.com/yourpwnguy/strix/internal/elf/format"
-	"github.com/yourpwnguy/strix/internal/elf/parser"
-	"github.com/yourpwnguy/strix/internal/reader"
-	"github.com/yourpwnguy/strix/internal/ui"
+	"github.com/yourpwnguy/strix/internal/elf/model"
+	"github.com/yourpwnguy/strix/internal/elf/render"
 )
 
 // phdrCmd displays ELF program headers with colored output.
@@ -20,38 +14,20 @@ var phdrCmd = &cobra.Command{
 	Example: "strix phdr /bin/ls",
 	Args:    cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
-		if strings.TrimSpace(args[0]) == "" {
-			fmt.Fprintf(os.Stderr, "%s %s\n",
-				ui.ErrPrefix,
-				ui.Red.Sprint("Provide an argument !"),
-			)
-			return
-		}
-
-		elfParser := parser.NewParser(&reader.MmapReader{})
-
-		if err := elfParser.Load(args[0]); err != nil {
-			fmt.Fprintf(os.Stderr, "%s %s\n",
-				ui.ErrPrefix,
-				err,
-			)
-			return
-		}
-		defer elfParser.Close()
-
-		ehdr, err := elfParser.ELFHeader()
+		elfParser, err := openParser(args[0])
 		if err != nil {
-			fmt.Fprint(os.Stderr, err)
+			fail(cmd, args[0], err)
 			return
 		}
+		defer elfParser.Close()
 
-		phdr, err := elfParser.ProgramHeaders()
+		// Class-neutral view of the ELF file
+		f, err := elfParser.File()
 		if err != nil {
-			fmt.Fprint(os.Stderr, err)
+			fail(cmd, args[0], err)
 			return
 		}
 
-		// Pretty Print the Program Headers
-		format.PrintProgramHeaders(ehdr, phdr, elfParser.Data())
+		emit(cmd, args[0], model.NewProgramHeaders(f.Header, f.Segments, f.Sections, f.SectionNames(), elfParser.Data()), render.Options{})
 	},
 }
@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+	"github.com/yourpwnguy/strix/internal/elf/model"
+	"github.com/yourpwnguy/strix/internal/elf/plt"
+	"github.com/yourpwnguy/strix/internal/elf/render"
+)
+
+// pltCmd maps the PLT stubs of a file to the functions they call.
+var pltCmd = &cobra.Command{
+	Use:   "plt [file]",
+	Short: "Map PLT stubs (.plt, .plt.sec, .plt.got) to the imported functions they call",
+	Long: `Decode the stubs of .plt, .plt.sec, .plt.bnd and .plt.got and name each one after the
+function bound to the GOT slot it jumps through (JUMP_SLOT, GLOB_DAT or IRELATIVE), so that
+a call to 0x401030 reads as puts@plt. x86-64 stubs are decoded with and without IBT
+(endbr64) and MPX (bnd) prefixes, and AArch64 stubs with and without BTI.
+
+The same name@plt symbols label addresses in the addr command.`,
+	Example: `strix plt /bin/ls
+strix plt -o csv ./build/app`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		elfParser, err := openParser(args[0])
+		if err != nil {
+			fail(cmd, args[0], err)
+			return
+		}
+		defer elfParser.Close()
+
+		report, err := plt.Analyze(elfParser)
+		if err != nil {
+			fail(cmd, args[0], err)
+			return
+		}
+
+		emit(cmd, args[0], model.NewPLT(report), render.Options{})
+	},
+}
+
+func init() {
+	addDemangleFlag(pltCmd)
+}
@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/yourpwnguy/strix/internal/elf/model"
+	"github.com/yourpwnguy/strix/internal/elf/render"
+)
+
+// showRelocTarget adds the computed target address column to the relocs output.
+var showRelocTarget bool
+
+// relocsCmd displays every relocation table with colored output.
+var relocsCmd = &cobra.Command{
+	Use:   "relocs [file]",
+	Short: "Display relocation tables (REL, RELA and RELR) from an ELF file",
+	Example: `strix relocs /bin/ls
+strix relocs --target /usr/lib/x86_64-linux-gnu/libc.so.6`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		elfParser, err := openParser(args[0])
+		if err != nil {
+			fail(cmd, args[0], err)
+			return
+		}
+		defer elfParser.Close()
+
+		f, err := elfParser.File()
+		if err != nil {
+			fail(cmd, args[0], err)
+			return
+		}
+
+		tables, err := elfParser.Relocations()
+		if err != nil {
+			fail(cmd, args[0], err)
+			return
+		}
+
+		if len(tables) == 0 {
+			fail(cmd, args[0], fmt.Errorf("no relocations in %s", args[0]))
+			return
+		}
+
+		emit(cmd, args[0], model.NewRelocations(f.Header, tables, f.Sections, f.SectionNames()), render.Options{Targets: showRelocTarget})
+	},
+}
+
+func init() {
+	relocsCmd.Flags().BoolVarP(&showRelocTarget, "target", "t", false, "show the address each relocation resolves to")
+	addDemangleFlag(relocsCmd)
+}
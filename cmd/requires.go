@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/yourpwnguy/strix/internal/elf/model"
+	"github.com/yourpwnguy/strix/internal/elf/render"
+	"github.com/yourpwnguy/strix/internal/elf/requires"
+)
+
+// requiresOptions holds the version limits of the requires command.
+type requiresOptions struct {
+	maxGlibc string
+	max      []string
+}
+
+var requiresOpts requiresOptions
+
+// requiresCmd reports the newest symbol versions each file needs from its libraries.
+// Files that fail to parse are reported and skipped, the rest are still checked.
+var requiresCmd = &cobra.Command{
+	Use:   "requires [file...]",
+	Short: "Report the newest symbol versions (GLIBC, GLIBCXX, ...) required from each library",
+	Long: `Report the newest symbol version each file requires from each of its libraries,
+along with the symbols that need it. This decides the oldest distribution a binary runs on.
+
+With --max-glibc or --max, strix exits with status 1 when a file requires a newer version
+than allowed.`,
+	Example: `strix requires /usr/bin/*
+strix requires --max-glibc 2.28 ./build/app
+strix requires --max GLIBCXX=3.4.22 --max CXXABI=1.3.9 ./build/app`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		limits, err := requiresOpts.limits()
+		if err != nil {
+			printError(err)
+			exitCode = 2
+			return
+		}
+
+		results := make([]*model.Envelope, 0, len(args))
+		for _, path := range args {
+			report, err := analyzeRequires(path)
+			if err != nil {
+				results = append(results, model.NewErrorEnvelope(cmd.Name(), path, err))
+				exitCode = max(exitCode, 1)
+				continue
+			}
+
+			view := model.NewRequires(report, limits)
+			if view.Exceeded {
+				exitCode = max(exitCode, 1)
+			}
+			results = append(results, model.NewEnvelope(cmd.Name(), path, view))
+		}
+
+		output(render.Options{List: true}, results...)
+	},
+}
+
+// analyzeRequires opens a single file and computes its version requirements.
+func analyzeRequires(path string) (*requires.Report, error) {
+	elfParser, err := openParser(path)
+	if err != nil {
+		return nil, err
+	}
+	defer elfParser.Close()
+
+	return requires.Analyze(elfParser)
+}
+
+func init() {
+	flags := requiresCmd.Flags()
+	flags.StringVar(&requiresOpts.maxGlibc, "max-glibc", "", "fail if a file requires a GLIBC version newer than this (e.g. 2.28)")
+	flags.StringSliceVar(&requiresOpts.max, "max", nil, "fail if a file requires a version newer than FAMILY=VERSION (e.g. GLIBCXX=3.4.22)")
+	addDemangleFlag(requiresCmd)
+}
+
+// limits collects the version limits by family.
+func (o *requiresOptions) limits() (map[string]string, error) {
+	limits := make(map[string]string)
+
+	if o.maxGlibc != "" {
+		limits["GLIBC"] = o.maxGlibc
+	}
+
+	for _, limit := range o.max {
+		family, version, ok := strings.Cut(limit, "=")
+		if !ok || family == "" {
+			return nil, fmt.Errorf("invalid --max %q, expected FAMILY=VERSION", limit)
+		}
+		limits[strings.TrimSpace(family)] = strings.TrimSpace(version)
+	}
+
+	for family, version := range limits {
+		if !requires.ValidVersion(version) {
+			return nil, fmt.Errorf("invalid %s version limit %q, expected a dotted version such as 2.28", family, version)
+		}
+	}
+
+	return limits, nil
+}
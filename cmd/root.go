@@ -1,17 +1,60 @@
 package cmd
 
-import "github.com/spf13/cobra"
+import (
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/yourpwnguy/strix/internal/elf/render"
+)
 
 var rootCmd = &cobra.Command{
 	Use:   "strix",
 	Short: "A better way to parse ELF files",
+
+	PersistentPreRunE: validateOutput,
 }
 
+// exitCode is the status strix exits with once the command has run. Commands that check
+// a condition, like requires with a version limit, raise it rather than exiting themselves,
+// and so does every failure reported through fail.
+var exitCode int
+
 func Execute() {
-	rootCmd.Execute()
+	os.Exit(run(os.Args[1:]))
+}
+
+// run executes a command line and returns the status to exit with. The errors cobra reports
+// itself, an unknown flag or a missing argument, and those of validateOutput exit with 1.
+func run(args []string) int {
+	exitCode = 0
+	rootCmd.SetArgs(args)
+	if err := rootCmd.Execute(); err != nil {
+		exitCode = max(exitCode, 1)
+	}
+	return exitCode
 }
 
 func init() {
+	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", render.Formats[0], "output format ("+strings.Join(render.Formats, ", ")+")")
+
 	rootCmd.AddCommand(infoCmd)
 	rootCmd.AddCommand(phdrCmd)
+	rootCmd.AddCommand(shdrCmd)
+	rootCmd.AddCommand(symsCmd)
+	rootCmd.AddCommand(dynamicCmd)
+	rootCmd.AddCommand(relocsCmd)
+	rootCmd.AddCommand(notesCmd)
+	rootCmd.AddCommand(checksecCmd)
+	rootCmd.AddCommand(hexdumpCmd)
+	rootCmd.AddCommand(addrCmd)
+	rootCmd.AddCommand(strtabCmd)
+	rootCmd.AddCommand(stringsCmd)
+	rootCmd.AddCommand(versionsCmd)
+	rootCmd.AddCommand(requiresCmd)
+	rootCmd.AddCommand(lddCmd)
+	rootCmd.AddCommand(ldcacheCmd)
+	rootCmd.AddCommand(lookupCmd)
+	rootCmd.AddCommand(pltCmd)
+	rootCmd.AddCommand(disasmCmd)
 }
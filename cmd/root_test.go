@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// TestExitStatus runs command lines in process and checks the status strix would exit
+// with. Every case sets --output, which would otherwise carry over from the previous one.
+func TestExitStatus(t *testing.T) {
+	self, err := os.Executable()
+	if err != nil {
+		t.Skip(err)
+	}
+
+	dir := t.TempDir()
+	malformed := filepath.Join(dir, "malformed")
+	if err := os.WriteFile(malformed, []byte("\x7fELF\x02\x01\x01"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	missing := filepath.Join(dir, "missing")
+
+	// The output is not checked, keep it out of the test log
+	devnull, err := os.OpenFile(os.DevNull, os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer devnull.Close()
+	stdout, stderr := os.Stdout, os.Stderr
+	os.Stdout, os.Stderr = devnull, devnull
+	defer func() { os.Stdout, os.Stderr = stdout, stderr }()
+	rootCmd.SetOut(io.Discard)
+	rootCmd.SetErr(io.Discard)
+	defer rootCmd.SetOut(nil)
+	defer rootCmd.SetErr(nil)
+
+	tests := []struct {
+		name string
+		args []string
+		want int
+	}{
+		{"success", []string{"ehdr", "-o", "json", self}, 0},
+		{"missing file", []string{"ehdr", "-o", "json", missing}, 1},
+		{"malformed file", []string{"phdr", "-o", "json", malformed}, 1},
+		{"unknown output format", []string{"ehdr", "-o", "bogus", self}, 1},
+		{"missing argument", []string{"ehdr", "-o", "json"}, 1},
+		{"unknown flag", []string{"ehdr", "-o", "json", "--bogus", self}, 1},
+		{"one of several files fails", []string{"checksec", "-o", "json", self, missing}, 1},
+		{"invalid usage", []string{"lookup", "-o", "json", self, "@VERS"}, 2},
+		// Last, --type keeps its value for the syms cases that would follow
+		{"unknown symbol type", []string{"syms", "-o", "json", "-t", "bogus", self}, 1},
+	}
+
+	for _, tt := range tests {
+		if got := run(tt.args); got != tt.want {
+			t.Errorf("%s: strix %v exits with %d, want %d", tt.name, tt.args, got, tt.want)
+		}
+	}
+}
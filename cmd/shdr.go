@@ -1,56 +1,34 @@
 package cmd
 
 import (
-	"fmt"
-	"os"
-	"strings"
-
 	"github.com/spf13/cobra"
-	"github.com/yourpwnguy/strix/internal/elf/parser"
-	"github.com/yourpwnguy/strix/internal/reader"
-	"github.com/yourpwnguy/strix/internal/ui"
+	"github.com/yourpwnguy/strix/internal/elf/model"
+	"github.com/yourpwnguy/strix/internal/elf/render"
 )
 
+// shdrCmd displays ELF section headers with colored output.
+// Section headers describe the link-time view of the file (.text, .data, .symtab, etc).
 var shdrCmd = &cobra.Command{
-	Use:   "shdr [file]",
-	Short: "Display section headers from an ELF file",
-	Long:  "Parse and display the section headers from a given ELF file in a structured and colorized format.",
+	Use:     "shdr [file]",
+	Short:   "Display section headers from an ELF file",
+	Long:    "Parse and display the section headers from a given ELF file in a structured and colorized format.",
+	Example: "strix shdr /bin/ls",
+	Args:    cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
-		if strings.TrimSpace(args[0]) == "" {
-			fmt.Fprintf(os.Stderr, "%s %s\n",
-				ui.ErrPrefix,
-				ui.Red.Sprint("Provide an argument !"),
-			)
-			return
-		}
-
-		elfParser := parser.NewParser(&reader.MmapReader{})
-
-		if err := elfParser.Load(args[0]); err != nil {
-			fmt.Fprintf(os.Stderr, "%s %s\n",
-				ui.ErrPrefix,
-				err,
-			)
+		elfParser, err := openParser(args[0])
+		if err != nil {
+			fail(cmd, args[0], err)
 			return
 		}
 		defer elfParser.Close()
 
-		fmt.Printf("%+v", elfParser)
-		// Getting initiliased ELF Header
-		_, err := elfParser.ELFHeader()
+		// Class-neutral view of the ELF file
+		f, err := elfParser.File()
 		if err != nil {
-			fmt.Fprint(os.Stderr, err)
+			fail(cmd, args[0], err)
 			return
 		}
 
-		// phdr, err := elfParser.ProgramHeaders()
-		// if err != nil {
-		// 	fmt.Fprint(os.Stderr, err)
-		// 	return
-		// }
-
-		fmt.Printf("%+v", elfParser)
-		// Pretty Print the ELF Header
-		// format.PrintELFHeader(hdr, phd)
+		emit(cmd, args[0], model.NewSectionHeaders(f.Header, f.Sections, f.SectionNames()), render.Options{})
 	},
 }
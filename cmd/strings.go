@@ -0,0 +1,186 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/spf13/cobra"
+	"github.com/yourpwnguy/strix/internal/elf/model"
+	"github.com/yourpwnguy/strix/internal/elf/render"
+	"github.com/yourpwnguy/strix/internal/elf/strscan"
+	"github.com/yourpwnguy/strix/pkg/elf"
+	"github.com/yourpwnguy/strix/pkg/elf/types"
+)
+
+// stringsOptions holds the region and encoding selection of the strings command.
+type stringsOptions struct {
+	sections  []string
+	segments  []int
+	all       bool
+	minLen    int
+	encodings []string
+}
+
+var stringsOpts stringsOptions
+
+// stringsCmd extracts printable strings, attributing each one to its section and address.
+var stringsCmd = &cobra.Command{
+	Use:   "strings [file]",
+	Short: "Extract printable strings with their section and virtual address",
+	Long: `Extract printable strings from the sections or segments of an ELF file.
+
+By default every allocated section with file contents is scanned, or the PT_LOAD
+segments when the file has no section headers. Each string is reported with its file
+offset, the virtual address it is loaded at and the section holding it.`,
+	Example: `strix strings /bin/ls
+strix strings -s .rodata -n 8 /bin/ls
+strix strings -a -e utf8,utf16le /usr/lib/x86_64-linux-gnu/libc.so.6
+strix strings -p 3 /bin/ls`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		encodings, err := stringsOpts.parseEncodings()
+		if err != nil {
+			fail(cmd, args[0], err)
+			return
+		}
+
+		if stringsOpts.minLen < 1 {
+			fail(cmd, args[0], fmt.Errorf("invalid minimum length %d", stringsOpts.minLen))
+			return
+		}
+
+		elfParser, err := openParser(args[0])
+		if err != nil {
+			fail(cmd, args[0], err)
+			return
+		}
+		defer elfParser.Close()
+
+		f, err := elfParser.File()
+		if err != nil {
+			fail(cmd, args[0], err)
+			return
+		}
+
+		regions, err := stringsOpts.regions(f)
+		if err != nil {
+			fail(cmd, args[0], err)
+			return
+		}
+
+		found, err := strscan.ScanFile(elfParser, f, regions, stringsOpts.minLen, encodings)
+		if err != nil {
+			fail(cmd, args[0], err)
+			return
+		}
+
+		out := &model.Strings{MinLength: stringsOpts.minLen, Regions: []string{}, Strings: []model.FoundString{}}
+		for _, region := range regions {
+			out.Regions = append(out.Regions, region.Name)
+		}
+		for _, s := range found {
+			out.Strings = append(out.Strings, foundString(s))
+		}
+
+		emit(cmd, args[0], out, render.Options{})
+	},
+}
+
+func init() {
+	flags := stringsCmd.Flags()
+	flags.StringSliceVarP(&stringsOpts.sections, "section", "s", nil, "only scan these sections")
+	flags.IntSliceVarP(&stringsOpts.segments, "segment", "p", nil, "only scan the file contents of the segments at these indices")
+	flags.BoolVarP(&stringsOpts.all, "all", "a", false, "scan every section with file contents, not only the allocated ones")
+	flags.IntVarP(&stringsOpts.minLen, "min-len", "n", 4, "minimum number of characters in a string")
+	flags.StringSliceVarP(&stringsOpts.encodings, "encoding", "e", []string{"ascii"}, "encodings to look for (ascii, utf8, utf16le)")
+	stringsCmd.MarkFlagsMutuallyExclusive("section", "segment", "all")
+}
+
+// parseEncodings resolves the --encoding values. UTF-8 already covers ASCII, so asking for
+// both only scans once.
+func (o *stringsOptions) parseEncodings() ([]strscan.Encoding, error) {
+	var out []strscan.Encoding
+	for _, name := range o.encodings {
+		enc, err := strscan.ParseEncoding(name)
+		if err != nil {
+			return nil, err
+		}
+		if !slices.Contains(out, enc) {
+			out = append(out, enc)
+		}
+	}
+
+	if slices.Contains(out, strscan.UTF8) {
+		out = slices.DeleteFunc(out, func(enc strscan.Encoding) bool { return enc == strscan.ASCII })
+	}
+
+	return out, nil
+}
+
+// regions lists the file ranges to scan.
+func (o *stringsOptions) regions(f *elf.File) ([]strscan.Region, error) {
+	var out []strscan.Region
+
+	switch {
+	case len(o.sections) > 0:
+		for _, name := range o.sections {
+			sh := f.SectionByName(name)
+			if sh == nil {
+				return nil, fmt.Errorf("no section named %s", name)
+			}
+			if sh.Sh_type == types.SHT_NOBITS {
+				return nil, fmt.Errorf("section %s has no file contents (NOBITS)", name)
+			}
+			out = append(out, strscan.Region{Name: name, Offset: sh.Sh_offset, Size: sh.Sh_size})
+		}
+
+	case len(o.segments) > 0:
+		for _, idx := range o.segments {
+			if idx < 0 || idx >= len(f.Segments) {
+				return nil, fmt.Errorf("no segment %d, the file has %d", idx, len(f.Segments))
+			}
+			ph := &f.Segments[idx]
+			out = append(out, strscan.Region{Name: fmt.Sprintf("segment %d (%s)", idx, types.GetPType(ph.P_type)), Offset: ph.P_offset, Size: ph.P_filesz})
+		}
+
+	case len(f.Sections) > 0:
+		for i := range f.Sections {
+			sh := &f.Sections[i]
+			if sh.Sh_type == types.SHT_NULL || sh.Sh_type == types.SHT_NOBITS || sh.Sh_size == 0 {
+				continue
+			}
+			if !o.all && sh.Sh_flags&types.SHF_ALLOC == 0 {
+				continue
+			}
+			out = append(out, strscan.Region{Name: f.SectionName(sh), Offset: sh.Sh_offset, Size: sh.Sh_size})
+		}
+
+	default:
+		for i := range f.Segments {
+			ph := &f.Segments[i]
+			if ph.P_type == types.PT_LOAD {
+				out = append(out, strscan.Region{Name: fmt.Sprintf("segment %d (%s)", i, types.GetPType(ph.P_type)), Offset: ph.P_offset, Size: ph.P_filesz})
+			}
+		}
+	}
+
+	if len(out) == 0 {
+		return nil, fmt.Errorf("nothing to scan, no sections or PT_LOAD segments with file contents")
+	}
+
+	return out, nil
+}
+
+// foundString converts a string placed in the file.
+func foundString(s strscan.Located) model.FoundString {
+	out := model.FoundString{
+		Offset:   s.Offset,
+		Section:  s.Section,
+		Encoding: s.Encoding.String(),
+		Value:    s.Value,
+	}
+	if s.Mapped {
+		out.Address = &s.Address
+	}
+	return out
+}
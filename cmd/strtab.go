@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/yourpwnguy/strix/internal/elf/model"
+	"github.com/yourpwnguy/strix/internal/elf/render"
+	"github.com/yourpwnguy/strix/pkg/elf/types"
+)
+
+// strtabSections limits the strtab command to the named sections.
+var strtabSections []string
+
+// strtabCmd lists the entries of the string tables with their byte index.
+var strtabCmd = &cobra.Command{
+	Use:   "strtab [file]",
+	Short: "Dump the entries of string tables (.strtab, .dynstr, .shstrtab) from an ELF file",
+	Example: `strix strtab /bin/ls
+strix strtab -s .dynstr /bin/ls
+strix strtab -s .comment /bin/ls`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		elfParser, err := openParser(args[0])
+		if err != nil {
+			fail(cmd, args[0], err)
+			return
+		}
+		defer elfParser.Close()
+
+		f, err := elfParser.File()
+		if err != nil {
+			fail(cmd, args[0], err)
+			return
+		}
+
+		// Every SHT_STRTAB section, or the named ones in the order given
+		var indices []int
+		if len(strtabSections) == 0 {
+			for i := range f.Sections {
+				if f.Sections[i].Sh_type == types.SHT_STRTAB {
+					indices = append(indices, i)
+				}
+			}
+		}
+		for _, name := range strtabSections {
+			idx := sectionIndex(f.Sections, f.SectionNames(), name)
+			if idx < 0 {
+				fail(cmd, args[0], fmt.Errorf("no section named %s", name))
+				return
+			}
+			if f.Sections[idx].Sh_type == types.SHT_NOBITS {
+				fail(cmd, args[0], fmt.Errorf("section %s has no file contents (NOBITS)", name))
+				return
+			}
+			indices = append(indices, idx)
+		}
+
+		if len(indices) == 0 {
+			fail(cmd, args[0], fmt.Errorf("no string tables in %s", args[0]))
+			return
+		}
+
+		var out model.StringTables
+		for _, idx := range indices {
+			sh := &f.Sections[idx]
+
+			data, err := elfParser.SectionData(sh)
+			if err != nil {
+				fail(cmd, args[0], err)
+				return
+			}
+
+			out.Tables = append(out.Tables, model.NewStringTable(idx, f.SectionName(sh), sh, data))
+		}
+
+		emit(cmd, args[0], &out, render.Options{})
+	},
+}
+
+func init() {
+	strtabCmd.Flags().StringSliceVarP(&strtabSections, "section", "s", nil, "only dump these sections (any section with file contents)")
+}
+
+// sectionIndex returns the index of the section with the given name, or -1.
+func sectionIndex(shdr []types.Elf64_Shdr, shstrtab []byte, name string) int {
+	for i := range shdr {
+		if types.GetString(shstrtab, shdr[i].Sh_name) == name {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,179 @@
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/yourpwnguy/strix/internal/elf/model"
+	"github.com/yourpwnguy/strix/internal/elf/render"
+	"github.com/yourpwnguy/strix/pkg/elf/types"
+)
+
+// symsOptions holds the filters of the syms command.
+type symsOptions struct {
+	types     []string
+	binds     []string
+	defined   bool
+	undefined bool
+	name      string
+	dynamic   bool
+}
+
+var symsOpts symsOptions
+
+// Values accepted by --type and --bind, the names strix prints for them in lower case.
+var (
+	symTypeNames = []string{"func", "object", "notype", "section", "file", "common", "tls", "ifunc"}
+	symBindNames = []string{"local", "global", "weak", "unique"}
+)
+
+// symsCmd displays the static and dynamic symbol tables with colored output.
+var symsCmd = &cobra.Command{
+	Use:   "syms [file]",
+	Short: "Display symbol tables (.symtab and .dynsym) from an ELF file",
+	Example: `strix syms /bin/ls
+strix syms -D --undefined /bin/ls
+strix syms -t func -b global -n '^str' /usr/lib/x86_64-linux-gnu/libc.so.6`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		match, err := symsOpts.matcher()
+		if err != nil {
+			fail(cmd, args[0], err)
+			return
+		}
+
+		elfParser, err := openParser(args[0])
+		if err != nil {
+			fail(cmd, args[0], err)
+			return
+		}
+		defer elfParser.Close()
+
+		f, err := elfParser.File()
+		if err != nil {
+			fail(cmd, args[0], err)
+			return
+		}
+
+		versions, err := elfParser.Versions()
+		if err != nil {
+			fail(cmd, args[0], err)
+			return
+		}
+
+		tables := []struct {
+			sh_type uint32
+			load    func() ([]types.Symbol, error)
+		}{
+			{types.SHT_DYNSYM, elfParser.DynamicSymbols},
+			{types.SHT_SYMTAB, elfParser.Symbols},
+		}
+
+		var out model.Symbols
+		for _, table := range tables {
+			if symsOpts.dynamic && table.sh_type != types.SHT_DYNSYM {
+				continue
+			}
+
+			sh := f.SectionByType(table.sh_type)
+			if sh == nil {
+				continue
+			}
+
+			syms, err := table.load()
+			if err != nil {
+				fail(cmd, args[0], err)
+				return
+			}
+
+			filtered := make([]types.Symbol, 0, len(syms))
+			for i := range syms {
+				if match(&syms[i]) {
+					filtered = append(filtered, syms[i])
+				}
+			}
+
+			var tableVersions *types.Versions
+			if table.sh_type == types.SHT_DYNSYM {
+				tableVersions = versions
+			}
+
+			out.Tables = append(out.Tables, model.NewSymbolTable(f.SectionName(sh), len(syms), filtered, tableVersions, f.Sections, f.SectionNames()))
+		}
+
+		if len(out.Tables) == 0 {
+			fail(cmd, args[0], fmt.Errorf("no symbol tables in %s", args[0]))
+			return
+		}
+
+		emit(cmd, args[0], &out, render.Options{})
+	},
+}
+
+func init() {
+	flags := symsCmd.Flags()
+	flags.StringSliceVarP(&symsOpts.types, "type", "t", nil, "Only show symbols of these types ("+strings.Join(symTypeNames, ", ")+")")
+	flags.StringSliceVarP(&symsOpts.binds, "bind", "b", nil, "Only show symbols with these bindings ("+strings.Join(symBindNames, ", ")+")")
+	flags.BoolVar(&symsOpts.defined, "defined", false, "Only show symbols defined in this file")
+	flags.BoolVar(&symsOpts.undefined, "undefined", false, "Only show symbols imported from other objects")
+	flags.StringVarP(&symsOpts.name, "name", "n", "", "Only show symbols whose name matches this regular expression")
+	flags.BoolVarP(&symsOpts.dynamic, "dynamic", "D", false, "Only show the dynamic symbol table (.dynsym)")
+	symsCmd.MarkFlagsMutuallyExclusive("defined", "undefined")
+	addDemangleFlag(symsCmd)
+}
+
+// matcher compiles the filters into a single predicate.
+func (o *symsOptions) matcher() (func(*types.Symbol) bool, error) {
+	var re *regexp.Regexp
+	if o.name != "" {
+		var err error
+		if re, err = regexp.Compile(o.name); err != nil {
+			return nil, fmt.Errorf("invalid --name pattern: %w", err)
+		}
+	}
+
+	wantTypes, err := parseNames("symbol type", o.types, symTypeNames)
+	if err != nil {
+		return nil, err
+	}
+	wantBinds, err := parseNames("symbol binding", o.binds, symBindNames)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(sym *types.Symbol) bool {
+		if len(wantTypes) > 0 && !slices.Contains(wantTypes, strings.ToLower(types.GetSymType(sym.Type()))) {
+			return false
+		}
+		if len(wantBinds) > 0 && !slices.Contains(wantBinds, strings.ToLower(types.GetSymBind(sym.Bind()))) {
+			return false
+		}
+		if o.defined && sym.IsUndefined() {
+			return false
+		}
+		if o.undefined && !sym.IsUndefined() {
+			return false
+		}
+		if re != nil && !re.MatchString(symbolName(sym.Name)) {
+			return false
+		}
+		return true
+	}, nil
+}
+
+// parseNames lowercases and trims every value of a list flag, failing on one that is not
+// among the accepted names.
+func parseNames(what string, values, accepted []string) ([]string, error) {
+	out := make([]string, 0, len(values))
+	for _, v := range values {
+		name := strings.ToLower(strings.TrimSpace(v))
+		if !slices.Contains(accepted, name) {
+			return nil, fmt.Errorf("unknown %s %q (expected %s)", what, name, strings.Join(accepted, ", "))
+		}
+		out = append(out, name)
+	}
+	return out, nil
+}
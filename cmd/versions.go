@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/yourpwnguy/strix/internal/elf/model"
+	"github.com/yourpwnguy/strix/internal/elf/render"
+)
+
+// versionsCmd lists the symbol version nodes a file defines and requires.
+var versionsCmd = &cobra.Command{
+	Use:   "versions [file]",
+	Short: "Display symbol version definitions and requirements (.gnu.version_d, .gnu.version_r)",
+	Example: `strix versions /bin/ls
+strix versions /usr/lib/x86_64-linux-gnu/libc.so.6`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		elfParser, err := openParser(args[0])
+		if err != nil {
+			fail(cmd, args[0], err)
+			return
+		}
+		defer elfParser.Close()
+
+		versions, err := elfParser.Versions()
+		if err != nil {
+			fail(cmd, args[0], err)
+			return
+		}
+
+		if len(versions.Definitions) == 0 && len(versions.Needs) == 0 {
+			fail(cmd, args[0], fmt.Errorf("no symbol versioning in %s", args[0]))
+			return
+		}
+
+		emit(cmd, args[0], model.NewVersions(versions), render.Options{})
+	},
+}
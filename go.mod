@@ -5,6 +5,8 @@ go 1.25.3
 require (
 	github.com/fatih/color v1.18.0
 	github.com/spf13/cobra v1.10.1
+	golang.org/x/arch v0.30.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -0,0 +1,62 @@
+// Package demangle turns mangled C++ and Rust symbol names back into source form.
+//
+// Itanium C++ names (_Z...), legacy Rust names (_ZN...17h<hash>E, shown without the hash)
+// and Rust v0 names (_R...) are supported. Names that are not mangled, or that fail to
+// demangle, are returned unchanged, so Name is safe to apply to every symbol.
+//
+// C++ names are parsed into a tree that is printed the way c++filt prints it, see
+// itanium.go. Rust names are printed as they are parsed, see rust.go. Symbol versions, and
+// suffixes neither grammar knows, are split off here and put back after the name.
+package demangle
+
+import (
+	"strings"
+)
+
+// Name demangles a symbol name, falling back to the raw name. A symbol version (name@VER or
+// name@@VER) is kept as it is, and so is a dotted suffix the demangler does not understand.
+func Name(name string) string {
+	if !mangled(name) {
+		return name
+	}
+
+	if out, ok := try(name); ok {
+		return out
+	}
+
+	// Split off the symbol version, then any suffix the demangler did not understand
+	base, version := name, ""
+	if i := strings.IndexByte(base, '@'); i > 0 {
+		base, version = base[:i], base[i:]
+	}
+
+	if out, ok := try(base); ok {
+		return out + version
+	}
+
+	if i := strings.IndexByte(base, '.'); i > 0 {
+		if out, ok := try(base[:i]); ok {
+			return out + base[i:] + version
+		}
+	}
+
+	return name
+}
+
+// mangled reports whether name looks like an Itanium or Rust v0 mangled name, leaving plain
+// C names alone without a trip through the demangler.
+func mangled(name string) bool {
+	return strings.HasPrefix(name, "_Z") || strings.HasPrefix(name, "_R") || strings.HasPrefix(name, "__Z")
+}
+
+// try runs the demangler the prefix of name calls for, reporting whether it recognized the
+// whole name.
+func try(name string) (string, bool) {
+	if strings.HasPrefix(name, "_R") {
+		return rustV0(name)
+	}
+	if out, ok := rustLegacy(name); ok {
+		return out, true
+	}
+	return itanium(name)
+}
@@ -0,0 +1,67 @@
+package demangle
+
+import "testing"
+
+func TestName(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		// Plain C names never reach the demangler
+		{"main", "main"},
+		{"memcpy@GLIBC_2.14", "memcpy@GLIBC_2.14"},
+
+		// Templates, including a function template whose return type is spelled out
+		{"_ZNSt6vectorIiSaIiEE9push_backERKi", "std::vector<int, std::allocator<int> >::push_back(int const&)"},
+		{"_Z3maxIiET_S0_S0_", "int max<int>(int, int)"},
+		{"_Z1fILi1EE", "f<1>"},
+
+		// Qualifiers on a parameter bound to an array or an already qualified type
+		{"_ZN4llvm2cl5applyIA14_cEEvRKT_", "void llvm::cl::apply<char [14]>(char const (&) [14])"},
+		{"_Z1fIKiEvRKT_", "void f<int const>(int const&)"},
+
+		// Substitutions, of earlier components (S0_, S1_, S3_) and the standard ones (Sa, Ss)
+		{"_ZNKSt6vectorISt4pairIiiESaIS1_EE4sizeEv", "std::vector<std::pair<int, int>, std::allocator<std::pair<int, int> > >::size() const"},
+		{"_Z1fPFPKcS0_E", "f(char const* (*)(char const*))"},
+		{"_ZNSt3mapISsiSt4lessISsESaISt4pairIKSsiEEEixERS3_", "std::map<std::string, int, std::less<std::string>, std::allocator<std::pair<std::string const, int> > >::operator[](std::string const&)"},
+
+		// Lambdas are numbered from 1 within their scope, generic ones take auto parameters
+		{"_ZZ4mainENKUlvE_clEv", "main::{lambda()#1}::operator()() const"},
+		{"_ZZ4mainENKUliE0_clEi", "main::{lambda(int)#2}::operator()(int) const"},
+		{"_Z1fIJZ1gvEUlvE_EEvDpOT_", "void f<g()::{lambda()#1}>(g()::{lambda()#1}&&)"},
+		{"_ZZN3foo3barEvENKUlRKT_E_clIiEEDaS2_", "auto foo::bar()::{lambda(auto:1 const&)#1}::operator()<int>(int const&) const"},
+
+		// Rust legacy names lose their h<hash> component, and $..$ escapes are decoded
+		{"_ZN4core3fmt9Formatter3pad17h1f3c5e2e0b7f4a1aE", "core::fmt::Formatter::pad"},
+		{"_ZN5alloc3vec12Vec$LT$T$GT$4push17hdeadbeefdeadbeefE", "alloc::vec::Vec<T>::push"},
+		{"_ZN3std2rt10lang_start28_$u7b$$u7b$closure$u7d$$u7d$17h0123456789abcdefE", "std::rt::lang_start::{{closure}}"},
+		{"_ZN3foo3bar17hnothexnothexnotxE", "foo::bar::hnothexnothexnotx"},
+		{"_ZN3std3env4_var17h9a842516e8bc1346E.llvm.1234", "std::env::_var"},
+
+		// Rust v0 names, with generic arguments and trait implementations
+		{"_RNvMs_NtCs4fqI2P2rA04_5hello3fooINtB4_3BarlE3baz", "<hello::foo::Bar<i32>>::baz"},
+		{"_RINvNtCs1234_4core3mem4swapNtCs5678_5hello3FooEB6_", "core::mem::swap::<hello::Foo>"},
+		{"_RNvXs_NtCs4fqI2P2rA04_5hello3fooINtB4_3BarlENtNtCs7Q7_4core3fmt5Debug3fmt", "<hello::foo::Bar<i32> as core::fmt::Debug>::fmt"},
+		{"_RNvCs1_3foou9bcher_kva", "foo::bücher"},
+		{"_RINvCs1_3foo3barKj2a_E", "foo::bar::<42>"},
+
+		// Symbol versions and clone suffixes are kept
+		{"_ZNSt9exceptionD2Ev@@GLIBCXX_3.4", "std::exception::~exception()@@GLIBCXX_3.4"},
+		{"_ZN3foo3barEv.cold", "foo::bar() [clone .cold]"},
+		{"_ZN3foo3barEv.isra.0", "foo::bar() [clone .isra.0]"},
+
+		// Names that look mangled but do not parse come back raw
+		{"_Z", "_Z"},
+		{"_R", "_R"},
+		{"_Zgarbage", "_Zgarbage"},
+		{"_ZN3foo", "_ZN3foo"},
+		{"_RNvC", "_RNvC"},
+		{"_Zgarbage@@VERS_1.0", "_Zgarbage@@VERS_1.0"},
+	}
+
+	for _, tt := range tests {
+		if got := Name(tt.name); got != tt.want {
+			t.Errorf("Name(%q) = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
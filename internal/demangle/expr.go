@@ -0,0 +1,442 @@
+package demangle
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Expressions show up in template arguments, array bounds and decltype. They are printed
+// the way c++filt prints them, with operands that are not plain names in parentheses.
+
+// expression parses <expression>.
+func (st *state) expression() node {
+	st.enter()
+	defer st.leave()
+
+	switch c := st.peek(); {
+	case c == 'L':
+		return st.exprPrimary()
+	case c == 'T':
+		return st.templateParam()
+	case isDigit(c):
+		return st.unresolvedName()
+	}
+
+	switch {
+	case st.next("fp"):
+		st.cvQualifiers()
+		return st.funcParam()
+	case st.next("fL"):
+		st.number()
+		st.expect('p')
+		st.cvQualifiers()
+		return st.funcParam()
+	case st.next("sr"), st.next("gs"):
+		st.pos -= 2
+		return st.unresolvedName()
+	case st.next("on"), st.next("dn"):
+		st.pos -= 2
+		return st.unresolvedName()
+	case st.next("sp"):
+		return &packExpansion{child: st.expression()}
+	case st.next("sZ"):
+		var pack node
+		if st.peek() == 'T' {
+			pack = st.templateParam()
+		} else {
+			pack = st.funcParam()
+		}
+		return &packSize{pack: pack}
+	case st.next("st"):
+		return &wrapped{pre: "sizeof (", child: st.typ(), post: ")"}
+	case st.next("at"):
+		return &wrapped{pre: "alignof (", child: st.typ(), post: ")"}
+	case st.next("nx"):
+		return &wrapped{pre: "noexcept(", child: st.expression(), post: ")"}
+	case st.next("ti"):
+		return &wrapped{pre: "typeid (", child: st.typ(), post: ")"}
+	case st.next("te"):
+		return &wrapped{pre: "typeid (", child: st.expression(), post: ")"}
+	case st.next("tw"):
+		return &wrapped{pre: "throw ", child: st.expression()}
+	case st.next("tr"):
+		return &name{s: "throw"}
+	case st.next("cl"):
+		c := &call{fn: st.expression()}
+		c.args = st.exprList('E')
+		return c
+	case st.next("cv"):
+		c := &cast{typ: st.typ()}
+		if st.next("_") {
+			c.args = st.exprList('E')
+			c.list = true
+		} else {
+			c.args = []node{st.expression()}
+		}
+		return c
+	case st.next("tl"):
+		t := st.typ()
+		return &wrapped{pre: "", child: &joined{a: t, sep: "{", b: &list{items: st.exprList('E')}}, post: "}"}
+	case st.next("il"):
+		return &wrapped{pre: "{", child: &list{items: st.exprList('E')}, post: "}"}
+	case st.next("dt"):
+		return &binary{op: ".", l: st.expression(), r: st.unresolvedName()}
+	case st.next("pt"):
+		return &binary{op: "->", l: st.expression(), r: st.unresolvedName()}
+	}
+
+	for _, c := range []struct{ code, name string }{
+		{"dc", "dynamic_cast"}, {"sc", "static_cast"}, {"cc", "const_cast"}, {"rc", "reinterpret_cast"},
+	} {
+		if st.next(c.code) {
+			t := st.typ()
+			return &joined{a: &wrapped{pre: c.name + "<", child: t, post: ">"}, sep: "", b: &wrapped{pre: "(", child: st.expression(), post: ")"}}
+		}
+	}
+
+	if st.pos+2 > len(st.s) {
+		st.fail()
+	}
+	op, ok := operators[st.s[st.pos:st.pos+2]]
+	if !ok || op.name == "new" || op.name == "new[]" {
+		st.fail()
+	}
+	st.pos += 2
+	switch op.arity {
+	case 1:
+		return &unary{op: strings.TrimSpace(op.name), x: st.expression()}
+	case 2:
+		l := st.expression()
+		return &binary{op: op.name, l: l, r: st.expression()}
+	default:
+		cond := st.expression()
+		then := st.expression()
+		return &ternary{cond: cond, then: then, els: st.expression()}
+	}
+}
+
+// funcParam parses the rest of a function parameter reference, _ or <n>_.
+func (st *state) funcParam() node {
+	num := 1
+	if st.peek() != '_' {
+		num = st.number() + 2
+	}
+	st.expect('_')
+	return &name{s: "{parm#" + strconv.Itoa(num) + "}"}
+}
+
+// exprList parses expressions up to end.
+func (st *state) exprList(end byte) []node {
+	var out []node
+	for st.peek() != end {
+		if st.peek() == 0 {
+			st.fail()
+		}
+		out = append(out, st.expression())
+	}
+	st.pos++
+	return out
+}
+
+// unresolvedName parses a name whose meaning depends on template arguments:
+// [gs]<base>, sr<type><base>, srN<type><qualifiers>E<base> and [gs]sr<qualifiers>E<base>.
+func (st *state) unresolvedName() node {
+	global := st.next("gs")
+	if !st.next("sr") {
+		n := st.baseUnresolvedName()
+		if global {
+			return &wrapped{pre: "::", child: n}
+		}
+		return n
+	}
+
+	var scope node
+	switch {
+	case st.next("N"):
+		scope = st.unresolvedType()
+		for !st.next("E") {
+			scope = &nested{scope: scope, name: st.simpleID()}
+		}
+	case isDigit(st.peek()):
+		for !st.next("E") {
+			if scope == nil {
+				scope = st.simpleID()
+			} else {
+				scope = &nested{scope: scope, name: st.simpleID()}
+			}
+		}
+	default:
+		scope = st.unresolvedType()
+	}
+	if global {
+		scope = &wrapped{pre: "::", child: scope}
+	}
+	return &nested{scope: scope, name: st.baseUnresolvedName()}
+}
+
+// unresolvedType parses a template parameter, a decltype or a substitution, followed by
+// optional template arguments.
+func (st *state) unresolvedType() node {
+	var t node
+	switch {
+	case st.peek() == 'T':
+		t = st.templateParam()
+	case st.peek() == 'D':
+		t = st.decltype()
+	case st.peek() == 'S':
+		t = st.substitution(false)
+		if st.peek() == 'I' {
+			return &template{name: t, args: st.typeArgs()}
+		}
+		return t
+	default:
+		st.fail()
+	}
+	st.push(t)
+	if st.peek() == 'I' {
+		t = &template{name: t, args: st.typeArgs()}
+		st.push(t)
+	}
+	return t
+}
+
+// simpleID parses <source-name>[<template-args>].
+func (st *state) simpleID() node {
+	var n node = st.sourceName()
+	if st.peek() == 'I' {
+		n = &template{name: n, args: st.typeArgs()}
+	}
+	return n
+}
+
+// typeArgs parses template arguments that do not belong to the encoding.
+func (st *state) typeArgs() *templateArgs {
+	st.inType++
+	defer func() { st.inType-- }()
+	return st.templateArgs()
+}
+
+// baseUnresolvedName parses a simple name, on<operator> or dn<destructor>.
+func (st *state) baseUnresolvedName() node {
+	switch {
+	case st.next("on"):
+		var n node = st.operatorName()
+		if st.peek() == 'I' {
+			n = &template{name: n, args: st.typeArgs()}
+		}
+		return &wrapped{pre: "(", child: n, post: ")"}
+	case st.next("dn"):
+		if isDigit(st.peek()) {
+			return &wrapped{pre: "~", child: st.simpleID()}
+		}
+		return &wrapped{pre: "~", child: st.unresolvedType()}
+	}
+	return st.simpleID()
+}
+
+// exprPrimary parses a literal, L<type><value>E, or a reference to an entity, L_Z<encoding>E.
+func (st *state) exprPrimary() node {
+	st.expect('L')
+	if st.next("_Z") || st.next("Z") {
+		saved := st.tagged
+		st.inType++
+		enc := st.encoding()
+		st.inType--
+		st.tagged = saved
+		st.expect('E')
+		return enc
+	}
+
+	t := st.typ()
+	if t == dBuiltins['n'] {
+		// nullptr, with or without a value
+		for st.peek() != 'E' && st.peek() != 0 {
+			st.pos++
+		}
+		st.expect('E')
+		return t
+	}
+
+	start := st.pos
+	for st.peek() != 'E' {
+		if st.peek() == 0 {
+			st.fail()
+		}
+		st.pos++
+	}
+	value := st.s[start:st.pos]
+	st.pos++
+	if value == "" {
+		st.fail()
+	}
+	return &literal{typ: t, value: value}
+}
+
+// Suffixes of the integer literals printed without a cast.
+var literalSuffixes = map[*name]string{
+	builtins['i']: "", builtins['j']: "u", builtins['l']: "l", builtins['m']: "ul",
+	builtins['x']: "ll", builtins['y']: "ull",
+}
+
+// literal is a constant: 5, 5ul, true, (char)65.
+type literal struct {
+	leaf
+	typ   node
+	value string
+}
+
+func (l *literal) left(p *printer) {
+	value := l.value
+	if strings.HasPrefix(value, "n") {
+		value = "-" + value[1:]
+	}
+	if b, ok := l.typ.(*name); ok {
+		if b == builtins['b'] && (value == "0" || value == "1") {
+			p.write(map[string]string{"0": "false", "1": "true"}[value])
+			return
+		}
+		if suffix, ok := literalSuffixes[b]; ok {
+			p.write(value + suffix)
+			return
+		}
+		if b == builtins['f'] || b == builtins['d'] || b == builtins['e'] {
+			value = "[" + value + "]"
+		}
+	}
+	p.write("(")
+	p.print(l.typ)
+	p.write(")" + value)
+}
+
+// simple reports whether an operand prints without parentheses.
+func simple(p *printer, n node) bool {
+	switch n := p.resolve(n).(type) {
+	case *name, *nested:
+		return true
+	case *wrapped:
+		return n.pre == "{"
+	}
+	return false
+}
+
+// operand prints an operand, in parentheses unless it is simple.
+func (p *printer) operand(n node) {
+	if simple(p, n) {
+		p.print(n)
+		return
+	}
+	p.write("(")
+	p.print(n)
+	p.write(")")
+}
+
+type unary struct {
+	leaf
+	op string
+	x  node
+}
+
+func (u *unary) left(p *printer) {
+	p.write(u.op)
+	// The address of a function is printed without its parameters: &f
+	if e, ok := u.x.(*encoding); ok && u.op == "&" {
+		p.print(e.name)
+		return
+	}
+	p.operand(u.x)
+}
+
+type binary struct {
+	leaf
+	op   string
+	l, r node
+}
+
+func (b *binary) left(p *printer) {
+	// A > inside template arguments would close them
+	if b.op == ">" {
+		p.write("(")
+	}
+	p.operand(b.l)
+	p.write(b.op)
+	if b.op == "." || b.op == "->" {
+		p.print(b.r)
+	} else {
+		p.operand(b.r)
+	}
+	if b.op == ">" {
+		p.write(")")
+	}
+}
+
+type ternary struct {
+	leaf
+	cond, then, els node
+}
+
+func (t *ternary) left(p *printer) {
+	p.operand(t.cond)
+	p.write("?")
+	p.operand(t.then)
+	p.write(" : ")
+	p.operand(t.els)
+}
+
+// call is a function call, fn(args).
+type call struct {
+	leaf
+	fn   node
+	args []node
+}
+
+func (c *call) left(p *printer) {
+	p.print(c.fn)
+	p.write("(")
+	p.commaList(c.args)
+	p.write(")")
+}
+
+// cast is a C style cast, (type)x, or a functional one with a list, (type)(a, b).
+type cast struct {
+	leaf
+	typ  node
+	args []node
+	list bool
+}
+
+func (c *cast) left(p *printer) {
+	p.write("(")
+	p.print(c.typ)
+	p.write(")")
+	if c.list {
+		p.write("(")
+		p.commaList(c.args)
+		p.write(")")
+		return
+	}
+	p.operand(c.args[0])
+}
+
+// packSize is sizeof...(pack), printed as the length of the pack when it is known.
+type packSize struct {
+	leaf
+	pack node
+}
+
+func (s *packSize) left(p *printer) {
+	if pack, ok := p.resolve(s.pack).(*argPack); ok {
+		p.write(strconv.Itoa(len(pack.args)))
+		return
+	}
+	p.write("sizeof...(")
+	p.print(s.pack)
+	p.write(")")
+}
+
+// list is a comma separated list of expressions.
+type list struct {
+	leaf
+	items []node
+}
+
+func (l *list) left(p *printer) { p.commaList(l.items) }
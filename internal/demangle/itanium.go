@@ -0,0 +1,1011 @@
+package demangle
+
+import (
+	"strconv"
+	"strings"
+)
+
+// itanium demangles a name mangled under the Itanium C++ ABI, _Z<encoding> followed by
+// any number of clone suffixes (.cold, .isra.0...), reporting false when it does not parse.
+//
+// The grammar is in https://itanium-cxx-abi.github.io/cxx-abi/abi.html#mangling. The output
+// follows c++filt: "char const*" rather than "const char*", and "> >" between closing
+// angle brackets.
+func itanium(mangled string) (out string, ok bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			if _, failed := r.(parseError); !failed {
+				panic(r)
+			}
+			out, ok = "", false
+		}
+	}()
+
+	st := &state{s: strings.TrimPrefix(mangled, "_")}
+	if !strings.HasPrefix(st.s, "Z") && !strings.HasPrefix(st.s, "_Z") {
+		return "", false
+	}
+	st.s = st.s[strings.IndexByte(st.s, 'Z')+1:]
+
+	n := st.encoding()
+	for st.pos < len(st.s) {
+		n = &cloneSuffix{child: n, suffix: st.cloneSuffix()}
+	}
+	return render(n)
+}
+
+// parseError is the panic value that unwinds the parser on malformed input.
+type parseError struct{}
+
+// state is the parser of one mangled name.
+type state struct {
+	s   string
+	pos int
+
+	// Substitution candidates, S_ refers to the first
+	subs []node
+
+	// Last template argument list of the name of the encoding being parsed, which the
+	// template parameters of its type refer to, and the qualifiers of its nested name
+	tagged *templateArgs
+	quals  string
+
+	// Nesting inside types, where template arguments belong to the type instead of the
+	// encoding, and overall nesting, bounded by maxDepth
+	inType int
+	depth  int
+}
+
+func (st *state) fail() {
+	panic(parseError{})
+}
+
+func (st *state) peek() byte {
+	return st.peekAt(0)
+}
+
+func (st *state) peekAt(i int) byte {
+	if st.pos+i >= len(st.s) {
+		return 0
+	}
+	return st.s[st.pos+i]
+}
+
+// next consumes prefix when the input continues with it.
+func (st *state) next(prefix string) bool {
+	if strings.HasPrefix(st.s[st.pos:], prefix) {
+		st.pos += len(prefix)
+		return true
+	}
+	return false
+}
+
+func (st *state) expect(c byte) {
+	if st.peek() != c {
+		st.fail()
+	}
+	st.pos++
+}
+
+// enter counts one more level of recursion, failing past maxDepth. Calls are paired with
+// a deferred leave.
+func (st *state) enter() {
+	st.depth++
+	if st.depth > maxDepth {
+		st.fail()
+	}
+}
+
+func (st *state) leave() {
+	st.depth--
+}
+
+func (st *state) push(n node) {
+	st.subs = append(st.subs, n)
+}
+
+// number parses <number>, a decimal with n for a minus sign.
+func (st *state) number() int {
+	neg := st.next("n")
+	start := st.pos
+	for isDigit(st.peek()) {
+		st.pos++
+	}
+	if st.pos == start || st.pos-start > 9 {
+		st.fail()
+	}
+	v, _ := strconv.Atoi(st.s[start:st.pos])
+	if neg {
+		return -v
+	}
+	return v
+}
+
+// seqID parses the base 36 index of S<seq-id>_ and T<n>_, terminated by an underscore.
+// An immediate underscore is -1, so that callers add one to get the position.
+func (st *state) seqID() int {
+	v := -1
+	for st.peek() != '_' {
+		c := st.peek()
+		var d int
+		switch {
+		case isDigit(c):
+			d = int(c - '0')
+		case c >= 'A' && c <= 'Z':
+			d = int(c-'A') + 10
+		default:
+			st.fail()
+		}
+		if v < 0 {
+			v = 0
+		}
+		if v > 1<<20 {
+			st.fail()
+		}
+		v = v*36 + d
+		st.pos++
+	}
+	st.pos++
+	return v
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isLower(c byte) bool {
+	return c >= 'a' && c <= 'z'
+}
+
+// cloneSuffix parses a suffix the compiler appended to a function it copied, a dot and
+// lowercase letters followed by dotted numbers: .cold, .constprop.0, .isra.0, .part.1.
+func (st *state) cloneSuffix() string {
+	start := st.pos
+	if st.peek() != '.' || !(isLower(st.peekAt(1)) || isDigit(st.peekAt(1)) || st.peekAt(1) == '_') {
+		st.fail()
+	}
+	st.pos += 2
+	for isLower(st.peek()) || st.peek() == '_' {
+		st.pos++
+	}
+	for st.peek() == '.' && isDigit(st.peekAt(1)) {
+		st.pos += 2
+		for isDigit(st.peek()) {
+			st.pos++
+		}
+	}
+	return st.s[start:st.pos]
+}
+
+// encoding parses <encoding>, a function with its type, a data object or a special name.
+func (st *state) encoding() node {
+	st.enter()
+	defer st.leave()
+
+	if c := st.peek(); c == 'T' || c == 'G' {
+		return st.specialName()
+	}
+
+	// Inside a type, the encoding of a local name keeps to itself
+	savedTagged, savedInType := st.tagged, st.inType
+	st.tagged, st.inType, st.quals = nil, 0, ""
+	defer func() {
+		st.inType = savedInType
+		if savedInType > 0 {
+			st.tagged = savedTagged
+		}
+	}()
+
+	n := st.name()
+	if c := st.peek(); c == 0 || c == 'E' || c == '.' {
+		return n
+	}
+
+	args, quals := st.tagged, st.quals
+	st.quals = ""
+	typ := &funcType{quals: quals}
+	st.inType++
+	if hasReturn(n) {
+		typ.ret = st.typ()
+	}
+	for {
+		if c := st.peek(); c == 0 || c == 'E' || c == '.' {
+			break
+		}
+		typ.params = append(typ.params, st.typ())
+	}
+	st.inType--
+	if len(typ.params) == 0 {
+		st.fail()
+	}
+	return &encoding{name: n, typ: typ, args: args}
+}
+
+// hasReturn reports whether the type of a function named n starts with its return type,
+// which is the case of function templates other than constructors and conversions.
+func hasReturn(n node) bool {
+	switch n := n.(type) {
+	case *template:
+		switch last(n.name).(type) {
+		case *ctor, *conversion:
+			return false
+		}
+		return true
+	case *localName:
+		return hasReturn(n.name)
+	case *nested:
+		return hasReturn(n.name)
+	case *abiTagged:
+		return hasReturn(n.child)
+	}
+	return false
+}
+
+// last returns the unqualified name at the end of a qualified one.
+func last(n node) node {
+	for {
+		switch m := n.(type) {
+		case *nested:
+			n = m.name
+		case *abiTagged:
+			n = m.child
+		default:
+			return n
+		}
+	}
+}
+
+// specialName parses the names of data and code the compiler generates: virtual tables,
+// type information, thunks, guard variables.
+func (st *state) specialName() node {
+	switch {
+	case st.next("TV"):
+		return &wrapped{pre: "vtable for ", child: st.typ()}
+	case st.next("TT"):
+		return &wrapped{pre: "VTT for ", child: st.typ()}
+	case st.next("TI"):
+		return &wrapped{pre: "typeinfo for ", child: st.typ()}
+	case st.next("TS"):
+		return &wrapped{pre: "typeinfo name for ", child: st.typ()}
+	case st.next("TH"):
+		return &wrapped{pre: "TLS init function for ", child: st.name()}
+	case st.next("TW"):
+		return &wrapped{pre: "TLS wrapper function for ", child: st.name()}
+	case st.next("TA"):
+		return &wrapped{pre: "template parameter object for ", child: st.templateArg()}
+	case st.next("Th"):
+		st.callOffset('h')
+		return &wrapped{pre: "non-virtual thunk to ", child: st.encoding()}
+	case st.next("Tv"):
+		st.callOffset('v')
+		return &wrapped{pre: "virtual thunk to ", child: st.encoding()}
+	case st.next("Tc"):
+		st.callOffset(0)
+		st.callOffset(0)
+		return &wrapped{pre: "covariant return thunk to ", child: st.encoding()}
+	case st.next("TC"):
+		derived := st.typ()
+		if st.number() < 0 {
+			st.fail()
+		}
+		st.expect('_')
+		base := st.typ()
+		return &wrapped{pre: "construction vtable for ", child: &joined{a: base, sep: "-in-", b: derived}}
+	case st.next("GV"):
+		return &wrapped{pre: "guard variable for ", child: st.name()}
+	case st.next("GR"):
+		n := st.name()
+		seq := st.seqID() + 1
+		return &wrapped{pre: "reference temporary #" + strconv.Itoa(seq) + " for ", child: n}
+	case st.next("GTt"):
+		return &wrapped{pre: "transaction clone for ", child: st.encoding()}
+	case st.next("GTn"):
+		return &wrapped{pre: "non-transaction clone for ", child: st.encoding()}
+	case st.next("GA"):
+		return &wrapped{pre: "hidden alias for ", child: st.encoding()}
+	}
+	st.fail()
+	return nil
+}
+
+// callOffset parses the this adjustment of a thunk, h<offset>_ or v<offset>_<offset>_.
+// kind is the letter already consumed, or 0 when the offset starts with it.
+func (st *state) callOffset(kind byte) {
+	if kind == 0 {
+		kind = st.peek()
+		st.pos++
+	}
+	switch kind {
+	case 'h':
+		st.number()
+		st.expect('_')
+	case 'v':
+		st.number()
+		st.expect('_')
+		st.number()
+		st.expect('_')
+	default:
+		st.fail()
+	}
+}
+
+// name parses <name>: a nested name, a local name, or an unscoped name with its template
+// arguments.
+func (st *state) name() node {
+	st.enter()
+	defer st.leave()
+
+	switch st.peek() {
+	case 'N':
+		return st.nestedName()
+	case 'Z':
+		return st.localName()
+	case 'S':
+		var n node
+		if st.next("St") {
+			n = &nested{scope: stdNamespace, name: st.unqualifiedName(nil)}
+		} else {
+			// Only a template name can be a substitution here
+			n = st.substitution(false)
+			if st.peek() != 'I' {
+				st.fail()
+			}
+			return &template{name: n, args: st.templateArgs()}
+		}
+		if st.peek() == 'I' {
+			st.push(n)
+			return &template{name: n, args: st.templateArgs()}
+		}
+		return n
+	}
+
+	n := st.unqualifiedName(nil)
+	if st.peek() == 'I' {
+		st.push(n)
+		return &template{name: n, args: st.templateArgs()}
+	}
+	return n
+}
+
+var stdNamespace = &name{s: "std"}
+
+// nestedName parses N[<qualifiers>]<prefix><unqualified-name>E. The qualifiers belong to
+// the member function the name is of, they are left in st.quals.
+func (st *state) nestedName() node {
+	st.expect('N')
+	quals := st.cvQualifiers()
+	switch {
+	case st.next("R"):
+		quals += " &"
+	case st.next("O"):
+		quals += " &&"
+	}
+
+	var prefix node
+	pushed := false
+	for !st.next("E") {
+		pushed = false
+		switch c := st.peek(); {
+		case c == 0:
+			st.fail()
+		case c == 'M':
+			// A closure in the initializer of a data member, the member is in the prefix
+			if prefix == nil {
+				st.fail()
+			}
+			st.pos++
+			continue
+		case c == 'S' && st.peekAt(1) == 't':
+			if prefix != nil {
+				st.fail()
+			}
+			st.pos += 2
+			prefix = stdNamespace
+			continue
+		case c == 'S':
+			if prefix != nil {
+				st.fail()
+			}
+			next := st.peekAt(st.substitutionLen())
+			prefix = st.substitution(next == 'C' || next == 'D')
+			continue
+		case c == 'I':
+			if prefix == nil {
+				st.fail()
+			}
+			prefix = &template{name: prefix, args: st.templateArgs()}
+		case c == 'T':
+			if prefix != nil {
+				st.fail()
+			}
+			prefix = st.templateParam()
+		case c == 'D' && (st.peekAt(1) == 't' || st.peekAt(1) == 'T'):
+			if prefix != nil {
+				st.fail()
+			}
+			prefix = st.decltype()
+		default:
+			n := st.unqualifiedName(prefix)
+			if prefix == nil {
+				prefix = n
+			} else {
+				prefix = &nested{scope: prefix, name: n}
+			}
+		}
+		st.push(prefix)
+		pushed = true
+	}
+
+	// The name as a whole is not a prefix, it is only substitutable as a type
+	if prefix == nil || !pushed {
+		st.fail()
+	}
+	st.subs = st.subs[:len(st.subs)-1]
+	st.quals = quals
+	return prefix
+}
+
+// substitutionLen returns the length of the substitution at the current position, to see
+// what follows it.
+func (st *state) substitutionLen() int {
+	if st.peekAt(1) == '_' || isLower(st.peekAt(1)) {
+		return 2
+	}
+	i := 1
+	for isDigit(st.peekAt(i)) || (st.peekAt(i) >= 'A' && st.peekAt(i) <= 'Z') {
+		i++
+	}
+	return i + 1
+}
+
+// cvQualifiers parses [r][V][K], returning them as printed after a type.
+func (st *state) cvQualifiers() string {
+	var quals string
+	if st.next("r") {
+		quals += " restrict"
+	}
+	if st.next("V") {
+		quals += " volatile"
+	}
+	if st.next("K") {
+		quals += " const"
+	}
+	return quals
+}
+
+// localName parses Z<encoding>E<entity>, an entity declared inside a function.
+func (st *state) localName() node {
+	st.expect('Z')
+	enc := st.encoding()
+	st.expect('E')
+
+	var args *templateArgs
+	if e, ok := enc.(*encoding); ok {
+		args = e.args
+	}
+
+	var entity node
+	switch {
+	case st.next("s"):
+		entity = &name{s: "string literal"}
+		st.discriminator()
+	case st.next("d"):
+		num := 0
+		if st.peek() != '_' {
+			num = st.number() + 1
+		}
+		st.expect('_')
+		entity = &wrapped{pre: "{default arg#" + strconv.Itoa(num+1) + "}::", child: st.name()}
+	default:
+		entity = st.name()
+		st.discriminator()
+	}
+	return &localName{enc: enc, entity: &scoped{child: entity, args: args}, name: entity}
+}
+
+// discriminator parses the optional number telling apart entities of the same name in one
+// function, _<digit> or __<number>_. It is not printed.
+func (st *state) discriminator() {
+	switch {
+	case st.peek() == '_' && isDigit(st.peekAt(1)):
+		st.pos += 2
+	case st.peek() == '_' && st.peekAt(1) == '_' && isDigit(st.peekAt(2)):
+		st.pos += 2
+		st.number()
+		st.expect('_')
+	}
+}
+
+// unqualifiedName parses a name without scope: an identifier, an operator, a constructor
+// or destructor of scope, or an unnamed type. It is followed by any ABI tags.
+func (st *state) unqualifiedName(scope node) node {
+	st.enter()
+	defer st.leave()
+
+	var n node
+	switch c := st.peek(); {
+	case isDigit(c):
+		n = st.sourceName()
+	case c == 'L':
+		// A name with internal linkage
+		st.pos++
+		n = st.sourceName()
+		st.discriminator()
+	case c == 'C':
+		if scope == nil {
+			st.fail()
+		}
+		st.pos++
+		inheriting := st.next("I")
+		if k := st.peek(); k < '1' || k > '5' {
+			st.fail()
+		}
+		st.pos++
+		if inheriting {
+			st.typ()
+		}
+		n = &ctor{class: scope}
+	case c == 'D' && st.peekAt(1) == 'C':
+		st.pos += 2
+		var names []string
+		for !st.next("E") {
+			names = append(names, st.sourceName().s)
+		}
+		n = &name{s: "[" + strings.Join(names, ", ") + "]"}
+	case c == 'D':
+		if scope == nil {
+			st.fail()
+		}
+		st.pos++
+		if k := st.peek(); k < '0' || k > '5' {
+			st.fail()
+		}
+		st.pos++
+		n = &ctor{class: scope, dtor: true}
+	case c == 'U':
+		n = st.unnamedType()
+	case isLower(c):
+		n = st.operatorName()
+	default:
+		st.fail()
+	}
+
+	for st.peek() == 'B' {
+		st.pos++
+		n = &abiTagged{child: n, tag: st.sourceName().s}
+	}
+	return n
+}
+
+// sourceName parses <length><identifier>.
+func (st *state) sourceName() *name {
+	n := st.number()
+	if n <= 0 || n > len(st.s)-st.pos {
+		st.fail()
+	}
+	id := st.s[st.pos : st.pos+n]
+	st.pos += n
+
+	// GCC names anonymous namespaces _GLOBAL__N_<n>
+	if len(id) >= 10 && strings.HasPrefix(id, "_GLOBAL_") && strings.ContainsRune("._$", rune(id[8])) && id[9] == 'N' {
+		return &name{s: "(anonymous namespace)"}
+	}
+	return &name{s: id}
+}
+
+// unnamedType parses the name of a closure type, Ul<signature>E[<n>]_, or of another
+// unnamed type, Ut[<n>]_.
+func (st *state) unnamedType() node {
+	switch {
+	case st.next("Ut"):
+		return &name{s: "{unnamed type#" + strconv.Itoa(st.unnamedNumber()) + "}"}
+	case st.next("Ul"):
+		c := &closure{}
+		st.inType++
+		for !st.next("E") {
+			if st.peek() == 0 {
+				st.fail()
+			}
+			c.params = append(c.params, st.typ())
+		}
+		st.inType--
+		if len(c.params) == 0 {
+			st.fail()
+		}
+		c.num = st.unnamedNumber()
+		return c
+	}
+	st.fail()
+	return nil
+}
+
+// unnamedNumber parses [<n>]_, which numbers unnamed types from 1.
+func (st *state) unnamedNumber() int {
+	num := 1
+	if st.peek() != '_' {
+		num = st.number() + 2
+	}
+	st.expect('_')
+	return num
+}
+
+// operator is an operator name, with the number of operands it takes in an expression.
+type operator struct {
+	name  string
+	arity int
+}
+
+var operators = map[string]operator{
+	"nw": {"new", 3}, "na": {"new[]", 3}, "dl": {"delete", 1}, "da": {"delete[]", 1},
+	"aw": {"co_await", 1}, "ps": {"+", 1}, "ng": {"-", 1}, "ad": {"&", 1}, "de": {"*", 1},
+	"co": {"~", 1}, "pl": {"+", 2}, "mi": {"-", 2}, "ml": {"*", 2}, "dv": {"/", 2},
+	"rm": {"%", 2}, "an": {"&", 2}, "or": {"|", 2}, "eo": {"^", 2}, "aS": {"=", 2},
+	"pL": {"+=", 2}, "mI": {"-=", 2}, "mL": {"*=", 2}, "dV": {"/=", 2}, "rM": {"%=", 2},
+	"aN": {"&=", 2}, "oR": {"|=", 2}, "eO": {"^=", 2}, "ls": {"<<", 2}, "rs": {">>", 2},
+	"lS": {"<<=", 2}, "rS": {">>=", 2}, "eq": {"==", 2}, "ne": {"!=", 2}, "lt": {"<", 2},
+	"gt": {">", 2}, "le": {"<=", 2}, "ge": {">=", 2}, "ss": {"<=>", 2}, "nt": {"!", 1},
+	"aa": {"&&", 2}, "oo": {"||", 2}, "pp": {"++", 1}, "mm": {"--", 1}, "cm": {",", 2},
+	"pm": {"->*", 2}, "pt": {"->", 2}, "cl": {"()", 2}, "ix": {"[]", 2}, "qu": {"?", 3},
+	"st": {"sizeof ", 1}, "sz": {"sizeof ", 1}, "at": {"alignof ", 1}, "az": {"alignof ", 1},
+}
+
+// operatorName parses <operator-name>: operator+, a conversion operator, a literal operator.
+func (st *state) operatorName() node {
+	switch {
+	case st.next("cv"):
+		return &conversion{typ: st.typ()}
+	case st.next("li"):
+		return &name{s: `operator"" ` + st.sourceName().s}
+	case st.peek() == 'v' && isDigit(st.peekAt(1)):
+		st.pos += 2
+		return &name{s: "operator " + st.sourceName().s}
+	}
+
+	if st.pos+2 > len(st.s) {
+		st.fail()
+	}
+	op, ok := operators[st.s[st.pos:st.pos+2]]
+	if !ok {
+		st.fail()
+	}
+	st.pos += 2
+	if isLower(op.name[0]) {
+		return &name{s: "operator " + strings.TrimSpace(op.name)}
+	}
+	return &name{s: "operator" + op.name}
+}
+
+// Standard abbreviations. The long forms are used for the scope of a constructor, so that
+// S::S() is not printed as std::string::basic_string().
+var (
+	stdAbbrevs = map[byte]*stdName{
+		'a': {s: "std::allocator", base: "allocator"},
+		'b': {s: "std::basic_string", base: "basic_string"},
+		's': {s: "std::string", base: "string"},
+		'i': {s: "std::istream", base: "istream"},
+		'o': {s: "std::ostream", base: "ostream"},
+		'd': {s: "std::iostream", base: "iostream"},
+	}
+	stdLongAbbrevs = map[byte]*stdName{
+		'a': {s: "std::allocator", base: "allocator"},
+		'b': {s: "std::basic_string", base: "basic_string"},
+		's': {s: "std::basic_string<char, std::char_traits<char>, std::allocator<char> >", base: "basic_string"},
+		'i': {s: "std::basic_istream<char, std::char_traits<char> >", base: "basic_istream"},
+		'o': {s: "std::basic_ostream<char, std::char_traits<char> >", base: "basic_ostream"},
+		'd': {s: "std::basic_iostream<char, std::char_traits<char> >", base: "basic_iostream"},
+	}
+)
+
+// substitution parses S_, S<seq-id>_ or a standard abbreviation. forCtor asks for the long
+// form of an abbreviation, for the scope of a constructor or destructor.
+func (st *state) substitution(forCtor bool) node {
+	st.expect('S')
+	if c := st.peek(); isLower(c) {
+		st.pos++
+		abbrevs := stdAbbrevs
+		if forCtor {
+			abbrevs = stdLongAbbrevs
+		}
+		if n, ok := abbrevs[c]; ok {
+			return n
+		}
+		st.fail()
+	}
+
+	i := st.seqID() + 1
+	if i >= len(st.subs) {
+		st.fail()
+	}
+	return st.subs[i]
+}
+
+// templateParam parses T_ or T<n>_.
+func (st *state) templateParam() node {
+	st.expect('T')
+	return &templateParam{index: st.seqID() + 1}
+}
+
+// templateArgs parses I<template-arg>+E. Outside types, the list belongs to the encoding
+// being parsed and its template parameters refer to it.
+func (st *state) templateArgs() *templateArgs {
+	st.enter()
+	defer st.leave()
+
+	st.expect('I')
+	args := &templateArgs{}
+	st.inType++
+	for !st.next("E") {
+		if st.peek() == 0 {
+			st.fail()
+		}
+		args.args = append(args.args, st.templateArg())
+	}
+	st.inType--
+	if st.inType == 0 {
+		st.tagged = args
+	}
+	return args
+}
+
+// templateArg parses a type, an expression X...E, a literal L...E or an argument pack J...E.
+func (st *state) templateArg() node {
+	switch st.peek() {
+	case 'X':
+		st.pos++
+		e := st.expression()
+		st.expect('E')
+		return e
+	case 'L':
+		return st.exprPrimary()
+	case 'J':
+		st.pos++
+		pack := &argPack{}
+		for !st.next("E") {
+			if st.peek() == 0 {
+				st.fail()
+			}
+			pack.args = append(pack.args, st.templateArg())
+		}
+		return pack
+	}
+	return st.typ()
+}
+
+// Builtin types, by their one letter code.
+var builtins = map[byte]*name{
+	'v': {s: "void"}, 'w': {s: "wchar_t"}, 'b': {s: "bool"}, 'c': {s: "char"},
+	'a': {s: "signed char"}, 'h': {s: "unsigned char"}, 's': {s: "short"},
+	't': {s: "unsigned short"}, 'i': {s: "int"}, 'j': {s: "unsigned int"}, 'l': {s: "long"},
+	'm': {s: "unsigned long"}, 'x': {s: "long long"}, 'y': {s: "unsigned long long"},
+	'n': {s: "__int128"}, 'o': {s: "unsigned __int128"}, 'f': {s: "float"},
+	'd': {s: "double"}, 'e': {s: "long double"}, 'g': {s: "__float128"}, 'z': {s: "..."},
+}
+
+// Builtin types with a two letter code starting with D.
+var dBuiltins = map[byte]*name{
+	'd': {s: "decimal64"}, 'e': {s: "decimal128"}, 'f': {s: "decimal32"}, 'h': {s: "half"},
+	'i': {s: "char32_t"}, 's': {s: "char16_t"}, 'u': {s: "char8_t"}, 'a': {s: "auto"},
+	'c': {s: "decltype(auto)"}, 'n': {s: "decltype(nullptr)"},
+}
+
+// typ parses <type>. Every type but the builtin ones is a substitution candidate.
+func (st *state) typ() node {
+	st.enter()
+	st.inType++
+	defer func() {
+		st.inType--
+		st.leave()
+	}()
+
+	c := st.peek()
+	if b, ok := builtins[c]; ok {
+		st.pos++
+		return b
+	}
+
+	var t node
+	switch c {
+	case 'u':
+		st.pos++
+		t = st.sourceName()
+	case 'r', 'V', 'K':
+		quals := st.cvQualifiers()
+		if c := st.peek(); c == 'F' || c == 'D' && strings.IndexByte("xoOw", st.peekAt(1)) >= 0 {
+			// The type of a const member function, in a pointer to member. The qualifiers
+			// are part of the function type, which is a substitution only as a whole.
+			f := st.functionType()
+			f.quals = quals + f.quals
+			t = f
+		} else {
+			t = &qualified{child: st.typ(), quals: quals}
+		}
+	case 'U':
+		st.pos++
+		vendor := st.sourceName().s
+		if st.peek() == 'I' {
+			args := st.templateArgs()
+			p := &printer{pack: -1}
+			args.left(p)
+			vendor += string(p.buf)
+		}
+		t = &qualified{child: st.typ(), quals: " " + vendor}
+	case 'F':
+		t = st.functionType()
+	case 'A':
+		t = st.arrayType()
+	case 'M':
+		st.pos++
+		class := st.typ()
+		t = &memberPointer{class: class, member: st.typ()}
+	case 'T':
+		switch st.peekAt(1) {
+		case 's', 'u', 'e':
+			// Elaborated type specifiers, struct, union and enum
+			st.pos += 2
+			t = st.name()
+		default:
+			t = st.templateParam()
+			if st.peek() == 'I' {
+				st.push(t)
+				t = &template{name: t, args: st.templateArgs()}
+			}
+		}
+	case 'P':
+		st.pos++
+		t = &pointer{child: st.typ(), op: "*"}
+	case 'R':
+		st.pos++
+		t = &pointer{child: st.typ(), op: "&"}
+	case 'O':
+		st.pos++
+		t = &pointer{child: st.typ(), op: "&&"}
+	case 'C':
+		st.pos++
+		t = &qualified{child: st.typ(), quals: " _Complex"}
+	case 'G':
+		st.pos++
+		t = &qualified{child: st.typ(), quals: " _Imaginary"}
+	case 'S':
+		if st.peekAt(1) == 't' {
+			t = st.name()
+			break
+		}
+		t = st.substitution(false)
+		if st.peek() != 'I' {
+			return t
+		}
+		t = &template{name: t, args: st.templateArgs()}
+	case 'D':
+		if b, ok := dBuiltins[st.peekAt(1)]; ok {
+			st.pos += 2
+			return b
+		}
+		t = st.dType()
+	default:
+		t = st.name()
+	}
+
+	st.push(t)
+	return t
+}
+
+// dType parses the types with a two letter code starting with D that are not builtin.
+func (st *state) dType() node {
+	switch st.peekAt(1) {
+	case 'F':
+		// _FloatN and _FloatNx
+		st.pos += 2
+		bits := st.number()
+		s := "_Float" + strconv.Itoa(bits)
+		if st.next("x") {
+			s += "x"
+		} else {
+			st.expect('_')
+		}
+		return &name{s: s}
+	case 'B', 'U':
+		unsigned := st.peekAt(1) == 'U'
+		st.pos += 2
+		var width node
+		if isDigit(st.peek()) {
+			width = &name{s: strconv.Itoa(st.number())}
+		} else {
+			width = st.expression()
+		}
+		st.expect('_')
+		n := &wrapped{pre: "_BitInt(", child: width, post: ")"}
+		if unsigned {
+			n.pre = "unsigned " + n.pre
+		}
+		return n
+	case 'p':
+		st.pos += 2
+		return &packExpansion{child: st.typ()}
+	case 't', 'T':
+		return st.decltype()
+	case 'v':
+		st.pos += 2
+		v := &vector{}
+		if st.next("_") {
+			v.dim = st.expression()
+		} else {
+			v.dim = &name{s: strconv.Itoa(st.number())}
+		}
+		st.expect('_')
+		v.elem = st.typ()
+		return v
+	case 'x', 'o', 'O', 'w':
+		// Transaction safety and exception specifications of a function type
+		return st.functionType()
+	}
+	st.fail()
+	return nil
+}
+
+// decltype parses Dt<expression>E and DT<expression>E.
+func (st *state) decltype() node {
+	st.pos += 2
+	e := st.expression()
+	st.expect('E')
+	return &wrapped{pre: "decltype (", child: e, post: ")"}
+}
+
+// functionType parses [Dx][<exception-spec>]F[Y]<return><params>[<ref-qualifier>]E.
+func (st *state) functionType() *funcType {
+	for {
+		switch {
+		case st.next("Dx"), st.next("Do"):
+			continue
+		case st.next("DO"):
+			// Computed noexcept
+			st.expression()
+			st.expect('E')
+			continue
+		case st.next("Dw"):
+			// Dynamic exception specification
+			for !st.next("E") {
+				if st.peek() == 0 {
+					st.fail()
+				}
+				st.typ()
+			}
+			continue
+		}
+		break
+	}
+	st.expect('F')
+	st.next("Y")
+
+	f := &funcType{ret: st.typ()}
+	for {
+		switch {
+		case st.next("E"):
+			return f
+		case st.next("RE"):
+			f.quals = " &"
+			return f
+		case st.next("OE"):
+			f.quals = " &&"
+			return f
+		case st.peek() == 0:
+			st.fail()
+		}
+		f.params = append(f.params, st.typ())
+	}
+}
+
+// arrayType parses A<dimension>_<element>, where the dimension is a number, an expression or
+// missing.
+func (st *state) arrayType() node {
+	st.expect('A')
+	a := &arrayType{}
+	switch {
+	case isDigit(st.peek()):
+		a.dim = &name{s: strconv.Itoa(st.number())}
+	case st.peek() != '_':
+		a.dim = st.expression()
+	}
+	st.expect('_')
+	a.elem = st.typ()
+	return a
+}
@@ -0,0 +1,679 @@
+package demangle
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Limits that keep hostile names from blowing up. Substitutions let a short name refer to
+// the same subtree over and over, so the output is capped rather than the input.
+const (
+	maxOutput = 1 << 16
+	maxDepth  = 256
+)
+
+// node is a piece of a demangled C++ name. Types print in two halves around whatever they
+// declare, so that a pointer to function comes out as "void (*)(int)": left is the part
+// before the declarator and right the part after it. Most nodes only have a left half.
+type node interface {
+	left(p *printer)
+	right(p *printer)
+}
+
+// leaf provides the empty right half of the nodes that print in one piece.
+type leaf struct{}
+
+func (leaf) right(*printer) {}
+
+// printer renders a node tree. Template parameters are bound while printing, against the
+// argument lists of the encodings being printed, which is how a substitution that refers to
+// T_ picks up the arguments of the function it is used in.
+type printer struct {
+	buf    []byte
+	args   []*templateArgs // argument lists template parameters refer to, innermost last
+	lambda bool            // printing a lambda signature, where unbound parameters are auto
+	pack   int             // element of the argument pack being expanded, -1 outside
+	packs  int             // length of the last argument pack met while sizing an expansion
+	depth  int
+	failed bool
+}
+
+// render prints n, reporting false when it does not fit the limits.
+func render(n node) (string, bool) {
+	p := &printer{pack: -1}
+	p.print(n)
+	if p.failed {
+		return "", false
+	}
+	return string(p.buf), true
+}
+
+func (p *printer) print(n node) {
+	p.left(n)
+	p.right(n)
+}
+
+func (p *printer) left(n node) {
+	if p.enter() {
+		n.left(p)
+	}
+	p.depth--
+}
+
+func (p *printer) right(n node) {
+	if p.enter() {
+		n.right(p)
+	}
+	p.depth--
+}
+
+// enter counts one more level of nesting, reporting whether printing may go on.
+func (p *printer) enter() bool {
+	p.depth++
+	if p.depth > maxDepth || len(p.buf) > maxOutput {
+		p.failed = true
+	}
+	return !p.failed
+}
+
+func (p *printer) write(s string) {
+	p.buf = append(p.buf, s...)
+}
+
+// last returns the last byte printed, or 0 when nothing was.
+func (p *printer) last() byte {
+	if len(p.buf) == 0 {
+		return 0
+	}
+	return p.buf[len(p.buf)-1]
+}
+
+// name is text printed as it is: identifiers, builtin types and literal values.
+type name struct {
+	leaf
+	s string
+}
+
+func (n *name) left(p *printer) { p.write(n.s) }
+
+// nested is a qualified name, scope::name.
+type nested struct {
+	leaf
+	scope, name node
+}
+
+func (n *nested) left(p *printer) {
+	p.print(n.scope)
+	p.write("::")
+	p.print(n.name)
+}
+
+// template is a template name with its arguments.
+type template struct {
+	leaf
+	name node
+	args *templateArgs
+}
+
+func (t *template) left(p *printer) {
+	p.print(t.name)
+	t.args.left(p)
+}
+
+// templateArgs is a template argument list. Template parameters refer to its elements.
+type templateArgs struct {
+	leaf
+	args []node
+}
+
+func (t *templateArgs) left(p *printer) {
+	// "operator< <int>" and "a<b<c> >" keep the angle brackets apart
+	if p.last() == '<' {
+		p.write(" ")
+	}
+	p.write("<")
+	p.commaList(t.args)
+	if p.last() == '>' {
+		p.write(" ")
+	}
+	p.write(">")
+}
+
+// commaList prints nodes separated by commas. Expansions of empty packs leave no gap.
+func (p *printer) commaList(nodes []node) {
+	first := true
+	for _, n := range nodes {
+		mark := len(p.buf)
+		if !first {
+			p.write(", ")
+		}
+		start := len(p.buf)
+		p.print(n)
+		if len(p.buf) == start {
+			p.buf = p.buf[:mark]
+			continue
+		}
+		first = false
+	}
+}
+
+// argPack is a template argument pack, J...E.
+type argPack struct {
+	leaf
+	args []node
+}
+
+func (a *argPack) left(p *printer) { p.commaList(a.args) }
+
+// templateParam is a reference to a template argument, T_ or T<n>_.
+type templateParam struct {
+	index int
+}
+
+// bind looks the parameter up in the innermost argument list. Its argument is printed
+// against the enclosing lists only, so that a parameter bound to itself cannot loop.
+func (t *templateParam) bind(p *printer) (node, func()) {
+	if len(p.args) == 0 || p.args[len(p.args)-1] == nil || t.index >= len(p.args[len(p.args)-1].args) {
+		return nil, nil
+	}
+	saved := p.args
+	arg := saved[len(saved)-1].args[t.index]
+	// Capped, so that an encoding printed in the argument appends instead of overwriting
+	p.args = saved[: len(saved)-1 : len(saved)-1]
+
+	if pack, ok := arg.(*argPack); ok && p.pack >= 0 {
+		p.packs = len(pack.args)
+		if p.pack < len(pack.args) {
+			arg = pack.args[p.pack]
+		}
+	}
+	return arg, func() { p.args = saved }
+}
+
+func (t *templateParam) left(p *printer) {
+	arg, restore := t.bind(p)
+	if arg == nil {
+		if p.lambda {
+			p.write("auto:" + strconv.Itoa(t.index+1))
+			return
+		}
+		p.failed = true
+		return
+	}
+	p.left(arg)
+	restore()
+}
+
+func (t *templateParam) right(p *printer) {
+	if arg, restore := t.bind(p); arg != nil {
+		p.right(arg)
+		restore()
+	}
+}
+
+// resolve follows template parameters to the node they stand for.
+func (p *printer) resolve(n node) node {
+	n, restore := p.deref(n)
+	restore()
+	return n
+}
+
+// deref follows template parameters to the node they stand for, leaving the printer bound
+// to the arguments that node is written against until restore is called.
+func (p *printer) deref(n node) (node, func()) {
+	saved := p.args
+	for i := 0; i < maxDepth; i++ {
+		t, ok := n.(*templateParam)
+		if !ok {
+			break
+		}
+		arg, _ := t.bind(p)
+		if arg == nil {
+			break
+		}
+		n = arg
+	}
+	if q, ok := n.(*qualified); ok {
+		n = q.fold(p)
+	}
+	return n, func() { p.args = saved }
+}
+
+// packExpansion is a pack expansion, Dp or sp, printed once for each element of the pack
+// it expands.
+type packExpansion struct {
+	leaf
+	child node
+}
+
+func (e *packExpansion) left(p *printer) {
+	// Size the pack with a throwaway print of the first element
+	probe := &printer{args: p.args, lambda: p.lambda, pack: 0, packs: -1, depth: p.depth}
+	probe.print(e.child)
+	if probe.failed {
+		p.failed = true
+		return
+	}
+	if probe.packs < 0 {
+		p.print(e.child)
+		return
+	}
+
+	saved := p.pack
+	for i := 0; i < probe.packs; i++ {
+		if i > 0 {
+			p.write(", ")
+		}
+		p.pack = i
+		p.print(e.child)
+	}
+	p.pack = saved
+}
+
+// qualified is a type with cv-qualifiers or a vendor qualifier, printed after it: "char const".
+type qualified struct {
+	child node
+	quals string
+}
+
+func (q *qualified) left(p *printer) {
+	if n, restore := p.deref(q); n != q {
+		p.left(n)
+		restore()
+		return
+	}
+	p.left(q.child)
+	p.write(q.quals)
+}
+
+func (q *qualified) right(p *printer) {
+	if n, restore := p.deref(q); n != q {
+		p.right(n)
+		restore()
+		return
+	}
+	p.right(q.child)
+}
+
+// fold merges the qualifiers with those of a parameter bound to a qualified type, "T const"
+// with T = "int const", and moves them onto the element of an array, "char const [4]". It
+// returns q itself, with the printer left as it was, when there is nothing to fold.
+func (q *qualified) fold(p *printer) node {
+	saved := p.args
+	child, _ := p.deref(q.child)
+	switch c := child.(type) {
+	case *qualified:
+		quals := c.quals
+		for _, qual := range strings.Fields(q.quals) {
+			if !strings.Contains(quals+" ", " "+qual+" ") {
+				quals += " " + qual
+			}
+		}
+		return &qualified{child: c.child, quals: quals}
+	case *arrayType:
+		return &arrayType{elem: &qualified{child: c.elem, quals: q.quals}, dim: c.dim}
+	}
+	p.args = saved
+	return q
+}
+
+// pointer is a pointer or reference type, op is "*", "&" or "&&".
+type pointer struct {
+	child node
+	op    string
+}
+
+func (t *pointer) left(p *printer) {
+	child, op, restore := t.collapse(p)
+	defer restore()
+	p.left(child)
+	switch child.(type) {
+	case *funcType:
+		p.write("(")
+	case *arrayType:
+		p.write(" (")
+	}
+	p.write(op)
+}
+
+func (t *pointer) right(p *printer) {
+	child, _, restore := t.collapse(p)
+	defer restore()
+	switch child.(type) {
+	case *funcType, *arrayType:
+		p.write(")")
+	}
+	p.right(child)
+}
+
+// collapse resolves the pointee, folding references to references the way C++ does: T& &&
+// is T&, T&& && is T&&.
+func (t *pointer) collapse(p *printer) (node, string, func()) {
+	child, restore := p.deref(t.child)
+	op := t.op
+	if op == "*" {
+		return child, op, restore
+	}
+	for i := 0; i < maxDepth; i++ {
+		r, ok := child.(*pointer)
+		if !ok || r.op == "*" {
+			break
+		}
+		if r.op == "&" {
+			op = "&"
+		}
+		child, _ = p.deref(r.child)
+	}
+	return child, op, restore
+}
+
+// memberPointer is a pointer to member of class, M<class><member>.
+type memberPointer struct {
+	class, member node
+}
+
+func (m *memberPointer) left(p *printer) {
+	p.left(m.member)
+	switch p.resolve(m.member).(type) {
+	case *funcType:
+		p.write("(")
+	case *arrayType:
+		p.write(" (")
+	default:
+		p.write(" ")
+	}
+	p.print(m.class)
+	p.write("::*")
+}
+
+func (m *memberPointer) right(p *printer) {
+	switch p.resolve(m.member).(type) {
+	case *funcType, *arrayType:
+		p.write(")")
+	}
+	p.right(m.member)
+}
+
+// arrayType is an array type. The dimension may be missing, or be an expression.
+type arrayType struct {
+	elem node
+	dim  node
+}
+
+func (a *arrayType) left(p *printer) { p.left(a.elem) }
+
+func (a *arrayType) right(p *printer) {
+	// Inner dimensions follow the outer one without a space: "int [2][3]"
+	if p.last() != ']' {
+		p.write(" ")
+	}
+	p.write("[")
+	if a.dim != nil {
+		p.print(a.dim)
+	}
+	p.write("]")
+	p.right(a.elem)
+}
+
+// funcType is a function type, whether it is the type of an encoding or of a parameter.
+type funcType struct {
+	ret    node // nil when the encoding has no return type
+	params []node
+	quals  string // cv-qualifiers and ref-qualifier of a member function, " const &"
+}
+
+func (f *funcType) left(p *printer) {
+	if f.ret != nil {
+		p.left(f.ret)
+		if !rightHeavy(p, f.ret) {
+			p.write(" ")
+		}
+	}
+}
+
+func (f *funcType) right(p *printer) {
+	f.paramList(p)
+	if f.ret != nil {
+		p.right(f.ret)
+	}
+}
+
+func (f *funcType) paramList(p *printer) {
+	p.write("(")
+	if !(len(f.params) == 1 && isVoid(f.params[0])) {
+		p.commaList(f.params)
+	}
+	p.write(")")
+	p.write(f.quals)
+}
+
+// isVoid reports whether n is the lone void of an empty parameter list.
+func isVoid(n node) bool {
+	b, ok := n.(*name)
+	return ok && b == builtins['v']
+}
+
+// encoding is a function, its name with its type. Template parameters in the type refer to
+// args, the last template argument list of the name.
+type encoding struct {
+	leaf
+	name node
+	typ  *funcType
+	args *templateArgs
+}
+
+func (e *encoding) left(p *printer) {
+	e.print(p, true)
+}
+
+// print prints the function, without its return type when it is the scope of a local name.
+func (e *encoding) print(p *printer, withReturn bool) {
+	p.args = append(p.args, e.args)
+	defer func() { p.args = p.args[:len(p.args)-1] }()
+
+	ret := e.typ.ret
+	if !withReturn {
+		ret = nil
+	}
+	if ret != nil {
+		p.left(ret)
+		// A pointer to function returned keeps its declarator around the name
+		if !rightHeavy(p, ret) {
+			p.write(" ")
+		}
+	}
+	p.print(e.name)
+	e.typ.paramList(p)
+	if ret != nil {
+		p.right(ret)
+	}
+}
+
+// rightHeavy reports whether n prints something on its right, like a pointer to function.
+func rightHeavy(p *printer, n node) bool {
+	switch n := p.resolve(n).(type) {
+	case *pointer:
+		return rightHeavy(p, n.child) || isDeclarator(p, n.child)
+	case *memberPointer:
+		return isDeclarator(p, n.member)
+	case *qualified:
+		return rightHeavy(p, n.child)
+	}
+	return false
+}
+
+func isDeclarator(p *printer, n node) bool {
+	switch p.resolve(n).(type) {
+	case *funcType, *arrayType:
+		return true
+	}
+	return false
+}
+
+// scoped binds template parameters to args while printing child, for the entities of a
+// local name, which live in the scope of the function they are local to.
+type scoped struct {
+	leaf
+	child node
+	args  *templateArgs
+}
+
+func (s *scoped) left(p *printer) {
+	p.args = append(p.args, s.args)
+	p.print(s.child)
+	p.args = p.args[:len(p.args)-1]
+}
+
+// wrapped is a node printed between fixed text, like "vtable for " or "decltype (".
+type wrapped struct {
+	leaf
+	pre   string
+	child node
+	post  string
+}
+
+func (w *wrapped) left(p *printer) {
+	p.write(w.pre)
+	p.print(w.child)
+	p.write(w.post)
+}
+
+// joined is two nodes printed with text between them.
+type joined struct {
+	leaf
+	a   node
+	sep string
+	b   node
+}
+
+func (j *joined) left(p *printer) {
+	p.print(j.a)
+	p.write(j.sep)
+	p.print(j.b)
+}
+
+// localName is an entity declared inside a function. entity is name bound to the template
+// arguments of the function.
+type localName struct {
+	leaf
+	enc          node
+	entity, name node
+}
+
+func (l *localName) left(p *printer) {
+	if e, ok := l.enc.(*encoding); ok {
+		e.print(p, false)
+	} else {
+		p.print(l.enc)
+	}
+	p.write("::")
+	p.print(l.entity)
+}
+
+// conversion is a conversion operator, operator int.
+type conversion struct {
+	leaf
+	typ node
+}
+
+func (c *conversion) left(p *printer) {
+	p.write("operator ")
+	p.print(c.typ)
+}
+
+// ctor is a constructor or destructor, named after the class it belongs to.
+type ctor struct {
+	leaf
+	class node
+	dtor  bool
+}
+
+func (c *ctor) left(p *printer) {
+	if c.dtor {
+		p.write("~")
+	}
+	p.baseName(c.class)
+}
+
+// baseName prints the unqualified name of a class without its template arguments.
+func (p *printer) baseName(n node) {
+	switch n := p.resolve(n).(type) {
+	case *nested:
+		p.baseName(n.name)
+	case *template:
+		p.baseName(n.name)
+	case *abiTagged:
+		p.baseName(n.child)
+	case *stdName:
+		p.write(n.base)
+	case *name:
+		p.write(n.s[strings.LastIndex(n.s, "::")+1:])
+	default:
+		p.print(n)
+	}
+}
+
+// stdName is one of the abbreviations of the std namespace, Sa to Sd. base is the name its
+// constructors take.
+type stdName struct {
+	leaf
+	s, base string
+}
+
+func (n *stdName) left(p *printer) { p.write(n.s) }
+
+// abiTagged is a name with an ABI tag, name[abi:tag].
+type abiTagged struct {
+	leaf
+	child node
+	tag   string
+}
+
+func (a *abiTagged) left(p *printer) {
+	p.print(a.child)
+	p.write("[abi:" + a.tag + "]")
+}
+
+// closure is the type of a lambda, numbered from 1 within its scope.
+type closure struct {
+	leaf
+	params []node
+	num    int
+}
+
+func (c *closure) left(p *printer) {
+	saved := p.lambda
+	p.lambda = true
+	p.write("{lambda(")
+	if !(len(c.params) == 1 && isVoid(c.params[0])) {
+		p.commaList(c.params)
+	}
+	p.write(")#" + strconv.Itoa(c.num) + "}")
+	p.lambda = saved
+}
+
+// vector is a vector type, Dv<n>_<type>.
+type vector struct {
+	leaf
+	elem node
+	dim  node
+}
+
+func (v *vector) left(p *printer) {
+	p.print(v.elem)
+	p.write(" __vector(")
+	p.print(v.dim)
+	p.write(")")
+}
+
+// cloneSuffix is a compiler generated copy of a function, like foo() [clone .cold].
+type cloneSuffix struct {
+	leaf
+	child  node
+	suffix string
+}
+
+func (c *cloneSuffix) left(p *printer) {
+	p.print(c.child)
+	p.write(" [clone " + c.suffix + "]")
+}
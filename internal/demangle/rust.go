@@ -0,0 +1,872 @@
+package demangle
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Rust names come in two schemes. Legacy names borrow the Itanium nested-name syntax and end
+// in a hash component, _ZN4core3fmt9Formatter3pad17h1f3c5e2e0b7f4a1aE. The v0 scheme, _R...,
+// has a grammar of its own, https://doc.rust-lang.org/rustc/symbol-mangling/v0.html.
+//
+// Both print the way rustc-demangle prints them in its alternate form, {:#}: without the
+// hash of a legacy name, the disambiguators of crates or the type suffixes of constants.
+
+// rustLegacy demangles a legacy Rust name, reporting false for a name that does not end in
+// a hash component, which leaves it to the C++ demangler.
+func rustLegacy(mangled string) (string, bool) {
+	s := strings.TrimPrefix(mangled, "_")
+	if !strings.HasPrefix(s, "ZN") && !strings.HasPrefix(s, "_ZN") {
+		return "", false
+	}
+	s = s[strings.IndexByte(s, 'N')+1:]
+
+	var elems []string
+	for !strings.HasPrefix(s, "E") {
+		i := 0
+		for i < len(s) && isDigit(s[i]) {
+			i++
+		}
+		n, err := strconv.Atoi(s[:i])
+		if i == 0 || s[0] == '0' || err != nil || n > len(s)-i {
+			return "", false
+		}
+		elems = append(elems, s[i:i+n])
+		s = s[i+n:]
+	}
+	if len(elems) < 2 || !isRustHash(elems[len(elems)-1]) {
+		return "", false
+	}
+	elems = elems[:len(elems)-1]
+
+	// A suffix LLVM added when it made a local copy of the function is dropped, as
+	// rustc-demangle does, others are kept
+	suffix := s[1:]
+	if suffix != "" && suffix[0] != '.' {
+		return "", false
+	}
+	if strings.HasPrefix(suffix, ".llvm.") {
+		suffix = ""
+	}
+
+	var b strings.Builder
+	for i, elem := range elems {
+		if i > 0 {
+			b.WriteString("::")
+		}
+		unescapeLegacy(&b, elem)
+	}
+	b.WriteString(suffix)
+	return b.String(), true
+}
+
+// isRustHash reports whether elem is the hash that ends a legacy name, h and 16 hex digits.
+// A real hash uses a good few distinct digits, where a word spelled in hex repeats a handful.
+func isRustHash(elem string) bool {
+	if len(elem) != 17 || elem[0] != 'h' {
+		return false
+	}
+	seen := map[rune]bool{}
+	for _, c := range elem[1:] {
+		if !strings.ContainsRune("0123456789abcdef", c) {
+			return false
+		}
+		seen[c] = true
+	}
+	return len(seen) >= 5
+}
+
+// Escapes of the characters a legacy name component cannot hold.
+var legacyEscapes = map[string]string{
+	"SP": "@", "BP": "*", "RF": "&", "LT": "<", "GT": ">", "LP": "(", "RP": ")", "C": ",",
+}
+
+// unescapeLegacy writes a legacy name component with its $..$ escapes decoded and .. turned
+// into ::. An escape it does not know stops the decoding, the rest is written as it is.
+func unescapeLegacy(b *strings.Builder, elem string) {
+	// An underscore keeps a component from starting with an escape
+	rest := elem
+	if strings.HasPrefix(rest, "_$") {
+		rest = rest[1:]
+	}
+	for rest != "" {
+		switch {
+		case strings.HasPrefix(rest, ".."):
+			b.WriteString("::")
+			rest = rest[2:]
+		case rest[0] == '.':
+			b.WriteByte('.')
+			rest = rest[1:]
+		case rest[0] == '$':
+			end := strings.IndexByte(rest[1:], '$')
+			if end < 0 {
+				b.WriteString(rest)
+				return
+			}
+			escape := rest[1 : end+1]
+			if s, ok := legacyEscapes[escape]; ok {
+				b.WriteString(s)
+			} else if c, ok := unicodeEscape(escape); ok {
+				b.WriteRune(c)
+			} else {
+				b.WriteString(rest)
+				return
+			}
+			rest = rest[end+2:]
+		default:
+			i := strings.IndexAny(rest, "$.")
+			if i < 0 {
+				i = len(rest)
+			}
+			b.WriteString(rest[:i])
+			rest = rest[i:]
+		}
+	}
+}
+
+// unicodeEscape decodes a $u<hex>$ escape, which must spell out a printable character in
+// lower case hex.
+func unicodeEscape(escape string) (rune, bool) {
+	if len(escape) < 2 || escape[0] != 'u' || strings.Trim(escape[1:], "0123456789abcdef") != "" {
+		return 0, false
+	}
+	v, err := strconv.ParseUint(escape[1:], 16, 32)
+	if err != nil || !utf8.ValidRune(rune(v)) || unicode.IsControl(rune(v)) {
+		return 0, false
+	}
+	return rune(v), true
+}
+
+// rustV0 demangles a Rust v0 name, _R<path>[<instantiating-crate>].
+func rustV0(mangled string) (out string, ok bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			if _, failed := r.(parseError); !failed {
+				panic(r)
+			}
+			out, ok = "", false
+		}
+	}()
+
+	s := strings.TrimPrefix(mangled, "_")
+	if !strings.HasPrefix(s, "R") && !strings.HasPrefix(s, "_R") {
+		return "", false
+	}
+	// A version number would come first, only the unnumbered version 0 exists
+	s = s[strings.IndexByte(s, 'R')+1:]
+	if s == "" || s[0] < 'A' || s[0] > 'Z' {
+		return "", false
+	}
+
+	v := &v0{s: s}
+	v.path(true)
+	// The crate the item was instantiated in is not part of its name
+	if v.pos < len(v.s) {
+		v.skip++
+		v.path(false)
+		v.skip--
+	}
+	if v.pos != len(v.s) {
+		v.fail()
+	}
+	return v.out.String(), true
+}
+
+// v0 is the parser of a Rust v0 name. It prints as it parses: backreferences are followed
+// by parsing again from where they point, and skip silences the parts not printed.
+type v0 struct {
+	s   string
+	pos int
+	out strings.Builder
+
+	skip      int // nesting of the parts that are parsed without printing
+	lifetimes int // lifetimes bound by the enclosing for<...> binders
+	depth     int
+}
+
+func (v *v0) fail() {
+	panic(parseError{})
+}
+
+func (v *v0) peek() byte {
+	if v.pos >= len(v.s) {
+		return 0
+	}
+	return v.s[v.pos]
+}
+
+func (v *v0) next() byte {
+	c := v.peek()
+	if c == 0 {
+		v.fail()
+	}
+	v.pos++
+	return c
+}
+
+// eat consumes c when the input continues with it.
+func (v *v0) eat(c byte) bool {
+	if v.peek() == c {
+		v.pos++
+		return true
+	}
+	return false
+}
+
+func (v *v0) print(s string) {
+	if v.skip > 0 {
+		return
+	}
+	if v.out.Len() > maxOutput {
+		v.fail()
+	}
+	v.out.WriteString(s)
+}
+
+// enter counts one more level of recursion, failing past maxDepth. Calls are paired with
+// a deferred leave.
+func (v *v0) enter() {
+	v.depth++
+	if v.depth > maxDepth {
+		v.fail()
+	}
+}
+
+func (v *v0) leave() {
+	v.depth--
+}
+
+// base62 parses <base-62-number>, digits 0-9a-zA-Z terminated by an underscore, where a
+// lone underscore is 0 and the digits count from 1.
+func (v *v0) base62() uint64 {
+	if v.eat('_') {
+		return 0
+	}
+	var x uint64
+	for !v.eat('_') {
+		c := v.next()
+		var d uint64
+		switch {
+		case isDigit(c):
+			d = uint64(c - '0')
+		case c >= 'a' && c <= 'z':
+			d = uint64(c-'a') + 10
+		case c >= 'A' && c <= 'Z':
+			d = uint64(c-'A') + 36
+		default:
+			v.fail()
+		}
+		if x > (math.MaxUint64-d)/62-1 {
+			v.fail()
+		}
+		x = x*62 + d
+	}
+	return x + 1
+}
+
+// optBase62 parses a base 62 number introduced by tag, which counts from 1 so that 0 is
+// left for its absence.
+func (v *v0) optBase62(tag byte) uint64 {
+	if !v.eat(tag) {
+		return 0
+	}
+	return v.base62() + 1
+}
+
+// decimal parses the length of an identifier. A zero stands alone, the digits after it
+// start the identifier.
+func (v *v0) decimal() int {
+	if v.eat('0') {
+		return 0
+	}
+	start := v.pos
+	for isDigit(v.peek()) {
+		v.pos++
+	}
+	if v.pos == start || v.pos-start > 9 {
+		v.fail()
+	}
+	n, _ := strconv.Atoi(v.s[start:v.pos])
+	return n
+}
+
+// ident parses <identifier>, [u]<decimal>[_]<bytes>, where u marks a Punycode encoded one.
+func (v *v0) ident() string {
+	puny := v.eat('u')
+	n := v.decimal()
+	v.eat('_')
+	if n > len(v.s)-v.pos {
+		v.fail()
+	}
+	s := v.s[v.pos : v.pos+n]
+	v.pos += n
+	if !puny {
+		return s
+	}
+
+	// The basic code points come first, up to the last underscore, which stands in for
+	// Punycode's hyphen
+	basic, encoded := "", s
+	if i := strings.LastIndexByte(s, '_'); i >= 0 {
+		basic, encoded = s[:i], s[i+1:]
+	}
+	decoded, ok := punycode(basic, encoded)
+	if !ok {
+		return "punycode{" + s + "}"
+	}
+	return decoded
+}
+
+// backref follows B<base-62-number> to an earlier position and runs f from there.
+func (v *v0) backref(f func()) {
+	start := v.pos - 1
+	target := v.base62()
+	if target >= uint64(start) {
+		v.fail()
+	}
+	if v.skip > 0 {
+		return
+	}
+	v.enter()
+	defer v.leave()
+	saved := v.pos
+	v.pos = int(target)
+	f()
+	v.pos = saved
+}
+
+// path parses a <path>. Generic arguments of a path used as a value are introduced by ::<,
+// those of a path used as a type by < alone.
+func (v *v0) path(inValue bool) {
+	v.enter()
+	defer v.leave()
+
+	switch c := v.next(); c {
+	case 'C':
+		v.optBase62('s')
+		v.print(v.ident())
+	case 'M':
+		v.implPath()
+		v.print("<")
+		v.typ()
+		v.print(">")
+	case 'X':
+		v.implPath()
+		v.print("<")
+		v.typ()
+		v.print(" as ")
+		v.path(false)
+		v.print(">")
+	case 'Y':
+		v.print("<")
+		v.typ()
+		v.print(" as ")
+		v.path(false)
+		v.print(">")
+	case 'N':
+		ns := v.next()
+		v.path(inValue)
+		dis := v.optBase62('s')
+		name := v.ident()
+		switch {
+		case ns >= 'A' && ns <= 'Z':
+			// Closures and shims are numbered within their parent
+			v.print("::{")
+			switch ns {
+			case 'C':
+				v.print("closure")
+			case 'S':
+				v.print("shim")
+			default:
+				v.print(string(ns))
+			}
+			if name != "" {
+				v.print(":" + name)
+			}
+			v.print("#" + strconv.FormatUint(dis, 10) + "}")
+		case ns >= 'a' && ns <= 'z':
+			if name != "" {
+				v.print("::" + name)
+			}
+		default:
+			v.fail()
+		}
+	case 'I':
+		v.path(inValue)
+		if inValue {
+			v.print("::")
+		}
+		v.print("<")
+		v.list(v.genericArg)
+		v.print(">")
+	case 'B':
+		v.backref(func() { v.path(inValue) })
+	default:
+		v.fail()
+	}
+}
+
+// implPath parses the path an impl block sits in, which is not printed.
+func (v *v0) implPath() {
+	v.skip++
+	v.optBase62('s')
+	v.path(false)
+	v.skip--
+}
+
+// list parses elements with f up to an E, printing them separated by commas, and returns
+// how many there were.
+func (v *v0) list(f func()) int {
+	n := 0
+	for !v.eat('E') {
+		if n > 0 {
+			v.print(", ")
+		}
+		f()
+		n++
+	}
+	return n
+}
+
+func (v *v0) genericArg() {
+	switch {
+	case v.eat('L'):
+		v.lifetime(v.base62())
+	case v.eat('K'):
+		v.constant(false)
+	default:
+		v.typ()
+	}
+}
+
+// lifetime prints a lifetime by its de Bruijn index among the bound ones, 'a for the
+// outermost, or '_ for index 0, the erased lifetime.
+func (v *v0) lifetime(i uint64) {
+	if i == 0 {
+		v.print("'_")
+		return
+	}
+	if i > uint64(v.lifetimes) {
+		v.fail()
+	}
+	depth := uint64(v.lifetimes) - i
+	if depth < 26 {
+		v.print("'" + string(rune('a'+depth)))
+	} else {
+		v.print("'_" + strconv.FormatUint(depth, 10))
+	}
+}
+
+// binder parses the lifetimes a for<...> binder introduces, G<base-62-number>, and runs f
+// with them bound.
+func (v *v0) binder(f func()) {
+	n := v.optBase62('G')
+	if n > uint64(maxDepth) {
+		v.fail()
+	}
+	if n > 0 {
+		v.print("for<")
+		for i := uint64(0); i < n; i++ {
+			if i > 0 {
+				v.print(", ")
+			}
+			v.lifetimes++
+			v.lifetime(1)
+		}
+		v.print("> ")
+	}
+	f()
+	v.lifetimes -= int(n)
+}
+
+// rustBasicTypes are the types spelled with a single lower case letter.
+var rustBasicTypes = map[byte]string{
+	'a': "i8", 'b': "bool", 'c': "char", 'd': "f64", 'e': "str", 'f': "f32", 'h': "u8",
+	'i': "isize", 'j': "usize", 'l': "i32", 'm': "u32", 'n': "i128", 'o': "u128", 's': "i16",
+	't': "u16", 'u': "()", 'v': "...", 'x': "i64", 'y': "u64", 'z': "!", 'p': "_",
+}
+
+// typ parses a <type>.
+func (v *v0) typ() {
+	v.enter()
+	defer v.leave()
+
+	c := v.next()
+	if t, ok := rustBasicTypes[c]; ok {
+		v.print(t)
+		return
+	}
+	switch c {
+	case 'R', 'Q':
+		v.print("&")
+		if v.eat('L') {
+			if lt := v.base62(); lt != 0 {
+				v.lifetime(lt)
+				v.print(" ")
+			}
+		}
+		if c == 'Q' {
+			v.print("mut ")
+		}
+		v.typ()
+	case 'P':
+		v.print("*const ")
+		v.typ()
+	case 'O':
+		v.print("*mut ")
+		v.typ()
+	case 'A':
+		v.print("[")
+		v.typ()
+		v.print("; ")
+		v.constant(true)
+		v.print("]")
+	case 'S':
+		v.print("[")
+		v.typ()
+		v.print("]")
+	case 'T':
+		v.print("(")
+		if v.list(v.typ) == 1 {
+			v.print(",")
+		}
+		v.print(")")
+	case 'F':
+		v.binder(v.fnSig)
+	case 'D':
+		v.print("dyn ")
+		v.binder(func() {
+			for n := 0; !v.eat('E'); n++ {
+				if n > 0 {
+					v.print(" + ")
+				}
+				v.dynTrait()
+			}
+		})
+		if !v.eat('L') {
+			v.fail()
+		}
+		if lt := v.base62(); lt != 0 {
+			v.print(" + ")
+			v.lifetime(lt)
+		}
+	case 'B':
+		v.backref(v.typ)
+	default:
+		v.pos--
+		v.path(false)
+	}
+}
+
+// fnSig parses the signature of a function pointer type, [U][K<abi>]<type>*E<type>.
+func (v *v0) fnSig() {
+	if v.eat('U') {
+		v.print("unsafe ")
+	}
+	if v.eat('K') {
+		abi := "C"
+		if !v.eat('C') {
+			abi = strings.ReplaceAll(v.ident(), "_", "-")
+		}
+		v.print(`extern "` + abi + `" `)
+	}
+	v.print("fn(")
+	v.list(v.typ)
+	v.print(")")
+	if !v.eat('u') {
+		v.print(" -> ")
+		v.typ()
+	}
+}
+
+// dynTrait parses a trait of a dyn type with its associated type bindings, which join its
+// generic arguments: dyn Iterator<Item = u8>.
+func (v *v0) dynTrait() {
+	open := v.traitPath()
+	for v.eat('p') {
+		if open {
+			v.print(", ")
+		} else {
+			v.print("<")
+			open = true
+		}
+		v.print(v.ident() + " = ")
+		v.typ()
+	}
+	if open {
+		v.print(">")
+	}
+}
+
+// traitPath parses the path of a dyn trait, leaving its generic argument list open for the
+// bindings that follow. It reports whether it did.
+func (v *v0) traitPath() bool {
+	v.enter()
+	defer v.leave()
+
+	switch {
+	case v.eat('B'):
+		open := false
+		v.backref(func() { open = v.traitPath() })
+		return open
+	case v.eat('I'):
+		v.path(false)
+		v.print("<")
+		v.list(v.genericArg)
+		return true
+	}
+	v.path(false)
+	return false
+}
+
+// constant parses a <const>. Constants other than plain numbers, chars and bools are put
+// in braces among generic arguments.
+func (v *v0) constant(inValue bool) {
+	v.enter()
+	defer v.leave()
+
+	c := v.next()
+	switch c {
+	case 'p':
+		v.print("_")
+		return
+	case 'h', 't', 'm', 'y', 'o', 'j':
+		v.print(v.uint())
+		return
+	case 'a', 's', 'l', 'x', 'n', 'i':
+		if v.eat('n') {
+			v.print("-")
+		}
+		v.print(v.uint())
+		return
+	case 'b':
+		switch v.uint() {
+		case "0":
+			v.print("false")
+		case "1":
+			v.print("true")
+		default:
+			v.fail()
+		}
+		return
+	case 'c':
+		r, err := strconv.ParseUint(v.uint(), 10, 32)
+		if err != nil || !utf8.ValidRune(rune(r)) {
+			v.fail()
+		}
+		v.print(quoteChar(rune(r)))
+		return
+	case 'B':
+		v.backref(func() { v.constant(inValue) })
+		return
+	}
+
+	if !inValue {
+		v.print("{")
+	}
+	switch c {
+	case 'e':
+		v.print("*")
+		v.print(v.str())
+	case 'R', 'Q':
+		if c == 'R' && v.eat('e') {
+			v.print(v.str())
+			break
+		}
+		v.print("&")
+		if c == 'Q' {
+			v.print("mut ")
+		}
+		v.constant(true)
+	case 'A':
+		v.print("[")
+		v.list(func() { v.constant(true) })
+		v.print("]")
+	case 'T':
+		v.print("(")
+		if v.list(func() { v.constant(true) }) == 1 {
+			v.print(",")
+		}
+		v.print(")")
+	case 'V':
+		v.path(true)
+		switch v.next() {
+		case 'U':
+		case 'T':
+			v.print("(")
+			v.list(func() { v.constant(true) })
+			v.print(")")
+		case 'S':
+			v.print(" { ")
+			v.list(func() {
+				v.optBase62('s')
+				v.print(v.ident() + ": ")
+				v.constant(true)
+			})
+			v.print(" }")
+		default:
+			v.fail()
+		}
+	default:
+		v.fail()
+	}
+	if !inValue {
+		v.print("}")
+	}
+}
+
+// hex parses the hex digits of a constant, up to an underscore.
+func (v *v0) hex() string {
+	start := v.pos
+	for v.peek() != '_' {
+		if c := v.next(); !isDigit(c) && (c < 'a' || c > 'f') {
+			v.fail()
+		}
+	}
+	v.pos++
+	return v.s[start : v.pos-1]
+}
+
+// uint parses an unsigned constant, printed in decimal when it fits 64 bits.
+func (v *v0) uint() string {
+	h := v.hex()
+	digits := strings.TrimLeft(h, "0")
+	if len(digits) > 16 {
+		return "0x" + h
+	}
+	x, _ := strconv.ParseUint("0"+digits, 16, 64)
+	return strconv.FormatUint(x, 10)
+}
+
+// str parses a string constant, UTF-8 bytes in hex, and returns it quoted.
+func (v *v0) str() string {
+	h := v.hex()
+	if len(h)%2 != 0 {
+		v.fail()
+	}
+	b := make([]byte, len(h)/2)
+	for i := range b {
+		x, _ := strconv.ParseUint(h[2*i:2*i+2], 16, 8)
+		b[i] = byte(x)
+	}
+	if !utf8.Valid(b) {
+		v.fail()
+	}
+	var out strings.Builder
+	out.WriteByte('"')
+	for _, r := range string(b) {
+		if r == '\'' {
+			out.WriteRune(r)
+			continue
+		}
+		q := quoteChar(r)
+		out.WriteString(q[1 : len(q)-1])
+	}
+	out.WriteByte('"')
+	return out.String()
+}
+
+// quoteChar quotes a char constant the way Rust's Debug does.
+func quoteChar(r rune) string {
+	switch r {
+	case '\'':
+		return `'\''`
+	case '"':
+		return `'"'`
+	case 0:
+		return `'\0'`
+	case '\t':
+		return `'\t'`
+	case '\r':
+		return `'\r'`
+	case '\n':
+		return `'\n'`
+	case '\\':
+		return `'\\'`
+	}
+	if unicode.IsPrint(r) {
+		return "'" + string(r) + "'"
+	}
+	return `'\u{` + strconv.FormatInt(int64(r), 16) + `}'`
+}
+
+// punycode decodes an identifier encoded with RFC 3492, given its basic code points and
+// the encoded rest.
+func punycode(basic, encoded string) (string, bool) {
+	const (
+		base        = 36
+		tMin        = 1
+		tMax        = 26
+		skew        = 38
+		damp        = 700
+		initialBias = 72
+		initialN    = 128
+	)
+
+	out := []rune(basic)
+	n, bias, i := initialN, initialBias, 0
+	for pos := 0; pos < len(encoded); {
+		oldI, w := i, 1
+		for k := base; ; k += base {
+			if pos >= len(encoded) {
+				return "", false
+			}
+			c := encoded[pos]
+			pos++
+			var digit int
+			switch {
+			case c >= 'a' && c <= 'z':
+				digit = int(c - 'a')
+			case c >= '0' && c <= '9':
+				digit = int(c-'0') + 26
+			default:
+				return "", false
+			}
+			if digit > (1<<31-1-i)/w {
+				return "", false
+			}
+			i += digit * w
+			t := k - bias
+			if t < tMin {
+				t = tMin
+			} else if t > tMax {
+				t = tMax
+			}
+			if digit < t {
+				break
+			}
+			if w > (1<<31-1)/(base-t) {
+				return "", false
+			}
+			w *= base - t
+		}
+
+		// Adapt the bias for the next code point
+		length := len(out) + 1
+		delta := i - oldI
+		if oldI == 0 {
+			delta /= damp
+		} else {
+			delta /= 2
+		}
+		delta += delta / length
+		k := 0
+		for delta > ((base-tMin)*tMax)/2 {
+			delta /= base - tMin
+			k += base
+		}
+		bias = k + ((base-tMin+1)*delta)/(delta+skew)
+
+		n += i / length
+		if n > utf8.MaxRune || len(out) > maxOutput {
+			return "", false
+		}
+		i %= length
+		out = append(out[:i], append([]rune{rune(n)}, out[i:]...)...)
+		i++
+	}
+	return string(out), true
+}
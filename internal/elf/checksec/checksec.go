@@ -0,0 +1,391 @@
+// Package checksec reports which exploit mitigations an ELF file was built with.
+package checksec
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/yourpwnguy/strix/pkg/elf"
+	"github.com/yourpwnguy/strix/pkg/elf/types"
+)
+
+// Status rates the outcome of a single check.
+type Status int
+
+const (
+	StatusGood    Status = iota // Mitigation present
+	StatusPartial               // Mitigation present but incomplete
+	StatusBad                   // Mitigation missing, or a risky property present
+	StatusNone                  // Check does not apply to this file
+)
+
+// Check is the outcome of one mitigation check.
+type Check struct {
+	Name   string // Short name (e.g. "RELRO")
+	Value  string // One word result (e.g. "Full")
+	Status Status
+	Detail string // What the result is based on
+}
+
+// Report is the list of checks run against one file, in display order.
+type Report struct {
+	Checks []Check
+}
+
+// Find returns the check with the given name, or nil if it was not run.
+func (r *Report) Find(name string) *Check {
+	for i := range r.Checks {
+		if r.Checks[i].Name == name {
+			return &r.Checks[i]
+		}
+	}
+	return nil
+}
+
+// Analyze runs every check against the file loaded by the elf.
+func Analyze(p *elf.Parser) (*Report, error) {
+	ehdr, err := p.ELFHeader()
+	if err != nil {
+		return nil, err
+	}
+
+	phdr, err := p.ProgramHeaders()
+	if err != nil {
+		return nil, err
+	}
+
+	dyn, err := p.DynamicEntries()
+	if err != nil {
+		return nil, err
+	}
+
+	notes, err := p.Notes()
+	if err != nil {
+		return nil, err
+	}
+
+	dynsym, err := p.DynamicSymbols()
+	if err != nil {
+		return nil, err
+	}
+
+	symtab, err := p.Symbols()
+	if err != nil {
+		return nil, err
+	}
+
+	names := symbolNames(dynsym, symtab)
+
+	report := &Report{}
+	report.Checks = append(report.Checks,
+		checkRELRO(phdr, dyn),
+		checkNX(phdr),
+		checkPIE(ehdr, phdr, dyn),
+		checkCanary(names),
+		checkFortify(names),
+		checkPath("RPATH", types.DT_RPATH, dyn),
+		checkPath("RUNPATH", types.DT_RUNPATH, dyn),
+	)
+	report.Checks = append(report.Checks, checkCET(ehdr, notes)...)
+	report.Checks = append(report.Checks,
+		checkCFI(names),
+		checkSafeStack(names),
+	)
+
+	return report, nil
+}
+
+// symbolNames collects the set of names in the given symbol tables, defined or not.
+func symbolNames(tables ...[]types.Symbol) map[string]bool {
+	names := make(map[string]bool)
+	for _, syms := range tables {
+		for i := range syms {
+			if syms[i].Name != "" {
+				names[syms[i].Name] = true
+			}
+		}
+	}
+	return names
+}
+
+// findSegment returns the first program header of the given type, or nil if there is none.
+func findSegment(phdr []types.Elf64_Phdr, p_type uint32) *types.Elf64_Phdr {
+	for i := range phdr {
+		if phdr[i].P_type == p_type {
+			return &phdr[i]
+		}
+	}
+	return nil
+}
+
+// bindNow reports whether the dynamic section asks the loader to resolve every symbol at startup.
+func bindNow(dyn []types.DynamicEntry) bool {
+	if _, ok := types.DynamicValue(dyn, types.DT_BIND_NOW); ok {
+		return true
+	}
+	if flags, ok := types.DynamicValue(dyn, types.DT_FLAGS); ok && flags&types.DF_BIND_NOW != 0 {
+		return true
+	}
+	if flags, ok := types.DynamicValue(dyn, types.DT_FLAGS_1); ok && flags&types.DF_1_NOW != 0 {
+		return true
+	}
+	return false
+}
+
+// checkRELRO combines PT_GNU_RELRO with immediate binding. Without BIND_NOW the GOT entries
+// used by lazy binding stay writable, which is only partial protection.
+func checkRELRO(phdr []types.Elf64_Phdr, dyn []types.DynamicEntry) Check {
+	c := Check{Name: "RELRO"}
+
+	relro := findSegment(phdr, types.PT_GNU_RELRO) != nil
+	now := bindNow(dyn)
+
+	switch {
+	case relro && (now || len(dyn) == 0):
+		c.Value, c.Status = "Full", StatusGood
+		c.Detail = "PT_GNU_RELRO is present and symbols are bound at startup, the whole GOT is read-only after relocation"
+	case relro:
+		c.Value, c.Status = "Partial", StatusPartial
+		c.Detail = "PT_GNU_RELRO is present but lazy binding is used (no DT_BIND_NOW, DF_BIND_NOW or DF_1_NOW), .got.plt stays writable"
+	default:
+		c.Value, c.Status = "No", StatusBad
+		c.Detail = "no PT_GNU_RELRO segment, relocated data stays writable"
+	}
+	return c
+}
+
+// checkNX looks at the permissions the loader gives the stack.
+func checkNX(phdr []types.Elf64_Phdr) Check {
+	c := Check{Name: "NX"}
+
+	stack := findSegment(phdr, types.PT_GNU_STACK)
+	switch {
+	case stack == nil:
+		c.Value, c.Status = "No", StatusBad
+		c.Detail = "no PT_GNU_STACK segment, the loader falls back to an executable stack on most architectures"
+	case stack.P_flags&types.PF_X != 0:
+		c.Value, c.Status = "No", StatusBad
+		c.Detail = fmt.Sprintf("PT_GNU_STACK requests an executable stack (%s)", strings.Join(types.PFlagNames(stack.P_flags), ""))
+	default:
+		c.Value, c.Status = "Yes", StatusGood
+		c.Detail = fmt.Sprintf("PT_GNU_STACK requests a non-executable stack (%s)", strings.Join(types.PFlagNames(stack.P_flags), ""))
+	}
+	return c
+}
+
+// checkPIE tells position independent executables apart from fixed address ones and shared objects.
+func checkPIE(ehdr *types.Elf64_Ehdr, phdr []types.Elf64_Phdr, dyn []types.DynamicEntry) Check {
+	c := Check{Name: "PIE"}
+
+	flags1, _ := types.DynamicValue(dyn, types.DT_FLAGS_1)
+	switch ehdr.E_type {
+	case types.ET_EXEC:
+		c.Value, c.Status = "No", StatusBad
+		c.Detail = "ET_EXEC is loaded at the fixed address it was linked at"
+	case types.ET_DYN:
+		switch {
+		case flags1&types.DF_1_PIE != 0:
+			c.Value, c.Status = "Yes", StatusGood
+			c.Detail = "ET_DYN with DF_1_PIE, the executable is loaded at a random base"
+		case findSegment(phdr, types.PT_INTERP) != nil:
+			c.Value, c.Status = "Yes", StatusGood
+			c.Detail = "ET_DYN with a program interpreter, the executable is loaded at a random base"
+		default:
+			c.Value, c.Status = "DSO", StatusNone
+			c.Detail = "ET_DYN without DF_1_PIE or PT_INTERP is a shared object, which is always position independent"
+		}
+	case types.ET_REL:
+		c.Value, c.Status = "REL", StatusNone
+		c.Detail = "relocatable object, position independence is decided at link time"
+	default:
+		c.Value, c.Status = "N/A", StatusNone
+		c.Detail = fmt.Sprintf("not applicable to %s files", types.GetEType(ehdr.E_type, false))
+	}
+	return c
+}
+
+// canarySymbols are referenced by code built with -fstack-protector.
+var canarySymbols = []string{"__stack_chk_fail", "__stack_chk_guard", "__intel_security_cookie"}
+
+// checkCanary looks for the stack protector runtime.
+func checkCanary(names map[string]bool) Check {
+	c := Check{Name: "Canary"}
+
+	for _, name := range canarySymbols {
+		if names[name] {
+			c.Value, c.Status = "Yes", StatusGood
+			c.Detail = fmt.Sprintf("references %s, functions check a stack canary before returning", name)
+			return c
+		}
+	}
+
+	c.Value, c.Status = "No", StatusBad
+	c.Detail = "no stack protector symbol (" + strings.Join(canarySymbols, ", ") + ")"
+	return c
+}
+
+// fortifiable lists the libc functions that have a checked __*_chk variant used by _FORTIFY_SOURCE.
+var fortifiable = []string{
+	"asprintf", "confstr", "dprintf", "explicit_bzero", "fdelt", "fgets", "fgets_unlocked",
+	"fgetws", "fgetws_unlocked", "fprintf", "fread", "fread_unlocked", "fwprintf", "getcwd",
+	"getdomainname", "getgroups", "gethostname", "getlogin_r", "gets", "getwd", "longjmp",
+	"mbsnrtowcs", "mbsrtowcs", "mbstowcs", "memcpy", "memmove", "mempcpy", "memset",
+	"obstack_printf", "obstack_vprintf", "poll", "ppoll", "pread", "pread64", "printf", "read",
+	"readlink", "readlinkat", "realpath", "recv", "recvfrom", "snprintf", "sprintf", "stpcpy",
+	"stpncpy", "strcat", "strcpy", "strlcat", "strlcpy", "strncat", "strncpy", "swprintf",
+	"syslog", "ttyname_r", "vasprintf", "vdprintf", "vfprintf", "vfwprintf", "vprintf",
+	"vsnprintf", "vsprintf", "vswprintf", "vsyslog", "vwprintf", "wcpcpy", "wcpncpy",
+	"wcrtomb", "wcscat", "wcscpy", "wcsncat", "wcsncpy", "wcsnrtombs", "wcsrtombs", "wcstombs",
+	"wctomb", "wmemcpy", "wmemmove", "wmempcpy", "wmemset", "wprintf",
+}
+
+// checkFortify counts the functions called through their checked variant against every
+// function that could have been.
+func checkFortify(names map[string]bool) Check {
+	c := Check{Name: "FORTIFY"}
+
+	var fortified, plain []string
+	for _, fn := range fortifiable {
+		if names["__"+fn+"_chk"] {
+			fortified = append(fortified, fn)
+		} else if names[fn] {
+			plain = append(plain, fn)
+		}
+	}
+
+	total := len(fortified) + len(plain)
+	c.Value = fmt.Sprintf("%d/%d", len(fortified), total)
+
+	switch {
+	case total == 0:
+		c.Status = StatusNone
+		c.Detail = "no fortifiable libc functions are used"
+	case len(fortified) == 0:
+		c.Status = StatusBad
+		c.Detail = "no __*_chk functions, not built with _FORTIFY_SOURCE; unchecked: " + strings.Join(plain, ", ")
+	case len(plain) == 0:
+		c.Status = StatusGood
+		c.Detail = "every fortifiable call goes through its __*_chk variant: " + strings.Join(fortified, ", ")
+	default:
+		c.Status = StatusPartial
+		c.Detail = "fortified: " + strings.Join(fortified, ", ") + "; unchecked: " + strings.Join(plain, ", ")
+	}
+	return c
+}
+
+// checkPath reports a DT_RPATH or DT_RUNPATH entry. Search paths baked into the binary can
+// let whoever controls those directories inject libraries.
+func checkPath(name string, tag int64, dyn []types.DynamicEntry) Check {
+	c := Check{Name: name}
+
+	paths := types.DynamicStrings(dyn, tag)
+	if len(paths) == 0 {
+		c.Value, c.Status = "No", StatusGood
+		c.Detail = fmt.Sprintf("no DT_%s entry", name)
+		return c
+	}
+
+	c.Value, c.Status = "Yes", StatusBad
+	c.Detail = fmt.Sprintf("DT_%s searches %s before the system paths", name, strings.Join(paths, ", "))
+	return c
+}
+
+// featureBit is a control flow protection bit of a GNU property note.
+type featureBit struct {
+	name string
+	bit  uint32
+	what string
+}
+
+// Control flow protection bits checked per architecture.
+var (
+	x86Features = []featureBit{
+		{"IBT", types.GNU_PROPERTY_X86_FEATURE_1_IBT, "indirect branch tracking (endbr landing pads)"},
+		{"SHSTK", types.GNU_PROPERTY_X86_FEATURE_1_SHSTK, "shadow stack"},
+	}
+
+	aarch64Features = []featureBit{
+		{"BTI", types.GNU_PROPERTY_AARCH64_FEATURE_1_BTI, "branch target identification"},
+		{"PAC", types.GNU_PROPERTY_AARCH64_FEATURE_1_PAC, "pointer authentication"},
+	}
+)
+
+// checkCET reads the control flow protection bits of the GNU property note: IBT and SHSTK
+// on x86, BTI and PAC on AArch64. Other architectures have no such checks.
+func checkCET(ehdr *types.Elf64_Ehdr, notes []types.Note) []Check {
+	var propType uint32
+	var bits []featureBit
+
+	switch ehdr.E_machine {
+	case types.EM_X86_64, types.EM_386:
+		propType, bits = types.GNU_PROPERTY_X86_FEATURE_1_AND, x86Features
+	case types.EM_AARCH64:
+		propType, bits = types.GNU_PROPERTY_AARCH64_FEATURE_1_AND, aarch64Features
+	default:
+		return nil
+	}
+
+	var prop *types.GNUProperty
+	for i := range notes {
+		if props, ok := notes[i].Value.(types.GNUProperties); ok {
+			if prop = props.Find(propType); prop != nil {
+				break
+			}
+		}
+	}
+
+	checks := make([]Check, len(bits))
+	for i, b := range bits {
+		checks[i].Name = b.name
+		if prop != nil && uint32(prop.Value)&b.bit != 0 {
+			checks[i].Value, checks[i].Status = "Yes", StatusGood
+			checks[i].Detail = fmt.Sprintf("the GNU property note marks every object as compatible with %s", b.what)
+		} else {
+			checks[i].Value, checks[i].Status = "No", StatusBad
+			checks[i].Detail = fmt.Sprintf("no GNU property note bit for %s", b.what)
+		}
+	}
+	return checks
+}
+
+// checkCFI looks for the runtime of Clang's -fsanitize=cfi.
+func checkCFI(names map[string]bool) Check {
+	c := Check{Name: "CFI"}
+
+	var found []string
+	for name := range names {
+		if strings.HasPrefix(name, "__cfi_") || name == "__ubsan_handle_cfi_check_fail" ||
+			name == "__ubsan_handle_cfi_check_fail_abort" {
+			found = append(found, name)
+		}
+	}
+
+	if len(found) == 0 {
+		c.Value, c.Status = "No", StatusNone
+		c.Detail = "no Clang CFI symbols (__cfi_check, __cfi_slowpath, __ubsan_handle_cfi_check_fail)"
+		return c
+	}
+
+	slices.Sort(found)
+	c.Value, c.Status = "Yes", StatusGood
+	c.Detail = "built with Clang control flow integrity: " + strings.Join(found, ", ")
+	return c
+}
+
+// checkSafeStack looks for the runtime of Clang's -fsanitize=safe-stack.
+func checkSafeStack(names map[string]bool) Check {
+	c := Check{Name: "SafeStack"}
+
+	for _, name := range []string{"__safestack_init", "__safestack_unsafe_stack_ptr", "__get_unsafe_stack_ptr"} {
+		if names[name] {
+			c.Value, c.Status = "Yes", StatusGood
+			c.Detail = fmt.Sprintf("references %s, buffers live on a separate unsafe stack", name)
+			return c
+		}
+	}
+
+	c.Value, c.Status = "No", StatusNone
+	c.Detail = "no SafeStack runtime symbols"
+	return c
+}
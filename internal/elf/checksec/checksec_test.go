@@ -0,0 +1,157 @@
+package checksec
+
+import (
+	"testing"
+
+	"github.com/yourpwnguy/strix/pkg/elf/types"
+)
+
+// dyn builds a dynamic section holding one entry after a DT_NEEDED, which makes the file
+// dynamically linked.
+func dyn(tag int64, val uint64) []types.DynamicEntry {
+	return []types.DynamicEntry{
+		{Elf64_Dyn: types.Elf64_Dyn{D_tag: types.DT_NEEDED, D_val: 1}, String: "libc.so.6"},
+		{Elf64_Dyn: types.Elf64_Dyn{D_tag: tag, D_val: val}},
+	}
+}
+
+// segments builds program headers of the given types, all readable and writable.
+func segments(p_types ...uint32) []types.Elf64_Phdr {
+	out := make([]types.Elf64_Phdr, len(p_types))
+	for i, t := range p_types {
+		out[i] = types.Elf64_Phdr{P_type: t, P_flags: types.PF_R | types.PF_W}
+	}
+	return out
+}
+
+// names builds a symbol name set.
+func names(list ...string) map[string]bool {
+	out := make(map[string]bool)
+	for _, name := range list {
+		out[name] = true
+	}
+	return out
+}
+
+func TestCheckRELRO(t *testing.T) {
+	relro := segments(types.PT_LOAD, types.PT_GNU_RELRO)
+
+	tests := []struct {
+		name   string
+		phdr   []types.Elf64_Phdr
+		dyn    []types.DynamicEntry
+		value  string
+		status Status
+	}{
+		{"none", segments(types.PT_LOAD), dyn(types.DT_BIND_NOW, 0), "No", StatusBad},
+		{"lazy", relro, dyn(types.DT_FLAGS, types.DF_SYMBOLIC), "Partial", StatusPartial},
+		{"DT_BIND_NOW", relro, dyn(types.DT_BIND_NOW, 0), "Full", StatusGood},
+		{"DF_BIND_NOW", relro, dyn(types.DT_FLAGS, types.DF_BIND_NOW), "Full", StatusGood},
+		{"DF_1_NOW", relro, dyn(types.DT_FLAGS_1, types.DF_1_NOW|types.DF_1_PIE), "Full", StatusGood},
+		{"DF_1_NOW in DT_FLAGS", relro, dyn(types.DT_FLAGS, types.DF_1_NOW), "Partial", StatusPartial},
+		// Static binaries have no lazy binding to leave the GOT writable for
+		{"static", relro, nil, "Full", StatusGood},
+	}
+
+	for _, tt := range tests {
+		c := checkRELRO(tt.phdr, tt.dyn)
+		if c.Value != tt.value || c.Status != tt.status {
+			t.Errorf("%s: checkRELRO() = %s (%d), want %s (%d)", tt.name, c.Value, c.Status, tt.value, tt.status)
+		}
+	}
+}
+
+func TestCheckNX(t *testing.T) {
+	execStack := segments(types.PT_LOAD, types.PT_GNU_STACK)
+	execStack[1].P_flags |= types.PF_X
+
+	tests := []struct {
+		name   string
+		phdr   []types.Elf64_Phdr
+		value  string
+		status Status
+	}{
+		{"no PT_GNU_STACK", segments(types.PT_LOAD), "No", StatusBad},
+		{"executable stack", execStack, "No", StatusBad},
+		{"non-executable stack", segments(types.PT_LOAD, types.PT_GNU_STACK), "Yes", StatusGood},
+	}
+
+	for _, tt := range tests {
+		c := checkNX(tt.phdr)
+		if c.Value != tt.value || c.Status != tt.status {
+			t.Errorf("%s: checkNX() = %s (%d), want %s (%d)", tt.name, c.Value, c.Status, tt.value, tt.status)
+		}
+	}
+}
+
+func TestCheckPIE(t *testing.T) {
+	tests := []struct {
+		name   string
+		e_type uint16
+		phdr   []types.Elf64_Phdr
+		dyn    []types.DynamicEntry
+		value  string
+		status Status
+	}{
+		{"ET_EXEC", types.ET_EXEC, segments(types.PT_INTERP), dyn(types.DT_FLAGS_1, types.DF_1_PIE), "No", StatusBad},
+		{"DF_1_PIE", types.ET_DYN, segments(types.PT_LOAD), dyn(types.DT_FLAGS_1, types.DF_1_PIE), "Yes", StatusGood},
+		{"PT_INTERP", types.ET_DYN, segments(types.PT_INTERP, types.PT_LOAD), dyn(types.DT_DEBUG, 0), "Yes", StatusGood},
+		{"static PIE", types.ET_DYN, segments(types.PT_LOAD), dyn(types.DT_FLAGS_1, types.DF_1_NOW|types.DF_1_PIE), "Yes", StatusGood},
+		{"shared object", types.ET_DYN, segments(types.PT_LOAD), dyn(types.DT_FLAGS_1, types.DF_1_NOW), "DSO", StatusNone},
+		{"shared object without flags", types.ET_DYN, segments(types.PT_LOAD), dyn(types.DT_DEBUG, 0), "DSO", StatusNone},
+		{"relocatable", types.ET_REL, nil, nil, "REL", StatusNone},
+		{"core", types.ET_CORE, segments(types.PT_NOTE), nil, "N/A", StatusNone},
+	}
+
+	for _, tt := range tests {
+		c := checkPIE(&types.Elf64_Ehdr{E_type: tt.e_type}, tt.phdr, tt.dyn)
+		if c.Value != tt.value || c.Status != tt.status {
+			t.Errorf("%s: checkPIE() = %s (%d), want %s (%d)", tt.name, c.Value, c.Status, tt.value, tt.status)
+		}
+	}
+}
+
+func TestCheckCanary(t *testing.T) {
+	tests := []struct {
+		name   string
+		names  map[string]bool
+		value  string
+		status Status
+	}{
+		{"none", names("main", "printf"), "No", StatusBad},
+		{"__stack_chk_fail", names("main", "__stack_chk_fail"), "Yes", StatusGood},
+		{"__stack_chk_guard", names("__stack_chk_guard"), "Yes", StatusGood},
+		{"icc", names("__intel_security_cookie"), "Yes", StatusGood},
+	}
+
+	for _, tt := range tests {
+		c := checkCanary(tt.names)
+		if c.Value != tt.value || c.Status != tt.status {
+			t.Errorf("%s: checkCanary() = %s (%d), want %s (%d)", tt.name, c.Value, c.Status, tt.value, tt.status)
+		}
+	}
+}
+
+func TestCheckFortify(t *testing.T) {
+	tests := []struct {
+		name   string
+		names  map[string]bool
+		value  string
+		status Status
+	}{
+		{"nothing fortifiable", names("main", "exit"), "0/0", StatusNone},
+		{"unchecked", names("memcpy", "printf", "exit"), "0/2", StatusBad},
+		{"fortified", names("__memcpy_chk", "__printf_chk"), "2/2", StatusGood},
+		{"partial", names("__memcpy_chk", "printf", "strcpy"), "1/3", StatusPartial},
+		// A function imported both ways counts once, as fortified
+		{"both forms", names("__memcpy_chk", "memcpy"), "1/1", StatusGood},
+		{"not a fortifiable function", names("__foo_chk", "foo"), "0/0", StatusNone},
+	}
+
+	for _, tt := range tests {
+		c := checkFortify(tt.names)
+		if c.Value != tt.value || c.Status != tt.status {
+			t.Errorf("%s: checkFortify() = %s (%d), want %s (%d)", tt.name, c.Value, c.Status, tt.value, tt.status)
+		}
+	}
+}
@@ -0,0 +1,297 @@
+package disasm
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strings"
+
+	"golang.org/x/arch/arm64/arm64asm"
+)
+
+// arm64Decoder decodes AArch64 code. It remembers the page each ADRP loads, so that the ADD
+// or load that completes the address can name it, until the register may have changed.
+type arm64Decoder struct {
+	pages [31]uint64
+	known uint32 // Bit n is set while pages[n] holds the page in xn
+}
+
+// arm64Fixed holds the instructions arm64asm predates that take no operand: the pointer
+// authentication and branch target identification hints, which it shows as "hint #n", and the
+// authenticated returns.
+var arm64Fixed = []struct {
+	word  uint32
+	text  string
+	class string
+}{
+	{0xd503241f, "bti", ClassNop},
+	{0xd503245f, "bti c", ClassNop},
+	{0xd503249f, "bti j", ClassNop},
+	{0xd50324df, "bti jc", ClassNop},
+	{0xd503233f, "paciasp", ClassOther},
+	{0xd503237f, "pacibsp", ClassOther},
+	{0xd50323bf, "autiasp", ClassOther},
+	{0xd50323ff, "autibsp", ClassOther},
+	{0xd503231f, "paciaz", ClassOther},
+	{0xd503235f, "pacibz", ClassOther},
+	{0xd503239f, "autiaz", ClassOther},
+	{0xd50323df, "autibz", ClassOther},
+	{0xd503211f, "pacia1716", ClassOther},
+	{0xd503215f, "pacib1716", ClassOther},
+	{0xd503219f, "autia1716", ClassOther},
+	{0xd50321df, "autib1716", ClassOther},
+	{0xd50320ff, "xpaclri", ClassOther},
+	{0xd50320df, "dgh", ClassOther},
+	{0xd503221f, "esb", ClassOther},
+	{0xd503223f, "psb csync", ClassOther},
+	{0xd503225f, "tsb csync", ClassOther},
+	{0xd503229f, "csdb", ClassOther},
+	{0xd65f0bff, "retaa", ClassReturn},
+	{0xd65f0fff, "retab", ClassReturn},
+	{0xd69f0bff, "eretaa", ClassReturn},
+	{0xd69f0fff, "eretab", ClassReturn},
+}
+
+// arm64RegisterForms holds the authenticated branches to a register, which arm64asm predates.
+// The Z forms use a zero modifier, the others take the modifier register in bits 0 to 4.
+var arm64RegisterForms = []struct {
+	word     uint32
+	mask     uint32
+	mnemonic string
+	class    string
+}{
+	{0xd61f081f, 0xfffffc1f, "braaz", ClassJump},
+	{0xd61f0c1f, 0xfffffc1f, "brabz", ClassJump},
+	{0xd63f081f, 0xfffffc1f, "blraaz", ClassCall},
+	{0xd63f0c1f, 0xfffffc1f, "blrabz", ClassCall},
+	{0xd71f0800, 0xfffffc00, "braa", ClassJump},
+	{0xd71f0c00, 0xfffffc00, "brab", ClassJump},
+	{0xd73f0800, 0xfffffc00, "blraa", ClassCall},
+	{0xd73f0c00, 0xfffffc00, "blrab", ClassCall},
+}
+
+func (d *arm64Decoder) reset() {
+	d.known = 0
+}
+
+func (d *arm64Decoder) decode(code []byte, pc uint64) Instruction {
+	if len(code) < 4 {
+		d.reset()
+		return Instruction{Address: pc, Bytes: code, Text: "(bad)", Class: ClassInvalid}
+	}
+	x := binary.LittleEndian.Uint32(code)
+	out := Instruction{Address: pc, Bytes: code[:4]}
+
+	if text, class, ok := arm64Extension(x); ok {
+		out.Text, out.Class = text, class
+		d.forget(x & 31)
+		d.forget(x >> 16 & 31)
+		if class != ClassOther {
+			d.reset()
+		}
+		return out
+	}
+
+	inst, err := arm64asm.Decode(code)
+	if err != nil {
+		d.reset()
+		out.Text, out.Class = "(bad)", ClassInvalid
+		return out
+	}
+
+	// Operands are printed relative to the instruction, as .+0x...
+	out.Text = strings.TrimSpace(arm64asm.GNUSyntax(inst))
+	out.Class = arm64Class(inst)
+	for _, arg := range inst.Args {
+		rel, ok := arg.(arm64asm.PCRel)
+		if !ok {
+			continue
+		}
+
+		addr := pc + uint64(rel)
+		if inst.Op == arm64asm.ADRP {
+			addr = pc&^0xfff + uint64(rel)
+		}
+		out.Text = strings.Replace(out.Text, rel.String(), fmt.Sprintf("%#x", addr), 1)
+
+		switch {
+		case out.Class == ClassCall || out.Class == ClassJump || out.Class == ClassBranch:
+			out.Target, out.HasTarget = addr, true
+		case inst.Op == arm64asm.ADRP:
+			if rd := x & 31; rd != 31 {
+				d.pages[rd], d.known = addr, d.known|1<<rd
+			}
+			return out
+		default:
+			out.Ref, out.HasRef = addr, true
+		}
+	}
+
+	// The low 12 bits of an ADRP page come from an ADD or from the offset of a load or store
+	rd, rn := x&31, x>>5&31
+	if d.known&(1<<rn) != 0 {
+		switch {
+		case x&0x7f800000 == 0x11000000: // ADD (immediate)
+			imm := uint64(x >> 10 & 0xfff)
+			if x&(1<<22) != 0 {
+				imm <<= 12
+			}
+			out.Ref, out.HasRef = d.pages[rn]+imm, true
+
+		case x&0x3b000000 == 0x39000000: // LDR, STR (unsigned offset)
+			scale := x >> 30
+			if x&(1<<26) != 0 && x&(1<<23) != 0 {
+				scale = 4 // 128 bit SIMD register
+			}
+			out.Ref, out.HasRef = d.pages[rn]+uint64(x>>10&0xfff)<<scale, true
+		}
+	}
+
+	switch out.Class {
+	case ClassCall, ClassJump, ClassReturn, ClassSystem:
+		d.reset()
+	case ClassBranch, ClassCompare:
+	default:
+		d.forget(rd)
+		if inst.Op == arm64asm.LDP || inst.Op == arm64asm.LDPSW || inst.Op == arm64asm.LDNP ||
+			inst.Op == arm64asm.LDXP || inst.Op == arm64asm.LDAXP {
+			d.forget(x >> 10 & 31)
+		}
+		for _, arg := range inst.Args {
+			if m, ok := arg.(arm64asm.MemImmediate); ok && m.Mode != arm64asm.AddrOffset {
+				d.forget(rn) // Writeback
+			}
+		}
+	}
+
+	return out
+}
+
+// forget drops the page held in register n.
+func (d *arm64Decoder) forget(n uint32) {
+	d.known &^= 1 << n
+}
+
+// arm64Extension decodes the instructions arm64asm does not know: udf, which pads code with
+// zeros, the entries of arm64Fixed and arm64RegisterForms, and the ARMv8.1 atomics with the
+// load acquire RCpc of ARMv8.3.
+func arm64Extension(x uint32) (string, string, bool) {
+	for _, f := range arm64Fixed {
+		if x == f.word {
+			return f.text, f.class, true
+		}
+	}
+	if x&0xffff0000 == 0 {
+		return fmt.Sprintf("udf #%d", x), ClassSystem, true
+	}
+	for _, f := range arm64RegisterForms {
+		if x&f.mask != f.word {
+			continue
+		}
+		text := fmt.Sprintf("%s %s", f.mnemonic, arm64Reg(x>>5&31, true))
+		if f.mask&31 == 0 {
+			text += ", " + arm64Base(x&31)
+		}
+		return text, f.class, true
+	}
+
+	size, rs, rn, rt := x>>30, x>>16&31, x>>5&31, x&31
+	wide := size == 3
+	suffix := [4]string{"b", "h", "", ""}[size]
+
+	// Acquire and release semantics, in bits 22 and 15 for CAS
+	order := func(acquire, release uint32) string {
+		return [4]string{"", "a", "l", "al"}[acquire|release<<1]
+	}
+
+	switch {
+	case x&0x3fa07c00 == 0x08a07c00: // CAS
+		return fmt.Sprintf("cas%s%s %s, %s, [%s]", order(x>>22&1, x>>15&1), suffix,
+			arm64Reg(rs, wide), arm64Reg(rt, wide), arm64Base(rn)), ClassOther, true
+
+	case x&0xbfa07c00 == 0x08207c00: // CASP
+		wide = x&(1<<30) != 0
+		return fmt.Sprintf("casp%s %s, %s, %s, %s, [%s]", order(x>>22&1, x>>15&1),
+			arm64Reg(rs, wide), arm64Reg(rs+1, wide), arm64Reg(rt, wide), arm64Reg(rt+1, wide), arm64Base(rn)), ClassOther, true
+
+	case x&0x3f200c00 == 0x38200000: // Atomic memory operations
+		acquire, release := x>>23&1, x>>22&1
+		o3, opc := x>>15&1, x>>12&7
+
+		switch {
+		case o3 == 0:
+			op := [8]string{"add", "clr", "eor", "set", "smax", "smin", "umax", "umin"}[opc]
+			if rt == 31 && acquire == 0 {
+				return fmt.Sprintf("st%s%s%s %s, [%s]", op, order(0, release), suffix,
+					arm64Reg(rs, wide), arm64Base(rn)), ClassOther, true
+			}
+			return fmt.Sprintf("ld%s%s%s %s, %s, [%s]", op, order(acquire, release), suffix,
+				arm64Reg(rs, wide), arm64Reg(rt, wide), arm64Base(rn)), ClassOther, true
+
+		case opc == 0:
+			return fmt.Sprintf("swp%s%s %s, %s, [%s]", order(acquire, release), suffix,
+				arm64Reg(rs, wide), arm64Reg(rt, wide), arm64Base(rn)), ClassOther, true
+
+		case opc == 4 && acquire == 1 && release == 0 && rs == 31:
+			return fmt.Sprintf("ldapr%s %s, [%s]", suffix, arm64Reg(rt, wide), arm64Base(rn)), ClassOther, true
+		}
+	}
+
+	return "", "", false
+}
+
+// arm64Reg names general purpose register n, 31 being the zero register.
+func arm64Reg(n uint32, wide bool) string {
+	switch {
+	case n == 31 && wide:
+		return "xzr"
+	case n == 31:
+		return "wzr"
+	case wide:
+		return fmt.Sprintf("x%d", n)
+	}
+	return fmt.Sprintf("w%d", n)
+}
+
+// arm64Base names the base register of an address, 31 being the stack pointer.
+func arm64Base(n uint32) string {
+	if n == 31 {
+		return "sp"
+	}
+	return fmt.Sprintf("x%d", n)
+}
+
+// arm64Class sorts an AArch64 instruction into the class it is colored by. Loads and stores
+// that move the stack pointer are the push and pop of AArch64.
+func arm64Class(inst arm64asm.Inst) string {
+	switch inst.Op {
+	case arm64asm.BL, arm64asm.BLR:
+		return ClassCall
+	case arm64asm.B:
+		if _, ok := inst.Args[0].(arm64asm.Cond); ok {
+			return ClassBranch
+		}
+		return ClassJump
+	case arm64asm.BR:
+		return ClassJump
+	case arm64asm.CBZ, arm64asm.CBNZ, arm64asm.TBZ, arm64asm.TBNZ:
+		return ClassBranch
+	case arm64asm.RET, arm64asm.ERET:
+		return ClassReturn
+	case arm64asm.SVC, arm64asm.HVC, arm64asm.SMC, arm64asm.BRK, arm64asm.HLT,
+		arm64asm.DCPS1, arm64asm.DCPS2, arm64asm.DCPS3:
+		return ClassSystem
+	case arm64asm.CMP, arm64asm.CMN, arm64asm.TST, arm64asm.CCMP, arm64asm.CCMN,
+		arm64asm.FCMP, arm64asm.FCMPE, arm64asm.FCCMP, arm64asm.FCCMPE:
+		return ClassCompare
+	case arm64asm.NOP:
+		return ClassNop
+	}
+
+	for _, arg := range inst.Args {
+		if m, ok := arg.(arm64asm.MemImmediate); ok && m.Base == arm64asm.RegSP(arm64asm.SP) &&
+			(m.Mode == arm64asm.AddrPreIndex || m.Mode == arm64asm.AddrPostIndex) {
+			return ClassStack
+		}
+	}
+	return ClassOther
+}
@@ -0,0 +1,438 @@
+// Package disasm disassembles the code of a file. The targets of branches and the addresses
+// operands refer to are named after the symbols, PLT stubs and GOT slots found there, and
+// fall back to section names on stripped binaries, the way objdump labels them.
+package disasm
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/yourpwnguy/strix/pkg/elf"
+	"github.com/yourpwnguy/strix/pkg/elf/types"
+)
+
+// ErrUnsupported is returned for machines without a decoder.
+var ErrUnsupported = errors.New("disassembly is only supported on x86-64, AArch64 and RV64")
+
+// Instruction classes, which the text output colors instructions by.
+const (
+	ClassCall    = "call"
+	ClassJump    = "jump"   // Unconditional jump
+	ClassBranch  = "branch" // Conditional jump
+	ClassReturn  = "return"
+	ClassSystem  = "system" // Traps, system calls and halts
+	ClassStack   = "stack"
+	ClassCompare = "compare"
+	ClassNop     = "nop"
+	ClassOther   = "other"
+	ClassInvalid = "invalid" // Bytes that do not decode
+)
+
+// Syntaxes of the x86 output. AArch64 and RISC-V code is shown in the syntax of GNU as.
+const (
+	SyntaxATT   = "att"
+	SyntaxIntel = "intel"
+)
+
+// maxString bounds the search for the NUL that ends a string an operand points to.
+const maxString = 256
+
+// Instruction is one decoded instruction. Bytes slices the mapped file, the strings are copies.
+type Instruction struct {
+	Address uint64
+	Bytes   []byte
+	Text    string // Assembly, without annotations
+	Class   string
+
+	Target     uint64 // Destination of a direct call or jump
+	HasTarget  bool
+	TargetName string // Symbol at the destination as name+0x..., empty when none is known
+
+	Ref     uint64 // Address a PC relative operand or an ADRP or AUIPC pair resolves to
+	HasRef  bool
+	RefName string
+	String  string // Printable NUL terminated string stored at Ref
+}
+
+// Block is a run of instructions from one symbol to the next. Name is that symbol, name+0x...
+// when the selection starts inside it, and the section name for code no symbol covers.
+type Block struct {
+	Name         string
+	Address      uint64
+	Section      string
+	Instructions []Instruction
+}
+
+// Report is the disassembly of the selected code.
+type Report struct {
+	Machine uint16
+	Syntax  string // SyntaxATT or SyntaxIntel on x86-64, empty elsewhere
+	Source  string // What was selected, e.g. "section .text"
+	Blocks  []Block
+}
+
+// Selection picks the code to disassemble. Section, Symbol and ByAddress are exclusive, with
+// none of them every executable section is disassembled.
+type Selection struct {
+	Section   string
+	Symbol    string
+	ByAddress bool
+	Address   uint64
+	Length    uint64 // Bytes from Address, 0 for up to the end of the symbol or section holding it
+	Syntax    string // SyntaxATT or SyntaxIntel, AT&T when empty
+}
+
+// region is a contiguous range of code, within one section or, without section headers, one
+// executable segment.
+type region struct {
+	name  string
+	shndx int // Section index, -1 for a segment
+	addr  uint64
+	data  []byte
+}
+
+// Analyze disassembles the selected code.
+func Analyze(p *elf.Parser, sel Selection) (*Report, error) {
+	f, err := p.File()
+	if err != nil {
+		return nil, err
+	}
+
+	// The syntax only applies to x86, the other machines have a single one
+	var dec decoder
+	syntax := ""
+	switch {
+	case f.Header.E_machine == types.EM_X86_64:
+		dec, syntax = &x86Decoder{intel: sel.Syntax == SyntaxIntel}, SyntaxATT
+		if sel.Syntax == SyntaxIntel {
+			syntax = SyntaxIntel
+		}
+	case f.Header.E_machine == types.EM_AARCH64:
+		dec = &arm64Decoder{}
+	case f.Header.E_machine == types.EM_RISCV && f.Class == types.ELFCLASS64:
+		dec = &riscvDecoder{}
+	case f.Header.E_machine == types.EM_RISCV:
+		return nil, fmt.Errorf("%w, not 32-bit RISC-V", ErrUnsupported)
+	default:
+		return nil, fmt.Errorf("%w, not %s", ErrUnsupported, types.GetEMachine(f.Header.E_machine))
+	}
+	if sel.Syntax == SyntaxIntel && syntax != SyntaxIntel {
+		return nil, fmt.Errorf("Intel syntax only applies to x86-64, not %s", types.GetEMachine(f.Header.E_machine))
+	}
+
+	d := &disassembler{p: p, f: f, dec: dec}
+	if d.labels, err = newLabels(p, f, d.sectionAt); err != nil {
+		return nil, err
+	}
+
+	source, regions, err := d.regions(sel)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{Machine: f.Header.E_machine, Syntax: syntax, Source: source}
+	for _, r := range regions {
+		report.Blocks = append(report.Blocks, d.disassemble(r)...)
+	}
+	return report, nil
+}
+
+// decoder decodes the instruction at the start of code, found at address pc. It always
+// consumes at least one byte, bytes that do not decode come back as ClassInvalid. Decoders
+// that track registers across instructions forget them on reset, at the start of each block.
+type decoder interface {
+	decode(code []byte, pc uint64) Instruction
+	reset()
+}
+
+// disassembler holds what naming addresses needs while regions are decoded.
+type disassembler struct {
+	p      *elf.Parser
+	f      *elf.File
+	labels *labels
+	dec    decoder
+}
+
+// regions resolves a selection into the ranges of code to decode.
+func (d *disassembler) regions(sel Selection) (string, []region, error) {
+	f := d.f
+
+	switch {
+	case sel.Section != "":
+		for i := range f.Sections {
+			sh := &f.Sections[i]
+			if f.SectionName(sh) != sel.Section {
+				continue
+			}
+			if sh.Sh_type == types.SHT_NOBITS {
+				return "", nil, fmt.Errorf("section %s has no file contents (NOBITS)", sel.Section)
+			}
+			r, err := d.section(i, sh.Sh_addr, sh.Sh_size)
+			if err != nil {
+				return "", nil, err
+			}
+			return "section " + sel.Section, []region{r}, nil
+		}
+		return "", nil, fmt.Errorf("no section named %s", sel.Section)
+
+	case sel.Symbol != "":
+		l, ok := d.labels.byName(sel.Symbol)
+		if !ok {
+			return "", nil, fmt.Errorf("no defined symbol named %s", sel.Symbol)
+		}
+		if l.shndx < 0 {
+			r, err := d.segment(l.addr, l.size)
+			return "symbol " + sel.Symbol, []region{r}, err
+		}
+		size := l.size
+		if size == 0 {
+			size = d.labels.extent(l.shndx, l.addr, d.sectionEnd(l.shndx))
+		}
+		r, err := d.section(l.shndx, l.addr, size)
+		return "symbol " + sel.Symbol, []region{r}, err
+
+	case sel.ByAddress:
+		if f.Header.E_type == types.ET_REL {
+			return "", nil, errors.New("relocatable objects have no virtual addresses, select a section or symbol")
+		}
+
+		idx := d.sectionAt(sel.Address)
+		if idx < 0 {
+			r, err := d.segment(sel.Address, sel.Length)
+			return "address range", []region{r}, err
+		}
+
+		end := d.sectionEnd(idx)
+		size := sel.Length
+		if size == 0 {
+			size = end - sel.Address
+			if l, ok := d.labels.at(idx, sel.Address); ok && l.size > 0 && sel.Address-l.addr < l.size {
+				size = l.addr + l.size - sel.Address
+			}
+		}
+		if size > end-sel.Address {
+			return "", nil, fmt.Errorf("range %#x-%#x runs past the end of section %s", sel.Address, sel.Address+size, f.SectionName(&f.Sections[idx]))
+		}
+		r, err := d.section(idx, sel.Address, size)
+		return "address range", []region{r}, err
+	}
+
+	// Every executable section, or every executable segment when the section headers are gone
+	var regions []region
+	for i := range f.Sections {
+		sh := &f.Sections[i]
+		if sh.Sh_flags&types.SHF_EXECINSTR == 0 || sh.Sh_type == types.SHT_NOBITS || sh.Sh_size == 0 {
+			continue
+		}
+		r, err := d.section(i, sh.Sh_addr, sh.Sh_size)
+		if err != nil {
+			return "", nil, err
+		}
+		regions = append(regions, r)
+	}
+	if len(f.Sections) > 0 {
+		return "executable sections", regions, nil
+	}
+
+	for i := range f.Segments {
+		ph := &f.Segments[i]
+		if ph.P_type != types.PT_LOAD || ph.P_flags&types.PF_X == 0 || ph.P_filesz == 0 {
+			continue
+		}
+		data, err := d.p.Bytes(fmt.Sprintf("segment %d", i), ph.P_offset, ph.P_filesz)
+		if err != nil {
+			return "", nil, err
+		}
+		regions = append(regions, region{name: fmt.Sprintf("segment %d", i), shndx: -1, addr: ph.P_vaddr, data: data})
+	}
+	return "executable segments", regions, nil
+}
+
+// section returns the size bytes of section idx from addr.
+func (d *disassembler) section(idx int, addr, size uint64) (region, error) {
+	sh := &d.f.Sections[idx]
+	name := strings.Clone(d.f.SectionName(sh))
+
+	data, err := d.p.SectionData(sh)
+	if err != nil {
+		return region{}, err
+	}
+
+	start := addr - sh.Sh_addr
+	if start > uint64(len(data)) || size > uint64(len(data))-start {
+		return region{}, fmt.Errorf("range %#x-%#x is not inside section %s", addr, addr+size, name)
+	}
+
+	return region{name: name, shndx: idx, addr: addr, data: data[start : start+size]}, nil
+}
+
+// segment returns the size bytes from addr of the PT_LOAD segment holding them, up to the end
+// of its file contents when size is 0.
+func (d *disassembler) segment(addr, size uint64) (region, error) {
+	for i := range d.f.Segments {
+		ph := &d.f.Segments[i]
+		if ph.P_type != types.PT_LOAD || addr < ph.P_vaddr || addr-ph.P_vaddr >= ph.P_filesz {
+			continue
+		}
+
+		left := ph.P_filesz - (addr - ph.P_vaddr)
+		if size == 0 {
+			size = left
+		}
+		if size > left {
+			return region{}, fmt.Errorf("range %#x-%#x runs past the file contents of its PT_LOAD segment", addr, addr+size)
+		}
+
+		name := fmt.Sprintf("segment %d", i)
+		data, err := d.p.Bytes(name, ph.P_offset+(addr-ph.P_vaddr), size)
+		if err != nil {
+			return region{}, err
+		}
+		return region{name: name, shndx: -1, addr: addr, data: data}, nil
+	}
+
+	return region{}, fmt.Errorf("address %#x is not backed by the file contents of a PT_LOAD segment", addr)
+}
+
+// sectionAt returns the index of the allocated section holding addr, -1 when there is none.
+// Zero sized sections and .tbss, which overlaps what follows it, never match.
+func (d *disassembler) sectionAt(addr uint64) int {
+	for i := range d.f.Sections {
+		sh := &d.f.Sections[i]
+		if sh.Sh_flags&types.SHF_ALLOC == 0 || sh.Sh_size == 0 {
+			continue
+		}
+		if sh.Sh_flags&types.SHF_TLS != 0 && sh.Sh_type == types.SHT_NOBITS {
+			continue
+		}
+		if addr >= sh.Sh_addr && addr-sh.Sh_addr < sh.Sh_size {
+			return i
+		}
+	}
+	return -1
+}
+
+// sectionEnd returns the address right past section idx.
+func (d *disassembler) sectionEnd(idx int) uint64 {
+	sh := &d.f.Sections[idx]
+	return sh.Sh_addr + sh.Sh_size
+}
+
+// disassemble decodes a region, starting a block at every symbol inside it.
+func (d *disassembler) disassemble(r region) []Block {
+	var out []Block
+	d.dec.reset()
+
+	block := &Block{Name: d.name(r.addr, r.shndx), Address: r.addr, Section: r.name}
+	if block.Name == "" {
+		block.Name = r.name
+	}
+
+	for off := 0; off < len(r.data); {
+		pc := r.addr + uint64(off)
+
+		// A new symbol starts a new block, unless it is the one the region starts at
+		if off > 0 {
+			if l, ok := d.labels.at(r.shndx, pc); ok && l.addr == pc {
+				out = append(out, *block)
+				block = &Block{Name: l.name, Address: pc, Section: r.name}
+				d.dec.reset()
+			}
+		}
+
+		ins := d.dec.decode(r.data[off:], pc)
+
+		if ins.HasTarget {
+			ins.TargetName = d.name(ins.Target, r.shndx)
+		}
+		if ins.HasRef {
+			ins.RefName = d.name(ins.Ref, r.shndx)
+			ins.String = d.stringAt(ins.Ref)
+		}
+
+		block.Instructions = append(block.Instructions, ins)
+		off += len(ins.Bytes)
+	}
+
+	return append(out, *block)
+}
+
+// name labels addr after the symbol at or below it in the section holding it, after the
+// section itself when no symbol precedes it there, and after its PT_LOAD segment when no
+// section holds it. Relocatable objects have no addresses yet, so their branches are named
+// within the section being decoded.
+func (d *disassembler) name(addr uint64, home int) string {
+	idx := home
+	if d.f.Header.E_type != types.ET_REL {
+		idx = d.sectionAt(addr)
+	}
+
+	if l, ok := d.labels.at(idx, addr); ok {
+		return l.offsetName(addr)
+	}
+	if idx < 0 {
+		return d.segmentName(addr)
+	}
+
+	sh := &d.f.Sections[idx]
+	name := d.f.SectionName(sh)
+	if delta := addr - sh.Sh_addr; delta > 0 {
+		return fmt.Sprintf("%s+%#x", name, delta)
+	}
+	return strings.Clone(name)
+}
+
+// segmentName labels addr after the PT_LOAD segment whose memory image holds it, empty when
+// none does.
+func (d *disassembler) segmentName(addr uint64) string {
+	for i := range d.f.Segments {
+		ph := &d.f.Segments[i]
+		if ph.P_type != types.PT_LOAD || addr < ph.P_vaddr || addr-ph.P_vaddr >= ph.P_memsz {
+			continue
+		}
+		if delta := addr - ph.P_vaddr; delta > 0 {
+			return fmt.Sprintf("segment %d+%#x", i, delta)
+		}
+		return fmt.Sprintf("segment %d", i)
+	}
+	return ""
+}
+
+// stringAt returns the NUL terminated string stored at addr in a read-only data section, empty
+// when the bytes there are not printable text. Writable sections, .data.rel.ro included, are
+// left alone: their pointers often start with a few printable bytes.
+func (d *disassembler) stringAt(addr uint64) string {
+	idx := d.sectionAt(addr)
+	if idx < 0 || d.f.Header.E_type == types.ET_REL {
+		return ""
+	}
+
+	sh := &d.f.Sections[idx]
+	if sh.Sh_type == types.SHT_NOBITS || sh.Sh_flags&(types.SHF_EXECINSTR|types.SHF_WRITE) != 0 {
+		return ""
+	}
+
+	data, err := d.p.SectionData(sh)
+	if err != nil {
+		return ""
+	}
+
+	start := addr - sh.Sh_addr
+	if start >= uint64(len(data)) {
+		return ""
+	}
+	data = data[start:min(start+maxString, uint64(len(data)))]
+
+	end := slices.Index(data, 0)
+	if end <= 0 {
+		return ""
+	}
+	for _, b := range data[:end] {
+		if (b < 0x20 || b > 0x7e) && b != '\t' && b != '\n' && b != '\r' {
+			return ""
+		}
+	}
+	return string(data[:end])
+}
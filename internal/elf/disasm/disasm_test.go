@@ -0,0 +1,272 @@
+package disasm
+
+import (
+	"testing"
+)
+
+func TestDecodeX86(t *testing.T) {
+	const pc = 0x1000
+
+	tests := []struct {
+		name   string
+		code   []byte
+		intel  bool
+		size   int
+		text   string
+		class  string
+		target uint64 // 0 when the instruction has none
+		ref    uint64 // 0 when the instruction has none
+	}{
+		{
+			name:   "call",
+			code:   []byte{0xe8, 0xfb, 0x00, 0x00, 0x00},
+			size:   5,
+			text:   "callq 0x1100",
+			class:  ClassCall,
+			target: 0x1100,
+		},
+		{
+			name:  "rip relative",
+			code:  []byte{0x48, 0x8d, 0x3d, 0xf9, 0x0f, 0x00, 0x00},
+			size:  7,
+			text:  "lea 0xff9(%rip),%rdi",
+			class: ClassOther,
+			ref:   0x2000,
+		},
+		{
+			name:  "intel",
+			code:  []byte{0x48, 0x8d, 0x3d, 0xf9, 0x0f, 0x00, 0x00},
+			intel: true,
+			size:  7,
+			text:  "lea rdi, ptr [rip+0xff9]",
+			class: ClassOther,
+			ref:   0x2000,
+		},
+		{
+			name:  "endbr64",
+			code:  []byte{0xf3, 0x0f, 0x1e, 0xfa, 0x55},
+			size:  4,
+			text:  "endbr64",
+			class: ClassNop,
+		},
+		{
+			name:  "rdsspq",
+			code:  []byte{0xf3, 0x48, 0x0f, 0x1e, 0xc8},
+			size:  5,
+			text:  "rdsspq %rax",
+			class: ClassStack,
+		},
+		{
+			name:  "vzeroupper",
+			code:  []byte{0xc5, 0xf8, 0x77, 0x39, 0xc2},
+			size:  3,
+			text:  "vzeroupper",
+			class: ClassOther,
+		},
+		{
+			name:  "sarx",
+			code:  []byte{0xc4, 0xe2, 0xfa, 0xf7, 0xc7}, // sarx rax, rdi, rax
+			size:  5,
+			text:  "sarx %rax,%rdi,%rax",
+			class: ClassOther,
+		},
+		{
+			name:  "blsr",
+			code:  []byte{0xc4, 0xe2, 0x78, 0xf3, 0xcf}, // blsr eax, edi
+			intel: true,
+			size:  5,
+			text:  "blsr eax, edi",
+			class: ClassOther,
+		},
+		{
+			name:  "vex rip relative",
+			code:  []byte{0xc5, 0xfd, 0x74, 0x05, 0x00, 0x10, 0x00, 0x00},
+			size:  8,
+			text:  "vpcmpeqb 0x1000(%rip),%ymm0,%ymm0",
+			class: ClassOther,
+			ref:   0x2008,
+		},
+		{
+			name:  "evex rip relative",
+			code:  []byte{0x62, 0xe1, 0xfe, 0x28, 0x6f, 0x05, 0x00, 0x10, 0x00, 0x00, 0xc3},
+			size:  10,
+			text:  "vmovdqu64 0x1000(%rip),%ymm16",
+			class: ClassOther,
+			ref:   0x200a,
+		},
+		{
+			name:  "bad",
+			code:  []byte{0x0f, 0xff},
+			size:  1,
+			text:  "(bad)",
+			class: ClassInvalid,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &x86Decoder{intel: tt.intel}
+			got := d.decode(tt.code, pc)
+
+			if len(got.Bytes) != tt.size || got.Text != tt.text || got.Class != tt.class {
+				t.Errorf("decode = %d bytes %q (%s), want %d bytes %q (%s)", len(got.Bytes), got.Text, got.Class, tt.size, tt.text, tt.class)
+			}
+			if got.HasTarget != (tt.target != 0) || got.Target != tt.target {
+				t.Errorf("target = %#x (%t), want %#x", got.Target, got.HasTarget, tt.target)
+			}
+			if got.HasRef != (tt.ref != 0) || got.Ref != tt.ref {
+				t.Errorf("ref = %#x (%t), want %#x", got.Ref, got.HasRef, tt.ref)
+			}
+		})
+	}
+}
+
+func TestLabels(t *testing.T) {
+	ls := &labels{sections: map[int][]label{
+		1: {
+			{name: "_start", addr: 0x1000, size: 0x20},
+			{name: "main", addr: 0x1040, size: 0x30},
+		},
+	}}
+
+	tests := []struct {
+		addr uint64
+		name string
+	}{
+		{0x1000, "_start"},
+		{0x1010, "_start+0x10"},
+		{0x1040, "main"},
+		{0x1080, "main+0x40"},
+	}
+
+	for _, tt := range tests {
+		l, ok := ls.at(1, tt.addr)
+		if !ok || l.offsetName(tt.addr) != tt.name {
+			t.Errorf("at(%#x) = %q, want %q", tt.addr, l.offsetName(tt.addr), tt.name)
+		}
+	}
+
+	if _, ok := ls.at(1, 0xfff); ok {
+		t.Errorf("at(0xfff) found a label below the first one")
+	}
+	if got := ls.extent(1, 0x1000, 0x2000); got != 0x40 {
+		t.Errorf("extent(0x1000) = %#x, want 0x40", got)
+	}
+	if got := ls.extent(1, 0x1040, 0x2000); got != 0xfc0 {
+		t.Errorf("extent(0x1040) = %#x, want 0xfc0", got)
+	}
+}
+
+// decoded is what decoding a stream is expected to give for one instruction.
+type decoded struct {
+	text   string
+	class  string
+	target uint64 // 0 when the instruction has none
+	ref    uint64 // 0 when the instruction has none
+	size   int    // 0 to leave the length unchecked
+}
+
+// checkStream decodes code from 0x1000 and compares each instruction with want.
+func checkStream(t *testing.T, dec decoder, code []byte, want []decoded) {
+	t.Helper()
+
+	pc := uint64(0x1000)
+	for i, w := range want {
+		if len(code) == 0 {
+			t.Fatalf("code ends before instruction %d (%s)", i, w.text)
+		}
+		got := dec.decode(code, pc)
+
+		if got.Text != w.text || got.Class != w.class {
+			t.Errorf("%#x: decode = %q (%s), want %q (%s)", pc, got.Text, got.Class, w.text, w.class)
+		}
+		if got.HasTarget != (w.target != 0) || got.Target != w.target {
+			t.Errorf("%#x: target = %#x (%t), want %#x", pc, got.Target, got.HasTarget, w.target)
+		}
+		if got.HasRef != (w.ref != 0) || got.Ref != w.ref {
+			t.Errorf("%#x: ref = %#x (%t), want %#x", pc, got.Ref, got.HasRef, w.ref)
+		}
+		if w.size != 0 && len(got.Bytes) != w.size {
+			t.Errorf("%#x: %d bytes, want %d", pc, len(got.Bytes), w.size)
+		}
+
+		code = code[len(got.Bytes):]
+		pc += uint64(len(got.Bytes))
+	}
+}
+
+func TestDecodeAArch64(t *testing.T) {
+	code := []byte{
+		0x3f, 0x23, 0x03, 0xd5, // paciasp
+		0x00, 0x00, 0x00, 0xb0, // adrp x0, 0x2000
+		0x01, 0x0c, 0x40, 0xf9, // ldr x1, [x0,#24]
+		0x00, 0x40, 0x00, 0x91, // add x0, x0, #0x10
+		0x02, 0x04, 0x40, 0xf9, // ldr x2, [x0,#8], x0 no longer holds the page
+		0x02, 0x00, 0xe1, 0xb8, // ldaddal w1, w2, [x0]
+		0x80, 0x00, 0x00, 0x54, // b.eq
+		0x10, 0x00, 0x00, 0x94, // bl
+		0xff, 0x0b, 0x5f, 0xd6, // retaa
+		0x00, 0x00, 0x00, 0x00, // udf #0
+	}
+
+	checkStream(t, &arm64Decoder{}, code, []decoded{
+		{text: "paciasp", class: ClassOther},
+		{text: "adrp x0, 0x2000", class: ClassOther},
+		{text: "ldr x1, [x0,#24]", class: ClassOther, ref: 0x2018},
+		{text: "add x0, x0, #0x10", class: ClassOther, ref: 0x2010},
+		{text: "ldr x2, [x0,#8]", class: ClassOther},
+		{text: "ldaddal w1, w2, [x0]", class: ClassOther},
+		{text: "b.eq 0x1028", class: ClassBranch, target: 0x1028},
+		{text: "bl 0x105c", class: ClassCall, target: 0x105c},
+		{text: "retaa", class: ClassReturn},
+		{text: "udf #0", class: ClassSystem},
+	})
+}
+
+func TestDecodeRISCV(t *testing.T) {
+	code := []byte{
+		0x41, 0x11, // addi sp,sp,-16
+		0x17, 0x15, 0x00, 0x00, // auipc a0,0x1
+		0x83, 0x35, 0x85, 0x00, // ld a1,8(a0)
+		0x13, 0x05, 0x05, 0x01, // addi a0,a0,16
+		0x03, 0x36, 0x05, 0x00, // ld a2,0(a0), a0 no longer holds the AUIPC address
+		0x01, 0xcd, // beqz a0
+		0x97, 0x00, 0x00, 0x00, // auipc ra,0x0
+		0xe7, 0x80, 0x00, 0x01, // jalr ra,16(ra)
+		0x82, 0x80, // ret
+		0x00, 0x00, // unimp
+	}
+
+	checkStream(t, &riscvDecoder{}, code, []decoded{
+		{text: "addi sp,sp,-16", class: ClassStack},
+		{text: "auipc a0,0x1", class: ClassOther},
+		{text: "ld a1,8(a0)", class: ClassOther, ref: 0x200a},
+		{text: "addi a0,a0,16", class: ClassOther, ref: 0x2012},
+		{text: "ld a2,0(a0)", class: ClassOther},
+		{text: "beqz a0,0x102a", class: ClassBranch, target: 0x102a},
+		{text: "auipc ra,0x0", class: ClassOther},
+		{text: "jalr ra,16(ra)", class: ClassCall, target: 0x1024},
+		{text: "ret", class: ClassReturn},
+		{text: "unimp", class: ClassSystem},
+	})
+}
+
+// Compressed instructions take 2 bytes, which moves the AUIPC that follows them off a 4 byte
+// boundary, and leave the address it builds alone when they write another register.
+func TestDecodeRISCVCompressed(t *testing.T) {
+	code := []byte{
+		0x22, 0x85, // c.mv a0,s0
+		0x97, 0x25, 0x00, 0x00, // auipc a1,0x2
+		0x22, 0x85, // c.mv a0,s0
+		0x03, 0xb6, 0x05, 0x01, // ld a2,16(a1)
+		0x93, 0x86, 0x85, 0xff, // addi a3,a1,-8
+	}
+
+	checkStream(t, &riscvDecoder{}, code, []decoded{
+		{text: "mv a0,s0", class: ClassOther, size: 2},
+		{text: "auipc a1,0x2", class: ClassOther, size: 4},
+		{text: "mv a0,s0", class: ClassOther, size: 2},
+		{text: "ld a2,16(a1)", class: ClassOther, ref: 0x3012, size: 4},
+		{text: "addi a3,a1,-8", class: ClassOther, ref: 0x2ffa, size: 4},
+	})
+}
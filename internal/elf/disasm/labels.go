@@ -0,0 +1,219 @@
+package disasm
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/yourpwnguy/strix/internal/elf/plt"
+	"github.com/yourpwnguy/strix/pkg/elf"
+	"github.com/yourpwnguy/strix/pkg/elf/types"
+)
+
+// label names an address: a symbol, a name@plt stub or a GOT slot.
+type label struct {
+	name  string
+	addr  uint64
+	size  uint64
+	shndx int // Section index, -1 when the file has no section headers
+	rank  int // Which label wins when several share an address
+}
+
+// offsetName names addr relative to the label, as name+0x....
+func (l *label) offsetName(addr uint64) string {
+	if addr == l.addr {
+		return l.name
+	}
+	return fmt.Sprintf("%s+%#x", l.name, addr-l.addr)
+}
+
+// labels indexes the labels of each section by address, one per address.
+type labels struct {
+	sections map[int][]label
+	names    map[string]label
+}
+
+// newLabels gathers the static and dynamic symbols, the PLT stubs and the GOT slots named
+// after their dynamic relocations, which is all objdump labels code with.
+func newLabels(p *elf.Parser, f *elf.File, sectionAt func(uint64) int) (*labels, error) {
+	symtab, err := p.Symbols()
+	if err != nil {
+		return nil, err
+	}
+
+	dynsym, err := p.DynamicSymbols()
+	if err != nil {
+		return nil, err
+	}
+
+	stubs, err := plt.Analyze(p)
+	if err != nil && !errors.Is(err, plt.ErrUnsupported) {
+		return nil, err
+	}
+
+	// Symbols and stubs can be looked up by name, GOT slots only label addresses
+	out := &labels{sections: make(map[int][]label), names: make(map[string]label)}
+	add := func(l label) {
+		out.sections[l.shndx] = append(out.sections[l.shndx], l)
+		if prev, ok := out.names[l.name]; !ok || l.rank > prev.rank {
+			out.names[l.name] = l
+		}
+	}
+
+	// Without section headers, section indexes mean nothing and every label goes in one list
+	shndx := func(sym *types.Symbol) (int, bool) {
+		if len(f.Sections) == 0 {
+			return -1, true
+		}
+		return int(sym.Shndx), sym.Shndx < uint32(len(f.Sections))
+	}
+
+	for _, table := range [][]types.Symbol{symtab, dynsym} {
+		for i := range table {
+			sym := &table[i]
+			if !labelsCode(sym) {
+				continue
+			}
+			if idx, ok := shndx(sym); ok {
+				add(label{name: strings.Clone(sym.Name), addr: sym.St_value, size: sym.St_size, shndx: idx, rank: symbolRank(sym)})
+			}
+		}
+	}
+
+	if stubs != nil {
+		for _, sym := range stubs.Symbols() {
+			if idx, ok := shndx(&sym); ok {
+				add(label{name: sym.Name, addr: sym.St_value, size: sym.St_size, shndx: idx, rank: 3})
+			}
+		}
+	}
+
+	slots, err := slotLabels(p, f, sectionAt)
+	if err != nil {
+		return nil, err
+	}
+	for _, l := range slots {
+		out.sections[l.shndx] = append(out.sections[l.shndx], l)
+	}
+
+	for idx, list := range out.sections {
+		slices.SortStableFunc(list, func(a, b label) int {
+			return cmp.Or(cmp.Compare(a.addr, b.addr), cmp.Compare(b.rank, a.rank))
+		})
+		out.sections[idx] = slices.CompactFunc(list, func(a, b label) bool { return a.addr == b.addr })
+	}
+
+	return out, nil
+}
+
+// slotLabels labels the slots patched by dynamic relocations against a symbol with the name
+// and version of that symbol, like objdump does for GOT references. Relocatable objects have
+// none, their relocations patch instructions rather than slots.
+func slotLabels(p *elf.Parser, f *elf.File, sectionAt func(uint64) int) ([]label, error) {
+	if f.Header.E_type == types.ET_REL {
+		return nil, nil
+	}
+
+	tables, err := p.Relocations()
+	if err != nil {
+		return nil, err
+	}
+
+	versions, err := p.Versions()
+	if err != nil {
+		return nil, err
+	}
+
+	size := uint64(8)
+	if f.Class == types.ELFCLASS32 {
+		size = 4
+	}
+
+	var out []label
+	for i := range tables {
+		for j := range tables[i].Relocs {
+			rel := &tables[i].Relocs[j]
+			if rel.Symbol == nil || rel.Symbol.Name == "" {
+				continue
+			}
+
+			idx := -1
+			if len(f.Sections) > 0 {
+				if idx = sectionAt(rel.Offset); idx < 0 {
+					continue
+				}
+			}
+
+			name := rel.Symbol.Name
+			if ver, ok := versions.Symbol(rel.Symbol.Index); ok && ver.Name != "" {
+				name += "@" + ver.Name
+			}
+			out = append(out, label{name: strings.Clone(name), addr: rel.Offset, size: size, shndx: idx})
+		}
+	}
+
+	return out, nil
+}
+
+// labelsCode reports whether a symbol names an address worth labelling code with. Section,
+// file and TLS symbols do not, nor do compiler local labels and ARM mapping symbols.
+func labelsCode(sym *types.Symbol) bool {
+	if sym.Name == "" || sym.IsUndefined() || sym.St_shndx == types.SHN_ABS || sym.St_shndx == types.SHN_COMMON {
+		return false
+	}
+	if strings.HasPrefix(sym.Name, ".L") || strings.HasPrefix(sym.Name, "$") {
+		return false
+	}
+	switch sym.Type() {
+	case types.STT_SECTION, types.STT_FILE, types.STT_TLS:
+		return false
+	}
+	return true
+}
+
+// symbolRank orders symbols at the same address by how well they describe it: sized
+// functions and objects over markers, global bindings over local ones.
+func symbolRank(sym *types.Symbol) int {
+	rank := 0
+	if t := sym.Type(); t == types.STT_FUNC || t == types.STT_GNU_IFUNC || t == types.STT_OBJECT {
+		rank += 2
+	}
+	if sym.Bind() != types.STB_LOCAL {
+		rank++
+	}
+	return rank
+}
+
+// at returns the label at or below addr in section idx.
+func (ls *labels) at(idx int, addr uint64) (label, bool) {
+	list := ls.sections[idx]
+	i, found := slices.BinarySearchFunc(list, addr, func(l label, addr uint64) int { return cmp.Compare(l.addr, addr) })
+	if !found {
+		i--
+	}
+	if i < 0 {
+		return label{}, false
+	}
+	return list[i], true
+}
+
+// extent returns how far the code at addr runs in section idx: up to the next label, or end.
+func (ls *labels) extent(idx int, addr, end uint64) uint64 {
+	list := ls.sections[idx]
+	i, found := slices.BinarySearchFunc(list, addr, func(l label, addr uint64) int { return cmp.Compare(l.addr, addr) })
+	if found {
+		i++
+	}
+	if i < len(list) && list[i].addr < end {
+		return list[i].addr - addr
+	}
+	return end - addr
+}
+
+// byName returns the best ranked label with the given name.
+func (ls *labels) byName(name string) (label, bool) {
+	l, ok := ls.names[name]
+	return l, ok
+}
@@ -0,0 +1,174 @@
+package disasm
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"golang.org/x/arch/riscv64/riscv64asm"
+)
+
+// riscvDecoder decodes RV64GC code. Compressed instructions take 2 bytes and are shown as the
+// instruction they expand to, as objdump does. It remembers the address each AUIPC builds, so
+// that the ADDI, load, store or JALR that completes it can name it, until the register may
+// have changed.
+type riscvDecoder struct {
+	upper [32]uint64
+	known uint32 // Bit n is set while upper[n] holds the address in xn
+}
+
+// riscvRegister matches the integer and floating point registers in operands, which objdump
+// shows under the names the calling convention gives them.
+var riscvRegister = regexp.MustCompile(`\b[xf]([12]?[0-9]|3[01])\b`)
+
+var (
+	riscvIntNames = [32]string{
+		"zero", "ra", "sp", "gp", "tp", "t0", "t1", "t2", "s0", "s1", "a0", "a1", "a2", "a3", "a4", "a5",
+		"a6", "a7", "s2", "s3", "s4", "s5", "s6", "s7", "s8", "s9", "s10", "s11", "t3", "t4", "t5", "t6",
+	}
+	riscvFloatNames = [32]string{
+		"ft0", "ft1", "ft2", "ft3", "ft4", "ft5", "ft6", "ft7", "fs0", "fs1", "fa0", "fa1", "fa2", "fa3", "fa4", "fa5",
+		"fa6", "fa7", "fs2", "fs3", "fs4", "fs5", "fs6", "fs7", "fs8", "fs9", "fs10", "fs11", "ft8", "ft9", "ft10", "ft11",
+	}
+)
+
+func (d *riscvDecoder) reset() {
+	d.known = 0
+}
+
+func (d *riscvDecoder) decode(code []byte, pc uint64) Instruction {
+	inst, err := riscv64asm.Decode(code)
+	if err != nil {
+		// The two low bits tell the length even of what does not decode
+		size := 4
+		if code[0]&3 != 3 {
+			size = 2
+		}
+		d.reset()
+		return Instruction{Address: pc, Bytes: code[:min(size, len(code))], Text: "(bad)", Class: ClassInvalid}
+	}
+
+	out := Instruction{Address: pc, Bytes: code[:inst.Len], Text: riscvABINames(riscv64asm.GNUSyntax(inst)), Class: riscvClass(inst)}
+
+	switch inst.Op {
+	case riscv64asm.JAL, riscv64asm.BEQ, riscv64asm.BNE, riscv64asm.BLT, riscv64asm.BGE,
+		riscv64asm.BLTU, riscv64asm.BGEU:
+		// The offset is the last operand, printed relative to the instruction
+		for _, arg := range inst.Args {
+			if imm, ok := arg.(riscv64asm.Simm); ok {
+				out.Target, out.HasTarget = pc+uint64(int64(imm.Imm)), true
+			}
+		}
+		if i := strings.LastIndexAny(out.Text, " ,"); i > 0 && out.HasTarget {
+			out.Text = fmt.Sprintf("%s%#x", out.Text[:i+1], out.Target)
+		}
+
+	case riscv64asm.AUIPC:
+		rd, _ := inst.Args[0].(riscv64asm.Reg)
+		imm, _ := inst.Args[1].(riscv64asm.Uimm)
+		if rd != riscv64asm.X0 {
+			d.upper[rd], d.known = pc+uint64(int64(int32(imm.Imm<<12))), d.known|1<<rd
+		}
+		return out
+
+	case riscv64asm.ADDI:
+		if rs, ok := inst.Args[1].(riscv64asm.Reg); ok && d.holds(rs) {
+			imm, _ := inst.Args[2].(riscv64asm.Simm)
+			out.Ref, out.HasRef = d.upper[rs]+uint64(int64(imm.Imm)), true
+		}
+
+	case riscv64asm.JALR:
+		if m, ok := inst.Args[1].(riscv64asm.RegOffset); ok && d.holds(m.OfsReg) {
+			out.Target, out.HasTarget = d.upper[m.OfsReg]+uint64(int64(m.Ofs.Imm)), true
+		}
+
+	default:
+		for _, arg := range inst.Args {
+			if m, ok := arg.(riscv64asm.RegOffset); ok && d.holds(m.OfsReg) {
+				out.Ref, out.HasRef = d.upper[m.OfsReg]+uint64(int64(m.Ofs.Imm)), true
+			}
+		}
+	}
+
+	switch out.Class {
+	case ClassCall, ClassJump, ClassReturn, ClassSystem:
+		d.reset()
+	case ClassBranch:
+	default:
+		// Stores read their first operand, everything else writes it
+		switch inst.Op {
+		case riscv64asm.SB, riscv64asm.SH, riscv64asm.SW, riscv64asm.SD, riscv64asm.FSH,
+			riscv64asm.FSW, riscv64asm.FSD:
+		default:
+			if rd, ok := inst.Args[0].(riscv64asm.Reg); ok && rd <= riscv64asm.X31 {
+				d.known &^= 1 << rd
+			}
+		}
+	}
+
+	return out
+}
+
+// riscvABINames renames the registers in the operands of an instruction after their ABI names.
+func riscvABINames(text string) string {
+	mnemonic, operands, ok := strings.Cut(text, " ")
+	if !ok {
+		return text
+	}
+	return mnemonic + " " + riscvRegister.ReplaceAllStringFunc(operands, func(r string) string {
+		n, _ := strconv.Atoi(r[1:])
+		if r[0] == 'f' {
+			return riscvFloatNames[n]
+		}
+		return riscvIntNames[n]
+	})
+}
+
+// holds reports whether register r holds an address built by AUIPC.
+func (d *riscvDecoder) holds(r riscv64asm.Reg) bool {
+	return r <= riscv64asm.X31 && d.known&(1<<r) != 0
+}
+
+// riscvClass sorts a RISC-V instruction into the class it is colored by. Jumps that link are
+// calls, and adjusting the stack pointer makes room for a frame or frees it.
+func riscvClass(inst riscv64asm.Inst) string {
+	switch inst.Op {
+	case riscv64asm.JAL:
+		if inst.Args[0] == riscv64asm.X0 {
+			return ClassJump
+		}
+		return ClassCall
+	case riscv64asm.JALR:
+		m, _ := inst.Args[1].(riscv64asm.RegOffset)
+		switch {
+		case inst.Args[0] != riscv64asm.X0:
+			return ClassCall
+		case m.OfsReg == riscv64asm.X1 && m.Ofs.Imm == 0:
+			return ClassReturn
+		}
+		return ClassJump
+	case riscv64asm.BEQ, riscv64asm.BNE, riscv64asm.BLT, riscv64asm.BGE, riscv64asm.BLTU, riscv64asm.BGEU:
+		return ClassBranch
+	case riscv64asm.ECALL, riscv64asm.EBREAK:
+		return ClassSystem
+	case riscv64asm.CSRRW:
+		// unimp, csrrw zero,cycle,zero. riscv64asm also returns it for 0x0000, the compressed
+		// encoding the ISA defines as illegal, which objdump shows as unimp too
+		if inst.Args[0] == riscv64asm.X0 && inst.Args[1] == riscv64asm.CYCLE && inst.Args[2] == riscv64asm.X0 {
+			return ClassSystem
+		}
+	case riscv64asm.ADDI:
+		rd, rs := inst.Args[0], inst.Args[1]
+		if rd == riscv64asm.X2 && rs == riscv64asm.X2 {
+			return ClassStack
+		}
+		if imm, _ := inst.Args[2].(riscv64asm.Simm); rd == riscv64asm.X0 && rs == riscv64asm.X0 && imm.Imm == 0 {
+			return ClassNop
+		}
+	case riscv64asm.SLT, riscv64asm.SLTU, riscv64asm.SLTI, riscv64asm.SLTIU,
+		riscv64asm.FEQ_S, riscv64asm.FLT_S, riscv64asm.FLE_S, riscv64asm.FEQ_D, riscv64asm.FLT_D, riscv64asm.FLE_D:
+		return ClassCompare
+	}
+	return ClassOther
+}
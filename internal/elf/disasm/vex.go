@@ -0,0 +1,215 @@
+package disasm
+
+import (
+	"encoding/binary"
+	"strings"
+
+	"golang.org/x/arch/x86/x86asm"
+)
+
+// vexPrefix is the decoded VEX or EVEX prefix of an instruction.
+type vexPrefix struct {
+	evex    bool
+	opmap   byte // 1 for 0F, 2 for 0F38, 3 for 0F3A
+	pp      byte // Implied legacy prefix: none, 66, F3, F2
+	w, l    bool // Operand width, vector length (256 bits)
+	r, x, b bool
+	vvvv    byte
+	opcode  int // Offset of the opcode byte
+}
+
+// parseVEX decodes the VEX (C4, C5) or EVEX (62) prefix at the start of code.
+func parseVEX(code []byte) (vexPrefix, bool) {
+	var v vexPrefix
+	switch {
+	case len(code) >= 3 && code[0] == 0xc5:
+		v.opmap, v.r = 1, code[1]&0x80 == 0
+		v.vvvv, v.l, v.pp = ^code[1]>>3&15, code[1]&4 != 0, code[1]&3
+		v.opcode = 2
+
+	case len(code) >= 4 && code[0] == 0xc4:
+		v.r, v.x, v.b = code[1]&0x80 == 0, code[1]&0x40 == 0, code[1]&0x20 == 0
+		v.opmap = code[1] & 0x1f
+		v.w, v.vvvv, v.l, v.pp = code[2]&0x80 != 0, ^code[2]>>3&15, code[2]&4 != 0, code[2]&3
+		v.opcode = 3
+
+	case len(code) >= 6 && code[0] == 0x62 && code[1]&0x08 == 0 && code[2]&0x04 != 0:
+		v.evex = true
+		v.r, v.x, v.b = code[1]&0x80 == 0, code[1]&0x40 == 0, code[1]&0x20 == 0
+		v.opmap = code[1] & 7
+		v.w, v.vvvv, v.pp = code[2]&0x80 != 0, ^code[2]>>3&15, code[2]&3
+		v.opcode = 4
+
+	default:
+		return v, false
+	}
+	return v, v.opmap >= 1 && v.opmap <= 6
+}
+
+// hasImmediate reports whether the opcode takes an 8 bit immediate after its operands.
+func (v *vexPrefix) hasImmediate(op byte) bool {
+	switch v.opmap {
+	case 3:
+		return true
+	case 1:
+		switch op {
+		case 0x70, 0x71, 0x72, 0x73, 0xc2, 0xc4, 0xc5, 0xc6:
+			return true
+		}
+	}
+	return false
+}
+
+// decodeVEX decodes the VEX and EVEX encoded instructions x86asm does not know: BMI1 and BMI2
+// are named, anything else (AVX-512 mostly) is only measured, so that decoding carries on at
+// the next instruction instead of going astray in the middle of this one.
+func decodeVEX(code []byte, pc uint64, intel bool) (Instruction, bool) {
+	v, ok := parseVEX(code)
+	if !ok {
+		return Instruction{}, false
+	}
+
+	op := code[v.opcode]
+	modrm := v.opcode + 1
+
+	end, ok := modrmEnd(code, modrm)
+	if !ok {
+		return Instruction{}, false
+	}
+	size := end
+	if v.hasImmediate(op) {
+		size++
+	}
+	if size > len(code) || size > 15 {
+		return Instruction{}, false
+	}
+
+	out := Instruction{Address: pc, Bytes: code[:size], Class: ClassOther}
+	if code[modrm]&0xc7 == 0x05 {
+		disp := int64(int32(binary.LittleEndian.Uint32(code[modrm+1:])))
+		out.Ref, out.HasRef = pc+uint64(size)+uint64(disp), true
+	}
+
+	if !v.evex {
+		if text, ok := bmi(&v, op, code[modrm:end], code[size-1], intel); ok {
+			out.Text = text
+			return out, true
+		}
+	}
+
+	out.Text, out.Class = "(unknown vex)", ClassInvalid
+	if v.evex {
+		out.Text = "(unknown evex)"
+	}
+	return out, true
+}
+
+// decodeVZero decodes vzeroupper and vzeroall, which have no operands. x86asm reads a ModRM
+// byte after them and swallows the first byte of the next instruction.
+func decodeVZero(code []byte, pc uint64) (Instruction, bool) {
+	v, ok := parseVEX(code)
+	if !ok || v.evex || v.opmap != 1 || code[v.opcode] != 0x77 {
+		return Instruction{}, false
+	}
+
+	text := "vzeroupper"
+	if v.l {
+		text = "vzeroall"
+	}
+	return Instruction{Address: pc, Bytes: code[:v.opcode+1], Text: text, Class: ClassOther}, true
+}
+
+// modrmEnd returns the offset right past the ModRM byte at i, its SIB byte and displacement.
+func modrmEnd(code []byte, i int) (int, bool) {
+	if i >= len(code) {
+		return 0, false
+	}
+	mod, rm := code[i]>>6, code[i]&7
+	end := i + 1
+
+	if mod != 3 && rm == 4 {
+		if end >= len(code) {
+			return 0, false
+		}
+		if mod == 0 && code[end]&7 == 5 {
+			end += 4
+		}
+		end++
+	}
+
+	switch {
+	case mod == 1:
+		end++
+	case mod == 2, mod == 0 && rm == 5:
+		end += 4
+	}
+	return end, end <= len(code)
+}
+
+// bmi names the BMI1 and BMI2 instructions, which work on general purpose registers. Their
+// ModRM operands are decoded by x86asm through the equivalent legacy mov, with the REX bits
+// of the VEX prefix.
+func bmi(v *vexPrefix, op byte, modrm []byte, imm byte, intel bool) (string, bool) {
+	pp := [4]string{"", "66", "f3", "f2"}[v.pp]
+	group := (modrm[0] >> 3) & 7
+
+	var mnemonic, form string
+	switch {
+	case v.opmap == 2 && op == 0xf2 && pp == "":
+		mnemonic, form = "andn", "r,v,m"
+	case v.opmap == 2 && op == 0xf3 && pp == "" && group >= 1 && group <= 3:
+		mnemonic, form = [4]string{"", "blsr", "blsmsk", "blsi"}[group], "v,m"
+	case v.opmap == 2 && op == 0xf5 && pp == "":
+		mnemonic, form = "bzhi", "r,m,v"
+	case v.opmap == 2 && op == 0xf5 && pp == "f2":
+		mnemonic, form = "pdep", "r,v,m"
+	case v.opmap == 2 && op == 0xf5 && pp == "f3":
+		mnemonic, form = "pext", "r,v,m"
+	case v.opmap == 2 && op == 0xf6 && pp == "f2":
+		mnemonic, form = "mulx", "r,v,m"
+	case v.opmap == 2 && op == 0xf7:
+		mnemonic = map[string]string{"": "bextr", "66": "shlx", "f3": "sarx", "f2": "shrx"}[pp]
+		form = "r,m,v"
+	case v.opmap == 3 && op == 0xf0 && pp == "f2":
+		mnemonic, form = "rorx", "r,m,i"
+	default:
+		return "", false
+	}
+
+	rex := byte(0x40)
+	for i, bit := range []bool{v.b, v.x, v.r, v.w} {
+		if bit {
+			rex |= 1 << i
+		}
+	}
+	legacy, err := x86asm.Decode(append([]byte{rex, 0x8b}, modrm...), 64)
+	if err != nil {
+		return "", false
+	}
+
+	vreg := x86asm.EAX + x86asm.Reg(v.vvvv)
+	if v.w {
+		vreg = x86asm.RAX + x86asm.Reg(v.vvvv)
+	}
+
+	inst := x86asm.Inst{Op: x86asm.MOV, Mode: 64, MemBytes: legacy.MemBytes, Len: legacy.Len}
+	for i, operand := range strings.Split(form, ",") {
+		switch operand {
+		case "r":
+			inst.Args[i] = legacy.Args[0]
+		case "m":
+			inst.Args[i] = legacy.Args[1]
+		case "v":
+			inst.Args[i] = vreg
+		case "i":
+			inst.Args[i] = x86asm.Imm(imm)
+		}
+	}
+
+	text := x86asm.GNUSyntax(inst, 0, nil)
+	if intel {
+		text = x86asm.IntelSyntax(inst, 0, nil)
+	}
+	_, operands, _ := strings.Cut(text, " ")
+	return mnemonic + " " + operands, true
+}
@@ -0,0 +1,182 @@
+package disasm
+
+import (
+	"bytes"
+	"strings"
+
+	"golang.org/x/arch/x86/x86asm"
+)
+
+// x86Decoder decodes x86-64 code, in AT&T syntax unless intel is set.
+type x86Decoder struct {
+	intel bool
+}
+
+// x86Fixed holds the operandless instructions x86asm predates: the CET landing pads and
+// shadow stack tokens, the protection key register accesses and the VIA PadLock engines.
+var x86Fixed = []struct {
+	code  []byte
+	text  string
+	class string
+}{
+	{[]byte{0xf3, 0x0f, 0x1e, 0xfa}, "endbr64", ClassNop},
+	{[]byte{0xf3, 0x0f, 0x1e, 0xfb}, "endbr32", ClassNop},
+	{[]byte{0xf3, 0x0f, 0x01, 0xea}, "saveprevssp", ClassOther},
+	{[]byte{0xf3, 0x0f, 0x01, 0xe8}, "setssbsy", ClassOther},
+	{[]byte{0x0f, 0x01, 0xee}, "rdpkru", ClassOther},
+	{[]byte{0x0f, 0x01, 0xef}, "wrpkru", ClassOther},
+	{[]byte{0x0f, 0xa7, 0xc0}, "xstore-rng", ClassOther},
+	{[]byte{0xf3, 0x0f, 0xa7, 0xc8}, "rep xcrypt-ecb", ClassOther},
+	{[]byte{0xf3, 0x0f, 0xa7, 0xd0}, "rep xcrypt-cbc", ClassOther},
+	{[]byte{0xf3, 0x0f, 0xa7, 0xd8}, "rep xcrypt-ctr", ClassOther},
+	{[]byte{0xf3, 0x0f, 0xa7, 0xe0}, "rep xcrypt-cfb", ClassOther},
+	{[]byte{0xf3, 0x0f, 0xa7, 0xe8}, "rep xcrypt-ofb", ClassOther},
+	{[]byte{0xf3, 0x0f, 0xa6, 0xc0}, "rep montmul", ClassOther},
+	{[]byte{0xf3, 0x0f, 0xa6, 0xc8}, "rep xsha1", ClassOther},
+	{[]byte{0xf3, 0x0f, 0xa6, 0xd0}, "rep xsha256", ClassOther},
+	{[]byte{0xf3, 0x0f, 0xa6, 0xe0}, "rep xsha512", ClassOther},
+}
+
+func (d *x86Decoder) reset() {}
+
+func (d *x86Decoder) decode(code []byte, pc uint64) Instruction {
+	for _, f := range x86Fixed {
+		if bytes.HasPrefix(code, f.code) {
+			return Instruction{Address: pc, Bytes: code[:len(f.code)], Text: f.text, Class: f.class}
+		}
+	}
+	if ins, ok := d.registerForm(code, pc); ok {
+		return ins
+	}
+	if ins, ok := decodeVZero(code, pc); ok {
+		return ins
+	}
+
+	// A lone prefix comes back as an instruction without an opcode
+	inst, err := x86asm.Decode(code, 64)
+	if err != nil || inst.Op == 0 {
+		if ins, ok := decodeVEX(code, pc, d.intel); ok {
+			return ins
+		}
+		return Instruction{Address: pc, Bytes: code[:1], Text: "(bad)", Class: ClassInvalid}
+	}
+
+	// x86asm loses the RIP base of VEX and EVEX memory operands
+	if v, ok := parseVEX(code); ok && v.opcode+1 < inst.Len && code[v.opcode+1]&0xc7 == 0x05 {
+		for i, arg := range inst.Args {
+			if m, ok := arg.(x86asm.Mem); ok && m.Base == 0 && m.Index == 0 {
+				m.Base = x86asm.RIP
+				inst.Args[i] = m
+			}
+		}
+	}
+
+	out := Instruction{Address: pc, Bytes: code[:inst.Len], Class: x86Class(inst.Op)}
+	if d.intel {
+		out.Text = x86asm.IntelSyntax(inst, pc, nil)
+	} else {
+		out.Text = x86asm.GNUSyntax(inst, pc, nil)
+	}
+
+	// Branch displacements and RIP relative operands count from the next instruction
+	next := pc + uint64(inst.Len)
+	for _, arg := range inst.Args {
+		switch a := arg.(type) {
+		case x86asm.Rel:
+			out.Target, out.HasTarget = next+uint64(int64(a)), true
+		case x86asm.Mem:
+			if a.Base == x86asm.RIP {
+				out.Ref, out.HasRef = next+uint64(a.Disp), true
+			}
+		}
+	}
+
+	return out
+}
+
+// x86RegisterForms holds the instructions x86asm predates that operate on the register in the
+// r/m field of their ModRM byte: the CET shadow stack pointer accesses, which take an F3 prefix
+// and a d or q suffix after their operand size, and rdseed.
+var x86RegisterForms = []struct {
+	rep      bool
+	opcode   byte // Second opcode byte, after 0F
+	group    byte // ModRM reg field
+	mnemonic string
+	suffix   bool
+	class    string
+}{
+	{true, 0x1e, 1, "rdssp", true, ClassStack},
+	{true, 0xae, 5, "incssp", true, ClassStack},
+	{false, 0xc7, 7, "rdseed", false, ClassOther},
+}
+
+// registerForm decodes the instructions of x86RegisterForms: an optional F3, an optional REX
+// prefix, then 0F, the opcode and a register ModRM.
+func (d *x86Decoder) registerForm(code []byte, pc uint64) (Instruction, bool) {
+	at := 0
+	rep := len(code) > 0 && code[0] == 0xf3
+	if rep {
+		at++
+	}
+	rex := byte(0)
+	if at < len(code) && code[at]&0xf0 == 0x40 {
+		rex = code[at]
+		at++
+	}
+	if len(code) < at+3 || code[at] != 0x0f || code[at+2]>>6 != 3 {
+		return Instruction{}, false
+	}
+
+	for _, f := range x86RegisterForms {
+		if f.rep != rep || f.opcode != code[at+1] || f.group != code[at+2]>>3&7 {
+			continue
+		}
+
+		n := x86asm.Reg(code[at+2]&7 | rex&1<<3)
+		reg, suffix := x86asm.EAX+n, "d"
+		if rex&8 != 0 {
+			reg, suffix = x86asm.RAX+n, "q"
+		}
+		mnemonic := f.mnemonic
+		if f.suffix {
+			mnemonic += suffix
+		}
+
+		operand := strings.ToLower(reg.String())
+		if !d.intel {
+			operand = "%" + operand
+		}
+		return Instruction{Address: pc, Bytes: code[:at+3], Text: mnemonic + " " + operand, Class: f.class}, true
+	}
+
+	return Instruction{}, false
+}
+
+// x86Class sorts an x86 instruction into the class it is colored by.
+func x86Class(op x86asm.Op) string {
+	switch op {
+	case x86asm.CALL, x86asm.LCALL:
+		return ClassCall
+	case x86asm.JMP, x86asm.LJMP:
+		return ClassJump
+	case x86asm.JA, x86asm.JAE, x86asm.JB, x86asm.JBE, x86asm.JCXZ, x86asm.JE, x86asm.JECXZ,
+		x86asm.JG, x86asm.JGE, x86asm.JL, x86asm.JLE, x86asm.JNE, x86asm.JNO, x86asm.JNP,
+		x86asm.JNS, x86asm.JO, x86asm.JP, x86asm.JRCXZ, x86asm.JS,
+		x86asm.LOOP, x86asm.LOOPE, x86asm.LOOPNE:
+		return ClassBranch
+	case x86asm.RET, x86asm.LRET, x86asm.IRET, x86asm.IRETD, x86asm.IRETQ:
+		return ClassReturn
+	case x86asm.INT, x86asm.INTO, x86asm.ICEBP, x86asm.UD0, x86asm.UD1, x86asm.UD2, x86asm.HLT,
+		x86asm.SYSCALL, x86asm.SYSENTER, x86asm.SYSEXIT, x86asm.SYSRET:
+		return ClassSystem
+	case x86asm.PUSH, x86asm.POP, x86asm.PUSHA, x86asm.PUSHAD, x86asm.POPA, x86asm.POPAD,
+		x86asm.PUSHF, x86asm.PUSHFD, x86asm.PUSHFQ, x86asm.POPF, x86asm.POPFD, x86asm.POPFQ,
+		x86asm.ENTER, x86asm.LEAVE:
+		return ClassStack
+	case x86asm.CMP, x86asm.TEST:
+		return ClassCompare
+	case x86asm.NOP, x86asm.PAUSE:
+		return ClassNop
+	}
+	return ClassOther
+}
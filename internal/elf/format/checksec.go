@@ -0,0 +1,81 @@
+package format
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/yourpwnguy/strix/internal/elf/model"
+	"github.com/yourpwnguy/strix/internal/ui"
+)
+
+// statusColor picks the color of a check result: green when the mitigation is present,
+// yellow when it is incomplete, red when it is missing and blue when it does not apply.
+func statusColor(status string) *color.Color {
+	switch status {
+	case "good":
+		return ui.Green
+	case "partial":
+		return ui.Yellow
+	case "bad":
+		return ui.Red
+	default:
+		return ui.Blue
+	}
+}
+
+// PrintChecksecSummary displays the report of one file on a single line.
+// The path is padded to width so that the results of several files line up.
+func PrintChecksecSummary(w io.Writer, path string, width int, report *model.Checksec) {
+	var sb strings.Builder
+
+	// Basic estimation
+	sb.Grow(256 + width)
+
+	sb.WriteString(ui.Bold.Sprintf("%-*s", width, path))
+	for i := range report.Checks {
+		c := &report.Checks[i]
+		sb.WriteString("  ")
+		sb.WriteString(ui.Cyan.Sprintf("%s:", c.Name))
+		sb.WriteString(statusColor(c.Status).Sprintf("%s", c.Value))
+	}
+	sb.WriteString("\n")
+
+	io.WriteString(w, sb.String())
+}
+
+// PrintChecksecDetails displays every check of one file along with what its result is based on.
+func PrintChecksecDetails(w io.Writer, path string, report *model.Checksec) {
+	var sb strings.Builder
+
+	// Basic estimation
+	sb.Grow(256 + len(report.Checks)*160)
+
+	sb.WriteString(ui.Cyan.Sprintf("\n%s", "Checksec: "))
+	sb.WriteString(ui.Bold.Sprintf("%s\n\n", path))
+
+	sb.WriteString(
+		ui.Magenta.Sprintf("%-12s%-10s%s\n",
+			"Check",
+			"Result",
+			"Details",
+		))
+
+	// Table rows
+	for i := range report.Checks {
+		c := &report.Checks[i]
+
+		sb.WriteString(
+			fmt.Sprintf("%s %s %s\n",
+				// Check
+				ui.Cyan.Sprintf("%-11s", c.Name),
+				// Result
+				statusColor(c.Status).Sprintf("%-9s", c.Value),
+				// Details
+				c.Detail,
+			))
+	}
+
+	io.WriteString(w, sb.String())
+}
@@ -0,0 +1,81 @@
+package format
+
+import (
+	"io"
+	"strings"
+
+	"github.com/yourpwnguy/strix/internal/elf/model"
+	"github.com/yourpwnguy/strix/internal/ui"
+)
+
+// PrintDependencies displays the dependency tree of a file, with where each library was found
+// and the candidates the search skipped. Missing libraries are shown in red.
+func PrintDependencies(w io.Writer, path string, v *model.Dependencies) {
+	var sb strings.Builder
+	sb.Grow(1024)
+
+	sb.WriteString(ui.Cyan.Sprint("\nDependencies: "))
+	sb.WriteString(ui.Bold.Sprintf("%s\n", path))
+
+	if v.Interpreter != "" {
+		sb.WriteString(ui.Cyan.Sprint("Interpreter: "))
+		sb.WriteString(ui.Green.Sprintf("%s\n", v.Interpreter))
+	}
+	if v.Sysroot != "" {
+		sb.WriteString(ui.Cyan.Sprint("Sysroot: "))
+		sb.WriteString(ui.Green.Sprintf("%s\n", v.Sysroot))
+	}
+	sb.WriteByte('\n')
+
+	if len(v.Needed) == 0 {
+		sb.WriteString(ui.Green.Sprint("No shared library dependencies\n"))
+		io.WriteString(w, sb.String())
+		return
+	}
+
+	sb.WriteString(ui.Bold.Sprintf("%s\n", path))
+	printDependencyTree(&sb, v.Needed, "")
+
+	if v.Missing > 0 {
+		sb.WriteString(ui.BoldRed.Sprintf("\nMissing libraries: %d\n", v.Missing))
+	}
+
+	io.WriteString(w, sb.String())
+}
+
+// printDependencyTree writes one level of the tree, prefix being the indentation drawn so far.
+func printDependencyTree(sb *strings.Builder, deps []model.Dependency, prefix string) {
+	for i := range deps {
+		d := &deps[i]
+
+		branch, indent := "├── ", "│   "
+		if i == len(deps)-1 {
+			branch, indent = "└── ", "    "
+		}
+
+		sb.WriteString(prefix + branch)
+		sb.WriteString(ui.Bold.Sprint(d.Name))
+		switch {
+		case !d.Found:
+			sb.WriteString(ui.BoldRed.Sprint(" => not found"))
+		case d.AlreadyLoaded:
+			sb.WriteString(ui.Blue.Sprint(" (already loaded)"))
+		default:
+			sb.WriteString(" => ")
+			sb.WriteString(ui.Green.Sprint(d.Path))
+			sb.WriteString(ui.Cyan.Sprintf(" [%s]", d.Source))
+		}
+		if d.Error != "" {
+			sb.WriteString(ui.Red.Sprintf(" (%s)", d.Error))
+		}
+		sb.WriteByte('\n')
+
+		// Skipped candidates, before the dependencies of the library
+		for _, r := range d.Rejected {
+			sb.WriteString(prefix + indent)
+			sb.WriteString(ui.Yellow.Sprintf("skipped %s: %s\n", r.Path, r.Reason))
+		}
+
+		printDependencyTree(sb, d.Needed, prefix+indent)
+	}
+}
@@ -0,0 +1,149 @@
+package format
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/yourpwnguy/strix/internal/elf/model"
+	"github.com/yourpwnguy/strix/internal/ui"
+)
+
+// disasmBytes is the number of instruction bytes shown per line, longer instructions continue
+// on the next one as objdump does.
+const disasmBytes = 7
+
+// disasmString caps the length of the strings shown next to operands.
+const disasmString = 60
+
+// classColor picks the color of an instruction from its class: calls in green, jumps in
+// yellow (bold when conditional), returns in red, traps and system calls in bold magenta,
+// stack operations in magenta, comparisons in cyan, nops in blue and bytes that do not decode
+// in bold red. Anything else keeps the default color.
+func classColor(class string) *color.Color {
+	switch class {
+	case "call":
+		return ui.Green
+	case "jump":
+		return ui.Yellow
+	case "branch":
+		return ui.BoldYellow
+	case "return":
+		return ui.Red
+	case "system":
+		return ui.BoldMagenta
+	case "stack":
+		return ui.Magenta
+	case "compare":
+		return ui.Cyan
+	case "nop":
+		return ui.Blue
+	case "invalid":
+		return ui.BoldRed
+	}
+	return nil
+}
+
+// PrintDisassembly displays the selected code block by block, with the symbol every call or
+// jump lands on and the address, symbol and string memory operands refer to.
+func PrintDisassembly(w io.Writer, path string, v *model.Disassembly) {
+	var sb strings.Builder
+
+	// Basic estimation
+	sb.Grow(1024)
+
+	sb.WriteString(ui.Cyan.Sprint("\nDisassembly: "))
+	sb.WriteString(ui.Bold.Sprintf("%s\n", path))
+	sb.WriteString(ui.Cyan.Sprint("Source: "))
+	sb.WriteString(ui.Green.Sprint(v.Source))
+	sb.WriteString(fmt.Sprintf(" (%s", v.Machine.Name))
+	switch v.Syntax {
+	case "att":
+		sb.WriteString(", AT&T syntax")
+	case "intel":
+		sb.WriteString(", Intel syntax")
+	}
+	sb.WriteString(")\n")
+
+	if len(v.Blocks) == 0 {
+		sb.WriteString(ui.Yellow.Sprint("\nNo code to disassemble\n"))
+		io.WriteString(w, sb.String())
+		return
+	}
+
+	// Addresses are padded to the widest one
+	width := 0
+	for i := range v.Blocks {
+		if n := len(v.Blocks[i].Instructions); n > 0 {
+			width = max(width, len(fmt.Sprintf("%x", v.Blocks[i].Instructions[n-1].Address)))
+		}
+	}
+
+	section := ""
+	for i := range v.Blocks {
+		b := &v.Blocks[i]
+
+		if b.Section != section || i == 0 {
+			section = b.Section
+			sb.WriteString(ui.Cyan.Sprint("\nDisassembly of "))
+			sb.WriteString(ui.Bold.Sprintf("%s:\n", section))
+		}
+
+		sb.WriteString("\n")
+		sb.WriteString(ui.Yellow.Sprintf("%#016x", b.Address))
+		sb.WriteString(ui.BoldMagenta.Sprintf(" <%s>:\n", b.Name))
+
+		for j := range b.Instructions {
+			writeInstruction(&sb, &b.Instructions[j], width)
+		}
+	}
+
+	io.WriteString(w, sb.String())
+}
+
+// writeInstruction writes one instruction with its annotations.
+func writeInstruction(sb *strings.Builder, ins *model.DisasmInstruction, width int) {
+	sb.WriteString(ui.Yellow.Sprintf("  %*x:  ", width, ins.Address))
+
+	first := ins.Bytes[:min(len(ins.Bytes), disasmBytes)]
+	sb.WriteString(fmt.Sprintf("%-*s  ", disasmBytes*3-1, fmt.Sprintf("% x", []byte(first))))
+
+	// The mnemonic is padded so that operands line up
+	text := ins.Text
+	if mnemonic, operands, ok := strings.Cut(text, " "); ok {
+		text = fmt.Sprintf("%-6s %s", mnemonic, operands)
+	}
+	if c := classColor(ins.Class); c != nil {
+		text = c.Sprint(text)
+	}
+	sb.WriteString(text)
+
+	if ins.TargetName != "" {
+		sb.WriteString(ui.Green.Sprintf(" <%s>", ins.TargetName))
+	}
+
+	if ins.Ref != nil {
+		sb.WriteString(ui.Blue.Sprintf("  # %#x", *ins.Ref))
+		if ins.RefName != "" {
+			sb.WriteString(ui.Green.Sprintf(" <%s>", ins.RefName))
+		}
+		if ins.String != "" {
+			s := ins.String
+			if len(s) > disasmString {
+				s = s[:disasmString] + "..."
+			}
+			sb.WriteString(" ")
+			sb.WriteString(ui.Cyan.Sprint(strconv.Quote(s)))
+		}
+	}
+	sb.WriteString("\n")
+
+	// Bytes past the first line
+	for i := disasmBytes; i < len(ins.Bytes); i += disasmBytes {
+		rest := ins.Bytes[i:min(len(ins.Bytes), i+disasmBytes)]
+		sb.WriteString(ui.Yellow.Sprintf("  %*x:  ", width, ins.Address+uint64(i)))
+		sb.WriteString(fmt.Sprintf("% x\n", []byte(rest)))
+	}
+}
@@ -0,0 +1,114 @@
+package format
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/yourpwnguy/strix/internal/elf/model"
+	"github.com/yourpwnguy/strix/internal/ui"
+	"github.com/yourpwnguy/strix/pkg/elf/types"
+)
+
+// PrintDynamic displays the dynamic section entries in a formatted layout.
+// String-valued tags show their resolved strings and flag words are decoded into names.
+func PrintDynamic(w io.Writer, v *model.Dynamic) {
+	entries := v.Entries
+
+	var sb strings.Builder
+
+	// Basic estimation
+	sb.Grow(1024 + len(entries)*128)
+
+	// Dynamic section location and entry count
+	sb.WriteString(ui.Cyan.Sprintf("\n%s", "Dynamic Section: "))
+	sb.WriteString(ui.Green.Sprintf("%d entries ", len(entries)))
+	sb.WriteString("(")
+	sb.WriteString(ui.Yellow.Sprintf("offset %#x", v.Offset))
+	sb.WriteString(")\n\n")
+
+	sb.WriteString(
+		ui.Magenta.Sprintf("%-7s%-20s%-18s%s\n",
+			"",
+			"Tag",
+			"Type",
+			"Name/Value",
+		))
+
+	// Table rows
+	for i := range entries {
+		e := &entries[i]
+
+		sb.WriteString(
+			fmt.Sprintf("%s   %s  %s %s\n",
+				// Count
+				fmt.Sprintf("[%s]", ui.Red.Sprintf("%02d", e.Index)),
+				// Tag
+				ui.Green.Sprintf("%#016x", e.Tag.Value),
+				// Type
+				ui.Cyan.Sprintf("%-17s", e.Tag.Name),
+				// Value
+				dynamicValue(e),
+			))
+
+		// Array contents, one pointer per line
+		for j, ptr := range e.Array {
+			sb.WriteString(fmt.Sprintf("%45s%s %s\n",
+				"",
+				ui.Magenta.Sprintf("[%d]", j),
+				ui.Yellow.Sprintf("%#016x", ptr),
+			))
+		}
+	}
+
+	io.WriteString(w, sb.String())
+}
+
+// dynamicValue renders the value of a dynamic entry the way readelf does, based on its tag.
+func dynamicValue(e *model.DynamicEntry) string {
+	switch int64(e.Tag.Value) {
+	case types.DT_NEEDED:
+		return ui.Yellow.Sprintf("Shared library: [%s]", e.String)
+	case types.DT_SONAME:
+		return ui.Yellow.Sprintf("Library soname: [%s]", e.String)
+	case types.DT_RPATH:
+		return ui.Red.Sprintf("Library rpath: [%s]", e.String)
+	case types.DT_RUNPATH:
+		return ui.Red.Sprintf("Library runpath: [%s]", e.String)
+	case types.DT_AUXILIARY:
+		return ui.Yellow.Sprintf("Auxiliary library: [%s]", e.String)
+	case types.DT_FILTER:
+		return ui.Yellow.Sprintf("Filter library: [%s]", e.String)
+	case types.DT_CONFIG:
+		return ui.Yellow.Sprintf("Configuration file: [%s]", e.String)
+	case types.DT_DEPAUDIT:
+		return ui.Yellow.Sprintf("Dependency audit library: [%s]", e.String)
+	case types.DT_AUDIT:
+		return ui.Yellow.Sprintf("Audit library: [%s]", e.String)
+
+	case types.DT_FLAGS:
+		return ui.Blue.Sprint(types.GetDynFlags(e.Value))
+	case types.DT_FLAGS_1:
+		return ui.Blue.Sprint(types.GetDynFlags1(e.Value))
+
+	case types.DT_PLTREL:
+		return ui.Blue.Sprint(types.GetDynTag(int64(e.Value)))
+
+	case types.DT_PLTRELSZ, types.DT_RELASZ, types.DT_RELAENT, types.DT_STRSZ,
+		types.DT_SYMENT, types.DT_RELSZ, types.DT_RELENT, types.DT_INIT_ARRAYSZ,
+		types.DT_FINI_ARRAYSZ, types.DT_PREINIT_ARRAYSZ, types.DT_RELRSZ, types.DT_RELRENT,
+		types.DT_GNU_CONFLICTSZ, types.DT_GNU_LIBLISTSZ, types.DT_PLTPADSZ,
+		types.DT_MOVEENT, types.DT_MOVESZ, types.DT_SYMINSZ, types.DT_SYMINENT:
+		return ui.Blue.Sprintf("%d (bytes)", e.Value)
+
+	case types.DT_VERDEFNUM, types.DT_VERNEEDNUM, types.DT_RELACOUNT, types.DT_RELCOUNT:
+		return ui.Blue.Sprintf("%d", e.Value)
+
+	case types.DT_BIND_NOW, types.DT_TEXTREL, types.DT_SYMBOLIC:
+		if e.Value == 0 {
+			return ""
+		}
+	}
+
+	return ui.Green.Sprintf("%#x", e.Value)
+}
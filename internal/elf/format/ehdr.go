@@ -1,16 +1,18 @@
 package format
 
 import (
+	"encoding/hex"
 	"fmt"
+	"io"
 	"strings"
 
-	"github.com/yourpwnguy/strix/internal/elf/types"
+	"github.com/yourpwnguy/strix/internal/elf/model"
 	"github.com/yourpwnguy/strix/internal/ui"
 )
 
 // Print ELF Header in colored and formatted way
-func PrintELFHeader(ehdr *types.Elf64_Ehdr, phdr []types.Elf64_Phdr) {
-	e_ident := &ehdr.E_ident
+func PrintELFHeader(w io.Writer, h *model.Header) {
+	ident, _ := hex.DecodeString(h.Ident.Bytes)
 
 	var sb strings.Builder
 	sb.Grow(2048)
@@ -18,61 +20,45 @@ func PrintELFHeader(ehdr *types.Elf64_Ehdr, phdr []types.Elf64_Phdr) {
 	// Header
 	sb.WriteString(ui.Bold.Sprint("ELF Header:\n"))
 
-	// e_ident magic bytes
+	// e_ident magic bytes, then the rest of the bytes
 	sb.WriteString(ui.Cyan.Sprint("  Magic:   "))
-	sb.WriteString(ui.Magenta.Sprintf("%02x %02x %02x %02x ",
-		e_ident.FileIdn[0],
-		e_ident.FileIdn[1],
-		e_ident.FileIdn[2],
-		e_ident.FileIdn[3],
-	))
-
-	// e_ident (rest of the bytes)
-	fmt.Fprintf(&sb, "%02x %02x %02x %02x %02x ",
-		e_ident.Ei_class,
-		e_ident.Ei_data,
-		e_ident.Ei_version,
-		e_ident.Ei_osabi,
-		e_ident.Ei_abiversion,
-	)
-
-	// e_ident - ei_pad bytes
-	for _, b := range e_ident.Ei_pad {
+	sb.WriteString(ui.Magenta.Sprintf("% x ", ident[:4]))
+	for _, b := range ident[4:] {
 		fmt.Fprintf(&sb, "%02x ", b)
 	}
 	sb.WriteByte('\n')
 
 	// ELF Class Information
 	sb.WriteString(ui.Cyan.Sprintf("  %-35s", "Class:"))
-	sb.WriteString(ui.Green.Sprint(types.GetEiClass(e_ident.Ei_class)))
+	sb.WriteString(ui.Green.Sprint(h.Ident.Class.Name))
 	sb.WriteByte('\n')
 
 	// ELF Data
 	sb.WriteString(ui.Cyan.Sprintf("  %-35s", "Data:"))
-	sb.WriteString(ui.Green.Sprint(types.GetEiData(e_ident.Ei_class)))
+	sb.WriteString(ui.Green.Sprint(h.Ident.Data.Name))
 	sb.WriteByte('\n')
 
 	// ELF Version
 	sb.WriteString(ui.Cyan.Sprintf("  %-35s", "Version:"))
-	sb.WriteString(ui.Green.Sprintf("%d (current)\n", e_ident.Ei_version))
+	sb.WriteString(ui.Green.Sprintf("%d (current)\n", h.Ident.Version))
 
 	// ELF OS/ABI
 	sb.WriteString(ui.Cyan.Sprintf("  %-35s", "OS/ABI:"))
-	sb.WriteString(ui.Green.Sprintf(types.GetEiOSABI(e_ident.Ei_osabi)))
+	sb.WriteString(ui.Green.Sprint(h.Ident.OSABI.Name))
 	sb.WriteByte('\n')
 
 	// ELF ABI Version
 	sb.WriteString(ui.Cyan.Sprintf("  %-35s", "ABI Version:"))
-	sb.WriteString(ui.Green.Sprintf("%d\n", e_ident.Ei_abiversion))
+	sb.WriteString(ui.Green.Sprintf("%d\n", h.Ident.ABIVersion))
 
 	// ELF Object file type
 	sb.WriteString(ui.Cyan.Sprintf("  %-35s", "Type:"))
-	sb.WriteString(ui.Yellow.Sprint(types.GetEType(ehdr.E_type, types.HasInterpreter(ehdr, phdr))))
+	sb.WriteString(ui.Yellow.Sprint(h.Type.Name))
 	sb.WriteByte('\n')
 
 	// ELF Machine - Architecture Information
 	sb.WriteString(ui.Cyan.Sprintf("  %-35s", "Machine:"))
-	eMachine := types.GetEMachine(ehdr.E_machine)
+	eMachine := h.Machine.Name
 	if strings.Contains((eMachine), "Advanced Micro Devices") {
 		sb.WriteString(ui.Red.Sprint(eMachine))
 	} else {
@@ -82,48 +68,48 @@ func PrintELFHeader(ehdr *types.Elf64_Ehdr, phdr []types.Elf64_Phdr) {
 
 	// ELF Object file version
 	sb.WriteString(ui.Cyan.Sprintf("  %-35s", "Version:"))
-	sb.WriteString(ui.Green.Sprintf("%#x\n", ehdr.E_version))
+	sb.WriteString(ui.Green.Sprintf("%#x\n", h.Version))
 
 	// ELF Entry point virtual address
 	sb.WriteString(ui.Cyan.Sprintf("  %-35s", "Entry Point Address:"))
-	sb.WriteString(ui.Yellow.Sprintf("%#016x\n", ehdr.E_entry))
+	sb.WriteString(ui.Yellow.Sprintf("%#016x\n", h.Entry))
 
 	// ELF Program header table file offset
 	sb.WriteString(ui.Cyan.Sprintf("  %-35s", "Start of program headers:"))
-	sb.WriteString(ui.Green.Sprintf("%d (bytes into file)\n", ehdr.E_phoff))
+	sb.WriteString(ui.Green.Sprintf("%d (bytes into file)\n", h.Phoff))
 
 	// ELF Section header table file offset
 	sb.WriteString(ui.Cyan.Sprintf("  %-35s", "Start of section headers:"))
-	sb.WriteString(ui.Green.Sprintf("%d (bytes into file)\n", ehdr.E_shoff))
+	sb.WriteString(ui.Green.Sprintf("%d (bytes into file)\n", h.Shoff))
 
 	// ELF Processor-specific flags
 	sb.WriteString(ui.Cyan.Sprintf("  %-35s", "Flags:"))
-	sb.WriteString(ui.Green.Sprintf("%#x\n", ehdr.E_flags))
+	sb.WriteString(ui.Green.Sprintf("%#x\n", h.Flags))
 
 	// ELF Header size in bytes
 	sb.WriteString(ui.Cyan.Sprintf("  %-35s", "Size of this header:"))
-	sb.WriteString(ui.Blue.Sprintf("%d (bytes)\n", ehdr.E_ehsize))
+	sb.WriteString(ui.Blue.Sprintf("%d (bytes)\n", h.Ehsize))
 
 	// ELF Program header table entry size
 	sb.WriteString(ui.Cyan.Sprintf("  %-35s", "Size of program headers:"))
-	sb.WriteString(ui.Blue.Sprintf("%d (bytes)\n", ehdr.E_phentsize))
+	sb.WriteString(ui.Blue.Sprintf("%d (bytes)\n", h.Phentsize))
 
 	// ELF Program header table entry count
 	sb.WriteString(ui.Cyan.Sprintf("  %-35s", "Number of program headers:"))
-	sb.WriteString(ui.Green.Sprintf("%d\n", ehdr.E_phnum))
+	sb.WriteString(ui.Green.Sprintf("%d\n", h.Phnum))
 
 	// ELF Section header table entry size
 	sb.WriteString(ui.Cyan.Sprintf("  %-35s", "Size of section headers:"))
-	sb.WriteString(ui.Blue.Sprintf("%d (bytes)\n", ehdr.E_shentsize))
+	sb.WriteString(ui.Blue.Sprintf("%d (bytes)\n", h.Shentsize))
 
 	// ELF Section header table entry count
 	sb.WriteString(ui.Cyan.Sprintf("  %-35s", "Number of section headers:"))
-	sb.WriteString(ui.Green.Sprintf("%d\n", ehdr.E_shnum))
+	sb.WriteString(ui.Green.Sprintf("%d\n", h.Shnum))
 
 	// ELF Section header string table index
 	sb.WriteString(ui.Cyan.Sprintf("  %-35s", "Section header string table index:"))
-	sb.WriteString(ui.Green.Sprintf("%d\n", ehdr.E_shstrndx))
+	sb.WriteString(ui.Green.Sprintf("%d\n", h.Shstrndx))
 
 	// ELF Single output
-	fmt.Print(sb.String())
+	io.WriteString(w, sb.String())
 }
@@ -0,0 +1,80 @@
+package format
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/yourpwnguy/strix/internal/elf/model"
+	"github.com/yourpwnguy/strix/internal/ui"
+)
+
+// hexDumpWidth is the number of bytes per row.
+const hexDumpWidth = 16
+
+// PrintHexDump displays a byte range as rows of hex bytes with their ASCII rendering.
+// Rows are labelled with file offsets, or with virtual addresses when virtual is set and the
+// range is mapped. Printable ASCII is highlighted in both columns.
+func PrintHexDump(w io.Writer, v *model.HexDump, virtual bool) {
+	var sb strings.Builder
+
+	// Basic estimation
+	sb.Grow(256 + len(v.Data)*8)
+
+	// Range description
+	sb.WriteString(ui.Cyan.Sprintf("\nHex dump of %s ", v.Source))
+	sb.WriteString("(")
+	sb.WriteString(ui.Yellow.Sprintf("offset %#x", v.Offset))
+	if v.Address != nil {
+		sb.WriteString(", ")
+		sb.WriteString(ui.Yellow.Sprintf("address %#x", *v.Address))
+	}
+	sb.WriteString("): ")
+	sb.WriteString(ui.Green.Sprintf("%d bytes\n\n", len(v.Data)))
+
+	base := v.Offset
+	if virtual && v.Address != nil {
+		base = *v.Address
+	}
+
+	// Colored hex and ASCII cells, computed once for every byte value
+	var hexCells, asciiCells [256]string
+	for b := range 256 {
+		if b >= 0x20 && b <= 0x7e {
+			hexCells[b] = ui.Green.Sprintf("%02x", b)
+			asciiCells[b] = ui.Green.Sprintf("%c", b)
+		} else {
+			hexCells[b] = fmt.Sprintf("%02x", b)
+			asciiCells[b] = "."
+		}
+	}
+
+	// Table rows
+	for i := 0; i < len(v.Data); i += hexDumpWidth {
+		row := v.Data[i:min(i+hexDumpWidth, len(v.Data))]
+
+		sb.WriteString(ui.Yellow.Sprintf("%#016x", base+uint64(i)))
+		sb.WriteString("  ")
+
+		for j := range hexDumpWidth {
+			// Halves of the row are split by an extra space
+			if j == hexDumpWidth/2 {
+				sb.WriteByte(' ')
+			}
+			if j < len(row) {
+				sb.WriteString(hexCells[row[j]])
+			} else {
+				sb.WriteString("  ")
+			}
+			sb.WriteByte(' ')
+		}
+
+		sb.WriteString(" |")
+		for _, b := range row {
+			sb.WriteString(asciiCells[b])
+		}
+		sb.WriteString("|\n")
+	}
+
+	io.WriteString(w, sb.String())
+}
@@ -0,0 +1,78 @@
+package format
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/yourpwnguy/strix/internal/elf/model"
+	"github.com/yourpwnguy/strix/internal/ui"
+)
+
+// ldcacheNameWidth caps the library name column.
+const ldcacheNameWidth = 32
+
+// PrintLoaderCache displays the entries of a loader cache the way ldconfig -p does,
+// one library name per line with its flags and the file it maps to.
+func PrintLoaderCache(w io.Writer, path string, v *model.LoaderCache) {
+	var sb strings.Builder
+
+	// Basic estimation
+	sb.Grow(256 + len(v.Entries)*128)
+
+	sb.WriteString(ui.Cyan.Sprint("\nLoader cache: "))
+	sb.WriteString(ui.Bold.Sprintf("%s\n", path))
+	sb.WriteString(ui.Cyan.Sprint("Format: "))
+	sb.WriteString(ui.Green.Sprintf("%s\n", v.Format))
+	if v.Generator != "" {
+		sb.WriteString(ui.Cyan.Sprint("Generator: "))
+		sb.WriteString(ui.Green.Sprintf("%s\n", v.Generator))
+	}
+	if len(v.Hwcaps) > 0 {
+		sb.WriteString(ui.Cyan.Sprint("glibc-hwcaps: "))
+		sb.WriteString(ui.Green.Sprintf("%s\n", strings.Join(v.Hwcaps, ", ")))
+	}
+
+	sb.WriteString(ui.Cyan.Sprint("Entries: "))
+	if len(v.Entries) == v.Total {
+		sb.WriteString(ui.Green.Sprintf("%d\n\n", v.Total))
+	} else {
+		sb.WriteString(ui.Green.Sprintf("%d of %d\n\n", len(v.Entries), v.Total))
+	}
+
+	// Column widths, a few very long names only push their own line out
+	nameWidth, flagsWidth := 0, 0
+	for i := range v.Entries {
+		nameWidth = max(nameWidth, min(len(v.Entries[i].Name), ldcacheNameWidth))
+		flagsWidth = max(flagsWidth, len(entryFlags(&v.Entries[i])))
+	}
+
+	for i := range v.Entries {
+		e := &v.Entries[i]
+		sb.WriteString(
+			fmt.Sprintf("  %s  %s  => %s\n",
+				// Library name
+				ui.Bold.Sprintf("%-*s", nameWidth, e.Name),
+				// Flags, hwcaps and OS ABI
+				ui.Yellow.Sprintf("%-*s", flagsWidth, entryFlags(e)),
+				// File
+				ui.Green.Sprint(e.Path),
+			))
+	}
+
+	io.WriteString(w, sb.String())
+}
+
+// entryFlags is the parenthesized description ldconfig -p prints for an entry.
+func entryFlags(e *model.CacheEntry) string {
+	desc := "(" + e.Flags.Name
+	if e.Hwcaps != "" {
+		desc += `, hwcap: "` + e.Hwcaps + `"`
+	} else if e.HWCap != 0 {
+		desc += fmt.Sprintf(", hwcap: %#016x", e.HWCap)
+	}
+	if e.OSVersion != "" {
+		desc += ", OS ABI: " + e.OSVersion
+	}
+	return desc + ")"
+}
@@ -0,0 +1,83 @@
+package format
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/yourpwnguy/strix/internal/elf/model"
+	"github.com/yourpwnguy/strix/internal/ui"
+)
+
+// PrintLocation displays where a virtual address or file offset lands in the file.
+func PrintLocation(w io.Writer, v *model.Location) {
+	var sb strings.Builder
+	sb.Grow(512)
+
+	// Query
+	title := "Address"
+	if v.Query == "offset" {
+		title = "File offset"
+	}
+	sb.WriteString(ui.Bold.Sprintf("%s %#x:\n", title, v.Value))
+
+	// Virtual address and file offset
+	sb.WriteString(ui.Cyan.Sprintf("  %-16s", "Address:"))
+	if v.Address != nil {
+		sb.WriteString(ui.Yellow.Sprintf("%#016x", *v.Address))
+		if !v.Mapped {
+			sb.WriteString(ui.Red.Sprint(" (not mapped by any PT_LOAD segment)"))
+		}
+	} else {
+		sb.WriteString(ui.Red.Sprint("not mapped by any PT_LOAD segment"))
+	}
+	sb.WriteByte('\n')
+
+	// An address outside every segment has no file offset either, but nothing zero-fills it
+	sb.WriteString(ui.Cyan.Sprintf("  %-16s", "File offset:"))
+	switch {
+	case v.Offset != nil && v.FileBacked:
+		sb.WriteString(ui.Yellow.Sprintf("%#x", *v.Offset))
+	case v.Query == "offset":
+		sb.WriteString(ui.Red.Sprint("none, past the end of the file"))
+	case !v.Mapped:
+		sb.WriteString(ui.Red.Sprint("none, not mapped by any PT_LOAD segment"))
+	default:
+		sb.WriteString(ui.Red.Sprint("none, no file backing (zero-filled at load time)"))
+	}
+	sb.WriteByte('\n')
+
+	// Containing section and segment
+	sb.WriteString(ui.Cyan.Sprintf("  %-16s", "Section:"))
+	if v.Section != nil {
+		sb.WriteString(ui.Green.Sprint(v.Section.Name))
+		fmt.Fprintf(&sb, " [%d] +%#x", v.Section.Index, v.Section.Delta)
+	} else {
+		sb.WriteString(ui.Red.Sprint("none"))
+	}
+	sb.WriteByte('\n')
+
+	sb.WriteString(ui.Cyan.Sprintf("  %-16s", "Segment:"))
+	if v.Segment != nil {
+		sb.WriteString(ui.Green.Sprint(v.Segment.Name))
+		fmt.Fprintf(&sb, " [%d] +%#x", v.Segment.Index, v.Segment.Delta)
+	} else {
+		sb.WriteString(ui.Red.Sprint("none"))
+	}
+	sb.WriteByte('\n')
+
+	// Nearest preceding symbol
+	sb.WriteString(ui.Cyan.Sprintf("  %-16s", "Symbol:"))
+	if v.Symbol != nil {
+		sb.WriteString(ui.Magenta.Sprint(v.Symbol.Name))
+		fmt.Fprintf(&sb, "+%#x", v.Symbol.Delta)
+		if v.Symbol.Size != 0 && v.Symbol.Delta >= v.Symbol.Size {
+			sb.WriteString(ui.Yellow.Sprintf(" (past its end, size %#x)", v.Symbol.Size))
+		}
+	} else {
+		sb.WriteString(ui.Red.Sprint("none"))
+	}
+	sb.WriteByte('\n')
+
+	io.WriteString(w, sb.String())
+}
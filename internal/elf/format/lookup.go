@@ -0,0 +1,167 @@
+package format
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/yourpwnguy/strix/internal/elf/model"
+	"github.com/yourpwnguy/strix/internal/ui"
+)
+
+// PrintSymbolLookup displays each hash table of a file: the walk of the lookup through it
+// when a symbol was given, then how evenly it spreads its symbols over the buckets.
+func PrintSymbolLookup(w io.Writer, path string, v *model.SymbolLookup) {
+	var sb strings.Builder
+
+	// Basic estimation
+	sb.Grow(1024)
+
+	sb.WriteString(ui.Cyan.Sprint("\nHash tables: "))
+	sb.WriteString(ui.Bold.Sprintf("%s\n", path))
+
+	if v.Symbol != "" {
+		sb.WriteString(ui.Cyan.Sprint("Symbol: "))
+		sb.WriteString(ui.Bold.Sprint(v.Symbol))
+		if v.Version != "" {
+			sb.WriteString(ui.Green.Sprintf(" (version %s)", v.Version))
+		}
+		sb.WriteString("\n")
+	}
+
+	if len(v.Tables) == 0 {
+		sb.WriteString(ui.Yellow.Sprint("\nNo DT_GNU_HASH or DT_HASH table\n"))
+		io.WriteString(w, sb.String())
+		return
+	}
+
+	for i := range v.Tables {
+		t := &v.Tables[i]
+
+		sb.WriteString(ui.BoldMagenta.Sprintf("\n%s", t.Kind))
+		sb.WriteString(fmt.Sprintf(" at offset %#x", t.Offset))
+		if t.Used {
+			sb.WriteString(ui.Green.Sprint(" (used by the loader)"))
+		}
+		sb.WriteString("\n")
+
+		if t.Lookup != nil {
+			writeHashLookup(&sb, t.Lookup)
+			sb.WriteString("\n")
+		}
+		writeHashStats(&sb, &t.Stats)
+	}
+
+	io.WriteString(w, sb.String())
+}
+
+// writeHashLookup writes the steps of one lookup: hash, bloom filter, bucket and the
+// symbols of the chain it compared.
+func writeHashLookup(sb *strings.Builder, l *model.HashLookup) {
+	sb.WriteString(fmt.Sprintf("  %s %s\n", ui.Cyan.Sprintf("%-14s", "Hash:"), ui.Green.Sprintf("%#08x", l.Hash)))
+
+	if l.Bloom != nil {
+		result := ui.Green.Sprint("hit")
+		if !l.Bloom.Hit {
+			result = ui.Yellow.Sprint("miss, the chain is not searched")
+		}
+		sb.WriteString(fmt.Sprintf("  %s word %d, bits %d and %d: %s\n",
+			ui.Cyan.Sprintf("%-14s", "Bloom filter:"),
+			l.Bloom.Word, l.Bloom.Bits[0], l.Bloom.Bits[1],
+			result,
+		))
+	}
+
+	bucket := fmt.Sprintf("%d, empty", l.Bucket)
+	if l.Start != 0 {
+		bucket = fmt.Sprintf("%d, chain of %d from symbol %d", l.Bucket, l.ChainLength, l.Start)
+	}
+	sb.WriteString(fmt.Sprintf("  %s %s\n", ui.Cyan.Sprintf("%-14s", "Bucket:"), bucket))
+
+	// Compared symbols
+	indexWidth, nameWidth := 0, 0
+	for _, p := range l.Probes {
+		indexWidth = max(indexWidth, len(fmt.Sprintf("[%d]", p.Index)))
+		nameWidth = max(nameWidth, len(p.Name))
+	}
+	for _, p := range l.Probes {
+		outcome := ui.Green.Sprint("match")
+		if !p.Match {
+			outcome = ui.Yellow.Sprint(p.Reason)
+		}
+		sb.WriteString(fmt.Sprintf("    %s %s  %s\n",
+			ui.Blue.Sprintf("%-*s", indexWidth, fmt.Sprintf("[%d]", p.Index)),
+			ui.Bold.Sprintf("%-*s", nameWidth, p.Name),
+			outcome,
+		))
+	}
+
+	sb.WriteString(ui.Cyan.Sprintf("  %-14s ", "Resolved:"))
+	sym := l.Symbol
+	if sym == nil {
+		sb.WriteString(ui.BoldRed.Sprint("not found\n"))
+		return
+	}
+
+	fallback := true
+	for _, p := range l.Probes {
+		fallback = fallback && !p.Match
+	}
+
+	sb.WriteString(ui.Green.Sprint(sym.VersionedName()))
+	sb.WriteString(fmt.Sprintf(" [%d] %s %s, value %#x, size %d",
+		sym.Index, sym.Type.Name, sym.Bind.Name, sym.Value, sym.Size))
+	if fallback {
+		sb.WriteString(ui.Yellow.Sprint(" (the only default version)"))
+	}
+	sb.WriteString("\n")
+}
+
+// writeHashStats writes the bucket occupancy of a table with its chain length histogram.
+func writeHashStats(sb *strings.Builder, s *model.HashStats) {
+	sb.WriteString(fmt.Sprintf("  %s %d, %d empty, %s\n",
+		ui.Cyan.Sprintf("%-14s", "Buckets:"),
+		s.Buckets, s.Empty,
+		fmt.Sprintf("longest chain %d, average %.2f", s.Longest, s.AverageChain),
+	))
+
+	symbols := fmt.Sprintf("%d", s.Symbols)
+	if s.SymOffset > 0 {
+		symbols += fmt.Sprintf(", the first %d dynamic symbols are not hashed", s.SymOffset)
+	}
+	sb.WriteString(fmt.Sprintf("  %s %s\n", ui.Cyan.Sprintf("%-14s", "Symbols:"), symbols))
+
+	if b := s.Bloom; b != nil {
+		sb.WriteString(fmt.Sprintf("  %s %d words, shift %d, %d of %d bits set (%.1f%%)\n",
+			ui.Cyan.Sprintf("%-14s", "Bloom filter:"),
+			b.Words, b.Shift, b.Set, b.Bits, b.Fill,
+		))
+	}
+
+	if len(s.Histogram) == 0 {
+		return
+	}
+
+	most := 0
+	for _, row := range s.Histogram {
+		most = max(most, row.Buckets)
+	}
+
+	sb.WriteString(ui.Magenta.Sprintf("\n  %6s  %8s  %6s  %8s\n", "Length", "Buckets", "%", "Coverage"))
+	for _, row := range s.Histogram {
+		sb.WriteString(fmt.Sprintf("  %6d  %8d  %5.1f%%  %7.1f%%", row.Length, row.Buckets, row.Percent, row.Coverage))
+		if bar := histogramBar(row.Buckets, most); bar > 0 {
+			sb.WriteString(ui.Green.Sprint("  " + strings.Repeat("█", bar)))
+		}
+		sb.WriteString("\n")
+	}
+}
+
+// histogramBar is the length of the bar drawn for a row of the histogram, scaled so that the
+// most common chain length gets 40 characters. Any non empty row gets at least one.
+func histogramBar(buckets, most int) int {
+	if buckets == 0 {
+		return 0
+	}
+	return max(buckets*40/most, 1)
+}
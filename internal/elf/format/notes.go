@@ -0,0 +1,149 @@
+package format
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/yourpwnguy/strix/internal/elf/model"
+	"github.com/yourpwnguy/strix/internal/ui"
+)
+
+// maxNoteData is the number of raw descriptor bytes shown for notes that are not decoded.
+const maxNoteData = 32
+
+// PrintNotes displays the notes grouped by the section or segment they were found in.
+// Decoded descriptors are shown in readable form, and anything else as raw hex.
+func PrintNotes(w io.Writer, v *model.Notes) {
+	notes := v.Notes
+
+	var sb strings.Builder
+
+	// Basic estimation
+	sb.Grow(1024 + len(notes)*256)
+
+	for i := 0; i < len(notes); {
+		// Consecutive notes share their source
+		j := i
+		for j < len(notes) && notes[j].Source == notes[i].Source {
+			j++
+		}
+
+		sb.WriteString(ui.Cyan.Sprintf("\nNotes in '%s' ", notes[i].Source))
+		sb.WriteString("(")
+		sb.WriteString(ui.Yellow.Sprintf("offset %#x", notes[i].Offset))
+		sb.WriteString("): ")
+		sb.WriteString(ui.Green.Sprintf("%d entries\n\n", j-i))
+
+		sb.WriteString(
+			ui.Magenta.Sprintf("%-12s%-12s%-28s%s\n",
+				"Owner",
+				"Size",
+				"Type",
+				"Description",
+			))
+
+		for ; i < j; i++ {
+			n := &notes[i]
+			lines := noteDescription(n)
+
+			sb.WriteString(
+				fmt.Sprintf("%s %s %s %s\n",
+					// Owner
+					ui.Cyan.Sprintf("%-11s", n.Owner),
+					// Descriptor size
+					ui.Green.Sprintf("%#-11x", n.Size),
+					// Type
+					ui.Blue.Sprintf("%-27s", n.Type.Name),
+					// Description
+					lines[0],
+				))
+
+			// Multi-line descriptions continue under the Description column
+			for _, line := range lines[1:] {
+				sb.WriteString(fmt.Sprintf("%52s%s\n", "", line))
+			}
+		}
+	}
+
+	io.WriteString(w, sb.String())
+}
+
+// noteDescription renders the decoded value of a note, one entry per output line.
+func noteDescription(n *model.Note) []string {
+	switch {
+	case n.BuildID != "":
+		return []string{ui.Yellow.Sprintf("Build ID: %s", n.BuildID)}
+
+	case n.GoBuildID != "":
+		return []string{ui.Yellow.Sprintf("Go build ID: %s", n.GoBuildID)}
+
+	case n.ABITag != nil:
+		return []string{ui.Yellow.Sprintf("OS: %s, ABI: %s", n.ABITag.OS.Name, n.ABITag.ABI)}
+
+	case n.Properties != nil:
+		if len(n.Properties) == 0 {
+			return []string{""}
+		}
+		lines := make([]string, len(n.Properties))
+		for i := range n.Properties {
+			lines[i] = ui.Yellow.Sprintf("%s: %s", n.Properties[i].Type.Name, n.Properties[i].Description)
+		}
+		return lines
+
+	case n.PackageMetadata != nil:
+		// Metadata that is not valid JSON is carried as a JSON string
+		metadata := string(n.PackageMetadata)
+		var text string
+		if json.Unmarshal(n.PackageMetadata, &text) == nil {
+			metadata = text
+		}
+		return []string{ui.Yellow.Sprintf("Packaging Metadata: %s", metadata)}
+
+	case n.ProcessStatus != nil:
+		return []string{ui.Yellow.Sprintf("PID: %d, Signal: %d", n.ProcessStatus.Pid, n.ProcessStatus.Signal)}
+
+	case n.ProcessInfo != nil:
+		return []string{
+			ui.Yellow.Sprintf("PID: %d, Name: %s, State: %s", n.ProcessInfo.Pid, n.ProcessInfo.Name, n.ProcessInfo.State),
+			ui.Yellow.Sprintf("Args: %s", n.ProcessInfo.Args),
+		}
+
+	case n.SignalInfo != nil:
+		return []string{ui.Yellow.Sprintf("Signal: %d, Errno: %d, Code: %d", n.SignalInfo.Signo, n.SignalInfo.Errno, n.SignalInfo.Code)}
+
+	case n.Auxv != nil:
+		if len(n.Auxv) == 0 {
+			return []string{""}
+		}
+		lines := make([]string, len(n.Auxv))
+		for i := range n.Auxv {
+			lines[i] = ui.Yellow.Sprintf("%-22s", n.Auxv[i].Type.Name) + ui.Green.Sprintf("%#x", n.Auxv[i].Value)
+		}
+		return lines
+
+	case n.Files != nil:
+		lines := []string{ui.Yellow.Sprintf("%d mapped files", len(n.Files))}
+		for i := range n.Files {
+			f := &n.Files[i]
+			lines = append(lines, fmt.Sprintf("%s-%s %s %s",
+				ui.Green.Sprintf("%#x", f.Start),
+				ui.Green.Sprintf("%#x", f.End),
+				ui.Blue.Sprintf("@%#x", f.Offset),
+				f.Path,
+			))
+		}
+		return lines
+	}
+
+	if n.Desc == "" {
+		return []string{""}
+	}
+
+	// Register dumps in core files run into kilobytes, only the head is useful
+	if len(n.Desc) > 2*maxNoteData {
+		return []string{fmt.Sprintf("Description data: %s... (%d bytes)", n.Desc[:2*maxNoteData], n.Size)}
+	}
+	return []string{fmt.Sprintf("Description data: %s", n.Desc)}
+}
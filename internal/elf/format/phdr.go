@@ -2,38 +2,45 @@ package format
 
 import (
 	"fmt"
+	"io"
 	"strings"
 
-	"github.com/yourpwnguy/strix/internal/elf/types"
+	"github.com/yourpwnguy/strix/internal/elf/model"
 	"github.com/yourpwnguy/strix/internal/ui"
+	"github.com/yourpwnguy/strix/pkg/elf/types"
 )
 
 // PrintProgramHeaders displays the program header table in a formatted layout.
-func PrintProgramHeaders(ehdr *types.Elf64_Ehdr, phdr []types.Elf64_Phdr, data []byte) {
+// Each segment row is followed by the sections it maps.
+func PrintProgramHeaders(w io.Writer, v *model.ProgramHeaders) {
 	var sb strings.Builder
 
 	// Basic estimation
-	sb.Grow(2048 + int(ehdr.E_phnum)*200)
+	sb.Grow(2048 + len(v.Segments)*200)
 
 	// ELF Object Type
 	sb.WriteString(ui.Cyan.Sprintf("\n%s", "ELF Type: "))
-	sb.WriteString(ui.Green.Sprint(types.GetEType(ehdr.E_type, types.HasInterpreter(ehdr, phdr))))
+	sb.WriteString(ui.Green.Sprint(v.Type.Name))
 	sb.WriteByte('\n')
 
 	// ELF Entry point virtual address
 	sb.WriteString(ui.Cyan.Sprintf("%s", "Entry: "))
-	sb.WriteString(ui.Green.Sprintf("%#016x\n", ehdr.E_entry))
+	sb.WriteString(ui.Green.Sprintf("%#016x\n", v.Entry))
 
 	// ELF Interpreter
+	interpreter := v.Interpreter
+	if interpreter == "" {
+		interpreter = "<Interpreter not available>"
+	}
 	sb.WriteString(ui.Cyan.Sprintf("%s", "Interpreter: "))
-	sb.WriteString(ui.Green.Sprint(types.GetInterpreter(ehdr, phdr, data)))
+	sb.WriteString(ui.Green.Sprint(interpreter))
 	sb.WriteString("\n\n")
 
 	// ELF Program headers count and offset
 	sb.WriteString(ui.Cyan.Sprintf("%s", "Program Headers: "))
-	sb.WriteString(ui.Green.Sprintf("%d entries ", ehdr.E_phnum))
+	sb.WriteString(ui.Green.Sprintf("%d entries ", len(v.Segments)))
 	sb.WriteString("(")
-	sb.WriteString(ui.Yellow.Sprintf("offset %#x", ehdr.E_phoff))
+	sb.WriteString(ui.Yellow.Sprintf("offset %#x", v.Offset))
 	sb.WriteString(")\n\n")
 
 	sb.WriteString(
@@ -50,29 +57,69 @@ func PrintProgramHeaders(ehdr *types.Elf64_Ehdr, phdr []types.Elf64_Phdr, data [
 		))
 
 	// Table rows
-	for i := uint16(0); i < ehdr.E_phnum; i++ {
-		ph := &phdr[i]
+	for i := range v.Segments {
+		seg := &v.Segments[i]
 		sb.WriteString(
-			fmt.Sprintf("%-16s%-25s%s  %-20s%-30s%-30s%s\n%69s%30s\n",
+			fmt.Sprintf("%s   %s %s  %s  %s   %s   %s\n%42s%s   %s\n",
 				// Count
-				fmt.Sprintf("[%02s]", ui.Red.Sprintf("%02d", i)),
+				fmt.Sprintf("[%s]", ui.Red.Sprintf("%02d", seg.Index)),
 				// Type
-				ui.Cyan.Sprint(types.GetPType(ph.P_type)),
+				ui.Cyan.Sprintf("%-15s", seg.Type.Name),
 				// Flags
-				types.GetPFlags(ph.P_flags),
+				segmentFlags(uint32(seg.Flags.Value)),
 				// Align
-				ui.Yellow.Sprintf("%#04x", ph.P_align),
+				ui.Yellow.Sprintf("%-9s", fmt.Sprintf("%#04x", seg.Align)),
 				// Offset
-				ui.Green.Sprintf("%#016x", ph.P_offset),
+				ui.Green.Sprintf("%#016x", seg.Offset),
 				// VirtAddr
-				ui.Green.Sprintf("%#016x", ph.P_vaddr),
+				ui.Green.Sprintf("%#016x", seg.Vaddr),
 				// PhysAddr
-				ui.Green.Sprintf("%#016x", ph.P_paddr),
+				ui.Green.Sprintf("%#016x", seg.Paddr),
+				"",
 				// File Size
-				ui.Green.Sprintf("%#016x", ph.P_filesz),
+				ui.Green.Sprintf("%#016x", seg.Filesz),
 				// Memory Size
-				ui.Green.Sprintf("%#016x", ph.P_memsz),
+				ui.Green.Sprintf("%#016x", seg.Memsz),
 			))
+
+		// Sections
+		if len(seg.Sections) > 0 {
+			writeSegmentSections(&sb, seg.Sections)
+		}
+	}
+
+	io.WriteString(w, sb.String())
+}
+
+// segmentFlags colors the readelf style segment flags, read in blue, write in yellow and execute in red.
+func segmentFlags(p_flags uint32) string {
+	flags := types.GetPFlags(p_flags)
+	return fmt.Sprintf("%s %s %s ",
+		ui.Blue.Sprint(flags[0:1]),
+		ui.Yellow.Sprint(flags[1:2]),
+		ui.Red.Sprint(flags[2:3]),
+	)
+}
+
+// writeSegmentSections writes the section names of a segment, wrapped under the row.
+func writeSegmentSections(sb *strings.Builder, names []string) {
+	const indent = 7
+	const width = 98
+
+	sb.WriteString(strings.Repeat(" ", indent))
+	sb.WriteString(ui.Magenta.Sprint("Sections: "))
+
+	col := indent + len("Sections: ")
+	for _, name := range names {
+		if col+len(name) > width {
+			sb.WriteByte('\n')
+			sb.WriteString(strings.Repeat(" ", indent+len("Sections: ")))
+			col = indent + len("Sections: ")
+		}
+
+		sb.WriteString(ui.Yellow.Sprint(name))
+		sb.WriteByte(' ')
+		col += len(name) + 1
 	}
-	fmt.Print(sb.String())
+	sb.WriteByte('\n')
 }
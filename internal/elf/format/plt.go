@@ -0,0 +1,90 @@
+package format
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/yourpwnguy/strix/internal/elf/model"
+	"github.com/yourpwnguy/strix/internal/ui"
+)
+
+// PrintPLT displays the PLT stubs of a file with the GOT slot each one goes through and the
+// function bound to it, as name@plt. Lazy stubs are flagged, calls never land on them.
+func PrintPLT(w io.Writer, path string, v *model.PLT) {
+	var sb strings.Builder
+
+	// Basic estimation
+	sb.Grow(256 + len(v.Entries)*128)
+
+	sb.WriteString(ui.Cyan.Sprint("\nPLT stubs: "))
+	sb.WriteString(ui.Bold.Sprintf("%s\n", path))
+	sb.WriteString(ui.Cyan.Sprint("Entries: "))
+	sb.WriteString(ui.Green.Sprintf("%d\n\n", len(v.Entries)))
+
+	if len(v.Entries) == 0 {
+		io.WriteString(w, sb.String())
+		return
+	}
+
+	// Column widths
+	sectionWidth, typeWidth := len("Section"), len("Type")
+	for i := range v.Entries {
+		e := &v.Entries[i]
+		sectionWidth = max(sectionWidth, len(e.Section))
+		typeWidth = max(typeWidth, len(pltType(e)))
+	}
+
+	sb.WriteString(
+		ui.Magenta.Sprintf("%-18s  %-*s  %-18s  %-*s  %s\n",
+			"Address",
+			sectionWidth, "Section",
+			"GOT slot",
+			typeWidth, "Type",
+			"Symbol",
+		))
+
+	// Table rows
+	for i := range v.Entries {
+		e := &v.Entries[i]
+
+		symbol := ui.Yellow.Sprint("?")
+		switch {
+		case e.Symbol == "":
+		case e.Lazy:
+			symbol = e.Symbol
+		default:
+			symbol = ui.Bold.Sprintf("%s@plt", e.Symbol)
+		}
+		if e.Version != "" {
+			symbol += ui.Blue.Sprintf(" (%s)", e.Version)
+		}
+		if e.Lazy {
+			symbol += ui.Yellow.Sprint(" [lazy stub]")
+		}
+
+		sb.WriteString(
+			fmt.Sprintf("%s  %s  %s  %s  %s\n",
+				// Stub address
+				ui.Green.Sprintf("%#016x", e.Address),
+				// Section
+				ui.Cyan.Sprintf("%-*s", sectionWidth, e.Section),
+				// GOT slot
+				ui.Yellow.Sprintf("%#016x", e.Slot),
+				// Relocation of the slot
+				fmt.Sprintf("%-*s", typeWidth, pltType(e)),
+				// Bound function
+				symbol,
+			))
+	}
+
+	io.WriteString(w, sb.String())
+}
+
+// pltType is the relocation type column of a stub, "-" when the slot has none.
+func pltType(e *model.PLTEntry) string {
+	if e.Type == nil {
+		return "-"
+	}
+	return e.Type.Name
+}
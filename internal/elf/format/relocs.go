@@ -0,0 +1,106 @@
+package format
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/yourpwnguy/strix/internal/elf/model"
+	"github.com/yourpwnguy/strix/internal/ui"
+)
+
+// PrintRelocations displays a relocation table in a formatted layout.
+// showTarget adds the address each relocation resolves to.
+func PrintRelocations(w io.Writer, table *model.RelocationTable, showTarget bool) {
+	var sb strings.Builder
+
+	// Basic estimation
+	sb.Grow(1024 + len(table.Relocations)*160)
+
+	// Relocation table name, kind and entry count
+	sb.WriteString(ui.Cyan.Sprintf("\nRelocation table '%s' ", table.Name))
+	sb.WriteString("(")
+	sb.WriteString(ui.Blue.Sprint(table.Kind.Name))
+	sb.WriteString(", ")
+	sb.WriteString(ui.Yellow.Sprintf("offset %#x", table.Offset))
+	sb.WriteString("): ")
+	sb.WriteString(ui.Green.Sprintf("%d entries\n\n", len(table.Relocations)))
+
+	header := fmt.Sprintf("%-8s%-20s%-26s%-20s%s",
+		"Num",
+		"Offset",
+		"Type",
+		"Sym. Value",
+		"Sym. Name + Addend",
+	)
+	if showTarget {
+		header = fmt.Sprintf("%-8s%-20s%-26s%-20s%-20s%s",
+			"Num",
+			"Offset",
+			"Type",
+			"Sym. Value",
+			"Target",
+			"Sym. Name + Addend",
+		)
+	}
+	sb.WriteString(ui.Magenta.Sprintln(header))
+
+	// Table rows
+	for i := range table.Relocations {
+		r := &table.Relocations[i]
+
+		value := fmt.Sprintf("%18s", "")
+		if r.SymbolValue != nil {
+			value = ui.Green.Sprintf("%#016x", *r.SymbolValue)
+		}
+
+		target := ""
+		if showTarget {
+			if r.Target != nil {
+				target = ui.Yellow.Sprintf("%#016x", *r.Target) + "  "
+			} else {
+				target = fmt.Sprintf("%-20s", "-")
+			}
+		}
+
+		sb.WriteString(
+			fmt.Sprintf("%s  %s  %s %s  %s%s\n",
+				// Index
+				fmt.Sprintf("[%s]", ui.Red.Sprintf("%04d", r.Index)),
+				// Offset
+				ui.Green.Sprintf("%#016x", r.Offset),
+				// Type
+				ui.Cyan.Sprintf("%-25s", r.Type.Name),
+				// Symbol value
+				value,
+				// Target
+				target,
+				// Symbol name and addend
+				relocSymbol(r),
+			))
+	}
+
+	io.WriteString(w, sb.String())
+}
+
+// relocSymbol renders the symbol name and explicit addend of a relocation.
+func relocSymbol(r *model.Relocation) string {
+	name := r.Symbol
+	if r.SymbolValue == nil && r.SymbolIndex != 0 {
+		name = fmt.Sprintf("<symbol %d>", r.SymbolIndex)
+	}
+
+	if !r.ExplicitAddend {
+		return ui.Bold.Sprint(name)
+	}
+
+	if name == "" {
+		return ui.Blue.Sprintf("%#x", r.Addend)
+	}
+
+	sign, addend := "+", uint64(r.Addend)
+	if r.Addend < 0 {
+		sign, addend = "-", uint64(-r.Addend)
+	}
+	return ui.Bold.Sprint(name) + ui.Blue.Sprintf(" %s %#x", sign, addend)
+}
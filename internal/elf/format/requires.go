@@ -0,0 +1,90 @@
+package format
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/yourpwnguy/strix/internal/elf/model"
+	"github.com/yourpwnguy/strix/internal/ui"
+)
+
+// requiresSymbols is the number of symbols listed per requirement before the rest is counted.
+const requiresSymbols = 4
+
+// PrintRequires displays the newest version of each family required from each library, with
+// the symbols that need it. Versions above their limit are shown in red along with the limit.
+func PrintRequires(w io.Writer, path string, v *model.Requires) {
+	var sb strings.Builder
+
+	// Basic estimation
+	sb.Grow(256 + len(v.Requirements)*160)
+
+	sb.WriteString(ui.Cyan.Sprint("\nRequires: "))
+	sb.WriteString(ui.Bold.Sprintf("%s\n\n", path))
+
+	if len(v.Requirements) == 0 {
+		sb.WriteString(ui.Green.Sprint("No versioned symbols required\n"))
+		io.WriteString(w, sb.String())
+		return
+	}
+
+	// Column widths
+	libWidth, familyWidth, versionWidth := len("Library"), len("Family"), len("Version")
+	for i := range v.Requirements {
+		r := &v.Requirements[i]
+		libWidth = max(libWidth, len(r.Library))
+		familyWidth = max(familyWidth, len(r.Family))
+		versionWidth = max(versionWidth, len(versionCell(r)))
+	}
+
+	sb.WriteString(
+		ui.Magenta.Sprintf("%-*s  %-*s  %-*s  %s\n",
+			libWidth, "Library",
+			familyWidth, "Family",
+			versionWidth, "Version",
+			"Symbols",
+		))
+
+	// Table rows
+	for i := range v.Requirements {
+		r := &v.Requirements[i]
+
+		versionColor := ui.Green
+		if r.Exceeds {
+			versionColor = ui.Red
+		}
+
+		symbols := strings.Join(r.Symbols[:min(len(r.Symbols), requiresSymbols)], ", ")
+		if len(r.Symbols) > requiresSymbols {
+			symbols += fmt.Sprintf(" (+%d more)", len(r.Symbols)-requiresSymbols)
+		}
+
+		sb.WriteString(
+			fmt.Sprintf("%s  %s  %s  %s\n",
+				// Library
+				ui.Bold.Sprintf("%-*s", libWidth, r.Library),
+				// Family
+				ui.Cyan.Sprintf("%-*s", familyWidth, r.Family),
+				// Version, and the limit it exceeds
+				versionColor.Sprintf("%-*s", versionWidth, versionCell(r)),
+				// Symbols
+				symbols,
+			))
+	}
+
+	io.WriteString(w, sb.String())
+}
+
+// versionCell is the version column of a requirement: the version, "-" for non numeric
+// nodes, followed by the limit when it is exceeded.
+func versionCell(r *model.Requirement) string {
+	version := r.Version
+	if version == "" {
+		version = "-"
+	}
+	if r.Exceeds {
+		version += " > " + r.Limit
+	}
+	return version
+}
@@ -0,0 +1,102 @@
+package format
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/yourpwnguy/strix/internal/elf/model"
+	"github.com/yourpwnguy/strix/internal/ui"
+	"github.com/yourpwnguy/strix/pkg/elf/types"
+)
+
+// PrintSectionHeaders displays the section header table in a formatted layout.
+func PrintSectionHeaders(w io.Writer, v *model.SectionHeaders) {
+	var sb strings.Builder
+
+	// Basic estimation
+	sb.Grow(2048 + len(v.Sections)*256)
+
+	// ELF Section headers count and offset
+	sb.WriteString(ui.Cyan.Sprintf("\n%s", "Section Headers: "))
+	sb.WriteString(ui.Green.Sprintf("%d entries ", len(v.Sections)))
+	sb.WriteString("(")
+	sb.WriteString(ui.Yellow.Sprintf("offset %#x", v.Offset))
+	sb.WriteString(")\n")
+
+	// ELF Section header string table index
+	sb.WriteString(ui.Cyan.Sprintf("%s", "String Table Index: "))
+	if v.StringTableIndex == types.SHN_XINDEX {
+		// Like readelf, the escape and then the index it stands for
+		sb.WriteString(ui.Green.Sprintf("%d (%d)\n\n", v.StringTableIndex, v.StringTableSection))
+	} else {
+		sb.WriteString(ui.Green.Sprintf("%d\n\n", v.StringTableIndex))
+	}
+
+	if len(v.Sections) == 0 {
+		sb.WriteString(ui.Yellow.Sprint("There are no sections in this file.\n"))
+		io.WriteString(w, sb.String())
+		return
+	}
+
+	sb.WriteString(
+		ui.Magenta.Sprintf("%-7s%-21s%-19s%-20s%s\n%-7s%-21s%-19s%-7s%-6s%-6s%s\n",
+			"",
+			"Name",
+			"Type",
+			"Address",
+			"Offset",
+			"",
+			"Size",
+			"EntSize",
+			"Flags",
+			"Link",
+			"Info",
+			"Align",
+		))
+
+	// Table rows
+	for i := range v.Sections {
+		sh := &v.Sections[i]
+
+		name := sh.Name
+		if name == "" && i == 0 {
+			name = "<null>"
+		}
+
+		sb.WriteString(
+			fmt.Sprintf("%s   %s %s %s  %s\n%7s%s   %s %s %s %s %s\n",
+				// Count
+				fmt.Sprintf("[%s]", ui.Red.Sprintf("%02d", sh.Index)),
+				// Name
+				ui.Cyan.Sprintf("%-20s", name),
+				// Type
+				ui.Blue.Sprintf("%-18s", sh.Type.Name),
+				// Address
+				ui.Green.Sprintf("%#016x", sh.Addr),
+				// Offset
+				ui.Green.Sprintf("%#016x", sh.Offset),
+				"",
+				// Size
+				ui.Green.Sprintf("%#016x", sh.Size),
+				// Entry Size
+				ui.Green.Sprintf("%#016x", sh.Entsize),
+				// Flags
+				ui.Yellow.Sprintf("%-6s", types.GetShFlags(sh.Flags.Value)),
+				// Link
+				ui.Blue.Sprintf("%-5d", sh.Link),
+				// Info
+				ui.Blue.Sprintf("%-5d", sh.Info),
+				// Align
+				ui.Yellow.Sprintf("%d", sh.Addralign),
+			))
+	}
+
+	// Flag legend, same letters as readelf
+	sb.WriteString(ui.Cyan.Sprint("\nKey to Flags:\n"))
+	sb.WriteString("  W (write), A (alloc), X (execute), M (merge), S (strings), I (info),\n")
+	sb.WriteString("  L (link order), O (extra OS processing required), G (group), T (TLS),\n")
+	sb.WriteString("  C (compressed), R (retain), E (exclude), o (OS specific), p (processor specific)\n")
+
+	io.WriteString(w, sb.String())
+}
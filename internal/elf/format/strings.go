@@ -0,0 +1,68 @@
+package format
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/yourpwnguy/strix/internal/elf/model"
+	"github.com/yourpwnguy/strix/internal/ui"
+)
+
+// PrintStrings displays the extracted strings with their file offset, virtual address and
+// the section they were found in. Unmapped strings leave the address column blank.
+func PrintStrings(w io.Writer, v *model.Strings) {
+	var sb strings.Builder
+
+	// Basic estimation
+	sb.Grow(256 + len(v.Strings)*96)
+
+	// Section column width
+	width := len("Section")
+	for i := range v.Strings {
+		width = max(width, len(v.Strings[i].Section))
+	}
+
+	// Scanned regions, named when there are only a few, and string count
+	regions := fmt.Sprintf("%d regions", len(v.Regions))
+	if len(v.Regions) <= 4 {
+		regions = strings.Join(v.Regions, ", ")
+	}
+	sb.WriteString(ui.Cyan.Sprintf("\nStrings of at least %d characters in %s: ", v.MinLength, regions))
+	sb.WriteString(ui.Green.Sprintf("%d found\n\n", len(v.Strings)))
+
+	sb.WriteString(
+		ui.Magenta.Sprintf("%-12s%-20s%-*s  %-9s%s\n",
+			"Offset",
+			"Address",
+			width, "Section",
+			"Encoding",
+			"String",
+		))
+
+	// Table rows
+	for i := range v.Strings {
+		s := &v.Strings[i]
+
+		address := fmt.Sprintf("%18s", "")
+		if s.Address != nil {
+			address = ui.Green.Sprintf("%#016x", *s.Address)
+		}
+
+		sb.WriteString(
+			fmt.Sprintf("%s  %s  %s  %s %s\n",
+				// File offset
+				ui.Yellow.Sprintf("%#08x", s.Offset),
+				// Virtual address
+				address,
+				// Section
+				ui.Blue.Sprintf("%-*s", width, s.Section),
+				// Encoding
+				ui.Cyan.Sprintf("%-8s", s.Encoding),
+				// String
+				ui.Bold.Sprint(s.Value),
+			))
+	}
+
+	io.WriteString(w, sb.String())
+}
@@ -0,0 +1,37 @@
+package format
+
+import (
+	"io"
+	"strings"
+
+	"github.com/yourpwnguy/strix/internal/elf/model"
+	"github.com/yourpwnguy/strix/internal/ui"
+)
+
+// PrintStringTable displays the entries of a string table with their byte index, like readelf -p.
+func PrintStringTable(w io.Writer, table *model.StringTable) {
+	var sb strings.Builder
+
+	// Basic estimation
+	sb.Grow(256 + len(table.Entries)*48)
+
+	// Table name, location and entry count
+	sb.WriteString(ui.Cyan.Sprintf("\nString table '%s' [%d] ", table.Name, table.Section))
+	sb.WriteString("(")
+	sb.WriteString(ui.Yellow.Sprintf("offset %#x", table.Offset))
+	sb.WriteString(", ")
+	sb.WriteString(ui.Yellow.Sprintf("%#x bytes", table.Size))
+	sb.WriteString("): ")
+	sb.WriteString(ui.Green.Sprintf("%d entries\n\n", len(table.Entries)))
+
+	// Table rows
+	for _, e := range table.Entries {
+		sb.WriteString("  [")
+		sb.WriteString(ui.Red.Sprintf("%6d", e.Index))
+		sb.WriteString("]  ")
+		sb.WriteString(e.Value)
+		sb.WriteByte('\n')
+	}
+
+	io.WriteString(w, sb.String())
+}
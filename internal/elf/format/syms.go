@@ -0,0 +1,88 @@
+package format
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/yourpwnguy/strix/internal/elf/model"
+	"github.com/yourpwnguy/strix/internal/ui"
+	"github.com/yourpwnguy/strix/pkg/elf/types"
+)
+
+// PrintSymbols displays a symbol table in a formatted layout.
+// The total differs from the number of rows when a filter was applied.
+// Unnamed STT_SECTION symbols are labelled with the name of their section, and versioned
+// dynamic symbols carry their @VER or @@VER suffix.
+func PrintSymbols(w io.Writer, table *model.SymbolTable) {
+	syms, total := table.Symbols, table.Total
+
+	var sb strings.Builder
+
+	// Basic estimation
+	sb.Grow(1024 + len(syms)*160)
+
+	// Symbol table name and entry count
+	sb.WriteString(ui.Cyan.Sprintf("\nSymbol table '%s': ", table.Name))
+	if len(syms) == total {
+		sb.WriteString(ui.Green.Sprintf("%d entries\n\n", total))
+	} else {
+		sb.WriteString(ui.Green.Sprintf("%d of %d entries\n\n", len(syms), total))
+	}
+
+	sb.WriteString(
+		ui.Magenta.Sprintf("%-8s%-20s%-10s%-9s%-9s%-11s%-6s%s\n",
+			"Num",
+			"Value",
+			"Size",
+			"Type",
+			"Bind",
+			"Vis",
+			"Ndx",
+			"Name",
+		))
+
+	// Table rows
+	for i := range syms {
+		sym := &syms[i]
+
+		name := sym.Name
+		if name == "" && sym.Type.Value == uint64(types.STT_SECTION) {
+			name = sym.Section
+		}
+
+		version := ""
+		if sym.Version != "" {
+			version = ui.Yellow.Sprint(strings.TrimPrefix(sym.VersionedName(), sym.Name))
+		}
+
+		ndxColor := ui.Blue
+		if sym.Shndx == uint32(types.SHN_UNDEF) {
+			ndxColor = ui.Red
+		}
+
+		sb.WriteString(
+			fmt.Sprintf("%s  %s  %s %s %s %s %s %s%s\n",
+				// Index
+				fmt.Sprintf("[%s]", ui.Red.Sprintf("%04d", sym.Index)),
+				// Value
+				ui.Green.Sprintf("%#016x", sym.Value),
+				// Size
+				ui.Blue.Sprintf("%-9d", sym.Size),
+				// Type
+				ui.Cyan.Sprintf("%-8s", sym.Type.Name),
+				// Bind
+				ui.Yellow.Sprintf("%-8s", sym.Bind.Name),
+				// Visibility
+				ui.Magenta.Sprintf("%-10s", sym.Visibility.Name),
+				// Section index
+				ndxColor.Sprintf("%-5s", sym.Ndx),
+				// Name
+				ui.Bold.Sprint(name),
+				// Version
+				version,
+			))
+	}
+
+	io.WriteString(w, sb.String())
+}
@@ -0,0 +1,80 @@
+package format
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/yourpwnguy/strix/internal/elf/model"
+	"github.com/yourpwnguy/strix/internal/ui"
+)
+
+// PrintVersions displays the version nodes the file defines and those it requires from each library.
+func PrintVersions(w io.Writer, v *model.Versions) {
+	var sb strings.Builder
+	sb.Grow(1024)
+
+	// Version name column width
+	width := len("Name")
+	for i := range v.Definitions {
+		width = max(width, len(v.Definitions[i].Name))
+	}
+	for i := range v.Needs {
+		for j := range v.Needs[i].Versions {
+			width = max(width, len(v.Needs[i].Versions[j].Name))
+		}
+	}
+
+	// Version definitions
+	sb.WriteString(ui.Cyan.Sprint("\nVersion definitions (.gnu.version_d): "))
+	sb.WriteString(ui.Green.Sprintf("%d entries\n", len(v.Definitions)))
+
+	if len(v.Definitions) > 0 {
+		sb.WriteByte('\n')
+		sb.WriteString(ui.Magenta.Sprintf("  %-8s%-*s  %-12s%-8s%s\n", "Index", width, "Name", "Hash", "Flags", "Parents"))
+	}
+	for i := range v.Definitions {
+		def := &v.Definitions[i]
+		fmt.Fprintf(&sb, "  [%s]  %s  %s  %s %s\n",
+			// Version index
+			ui.Red.Sprintf("%04d", def.Index),
+			// Name
+			ui.Bold.Sprintf("%-*s", width, def.Name),
+			// Hash
+			ui.Blue.Sprintf("%#08x", def.Hash),
+			// Flags
+			ui.Yellow.Sprintf("%-7s", strings.Join(def.Flags.Names, " ")),
+			// Parents
+			ui.Green.Sprint(strings.Join(def.Parents, ", ")),
+		)
+	}
+
+	// Version requirements, grouped by library
+	sb.WriteString(ui.Cyan.Sprint("\nVersion requirements (.gnu.version_r): "))
+	sb.WriteString(ui.Green.Sprintf("%d libraries\n", len(v.Needs)))
+
+	for i := range v.Needs {
+		need := &v.Needs[i]
+
+		sb.WriteByte('\n')
+		sb.WriteString("  ")
+		sb.WriteString(ui.Green.Sprint(need.File))
+		sb.WriteString(ui.Cyan.Sprintf(": %d required\n", len(need.Versions)))
+
+		for j := range need.Versions {
+			req := &need.Versions[j]
+			fmt.Fprintf(&sb, "    [%s]  %s  %s  %s\n",
+				// Version index
+				ui.Red.Sprintf("%04d", req.Index),
+				// Name
+				ui.Bold.Sprintf("%-*s", width, req.Name),
+				// Hash
+				ui.Blue.Sprintf("%#08x", req.Hash),
+				// Flags
+				ui.Yellow.Sprint(strings.Join(req.Flags.Names, " ")),
+			)
+		}
+	}
+
+	io.WriteString(w, sb.String())
+}
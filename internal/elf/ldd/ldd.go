@@ -0,0 +1,306 @@
+// Package ldd resolves the shared libraries an ELF file depends on by following the search
+// order of the glibc dynamic loader, without running the file.
+package ldd
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"strings"
+
+	"github.com/yourpwnguy/strix/internal/reader"
+	"github.com/yourpwnguy/strix/pkg/elf"
+	"github.com/yourpwnguy/strix/pkg/elf/types"
+)
+
+// Source is the step of the search order a library was found through.
+type Source string
+
+const (
+	SourceInterpreter Source = "interpreter"     // The program interpreter itself (PT_INTERP)
+	SourceDirect      Source = "direct"          // DT_NEEDED holds a path
+	SourceRPATH       Source = "rpath"           // DT_RPATH of the requester or one of its loaders
+	SourceEnv         Source = "LD_LIBRARY_PATH" // Directories from the environment
+	SourceRUNPATH     Source = "runpath"         // DT_RUNPATH of the requester
+	SourceCache       Source = "ld.so.cache"     // Loader cache
+	SourceDefault     Source = "default"         // Trusted system directories
+)
+
+// Cache is the loader cache step of the search. Lookup returns the paths the cache maps
+// a library name to for a file with the given ELF header, best match first.
+type Cache interface {
+	Lookup(name string, ehdr *types.Elf64_Ehdr) []string
+}
+
+// Rejection is a file the search found but skipped, as the loader would.
+type Rejection struct {
+	Path   string
+	Reason string // e.g. "ELF32 instead of ELF64"
+}
+
+// Library is one DT_NEEDED entry and the file it resolved to.
+type Library struct {
+	Name     string      // DT_NEEDED entry (e.g. "libc.so.6")
+	Path     string      // Path the loader would open, empty when not found
+	Source   Source      // Search step that found it
+	Loaded   bool        // Already loaded earlier, its dependencies are listed there
+	Rejected []Rejection // Candidates skipped for their class, machine or format
+	Err      error       // Set when the library was found but its dynamic section is unreadable
+	Needed   []*Library  // Dependencies, in DT_NEEDED order
+}
+
+// Report is the dependency tree of one file. Libraries are loaded breadth first, like the
+// loader does, so a library shows its dependencies where it is first reached in that order.
+// Its strings are copies, so the report outlives the files it was computed from.
+type Report struct {
+	Interpreter string     // PT_INTERP, empty for static files
+	Needed      []*Library // Direct dependencies, in DT_NEEDED order
+	Missing     int        // Libraries that were not found
+}
+
+// Resolver emulates the library search of the glibc loader for one filesystem.
+type Resolver struct {
+	Sysroot     string // Root of the filesystem to search, empty for the running system
+	LibraryPath string // LD_LIBRARY_PATH to emulate, colon separated
+	Cache       Cache  // Loader cache, nil to skip that step
+}
+
+// object is a loaded file, as much of it as the search needs.
+type object struct {
+	path     string // Path in the sysroot it was loaded from
+	info     os.FileInfo
+	origin   string   // Directory substituted for $ORIGIN, empty when unknown
+	rpath    []string // DT_RPATH entries, nil when DT_RUNPATH is present
+	runpath  []string // DT_RUNPATH entries
+	nodeflib bool     // DF_1_NODEFLIB, skip the cache and the default directories
+	soname   string
+	needed   []string
+	loader   *object // Object whose DT_NEEDED entry loaded this one
+	err      error   // Dynamic section could not be read
+}
+
+// resolution holds the state of one Resolve call.
+type resolution struct {
+	*Resolver
+
+	ehdr types.Elf64_Ehdr // Copy of the header of the file, libraries have to match it
+
+	lib      string   // Value of $LIB
+	platform string   // Value of $PLATFORM
+	defaults []string // Default directories that exist in the sysroot
+
+	env    []string           // LD_LIBRARY_PATH entries
+	interp *object            // The program interpreter, matched by its soname
+	names  map[string]*object // Loaded objects by requested name and soname
+	loaded []*object          // Loaded objects, matched by file identity
+}
+
+// Resolve builds the dependency tree of the file at path, a path on the host. With a
+// Sysroot, $ORIGIN is only known for files inside it.
+func (r *Resolver) Resolve(file string) (*Report, error) {
+	p, err := open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer p.Close()
+
+	ehdr, err := p.ELFHeader()
+	if err != nil {
+		return nil, err
+	}
+
+	phdr, err := p.ProgramHeaders()
+	if err != nil {
+		return nil, err
+	}
+
+	res := &resolution{
+		Resolver: r,
+		ehdr:     *ehdr,
+		names:    make(map[string]*object),
+	}
+	res.initPaths()
+
+	report := &Report{}
+	if types.HasInterpreter(phdr) {
+		report.Interpreter = strings.Clone(types.GetInterpreter(phdr, p.Data()))
+		if interp, _ := res.load(report.Interpreter); interp != nil && interp.err == nil {
+			res.interp = interp
+			res.loaded = append(res.loaded, interp)
+		}
+	}
+
+	main, err := res.newObject(p, res.virtual(file))
+	if err != nil {
+		return nil, err
+	}
+	res.env = res.expandList(r.LibraryPath, main)
+
+	// Breadth first, the order the loader maps libraries in
+	type pending struct {
+		obj    *object
+		needed *[]*Library
+	}
+	queue := []pending{{main, &report.Needed}}
+	for len(queue) > 0 {
+		item := queue[0]
+		queue = queue[1:]
+
+		for _, name := range item.obj.needed {
+			lib := &Library{Name: name}
+			*item.needed = append(*item.needed, lib)
+
+			obj := res.resolve(item.obj, lib)
+			if obj != nil {
+				queue = append(queue, pending{obj, &lib.Needed})
+			} else if lib.Path == "" {
+				report.Missing++
+			}
+		}
+	}
+
+	return report, nil
+}
+
+// resolve finds the file behind one DT_NEEDED entry of requester. It returns the object
+// when it is newly loaded and its dependencies still have to be resolved.
+func (res *resolution) resolve(requester *object, lib *Library) *object {
+	// Matched by name before searching, whoever asked for it first
+	if obj, ok := res.names[lib.Name]; ok {
+		lib.Path, lib.Loaded = obj.path, true
+		return nil
+	}
+	if res.interp != nil && lib.Name == res.interp.soname {
+		lib.Path, lib.Source = res.interp.path, SourceInterpreter
+		return nil
+	}
+
+	obj := res.search(requester, lib)
+	if obj == nil {
+		return nil
+	}
+	obj.loader = requester
+
+	// The same file reached through another name or directory
+	for _, other := range res.loaded {
+		if os.SameFile(other.info, obj.info) {
+			lib.Loaded = true
+			res.names[lib.Name] = other
+			return nil
+		}
+	}
+
+	res.loaded = append(res.loaded, obj)
+	res.names[lib.Name] = obj
+	if obj.soname != "" {
+		res.names[obj.soname] = obj
+	}
+
+	if obj.err != nil {
+		lib.Err = obj.err
+		return nil
+	}
+	return obj
+}
+
+// open memory maps an ELF file and validates its header.
+func open(file string) (*elf.Parser, error) {
+	p := elf.NewParser(&reader.MmapReader{})
+	if err := p.Load(file); err != nil {
+		p.Close()
+		return nil, err
+	}
+
+	if _, err := p.ELFHeader(); err != nil {
+		p.Close()
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// load opens a candidate file, given as a path in the sysroot. It returns nil and no reason
+// when the file does not exist, and nil and the reason when the loader would skip it.
+func (res *resolution) load(file string) (*object, string) {
+	host, err := res.hostPath(file)
+	if err != nil {
+		return nil, ""
+	}
+
+	info, err := os.Stat(host)
+	if err != nil {
+		return nil, ""
+	}
+	if !info.Mode().IsRegular() {
+		return nil, ""
+	}
+
+	p, err := open(host)
+	if err != nil {
+		return nil, err.Error()
+	}
+	defer p.Close()
+
+	ehdr, err := p.ELFHeader()
+	if err != nil {
+		return nil, err.Error()
+	}
+
+	switch class := ehdr.E_ident.Ei_class; {
+	case class != res.ehdr.E_ident.Ei_class:
+		return nil, fmt.Sprintf("%s instead of %s", types.GetEiClass(class), types.GetEiClass(res.ehdr.E_ident.Ei_class))
+	case ehdr.E_ident.Ei_data != res.ehdr.E_ident.Ei_data:
+		return nil, fmt.Sprintf("%s byte order", types.GetEiData(ehdr.E_ident.Ei_data))
+	case ehdr.E_machine != res.ehdr.E_machine:
+		return nil, fmt.Sprintf("built for %s", types.GetEMachine(ehdr.E_machine))
+	case ehdr.E_type != types.ET_DYN:
+		return nil, "not a shared object"
+	}
+
+	obj, err := res.newObject(p, file)
+	if err != nil {
+		// Loaded all the same, the error is reported on the library
+		obj = &object{origin: path.Dir(file), err: err}
+	}
+	obj.path = file
+	obj.info = info
+
+	return obj, ""
+}
+
+// newObject reads what the search needs from the dynamic section of a file loaded from
+// file, a path in the sysroot. Strings are copied since the file is unmapped afterwards.
+func (res *resolution) newObject(p *elf.Parser, file string) (*object, error) {
+	obj := &object{}
+	if file != "" {
+		obj.origin = path.Dir(file)
+	}
+
+	dyn, err := p.DynamicEntries()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range types.DynamicStrings(dyn, types.DT_NEEDED) {
+		obj.needed = append(obj.needed, strings.Clone(name))
+	}
+	if soname := types.DynamicStrings(dyn, types.DT_SONAME); len(soname) > 0 {
+		obj.soname = strings.Clone(soname[0])
+	}
+	if flags, ok := types.DynamicValue(dyn, types.DT_FLAGS_1); ok {
+		obj.nodeflib = flags&types.DF_1_NODEFLIB != 0
+	}
+
+	// DT_RPATH is ignored when DT_RUNPATH is present
+	runpath := types.DynamicStrings(dyn, types.DT_RUNPATH)
+	for _, list := range runpath {
+		obj.runpath = append(obj.runpath, splitPath(strings.Clone(list))...)
+	}
+	if len(runpath) == 0 {
+		for _, list := range types.DynamicStrings(dyn, types.DT_RPATH) {
+			obj.rpath = append(obj.rpath, splitPath(strings.Clone(list))...)
+		}
+	}
+
+	return obj, nil
+}
@@ -0,0 +1,300 @@
+package ldd
+
+import (
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"syscall"
+
+	"github.com/yourpwnguy/strix/pkg/elf/types"
+)
+
+// maxSymlinks bounds the symlinks followed inside a sysroot, like the kernel's ELOOP limit.
+const maxSymlinks = 40
+
+// multiarch maps machines to their Debian multiarch directory (/usr/lib/<triplet>).
+var multiarch = map[uint16]string{
+	types.EM_X86_64:  "x86_64-linux-gnu",
+	types.EM_386:     "i386-linux-gnu",
+	types.EM_AARCH64: "aarch64-linux-gnu",
+	types.EM_ARM:     "arm-linux-gnueabihf",
+	types.EM_RISCV:   "riscv64-linux-gnu",
+	types.EM_PPC64:   "powerpc64le-linux-gnu",
+	types.EM_S390:    "s390x-linux-gnu",
+}
+
+// platforms maps machines to the AT_PLATFORM string $PLATFORM expands to.
+var platforms = map[uint16]string{
+	types.EM_X86_64:  "x86_64",
+	types.EM_386:     "i686",
+	types.EM_AARCH64: "aarch64",
+}
+
+// search walks the loader search order for one DT_NEEDED entry of requester, recording
+// where the library was found and every candidate skipped on the way.
+func (res *resolution) search(requester *object, lib *Library) *object {
+	try := func(file string, source Source) *object {
+		obj, reason := res.load(file)
+		if reason != "" {
+			lib.Rejected = append(lib.Rejected, Rejection{Path: file, Reason: reason})
+			return nil
+		}
+		if obj != nil {
+			lib.Path, lib.Source = file, source
+		}
+		return obj
+	}
+
+	tryDirs := func(dirs []string, source Source) *object {
+		for _, dir := range dirs {
+			if obj := try(path.Join(dir, lib.Name), source); obj != nil {
+				return obj
+			}
+		}
+		return nil
+	}
+
+	// A name with a slash is opened as is, no search
+	if strings.Contains(lib.Name, "/") {
+		file, ok := res.expand(lib.Name, requester)
+		if !ok {
+			return nil
+		}
+		return try(file, SourceDirect)
+	}
+
+	// DT_RPATH of the requester and of each object up the chain that loaded it,
+	// unless the requester has a DT_RUNPATH
+	if requester.runpath == nil {
+		for obj := requester; obj != nil; obj = obj.loader {
+			if found := tryDirs(res.expandDirs(obj.rpath, obj), SourceRPATH); found != nil {
+				return found
+			}
+		}
+	}
+
+	if found := tryDirs(res.env, SourceEnv); found != nil {
+		return found
+	}
+
+	if found := tryDirs(res.expandDirs(requester.runpath, requester), SourceRUNPATH); found != nil {
+		return found
+	}
+
+	if requester.nodeflib {
+		return nil
+	}
+
+	if res.Cache != nil {
+		for _, file := range res.Cache.Lookup(lib.Name, &res.ehdr) {
+			if found := try(file, SourceCache); found != nil {
+				return found
+			}
+		}
+	}
+
+	return tryDirs(res.defaults, SourceDefault)
+}
+
+// initPaths works out the default directories and $LIB from the layout of the sysroot:
+// Debian multiarch directories, then lib64 directories for 64-bit files, then plain lib.
+func (res *resolution) initPaths() {
+	res.platform = platforms[res.ehdr.E_machine]
+
+	triplet, ok := multiarch[res.ehdr.E_machine]
+	var dirs []string
+	switch {
+	case ok && (res.isDir("/lib/"+triplet) || res.isDir("/usr/lib/"+triplet)):
+		res.lib = "lib/" + triplet
+		dirs = []string{"/lib/" + triplet, "/usr/lib/" + triplet, "/lib", "/usr/lib"}
+	case res.ehdr.E_ident.Ei_class == types.ELFCLASS64 && (res.isDir("/lib64") || res.isDir("/usr/lib64")):
+		res.lib = "lib64"
+		dirs = []string{"/lib64", "/usr/lib64"}
+	default:
+		res.lib = "lib"
+		dirs = []string{"/lib", "/usr/lib"}
+	}
+
+	for _, dir := range dirs {
+		if res.isDir(dir) {
+			res.defaults = append(res.defaults, dir)
+		}
+	}
+}
+
+// isDir reports whether dir, a path in the sysroot, is a directory.
+func (res *resolution) isDir(dir string) bool {
+	host, err := res.hostPath(dir)
+	if err != nil {
+		return false
+	}
+	info, err := os.Stat(host)
+	return err == nil && info.IsDir()
+}
+
+// expandList splits a colon or semicolon separated list, as in LD_LIBRARY_PATH, and expands it.
+func (res *resolution) expandList(list string, obj *object) []string {
+	if list == "" {
+		return nil
+	}
+	return res.expandDirs(strings.FieldsFunc(list, func(r rune) bool { return r == ':' || r == ';' }), obj)
+}
+
+// expandDirs expands the dynamic string tokens of search directories on behalf of obj,
+// dropping the directories that use a token with no value.
+func (res *resolution) expandDirs(dirs []string, obj *object) []string {
+	out := make([]string, 0, len(dirs))
+	for _, dir := range dirs {
+		if expanded, ok := res.expand(dir, obj); ok {
+			out = append(out, expanded)
+		}
+	}
+	return out
+}
+
+// expand substitutes $ORIGIN, $LIB and $PLATFORM (bare or in braces) in s. It fails when a
+// token has no value, such as $ORIGIN for a file outside the sysroot. Unknown tokens are kept.
+func (res *resolution) expand(s string, obj *object) (string, bool) {
+	if !strings.Contains(s, "$") {
+		return s, true
+	}
+
+	var sb strings.Builder
+	for {
+		i := strings.IndexByte(s, '$')
+		if i < 0 {
+			sb.WriteString(s)
+			return sb.String(), true
+		}
+		sb.WriteString(s[:i])
+
+		name, rest := tokenName(s[i+1:])
+		var value string
+		switch name {
+		case "ORIGIN":
+			value = obj.origin
+		case "LIB":
+			value = res.lib
+		case "PLATFORM":
+			value = res.platform
+		default:
+			sb.WriteByte('$')
+			s = s[i+1:]
+			continue
+		}
+
+		if value == "" {
+			return "", false
+		}
+		sb.WriteString(value)
+		s = rest
+	}
+}
+
+// tokenName splits the name of a dynamic string token, bare or in braces, from what follows.
+func tokenName(s string) (name, rest string) {
+	if strings.HasPrefix(s, "{") {
+		if end := strings.IndexByte(s, '}'); end > 0 {
+			return s[1:end], s[end+1:]
+		}
+		return "", s
+	}
+
+	end := 0
+	for end < len(s) && (s[end] == '_' || s[end] >= 'A' && s[end] <= 'Z' || s[end] >= 'a' && s[end] <= 'z' || s[end] >= '0' && s[end] <= '9') {
+		end++
+	}
+	return s[:end], s[end:]
+}
+
+// splitPath splits a DT_RPATH or DT_RUNPATH value. An empty entry is the current directory.
+func splitPath(list string) []string {
+	dirs := strings.Split(list, ":")
+	for i := range dirs {
+		if dirs[i] == "" {
+			dirs[i] = "."
+		}
+	}
+	return dirs
+}
+
+// virtual turns a host path into a path in the sysroot, with symlinks resolved as the loader
+// does for $ORIGIN of the main program. It is empty for files outside the sysroot.
+func (res *resolution) virtual(file string) string {
+	resolved, err := filepath.EvalSymlinks(file)
+	if err != nil {
+		return ""
+	}
+	resolved, err = filepath.Abs(resolved)
+	if err != nil {
+		return ""
+	}
+	if res.Sysroot == "" {
+		return filepath.ToSlash(resolved)
+	}
+
+	root, err := filepath.EvalSymlinks(res.Sysroot)
+	if err != nil {
+		return ""
+	}
+	root, err = filepath.Abs(root)
+	if err != nil {
+		return ""
+	}
+
+	rel, err := filepath.Rel(root, resolved)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return ""
+	}
+	return "/" + filepath.ToSlash(rel)
+}
+
+// hostPath maps a path in the sysroot to the host. Symlinks are followed inside the sysroot,
+// so absolute targets such as /lib64/ld-linux-x86-64.so.2 stay in it.
+func (res *resolution) hostPath(file string) (string, error) {
+	if res.Sysroot == "" {
+		return file, nil
+	}
+
+	parts := strings.Split(file, "/")
+	resolved := "/"
+	for links := 0; len(parts) > 0; {
+		part := parts[0]
+		parts = parts[1:]
+
+		switch part {
+		case "", ".":
+			continue
+		case "..":
+			resolved = path.Dir(resolved)
+			continue
+		}
+
+		next := path.Join(resolved, part)
+		host := filepath.Join(res.Sysroot, filepath.FromSlash(next))
+
+		info, err := os.Lstat(host)
+		if err != nil {
+			return "", err
+		}
+		if info.Mode()&os.ModeSymlink == 0 {
+			resolved = next
+			continue
+		}
+
+		if links++; links > maxSymlinks {
+			return "", &os.PathError{Op: "readlink", Path: host, Err: syscall.ELOOP}
+		}
+		target, err := os.Readlink(host)
+		if err != nil {
+			return "", err
+		}
+		if strings.HasPrefix(target, "/") {
+			resolved = "/"
+		}
+		parts = append(strings.Split(target, "/"), parts...)
+	}
+
+	return filepath.Join(res.Sysroot, filepath.FromSlash(resolved)), nil
+}
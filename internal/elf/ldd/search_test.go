@@ -0,0 +1,280 @@
+package ldd
+
+import (
+	"bytes"
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/yourpwnguy/strix/pkg/elf/types"
+)
+
+func TestExpand(t *testing.T) {
+	res := &resolution{lib: "lib64", platform: "x86_64"}
+
+	tests := []struct {
+		in     string
+		origin string
+		want   string
+		ok     bool
+	}{
+		{"/usr/lib", "/opt/app/bin", "/usr/lib", true},
+		{"$ORIGIN/../lib", "/opt/app/bin", "/opt/app/bin/../lib", true},
+		{"${ORIGIN}/$LIB/$PLATFORM", "/opt/app/bin", "/opt/app/bin/lib64/x86_64", true},
+		{"/opt/${LIB}x", "/opt/app/bin", "/opt/lib64x", true},
+		{"/opt/$UNKNOWN/lib", "/opt/app/bin", "/opt/$UNKNOWN/lib", true},
+		{"$ORIGIN/lib", "", "", false},
+	}
+
+	for _, tt := range tests {
+		got, ok := res.expand(tt.in, &object{origin: tt.origin})
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("expand(%q) = %q, %v, want %q, %v", tt.in, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+// stub describes a small generated ELF file: its class and machine, and the dynamic
+// entries the search looks at.
+type stub struct {
+	class   uint8
+	machine uint16
+	needed  []string
+	rpath   string
+	runpath string
+	flags1  uint64
+}
+
+// lib is an x86-64 shared library stub.
+func lib(needed ...string) stub {
+	return stub{class: types.ELFCLASS64, machine: types.EM_X86_64, needed: needed}
+}
+
+// bytes lays the stub out as one PT_LOAD segment mapped at address 0, so that addresses
+// and file offsets agree: the header, two program headers, the dynamic table and the
+// string table it points to. ELF32 stubs only get a header, the search rejects them
+// before it reads anything else.
+func (s stub) bytes() []byte {
+	ident := func(h *types.Elf64_Ehdr) {
+		h.E_ident.FileIdn = [4]uint8{0x7f, 'E', 'L', 'F'}
+		h.E_ident.Ei_class = s.class
+		h.E_ident.Ei_data = types.ELFDATA2LSB
+		h.E_ident.Ei_version = 1
+	}
+
+	var buf bytes.Buffer
+	if s.class == types.ELFCLASS32 {
+		var h types.Elf64_Ehdr
+		ident(&h)
+		hdr := types.Elf32_Ehdr{E_ident: h.E_ident, E_type: types.ET_DYN, E_machine: s.machine, E_version: 1, E_ehsize: 52}
+		binary.Write(&buf, binary.LittleEndian, hdr)
+		return buf.Bytes()
+	}
+
+	strtab := []byte{0}
+	str := func(v string) uint64 {
+		off := len(strtab)
+		strtab = append(append(strtab, v...), 0)
+		return uint64(off)
+	}
+	var dyn []types.Elf64_Dyn
+	for _, name := range s.needed {
+		dyn = append(dyn, types.Elf64_Dyn{D_tag: types.DT_NEEDED, D_val: str(name)})
+	}
+	if s.rpath != "" {
+		dyn = append(dyn, types.Elf64_Dyn{D_tag: types.DT_RPATH, D_val: str(s.rpath)})
+	}
+	if s.runpath != "" {
+		dyn = append(dyn, types.Elf64_Dyn{D_tag: types.DT_RUNPATH, D_val: str(s.runpath)})
+	}
+	if s.flags1 != 0 {
+		dyn = append(dyn, types.Elf64_Dyn{D_tag: types.DT_FLAGS_1, D_val: s.flags1})
+	}
+
+	const dynOffset = 64 + 2*56
+	strOffset := dynOffset + uint64(len(dyn)+2)*16
+	dyn = append(dyn,
+		types.Elf64_Dyn{D_tag: types.DT_STRTAB, D_val: strOffset},
+		types.Elf64_Dyn{D_tag: types.DT_STRSZ, D_val: uint64(len(strtab))},
+	)
+	size := strOffset + uint64(len(strtab))
+
+	var hdr types.Elf64_Ehdr
+	ident(&hdr)
+	hdr.E_type, hdr.E_machine, hdr.E_version = types.ET_DYN, s.machine, 1
+	hdr.E_phoff, hdr.E_ehsize, hdr.E_phentsize, hdr.E_phnum = 64, 64, 56, 2
+	phdr := []types.Elf64_Phdr{
+		{P_type: types.PT_LOAD, P_flags: types.PF_R, P_filesz: size, P_memsz: size},
+		{P_type: types.PT_DYNAMIC, P_flags: types.PF_R, P_offset: dynOffset, P_vaddr: dynOffset, P_filesz: uint64(len(dyn)) * 16, P_memsz: uint64(len(dyn)) * 16},
+	}
+
+	binary.Write(&buf, binary.LittleEndian, hdr)
+	binary.Write(&buf, binary.LittleEndian, phdr)
+	binary.Write(&buf, binary.LittleEndian, dyn)
+	buf.Write(strtab)
+	return buf.Bytes()
+}
+
+// staticCache is a loader cache that maps every name to the same directories.
+type staticCache []string
+
+func (c staticCache) Lookup(name string, _ *types.Elf64_Ehdr) []string {
+	var out []string
+	for _, dir := range c {
+		out = append(out, dir+"/"+name)
+	}
+	return out
+}
+
+func TestSearch(t *testing.T) {
+	exe := func(s stub) stub {
+		s.class, s.machine = types.ELFCLASS64, types.EM_X86_64
+		return s
+	}
+	elf32 := stub{class: types.ELFCLASS32, machine: types.EM_386}
+	arm64 := stub{class: types.ELFCLASS64, machine: types.EM_AARCH64}
+
+	tests := []struct {
+		name        string
+		files       map[string]stub // sysroot contents, /app/main is resolved
+		libraryPath string
+		cache       staticCache
+		want        []string // DT_NEEDED names leading to the library checked
+		path        string
+		source      Source
+		rejected    []Rejection
+	}{
+		{
+			name: "RPATH ignored with RUNPATH",
+			files: map[string]stub{
+				"/app/main":          exe(stub{needed: []string{"libfoo.so"}, rpath: "/rpath", runpath: "/runpath"}),
+				"/rpath/libfoo.so":   lib(),
+				"/runpath/libfoo.so": lib(),
+			},
+			want: []string{"libfoo.so"}, path: "/runpath/libfoo.so", source: SourceRUNPATH,
+		},
+		{
+			name: "RPATH inherited from the loader",
+			files: map[string]stub{
+				"/app/main":        exe(stub{needed: []string{"liba.so"}, rpath: "/rpath"}),
+				"/usr/lib/liba.so": lib("libb.so"),
+				"/usr/lib/libb.so": lib(),
+				"/rpath/libb.so":   lib(),
+			},
+			want: []string{"liba.so", "libb.so"}, path: "/rpath/libb.so", source: SourceRPATH,
+		},
+		{
+			name: "RPATH not inherited past a RUNPATH",
+			files: map[string]stub{
+				"/app/main":        exe(stub{needed: []string{"liba.so"}, rpath: "/rpath"}),
+				"/usr/lib/liba.so": func() stub { s := lib("libb.so"); s.runpath = "/nowhere"; return s }(),
+				"/usr/lib/libb.so": lib(),
+				"/rpath/libb.so":   lib(),
+			},
+			want: []string{"liba.so", "libb.so"}, path: "/usr/lib/libb.so", source: SourceDefault,
+		},
+		{
+			name: "LD_LIBRARY_PATH before RUNPATH",
+			files: map[string]stub{
+				"/app/main":          exe(stub{needed: []string{"libfoo.so"}, runpath: "/runpath"}),
+				"/runpath/libfoo.so": lib(),
+				"/env/libfoo.so":     lib(),
+			},
+			libraryPath: "/env",
+			want:        []string{"libfoo.so"}, path: "/env/libfoo.so", source: SourceEnv,
+		},
+		{
+			name: "cache before the default directories",
+			files: map[string]stub{
+				"/app/main":          exe(stub{needed: []string{"libfoo.so"}}),
+				"/usr/lib/libfoo.so": lib(),
+				"/cached/libfoo.so":  lib(),
+			},
+			cache: staticCache{"/cached"},
+			want:  []string{"libfoo.so"}, path: "/cached/libfoo.so", source: SourceCache,
+		},
+		{
+			name: "DF_1_NODEFLIB skips the cache and the default directories",
+			files: map[string]stub{
+				"/app/main":          exe(stub{needed: []string{"libfoo.so"}, flags1: types.DF_1_NODEFLIB}),
+				"/usr/lib/libfoo.so": lib(),
+				"/cached/libfoo.so":  lib(),
+			},
+			cache: staticCache{"/cached"},
+			want:  []string{"libfoo.so"}, path: "", source: "",
+		},
+		{
+			name: "wrong class rejected",
+			files: map[string]stub{
+				"/app/main":          exe(stub{needed: []string{"libfoo.so"}, runpath: "/runpath"}),
+				"/runpath/libfoo.so": elf32,
+				"/usr/lib/libfoo.so": lib(),
+			},
+			want: []string{"libfoo.so"}, path: "/usr/lib/libfoo.so", source: SourceDefault,
+			rejected: []Rejection{{"/runpath/libfoo.so", "ELF32 instead of ELF64"}},
+		},
+		{
+			name: "wrong machine rejected",
+			files: map[string]stub{
+				"/app/main":          exe(stub{needed: []string{"libfoo.so"}}),
+				"/cached/libfoo.so":  arm64,
+				"/usr/lib/libfoo.so": lib(),
+			},
+			cache: staticCache{"/cached"},
+			want:  []string{"libfoo.so"}, path: "/usr/lib/libfoo.so", source: SourceDefault,
+			rejected: []Rejection{{"/cached/libfoo.so", "built for ARM AARCH64"}},
+		},
+	}
+
+	for _, tt := range tests {
+		root := t.TempDir()
+		for file, s := range tt.files {
+			host := filepath.Join(root, filepath.FromSlash(file))
+			if err := os.MkdirAll(filepath.Dir(host), 0o755); err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(host, s.bytes(), 0o644); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		r := &Resolver{Sysroot: root, LibraryPath: tt.libraryPath}
+		// A nil staticCache would still make a non-nil Cache
+		if tt.cache != nil {
+			r.Cache = tt.cache
+		}
+		report, err := r.Resolve(filepath.Join(root, "app", "main"))
+		if err != nil {
+			t.Errorf("%s: Resolve() error: %v", tt.name, err)
+			continue
+		}
+
+		var got *Library
+		libs := report.Needed
+		for _, name := range tt.want {
+			got = nil
+			for _, l := range libs {
+				if l.Name == name {
+					got = l
+				}
+			}
+			if got == nil {
+				break
+			}
+			libs = got.Needed
+		}
+		if got == nil {
+			t.Errorf("%s: %v not in the dependency tree", tt.name, tt.want)
+			continue
+		}
+
+		if got.Path != tt.path || got.Source != tt.source {
+			t.Errorf("%s: %s resolved to %q (%s), want %q (%s)", tt.name, got.Name, got.Path, got.Source, tt.path, tt.source)
+		}
+		if !reflect.DeepEqual(got.Rejected, tt.rejected) {
+			t.Errorf("%s: %s rejected %v, want %v", tt.name, got.Name, got.Rejected, tt.rejected)
+		}
+	}
+}
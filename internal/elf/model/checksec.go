@@ -0,0 +1,55 @@
+package model
+
+import (
+	"github.com/yourpwnguy/strix/internal/elf/checksec"
+)
+
+// Check is the outcome of one mitigation check. Status is one of "good", "partial", "bad" or "n/a".
+type Check struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Status string `json:"status"`
+	Detail string `json:"detail"`
+}
+
+// Checksec is the mitigation report of one file (checksec command).
+type Checksec struct {
+	Checks []Check `json:"checks"`
+}
+
+// statusNames maps checksec statuses to their schema names.
+var statusNames = map[checksec.Status]string{
+	checksec.StatusGood:    "good",
+	checksec.StatusPartial: "partial",
+	checksec.StatusBad:     "bad",
+	checksec.StatusNone:    "n/a",
+}
+
+// NewChecksec builds the mitigation report view.
+func NewChecksec(report *checksec.Report) *Checksec {
+	out := &Checksec{Checks: make([]Check, len(report.Checks))}
+
+	for i := range report.Checks {
+		c := &report.Checks[i]
+		out.Checks[i] = Check{
+			Name:   c.Name,
+			Value:  c.Value,
+			Status: statusNames[c.Status],
+			Detail: c.Detail,
+		}
+	}
+
+	return out
+}
+
+// Table lists one row per check.
+func (v *Checksec) Table() *Table {
+	t := &Table{Columns: []string{"check", "value", "status", "detail"}}
+
+	for i := range v.Checks {
+		c := &v.Checks[i]
+		t.Rows = append(t.Rows, []string{c.Name, c.Value, c.Status, c.Detail})
+	}
+
+	return t
+}
@@ -0,0 +1,99 @@
+package model
+
+import (
+	"strconv"
+
+	"github.com/yourpwnguy/strix/internal/elf/ldd"
+)
+
+// Rejection is a candidate file the loader search skipped, and why.
+type Rejection struct {
+	Path   string `json:"path"`
+	Reason string `json:"reason"`
+}
+
+// Dependency is one needed library and where it resolved. Source is the search step that
+// found it ("rpath", "LD_LIBRARY_PATH", "runpath", "ld.so.cache", "default", "direct" or
+// "interpreter"). Already loaded libraries list their dependencies where they were first loaded.
+type Dependency struct {
+	Name          string       `json:"name"`
+	Path          string       `json:"path,omitempty"`
+	Source        string       `json:"source,omitempty"`
+	Found         bool         `json:"found"`
+	AlreadyLoaded bool         `json:"already_loaded,omitempty"`
+	Error         string       `json:"error,omitempty"`
+	Rejected      []Rejection  `json:"rejected,omitempty"`
+	Needed        []Dependency `json:"needed,omitempty"`
+}
+
+// Dependencies is the resolved dependency tree of one file (ldd command).
+type Dependencies struct {
+	Interpreter string       `json:"interpreter,omitempty"`
+	Sysroot     string       `json:"sysroot,omitempty"`
+	Needed      []Dependency `json:"needed"`
+	Missing     int          `json:"missing"`
+}
+
+// NewDependencies builds the dependency tree view.
+func NewDependencies(report *ldd.Report, sysroot string) *Dependencies {
+	return &Dependencies{
+		Interpreter: report.Interpreter,
+		Sysroot:     sysroot,
+		Needed:      newDependencies(report.Needed),
+		Missing:     report.Missing,
+	}
+}
+
+// newDependencies converts one level of the tree.
+func newDependencies(libs []*ldd.Library) []Dependency {
+	out := make([]Dependency, len(libs))
+
+	for i, lib := range libs {
+		d := Dependency{
+			Name:          lib.Name,
+			Path:          lib.Path,
+			Source:        string(lib.Source),
+			Found:         lib.Path != "",
+			AlreadyLoaded: lib.Loaded,
+			Needed:        newDependencies(lib.Needed),
+		}
+		if lib.Err != nil {
+			d.Error = lib.Err.Error()
+		}
+		for _, r := range lib.Rejected {
+			d.Rejected = append(d.Rejected, Rejection{Path: r.Path, Reason: r.Reason})
+		}
+
+		out[i] = d
+	}
+
+	return out
+}
+
+// Table lists one row per needed entry, depth first, with the library that needs it.
+func (v *Dependencies) Table() *Table {
+	t := &Table{Columns: []string{"depth", "parent", "name", "path", "source", "status"}}
+
+	var walk func(deps []Dependency, parent string, depth int)
+	walk = func(deps []Dependency, parent string, depth int) {
+		for i := range deps {
+			d := &deps[i]
+
+			status := "found"
+			switch {
+			case !d.Found:
+				status = "not found"
+			case d.AlreadyLoaded:
+				status = "already loaded"
+			case d.Error != "":
+				status = d.Error
+			}
+
+			t.Rows = append(t.Rows, []string{strconv.Itoa(depth), parent, d.Name, d.Path, d.Source, status})
+			walk(d.Needed, d.Name, depth+1)
+		}
+	}
+	walk(v.Needed, "", 1)
+
+	return t
+}
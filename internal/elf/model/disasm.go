@@ -0,0 +1,147 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/yourpwnguy/strix/internal/elf/disasm"
+	"github.com/yourpwnguy/strix/pkg/elf/types"
+)
+
+// DisasmInstruction is one decoded instruction. Target is the destination of a direct call or
+// jump, Ref the address a memory operand resolves to, each with the symbol found there. String
+// is the text stored at Ref, when it holds any.
+type DisasmInstruction struct {
+	Address    uint64  `json:"address"`
+	Bytes      Hex     `json:"bytes"`
+	Text       string  `json:"text"`
+	Class      string  `json:"class"`
+	Target     *uint64 `json:"target,omitempty"`
+	TargetName string  `json:"target_symbol,omitempty"`
+	Ref        *uint64 `json:"ref,omitempty"`
+	RefName    string  `json:"ref_symbol,omitempty"`
+	String     string  `json:"string,omitempty"`
+}
+
+// DisasmBlock is the code from one symbol to the next, or of a whole section when no symbol
+// covers it.
+type DisasmBlock struct {
+	Name         string              `json:"name"`
+	Address      uint64              `json:"address"`
+	Section      string              `json:"section"`
+	Instructions []DisasmInstruction `json:"instructions"`
+}
+
+// Disassembly is the disassembled code of a file (disasm command).
+type Disassembly struct {
+	Source  string        `json:"source"`
+	Machine Named         `json:"machine"`
+	Syntax  string        `json:"syntax,omitempty"`
+	Blocks  []DisasmBlock `json:"blocks"`
+}
+
+// NewDisassembly builds the disassembly view.
+func NewDisassembly(report *disasm.Report) *Disassembly {
+	out := &Disassembly{
+		Source:  report.Source,
+		Machine: Named{uint64(report.Machine), types.GetEMachine(report.Machine)},
+		Syntax:  report.Syntax,
+		Blocks:  make([]DisasmBlock, len(report.Blocks)),
+	}
+
+	for i := range report.Blocks {
+		b := &report.Blocks[i]
+
+		block := DisasmBlock{
+			Name:         b.Name,
+			Address:      b.Address,
+			Section:      b.Section,
+			Instructions: make([]DisasmInstruction, len(b.Instructions)),
+		}
+
+		for j := range b.Instructions {
+			ins := &b.Instructions[j]
+
+			entry := DisasmInstruction{
+				Address:    ins.Address,
+				Bytes:      ins.Bytes,
+				Text:       ins.Text,
+				Class:      ins.Class,
+				TargetName: ins.TargetName,
+				RefName:    ins.RefName,
+				String:     ins.String,
+			}
+			if ins.HasTarget {
+				entry.Target = &ins.Target
+			}
+			if ins.HasRef {
+				entry.Ref = &ins.Ref
+			}
+
+			block.Instructions[j] = entry
+		}
+
+		out.Blocks[i] = block
+	}
+
+	return out
+}
+
+// Table lists one row per instruction, with the block it belongs to.
+func (v *Disassembly) Table() *Table {
+	t := &Table{Columns: []string{"block", "address", "bytes", "instruction", "class", "target", "target_symbol", "ref", "ref_symbol", "string"}}
+
+	for i := range v.Blocks {
+		b := &v.Blocks[i]
+		for j := range b.Instructions {
+			ins := &b.Instructions[j]
+
+			target, ref := "", ""
+			if ins.Target != nil {
+				target = hexCell(*ins.Target)
+			}
+			if ins.Ref != nil {
+				ref = hexCell(*ins.Ref)
+			}
+
+			t.Rows = append(t.Rows, []string{
+				b.Name,
+				hexCell(ins.Address),
+				fmt.Sprintf("% x", []byte(ins.Bytes)),
+				ins.Text,
+				ins.Class,
+				target,
+				ins.TargetName,
+				ref,
+				ins.RefName,
+				ins.String,
+			})
+		}
+	}
+
+	return t
+}
+
+// Demangle rewrites the names of the blocks and of the symbols instructions refer to.
+func (v *Disassembly) Demangle(fn func(string) string) {
+	for i := range v.Blocks {
+		b := &v.Blocks[i]
+		b.Name = demangleOffset(b.Name, fn)
+		for j := range b.Instructions {
+			ins := &b.Instructions[j]
+			ins.TargetName = demangleOffset(ins.TargetName, fn)
+			ins.RefName = demangleOffset(ins.RefName, fn)
+		}
+	}
+}
+
+// demangleOffset demangles the symbol of a name+0x... label, keeping the offset.
+func demangleOffset(name string, fn func(string) string) string {
+	if name == "" {
+		return ""
+	}
+	if i := strings.LastIndex(name, "+0x"); i > 0 {
+		return fn(name[:i]) + name[i:]
+	}
+	return fn(name)
+}
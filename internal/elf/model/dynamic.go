@@ -0,0 +1,83 @@
+package model
+
+import (
+	"strings"
+
+	"github.com/yourpwnguy/strix/pkg/elf/types"
+)
+
+// DynamicEntry is a single dynamic section entry. String holds the resolved value of
+// string-valued tags, Flags the decoded DT_FLAGS/DT_FLAGS_1 bits and Array the contents
+// of the init/fini arrays.
+type DynamicEntry struct {
+	Index  int      `json:"index"`
+	Tag    Named    `json:"tag"`
+	Value  uint64   `json:"value"`
+	String string   `json:"string,omitempty"`
+	Flags  []string `json:"flags,omitempty"`
+	Array  []uint64 `json:"array,omitempty"`
+}
+
+// Dynamic is the dynamic section (dynamic command).
+type Dynamic struct {
+	Offset  uint64         `json:"offset"`
+	Entries []DynamicEntry `json:"entries"`
+}
+
+// NewDynamic builds the dynamic section view. Offset is the file offset of the table.
+func NewDynamic(offset uint64, entries []types.DynamicEntry) *Dynamic {
+	out := &Dynamic{
+		Offset:  offset,
+		Entries: make([]DynamicEntry, len(entries)),
+	}
+
+	for i := range entries {
+		e := &entries[i]
+
+		entry := DynamicEntry{
+			Index:  i,
+			Tag:    Named{uint64(e.D_tag), types.GetDynTag(e.D_tag)},
+			Value:  e.D_val,
+			String: e.String,
+			Array:  e.Array,
+		}
+
+		switch e.D_tag {
+		case types.DT_FLAGS:
+			entry.Flags = types.DynFlagNames(e.D_val)
+		case types.DT_FLAGS_1:
+			entry.Flags = types.DynFlags1Names(e.D_val)
+		}
+
+		out.Entries[i] = entry
+	}
+
+	return out
+}
+
+// Table lists one row per entry. The value is the resolved string or the decoded flags when
+// the tag has one, and the raw value otherwise.
+func (v *Dynamic) Table() *Table {
+	t := &Table{Columns: []string{"index", "tag", "type", "value"}}
+
+	for i := range v.Entries {
+		e := &v.Entries[i]
+
+		value := hexCell(e.Value)
+		switch {
+		case e.String != "":
+			value = e.String
+		case e.Flags != nil:
+			value = strings.Join(e.Flags, " ")
+		}
+
+		t.Rows = append(t.Rows, []string{
+			decCell(e.Index),
+			hexCell(e.Tag.Value),
+			e.Tag.Name,
+			value,
+		})
+	}
+
+	return t
+}
@@ -0,0 +1,112 @@
+package model
+
+import (
+	"encoding/hex"
+
+	"github.com/yourpwnguy/strix/pkg/elf/types"
+)
+
+// Ident is the decoded e_ident array. Bytes holds all 16 bytes as hex.
+type Ident struct {
+	Bytes      string `json:"bytes"`
+	Magic      string `json:"magic"`
+	Class      Named  `json:"class"`
+	Data       Named  `json:"data"`
+	Version    uint8  `json:"version"`
+	OSABI      Named  `json:"osabi"`
+	ABIVersion uint8  `json:"abi_version"`
+}
+
+// Header is the ELF header (ehdr command).
+type Header struct {
+	Ident       Ident  `json:"ident"`
+	Type        Named  `json:"type"`
+	Machine     Named  `json:"machine"`
+	Version     uint32 `json:"version"`
+	Entry       uint64 `json:"entry"`
+	Phoff       uint64 `json:"phoff"`
+	Shoff       uint64 `json:"shoff"`
+	Flags       uint32 `json:"flags"`
+	Ehsize      uint16 `json:"ehsize"`
+	Phentsize   uint16 `json:"phentsize"`
+	Phnum       uint16 `json:"phnum"`
+	Shentsize   uint16 `json:"shentsize"`
+	Shnum       uint16 `json:"shnum"`
+	Shstrndx    uint16 `json:"shstrndx"`
+	Interpreter string `json:"interpreter,omitempty"`
+}
+
+// NewHeader builds the header view. The interpreter is read through PT_INTERP.
+func NewHeader(ehdr *types.Elf64_Ehdr, phdr []types.Elf64_Phdr, data []byte) *Header {
+	e_ident := &ehdr.E_ident
+
+	h := &Header{
+		Ident: Ident{
+			Bytes:      hex.EncodeToString(identBytes(ehdr)),
+			Magic:      hex.EncodeToString(e_ident.FileIdn[:]),
+			Class:      Named{uint64(e_ident.Ei_class), types.GetEiClass(e_ident.Ei_class)},
+			Data:       Named{uint64(e_ident.Ei_data), types.GetEiData(e_ident.Ei_data)},
+			Version:    e_ident.Ei_version,
+			OSABI:      Named{uint64(e_ident.Ei_osabi), types.GetEiOSABI(e_ident.Ei_osabi)},
+			ABIVersion: e_ident.Ei_abiversion,
+		},
+		Type:      Named{uint64(ehdr.E_type), types.GetEType(ehdr.E_type, types.HasInterpreter(phdr))},
+		Machine:   Named{uint64(ehdr.E_machine), types.GetEMachine(ehdr.E_machine)},
+		Version:   ehdr.E_version,
+		Entry:     ehdr.E_entry,
+		Phoff:     ehdr.E_phoff,
+		Shoff:     ehdr.E_shoff,
+		Flags:     ehdr.E_flags,
+		Ehsize:    ehdr.E_ehsize,
+		Phentsize: ehdr.E_phentsize,
+		Phnum:     ehdr.E_phnum,
+		Shentsize: ehdr.E_shentsize,
+		Shnum:     ehdr.E_shnum,
+		Shstrndx:  ehdr.E_shstrndx,
+	}
+
+	if types.HasInterpreter(phdr) {
+		h.Interpreter = types.GetInterpreter(phdr, data)
+	}
+
+	return h
+}
+
+// identBytes lays e_ident out as it appears in the file.
+func identBytes(ehdr *types.Elf64_Ehdr) []byte {
+	e_ident := &ehdr.E_ident
+
+	b := make([]byte, 0, 16)
+	b = append(b, e_ident.FileIdn[:]...)
+	b = append(b, e_ident.Ei_class, e_ident.Ei_data, e_ident.Ei_version, e_ident.Ei_osabi, e_ident.Ei_abiversion)
+	return append(b, e_ident.Ei_pad[:]...)
+}
+
+// Table lists the header as field/value pairs.
+func (h *Header) Table() *Table {
+	return &Table{
+		Columns: []string{"field", "value"},
+		Rows: [][]string{
+			{"magic", h.Ident.Magic},
+			{"class", h.Ident.Class.Name},
+			{"data", h.Ident.Data.Name},
+			{"ident_version", decCell(h.Ident.Version)},
+			{"osabi", h.Ident.OSABI.Name},
+			{"abi_version", decCell(h.Ident.ABIVersion)},
+			{"type", h.Type.Name},
+			{"machine", h.Machine.Name},
+			{"version", hexCell(uint64(h.Version))},
+			{"entry", hexCell(h.Entry)},
+			{"phoff", decCell(h.Phoff)},
+			{"shoff", decCell(h.Shoff)},
+			{"flags", hexCell(uint64(h.Flags))},
+			{"ehsize", decCell(h.Ehsize)},
+			{"phentsize", decCell(h.Phentsize)},
+			{"phnum", decCell(h.Phnum)},
+			{"shentsize", decCell(h.Shentsize)},
+			{"shnum", decCell(h.Shnum)},
+			{"shstrndx", decCell(h.Shstrndx)},
+			{"interpreter", h.Interpreter},
+		},
+	}
+}
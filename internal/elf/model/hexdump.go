@@ -0,0 +1,77 @@
+package model
+
+import (
+	"encoding/hex"
+	"fmt"
+)
+
+// hexDumpWidth is the number of bytes per row of the tabular form.
+const hexDumpWidth = 16
+
+// Hex is a run of raw bytes, encoded as a hex string.
+type Hex []byte
+
+// MarshalText encodes the bytes as lowercase hex.
+func (h Hex) MarshalText() ([]byte, error) {
+	out := make([]byte, hex.EncodedLen(len(h)))
+	hex.Encode(out, h)
+	return out, nil
+}
+
+// HexDump is a raw byte range of the file (hexdump command). Source describes where the range
+// comes from, e.g. "section .rodata". Address is the virtual address of the first byte, only
+// present when a PT_LOAD segment maps the range. Data is a slice of the loaded file, not a copy.
+type HexDump struct {
+	Source  string  `json:"source"`
+	Offset  uint64  `json:"offset"`
+	Address *uint64 `json:"address,omitempty"`
+	Size    int     `json:"size"`
+	Data    Hex     `json:"data"`
+}
+
+// NewHexDump builds the view of a byte range found at offset. The address is dropped unless mapped is set.
+func NewHexDump(source string, offset, address uint64, mapped bool, data []byte) *HexDump {
+	out := &HexDump{
+		Source: source,
+		Offset: offset,
+		Size:   len(data),
+		Data:   data,
+	}
+
+	if mapped {
+		out.Address = &address
+	}
+
+	return out
+}
+
+// Table lists 16 bytes per row, with the address column left empty when the range is not mapped.
+func (v *HexDump) Table() *Table {
+	t := &Table{Columns: []string{"offset", "address", "hex", "ascii"}}
+
+	for i := 0; i < len(v.Data); i += hexDumpWidth {
+		row := v.Data[i:min(i+hexDumpWidth, len(v.Data))]
+
+		var address string
+		if v.Address != nil {
+			address = hexCell(*v.Address + uint64(i))
+		}
+
+		ascii := make([]byte, len(row))
+		for j, b := range row {
+			ascii[j] = '.'
+			if b >= 0x20 && b <= 0x7e {
+				ascii[j] = b
+			}
+		}
+
+		t.Rows = append(t.Rows, []string{
+			hexCell(v.Offset + uint64(i)),
+			address,
+			fmt.Sprintf("% x", row),
+			string(ascii),
+		})
+	}
+
+	return t
+}
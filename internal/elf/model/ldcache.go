@@ -0,0 +1,82 @@
+package model
+
+import (
+	"fmt"
+
+	"github.com/yourpwnguy/strix/internal/ldcache"
+	"github.com/yourpwnguy/strix/pkg/elf/types"
+)
+
+// CacheEntry maps one library name to a file. Flags are named the way ldconfig -p prints
+// them (e.g. "libc6,x86-64"), Hwcaps is the glibc-hwcaps subdirectory the file lives in and
+// HWCap the legacy hwcap bits of old caches.
+type CacheEntry struct {
+	Name      string `json:"name"`
+	Path      string `json:"path"`
+	Flags     Named  `json:"flags"`
+	Hwcaps    string `json:"hwcaps,omitempty"`
+	HWCap     uint64 `json:"hwcap,omitempty"`
+	OSVersion string `json:"os_version,omitempty"`
+}
+
+// LoaderCache is the content of a loader cache file (ldcache command). Total counts every
+// entry of the file, Entries only those that passed the filters.
+type LoaderCache struct {
+	Format    string       `json:"format"`
+	Generator string       `json:"generator,omitempty"`
+	Hwcaps    []string     `json:"hwcaps,omitempty"`
+	Total     int          `json:"total"`
+	Entries   []CacheEntry `json:"entries"`
+}
+
+// NewLoaderCache builds the loader cache view from the entries keep accepts.
+func NewLoaderCache(c *ldcache.Cache, keep func(*ldcache.Entry) bool) *LoaderCache {
+	out := &LoaderCache{
+		Format:    c.Format,
+		Generator: c.Generator,
+		Hwcaps:    c.Hwcaps,
+		Total:     len(c.Entries),
+		Entries:   []CacheEntry{},
+	}
+
+	for i := range c.Entries {
+		e := &c.Entries[i]
+		if !keep(e) {
+			continue
+		}
+
+		entry := CacheEntry{
+			Name:   e.Name,
+			Path:   e.Path,
+			Flags:  Named{uint64(uint32(e.Flags)), ldcache.FlagNames(e.Flags)},
+			Hwcaps: e.Hwcaps,
+		}
+		if e.Hwcaps == "" {
+			entry.HWCap = e.HWCap
+		}
+		if e.OSVersion != 0 {
+			entry.OSVersion = fmt.Sprintf("%s %d.%d.%d",
+				types.GetABITagOS(e.OSVersion>>24),
+				e.OSVersion>>16&0xff,
+				e.OSVersion>>8&0xff,
+				e.OSVersion&0xff,
+			)
+		}
+
+		out.Entries = append(out.Entries, entry)
+	}
+
+	return out
+}
+
+// Table lists one row per cache entry.
+func (v *LoaderCache) Table() *Table {
+	t := &Table{Columns: []string{"name", "flags", "hwcaps", "os_version", "path"}}
+
+	for i := range v.Entries {
+		e := &v.Entries[i]
+		t.Rows = append(t.Rows, []string{e.Name, e.Flags.Name, e.Hwcaps, e.OSVersion, e.Path})
+	}
+
+	return t
+}
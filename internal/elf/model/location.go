@@ -0,0 +1,239 @@
+package model
+
+import (
+	"fmt"
+
+	"github.com/yourpwnguy/strix/pkg/elf/types"
+)
+
+// Region is the section or segment holding a location. Delta is the distance from its start.
+type Region struct {
+	Index int    `json:"index"`
+	Name  string `json:"name"`
+	Start uint64 `json:"start"`
+	Delta uint64 `json:"delta"`
+}
+
+// NearestSymbol is the closest symbol at or below a location, as Name+Delta.
+type NearestSymbol struct {
+	Name  string `json:"name"`
+	Value uint64 `json:"value"`
+	Size  uint64 `json:"size"`
+	Delta uint64 `json:"delta"`
+}
+
+// Location is a virtual address or file offset resolved against the file (addr command).
+// Query is "address" or "offset", depending on what was looked up. Mapped tells whether a
+// PT_LOAD segment loads the location, and FileBacked whether the file holds bytes for it.
+// An address can be mapped without file backing, in the zero-filled tail of a segment (.bss).
+// Address is absent when the location is not mapped, Offset when it is not file backed.
+type Location struct {
+	Query      string         `json:"query"`
+	Value      uint64         `json:"value"`
+	Address    *uint64        `json:"address,omitempty"`
+	Offset     *uint64        `json:"offset,omitempty"`
+	Mapped     bool           `json:"mapped"`
+	FileBacked bool           `json:"file_backed"`
+	Section    *Region        `json:"section,omitempty"`
+	Segment    *Region        `json:"segment,omitempty"`
+	Symbol     *NearestSymbol `json:"symbol,omitempty"`
+}
+
+// NewAddressLocation resolves a virtual address. Sections are matched by address and segments
+// by their memory image, so addresses in the zero-filled tail of a segment are found but have
+// no file offset.
+func NewAddressLocation(addr uint64, phdr []types.Elf64_Phdr, shdr []types.Elf64_Shdr, shstrtab []byte, syms []types.Symbol) *Location {
+	out := &Location{Query: "address", Value: addr, Address: &addr}
+
+	for i := range phdr {
+		ph := &phdr[i]
+		if ph.P_type == types.PT_LOAD && addr >= ph.P_vaddr && addr-ph.P_vaddr < ph.P_memsz {
+			out.Segment = &Region{i, types.GetPType(ph.P_type), ph.P_vaddr, addr - ph.P_vaddr}
+			if addr-ph.P_vaddr < ph.P_filesz {
+				offset := ph.P_offset + (addr - ph.P_vaddr)
+				out.Offset = &offset
+			}
+			break
+		}
+	}
+
+	for i := range shdr {
+		sh := &shdr[i]
+		if sh.Sh_flags&types.SHF_ALLOC == 0 || isTBSS(sh) {
+			continue
+		}
+		if addr >= sh.Sh_addr && addr-sh.Sh_addr < sh.Sh_size {
+			out.Section = &Region{i, sectionName(shstrtab, sh, i), sh.Sh_addr, addr - sh.Sh_addr}
+
+			// The segment may cover a NOBITS section when it is not the last one mapped
+			if sh.Sh_type == types.SHT_NOBITS {
+				out.Offset = nil
+			}
+			break
+		}
+	}
+
+	out.Mapped = out.Segment != nil
+	out.FileBacked = out.Offset != nil
+	out.Symbol = out.nearestSymbol(syms, addr)
+
+	return out
+}
+
+// NewOffsetLocation resolves a file offset. Sections are matched by file range, so this also
+// works on relocatable objects, where the nearest symbol is looked up relative to the section.
+func NewOffsetLocation(offset uint64, fileSize int, phdr []types.Elf64_Phdr, shdr []types.Elf64_Shdr, shstrtab []byte, syms []types.Symbol) *Location {
+	out := &Location{
+		Query:      "offset",
+		Value:      offset,
+		Offset:     &offset,
+		FileBacked: offset < uint64(fileSize),
+	}
+
+	for i := range phdr {
+		ph := &phdr[i]
+		if ph.P_type == types.PT_LOAD && offset >= ph.P_offset && offset-ph.P_offset < ph.P_filesz {
+			out.Segment = &Region{i, types.GetPType(ph.P_type), ph.P_offset, offset - ph.P_offset}
+			addr := ph.P_vaddr + (offset - ph.P_offset)
+			out.Address, out.Mapped = &addr, true
+			break
+		}
+	}
+
+	for i := range shdr {
+		sh := &shdr[i]
+		if sh.Sh_type == types.SHT_NULL || sh.Sh_type == types.SHT_NOBITS {
+			continue
+		}
+		if offset >= sh.Sh_offset && offset-sh.Sh_offset < sh.Sh_size {
+			out.Section = &Region{i, sectionName(shstrtab, sh, i), sh.Sh_offset, offset - sh.Sh_offset}
+			break
+		}
+	}
+
+	switch {
+	case out.Address != nil:
+		out.Symbol = out.nearestSymbol(syms, *out.Address)
+	case out.Section != nil:
+		// Symbol values of relocatable objects are offsets into their section
+		out.Symbol = out.nearestSymbol(syms, out.Section.Delta)
+	}
+
+	return out
+}
+
+// nearestSymbol looks for the symbol labelling addr among those of the containing section,
+// or of the containing segment when no section header covers it. A symbol from an unrelated
+// part of the image would only mislead, so locations outside both get none.
+func (v *Location) nearestSymbol(syms []types.Symbol, addr uint64) *NearestSymbol {
+	switch {
+	case v.Section != nil:
+		idx := uint32(v.Section.Index)
+		return nearestSymbol(syms, addr, func(sym *types.Symbol) bool { return sym.Shndx == idx })
+	case v.Segment != nil && v.Query == "address":
+		start := v.Segment.Start
+		return nearestSymbol(syms, addr, func(sym *types.Symbol) bool { return sym.St_value >= start })
+	}
+	return nil
+}
+
+// isTBSS reports whether sh is a .tbss section. It takes no room in the memory image and its
+// address overlaps whatever follows it.
+func isTBSS(sh *types.Elf64_Shdr) bool {
+	return sh.Sh_flags&types.SHF_TLS != 0 && sh.Sh_type == types.SHT_NOBITS
+}
+
+// sectionName names a section, by index when it has no name.
+func sectionName(shstrtab []byte, sh *types.Elf64_Shdr, idx int) string {
+	if name := types.GetString(shstrtab, sh.Sh_name); name != "" {
+		return name
+	}
+	return fmt.Sprintf("<%d>", idx)
+}
+
+// nearestSymbol finds the named, defined symbol closest at or below value. Section, file and
+// TLS symbols do not label addresses and are skipped. On a tie, sized functions and objects win
+// over markers, and global bindings over local ones.
+func nearestSymbol(syms []types.Symbol, value uint64, keep func(*types.Symbol) bool) *NearestSymbol {
+	var best *types.Symbol
+	for i := range syms {
+		sym := &syms[i]
+		if sym.Name == "" || sym.IsUndefined() || sym.St_shndx == types.SHN_ABS || sym.St_value > value || !keep(sym) {
+			continue
+		}
+		switch sym.Type() {
+		case types.STT_SECTION, types.STT_FILE, types.STT_TLS:
+			continue
+		}
+
+		if best == nil || sym.St_value > best.St_value || (sym.St_value == best.St_value && symbolRank(sym) > symbolRank(best)) {
+			best = sym
+		}
+	}
+
+	if best == nil {
+		return nil
+	}
+
+	return &NearestSymbol{
+		Name:  best.Name,
+		Value: best.St_value,
+		Size:  best.St_size,
+		Delta: value - best.St_value,
+	}
+}
+
+// symbolRank orders symbols at the same value by how well they describe it.
+func symbolRank(sym *types.Symbol) int {
+	rank := 0
+	if sym.Type() == types.STT_FUNC || sym.Type() == types.STT_OBJECT {
+		rank += 2
+	}
+	if sym.Bind() != types.STB_LOCAL {
+		rank++
+	}
+	return rank
+}
+
+// Table lists the location as a single row.
+func (v *Location) Table() *Table {
+	t := &Table{Columns: []string{"query", "value", "address", "offset", "mapped", "file_backed", "section", "segment", "symbol"}}
+
+	var address, offset, section, segment, symbol string
+	if v.Address != nil {
+		address = hexCell(*v.Address)
+	}
+	if v.Offset != nil {
+		offset = hexCell(*v.Offset)
+	}
+	if v.Section != nil {
+		section = fmt.Sprintf("%s+%#x", v.Section.Name, v.Section.Delta)
+	}
+	if v.Segment != nil {
+		segment = fmt.Sprintf("%d+%#x", v.Segment.Index, v.Segment.Delta)
+	}
+	if v.Symbol != nil {
+		symbol = fmt.Sprintf("%s+%#x", v.Symbol.Name, v.Symbol.Delta)
+	}
+
+	t.Rows = append(t.Rows, []string{
+		v.Query,
+		hexCell(v.Value),
+		address,
+		offset,
+		fmt.Sprintf("%t", v.Mapped),
+		fmt.Sprintf("%t", v.FileBacked),
+		section,
+		segment,
+		symbol,
+	})
+
+	return t
+}
+
+// Demangle rewrites the name of the nearest symbol.
+func (v *Location) Demangle(fn func(string) string) {
+	if v.Symbol != nil {
+		v.Symbol.Name = fn(v.Symbol.Name)
+	}
+}
@@ -0,0 +1,115 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/yourpwnguy/strix/pkg/elf/types"
+)
+
+// Two PT_LOAD segments: text at 0x400000 and data at 0x401000, whose memory image runs
+// 0x280 bytes past its file contents, to hold .tbss and .bss and then padding.
+var (
+	locPhdr = []types.Elf64_Phdr{
+		{P_type: types.PT_PHDR, P_offset: 0x40, P_vaddr: 0x400040, P_filesz: 0x38, P_memsz: 0x38},
+		{P_type: types.PT_LOAD, P_offset: 0, P_vaddr: 0x400000, P_filesz: 0x300, P_memsz: 0x300},
+		{P_type: types.PT_LOAD, P_offset: 0x1000, P_vaddr: 0x401000, P_filesz: 0x100, P_memsz: 0x380},
+	}
+
+	locShstrtab = []byte("\x00.text\x00.data\x00.tbss\x00.bss\x00")
+
+	locShdr = []types.Elf64_Shdr{
+		{},
+		{Sh_name: 1, Sh_type: types.SHT_PROGBITS, Sh_flags: types.SHF_ALLOC | types.SHF_EXECINSTR, Sh_addr: 0x400100, Sh_offset: 0x100, Sh_size: 0x200},
+		{Sh_name: 7, Sh_type: types.SHT_PROGBITS, Sh_flags: types.SHF_ALLOC | types.SHF_WRITE, Sh_addr: 0x401000, Sh_offset: 0x1000, Sh_size: 0x100},
+		{Sh_name: 13, Sh_type: types.SHT_NOBITS, Sh_flags: types.SHF_ALLOC | types.SHF_WRITE | types.SHF_TLS, Sh_addr: 0x401100, Sh_offset: 0x1100, Sh_size: 0x80},
+		{Sh_name: 19, Sh_type: types.SHT_NOBITS, Sh_flags: types.SHF_ALLOC | types.SHF_WRITE, Sh_addr: 0x401100, Sh_offset: 0x1100, Sh_size: 0x200},
+	}
+
+	locSyms = []types.Symbol{
+		{Name: "main", Shndx: 1, Elf64_Sym: types.Elf64_Sym{St_info: types.STB_GLOBAL<<4 | types.STT_FUNC, St_shndx: 1, St_value: 0x400120, St_size: 0x40}},
+		{Name: "counter", Shndx: 4, Elf64_Sym: types.Elf64_Sym{St_info: types.STB_GLOBAL<<4 | types.STT_OBJECT, St_shndx: 4, St_value: 0x401180, St_size: 8}},
+	}
+)
+
+// wantLocation is what a test expects of a Location. Zero offsets and addresses stand for
+// absent ones, the locations tested never sit at zero.
+type wantLocation struct {
+	address, offset    uint64
+	mapped, fileBacked bool
+	section, symbol    string
+	segment            int // -1 for none
+}
+
+func checkLocation(t *testing.T, name string, got *Location, want wantLocation) {
+	t.Helper()
+
+	var address, offset uint64
+	if got.Address != nil {
+		address = *got.Address
+	}
+	if got.Offset != nil {
+		offset = *got.Offset
+	}
+	if address != want.address || offset != want.offset {
+		t.Errorf("%s: address %#x, offset %#x, want %#x and %#x", name, address, offset, want.address, want.offset)
+	}
+	if got.Mapped != want.mapped || got.FileBacked != want.fileBacked {
+		t.Errorf("%s: mapped %t, file backed %t, want %t and %t", name, got.Mapped, got.FileBacked, want.mapped, want.fileBacked)
+	}
+
+	section, segment, symbol := "", -1, ""
+	if got.Section != nil {
+		section = got.Section.Name
+	}
+	if got.Segment != nil {
+		segment = got.Segment.Index
+	}
+	if got.Symbol != nil {
+		symbol = got.Symbol.Name
+	}
+	if section != want.section || segment != want.segment || symbol != want.symbol {
+		t.Errorf("%s: section %q, segment %d, symbol %q, want %q, %d and %q", name, section, segment, symbol, want.section, want.segment, want.symbol)
+	}
+}
+
+func TestNewAddressLocation(t *testing.T) {
+	tests := []struct {
+		name string
+		addr uint64
+		want wantLocation
+	}{
+		{"in file", 0x400130, wantLocation{0x400130, 0x130, true, true, ".text", "main", 1}},
+		{"data", 0x401010, wantLocation{0x401010, 0x1010, true, true, ".data", "", 2}},
+		// .tbss overlaps .bss in the address space but takes no room there
+		{"tbss", 0x401100, wantLocation{0x401100, 0, true, false, ".bss", "", 2}},
+		{"bss", 0x401184, wantLocation{0x401184, 0, true, false, ".bss", "counter", 2}},
+		{"segment tail", 0x401340, wantLocation{0x401340, 0, true, false, "", "counter", 2}},
+		{"past segment end", 0x401380, wantLocation{0x401380, 0, false, false, "", "", -1}},
+		{"unmapped", 0x500000, wantLocation{0x500000, 0, false, false, "", "", -1}},
+		{"between segments", 0x400800, wantLocation{0x400800, 0, false, false, "", "", -1}},
+	}
+
+	for _, tt := range tests {
+		checkLocation(t, tt.name, NewAddressLocation(tt.addr, locPhdr, locShdr, locShstrtab, locSyms), tt.want)
+	}
+}
+
+func TestNewOffsetLocation(t *testing.T) {
+	const fileSize = 0x1200
+
+	tests := []struct {
+		name   string
+		offset uint64
+		want   wantLocation
+	}{
+		{"in file", 0x130, wantLocation{0x400130, 0x130, true, true, ".text", "main", 1}},
+		{"data", 0x1010, wantLocation{0x401010, 0x1010, true, true, ".data", "", 2}},
+		// The file offset of .bss holds whatever follows the section, not .bss itself
+		{"unloaded", 0x1100, wantLocation{0, 0x1100, false, true, "", "", -1}},
+		{"past EOF", 0x2000, wantLocation{0, 0x2000, false, false, "", "", -1}},
+	}
+
+	for _, tt := range tests {
+		checkLocation(t, tt.name, NewOffsetLocation(tt.offset, fileSize, locPhdr, locShdr, locShstrtab, locSyms), tt.want)
+	}
+}
@@ -0,0 +1,265 @@
+package model
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/yourpwnguy/strix/internal/elf/symhash"
+	"github.com/yourpwnguy/strix/pkg/elf/types"
+)
+
+// HashProbe is one chain entry a lookup compared against the name. Reason says why it was
+// passed over and is empty for the symbol the lookup stopped on.
+type HashProbe struct {
+	Index  uint32 `json:"index"`
+	Name   string `json:"name"`
+	Hash   uint32 `json:"hash,omitempty"`
+	Match  bool   `json:"match"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// BloomTest is the bloom filter check of a GNU hash lookup.
+type BloomTest struct {
+	Word uint32    `json:"word"`
+	Bits [2]uint32 `json:"bits"`
+	Hit  bool      `json:"hit"`
+}
+
+// HashLookup is the walk of one lookup through one hash table.
+type HashLookup struct {
+	Hash        uint32      `json:"hash"`
+	Bloom       *BloomTest  `json:"bloom,omitempty"`
+	Bucket      uint32      `json:"bucket"`
+	Start       uint32      `json:"start"`
+	ChainLength int         `json:"chain_length"`
+	Probes      []HashProbe `json:"probes"`
+	Symbol      *Symbol     `json:"symbol"`
+}
+
+// ChainBuckets counts the buckets whose chain holds Length symbols. Coverage is the share
+// of all symbols found in chains at most that long.
+type ChainBuckets struct {
+	Length   int     `json:"length"`
+	Buckets  int     `json:"buckets"`
+	Percent  float64 `json:"percent"`
+	Coverage float64 `json:"coverage"`
+}
+
+// BloomStats describes the fill of a GNU hash bloom filter. A filter that is mostly set
+// lets most misses through to the buckets.
+type BloomStats struct {
+	Words int     `json:"words"`
+	Shift uint32  `json:"shift"`
+	Bits  int     `json:"bits"`
+	Set   int     `json:"set"`
+	Fill  float64 `json:"fill"`
+}
+
+// HashStats describes how evenly a hash table spreads its symbols.
+type HashStats struct {
+	Buckets      int            `json:"buckets"`
+	Symbols      int            `json:"symbols"`
+	SymOffset    int            `json:"symoffset,omitempty"`
+	Empty        int            `json:"empty"`
+	Longest      int            `json:"longest"`
+	AverageChain float64        `json:"average_chain"`
+	Histogram    []ChainBuckets `json:"histogram"`
+	Bloom        *BloomStats    `json:"bloom,omitempty"`
+}
+
+// HashTable is one of the DT_GNU_HASH and DT_HASH tables. Used marks the one the loader
+// looks symbols up in.
+type HashTable struct {
+	Kind   string      `json:"kind"`
+	Offset uint64      `json:"offset"`
+	Used   bool        `json:"used"`
+	Stats  HashStats   `json:"stats"`
+	Lookup *HashLookup `json:"lookup,omitempty"`
+}
+
+// SymbolLookup is the hash table report of a file (lookup command). Found tells whether the
+// symbol resolves through the table the loader uses, it is false when none was asked for.
+type SymbolLookup struct {
+	Symbol  string      `json:"symbol,omitempty"`
+	Version string      `json:"version,omitempty"`
+	Found   bool        `json:"found"`
+	Tables  []HashTable `json:"tables"`
+}
+
+// NewSymbolLookup builds the lookup view from a hash table report.
+func NewSymbolLookup(report *symhash.Report) *SymbolLookup {
+	out := &SymbolLookup{
+		Symbol:  report.Name,
+		Version: report.Version,
+		Tables:  []HashTable{},
+	}
+
+	used := report.Used()
+	for _, t := range report.Tables() {
+		table := HashTable{
+			Kind:   t.Kind,
+			Offset: t.Offset,
+			Used:   t == used,
+			Stats:  newHashStats(&t.Stats, t.Kind == symhash.KindGNU),
+		}
+
+		if l := t.Lookup; l != nil {
+			table.Lookup = newHashLookup(l, t.Kind == symhash.KindGNU)
+			if table.Used {
+				out.Found = l.Symbol != nil
+			}
+		}
+
+		out.Tables = append(out.Tables, table)
+	}
+
+	return out
+}
+
+// newHashStats derives the chain length histogram and averages.
+func newHashStats(s *symhash.Stats, gnu bool) HashStats {
+	out := HashStats{
+		Buckets:   s.Buckets,
+		Symbols:   s.Symbols,
+		SymOffset: s.SymOffset,
+		Longest:   s.Longest(),
+		Histogram: make([]ChainBuckets, len(s.Histogram)),
+	}
+
+	if len(s.Histogram) > 0 {
+		out.Empty = s.Histogram[0]
+	}
+	if used := s.Buckets - out.Empty; used > 0 {
+		out.AverageChain = float64(s.Symbols) / float64(used)
+	}
+
+	reached := 0
+	for length, buckets := range s.Histogram {
+		reached += length * buckets
+
+		row := ChainBuckets{Length: length, Buckets: buckets}
+		if s.Buckets > 0 {
+			row.Percent = 100 * float64(buckets) / float64(s.Buckets)
+		}
+		if s.Symbols > 0 {
+			row.Coverage = 100 * float64(reached) / float64(s.Symbols)
+		}
+		out.Histogram[length] = row
+	}
+
+	if gnu {
+		out.Bloom = &BloomStats{Words: s.BloomWords, Shift: s.BloomShift, Bits: s.BloomSize, Set: s.BloomSet}
+		if s.BloomSize > 0 {
+			out.Bloom.Fill = 100 * float64(s.BloomSet) / float64(s.BloomSize)
+		}
+	}
+
+	return out
+}
+
+// newHashLookup converts the walk of one lookup.
+func newHashLookup(l *symhash.Lookup, gnu bool) *HashLookup {
+	out := &HashLookup{
+		Hash:        l.Hash,
+		Bucket:      l.Bucket,
+		Start:       l.Start,
+		ChainLength: l.ChainLength,
+		Probes:      make([]HashProbe, len(l.Probes)),
+	}
+
+	if gnu {
+		out.Bloom = &BloomTest{Word: l.BloomWord, Bits: l.BloomBits, Hit: l.BloomHit}
+	}
+
+	for i, p := range l.Probes {
+		out.Probes[i] = HashProbe{Index: p.Index, Name: p.Name, Hash: p.Hash, Match: p.Reason == "", Reason: p.Reason}
+	}
+
+	if sym := l.Symbol; sym != nil {
+		out.Symbol = &Symbol{
+			Index:          sym.Index,
+			Name:           sym.Name,
+			Value:          sym.St_value,
+			Size:           sym.St_size,
+			Type:           Named{uint64(sym.Type()), types.GetSymType(sym.Type())},
+			Bind:           Named{uint64(sym.Bind()), types.GetSymBind(sym.Bind())},
+			Visibility:     Named{uint64(sym.Visibility()), types.GetSymVisibility(sym.Visibility())},
+			Shndx:          sym.Shndx,
+			Ndx:            types.GetSymSectionIndex(sym.St_shndx, sym.Shndx),
+			Version:        sym.Version,
+			DefaultVersion: sym.Version != "" && !sym.Hidden,
+		}
+	}
+
+	return out
+}
+
+// Demangle rewrites the names of the probed and resolved symbols. The name looked up is kept
+// as typed, it is what the hash was computed over.
+func (v *SymbolLookup) Demangle(fn func(string) string) {
+	for i := range v.Tables {
+		l := v.Tables[i].Lookup
+		if l == nil {
+			continue
+		}
+		for j := range l.Probes {
+			l.Probes[j].Name = fn(l.Probes[j].Name)
+		}
+		if l.Symbol != nil {
+			l.Symbol.Name = fn(l.Symbol.Name)
+		}
+	}
+}
+
+// Table lists the outcome of the lookup in each table, or the chain length histogram of
+// each table when no symbol was looked up.
+func (v *SymbolLookup) Table() *Table {
+	if v.Symbol == "" {
+		t := &Table{Columns: []string{"table", "length", "buckets", "percent", "coverage"}}
+		for i := range v.Tables {
+			h := &v.Tables[i]
+			for _, row := range h.Stats.Histogram {
+				t.Rows = append(t.Rows, []string{
+					h.Kind,
+					strconv.Itoa(row.Length),
+					strconv.Itoa(row.Buckets),
+					fmt.Sprintf("%.1f", row.Percent),
+					fmt.Sprintf("%.1f", row.Coverage),
+				})
+			}
+		}
+		return t
+	}
+
+	t := &Table{Columns: []string{"table", "used", "hash", "bloom", "bucket", "chain_length", "symbol", "index", "value", "size"}}
+	for i := range v.Tables {
+		h := &v.Tables[i]
+		l := h.Lookup
+
+		bloom := ""
+		if l.Bloom != nil {
+			bloom = "miss"
+			if l.Bloom.Hit {
+				bloom = "hit"
+			}
+		}
+
+		row := []string{
+			h.Kind,
+			strconv.FormatBool(h.Used),
+			fmt.Sprintf("%#08x", l.Hash),
+			bloom,
+			strconv.FormatUint(uint64(l.Bucket), 10),
+			strconv.Itoa(l.ChainLength),
+			"", "", "", "",
+		}
+		if sym := l.Symbol; sym != nil {
+			row[6] = sym.VersionedName()
+			row[7] = strconv.FormatUint(uint64(sym.Index), 10)
+			row[8] = fmt.Sprintf("%#x", sym.Value)
+			row[9] = strconv.FormatUint(sym.Size, 10)
+		}
+		t.Rows = append(t.Rows, row)
+	}
+	return t
+}
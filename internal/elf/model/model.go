@@ -0,0 +1,65 @@
+// Package model is the machine readable view of everything strix parses.
+//
+// The structures in this package are the versioned output schema. Within a schema version
+// fields are only ever added; renaming, removing or changing the meaning of a field bumps
+// SchemaVersion. Enumerations carry both the raw number and its decoded name, and 64 bit
+// quantities are emitted as unsigned integers, which may exceed 2^53.
+package model
+
+// SchemaVersion is the version of the output schema, reported in every Envelope.
+const SchemaVersion = 1
+
+// Envelope wraps the result of one command on one file.
+type Envelope struct {
+	SchemaVersion int    `json:"schema_version"`
+	Command       string `json:"command"`
+	File          string `json:"file"`
+	Data          any    `json:"data,omitempty"`
+	Error         string `json:"error,omitempty"`
+}
+
+// NewEnvelope wraps the data produced by command for file.
+func NewEnvelope(command, file string, data any) *Envelope {
+	return &Envelope{
+		SchemaVersion: SchemaVersion,
+		Command:       command,
+		File:          file,
+		Data:          data,
+	}
+}
+
+// NewErrorEnvelope reports that command failed on file.
+func NewErrorEnvelope(command, file string, err error) *Envelope {
+	return &Envelope{
+		SchemaVersion: SchemaVersion,
+		Command:       command,
+		File:          file,
+		Error:         err.Error(),
+	}
+}
+
+// Demangler is implemented by views that carry symbol names. Demangle rewrites every name
+// through fn, which must return the name unchanged when it cannot demangle it.
+type Demangler interface {
+	Demangle(fn func(string) string)
+}
+
+// Named is a raw enumeration value along with its decoded name.
+type Named struct {
+	Value uint64 `json:"value"`
+	Name  string `json:"name"`
+}
+
+// Flags is a raw bitmask along with the names of its set bits.
+type Flags struct {
+	Value uint64   `json:"value"`
+	Names []string `json:"names"`
+}
+
+// newFlags builds Flags, keeping Names an empty list rather than null when no bit is set.
+func newFlags(value uint64, names []string) Flags {
+	if names == nil {
+		names = []string{}
+	}
+	return Flags{Value: value, Names: names}
+}
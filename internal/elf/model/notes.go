@@ -0,0 +1,246 @@
+package model
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/yourpwnguy/strix/pkg/elf/types"
+)
+
+// ABITag is the decoded NT_GNU_ABI_TAG descriptor.
+type ABITag struct {
+	OS    Named  `json:"os"`
+	ABI   string `json:"abi"`
+	Major uint32 `json:"major"`
+	Minor uint32 `json:"minor"`
+	Patch uint32 `json:"patch"`
+}
+
+// Property is a single program property of a NT_GNU_PROPERTY_TYPE_0 note.
+type Property struct {
+	Type        Named  `json:"type"`
+	Value       uint64 `json:"value"`
+	Description string `json:"description"`
+	Data        string `json:"data"`
+}
+
+// ProcessStatus is the decoded NT_PRSTATUS descriptor.
+type ProcessStatus struct {
+	Pid    int32  `json:"pid"`
+	Signal uint16 `json:"signal"`
+}
+
+// ProcessInfo is the decoded NT_PRPSINFO descriptor.
+type ProcessInfo struct {
+	Pid   int32  `json:"pid"`
+	Name  string `json:"name"`
+	Args  string `json:"args"`
+	State string `json:"state"`
+}
+
+// AuxEntry is a single auxiliary vector entry of a NT_AUXV note.
+type AuxEntry struct {
+	Type  Named  `json:"type"`
+	Value uint64 `json:"value"`
+}
+
+// SignalInfo is the decoded NT_SIGINFO descriptor.
+type SignalInfo struct {
+	Signo int32 `json:"signo"`
+	Errno int32 `json:"errno"`
+	Code  int32 `json:"code"`
+}
+
+// MappedFile is a single file backed mapping of a NT_FILE note.
+type MappedFile struct {
+	Start  uint64 `json:"start"`
+	End    uint64 `json:"end"`
+	Offset uint64 `json:"offset"`
+	Path   string `json:"path"`
+}
+
+// Note is a single note. At most one of the decoded fields is set, depending on the note;
+// Desc holds the raw descriptor as hex when none is.
+type Note struct {
+	Index  int    `json:"index"`
+	Owner  string `json:"owner"`
+	Type   Named  `json:"type"`
+	Size   int    `json:"size"`
+	Source string `json:"source"`
+	Offset uint64 `json:"offset"`
+
+	BuildID         string          `json:"build_id,omitempty"`
+	GoBuildID       string          `json:"go_build_id,omitempty"`
+	ABITag          *ABITag         `json:"abi_tag,omitempty"`
+	Properties      []Property      `json:"properties,omitempty"`
+	PackageMetadata json.RawMessage `json:"package_metadata,omitempty"`
+	ProcessStatus   *ProcessStatus  `json:"process_status,omitempty"`
+	ProcessInfo     *ProcessInfo    `json:"process_info,omitempty"`
+	Auxv            []AuxEntry      `json:"auxv,omitempty"`
+	SignalInfo      *SignalInfo     `json:"signal_info,omitempty"`
+	Files           []MappedFile    `json:"files,omitempty"`
+	Desc            string          `json:"desc,omitempty"`
+}
+
+// Notes is the list of notes (notes command).
+type Notes struct {
+	Notes []Note `json:"notes"`
+}
+
+// NewNotes builds the notes view.
+func NewNotes(ehdr *types.Elf64_Ehdr, notes []types.Note) *Notes {
+	out := &Notes{Notes: make([]Note, len(notes))}
+
+	for i := range notes {
+		n := &notes[i]
+
+		note := Note{
+			Index:  i,
+			Owner:  n.Owner,
+			Type:   Named{uint64(n.Type), types.GetNoteType(n.Owner, n.Type, ehdr.E_type)},
+			Size:   len(n.Desc),
+			Source: n.Source,
+			Offset: n.Offset,
+		}
+
+		switch v := n.Value.(type) {
+		case types.BuildID:
+			note.BuildID = v.String()
+
+		case types.GoBuildID:
+			note.GoBuildID = string(v)
+
+		case *types.ABITag:
+			note.ABITag = &ABITag{
+				OS:    Named{uint64(v.OS), types.GetABITagOS(v.OS)},
+				ABI:   fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch),
+				Major: v.Major,
+				Minor: v.Minor,
+				Patch: v.Patch,
+			}
+
+		case types.GNUProperties:
+			note.Properties = make([]Property, len(v))
+			for j := range v {
+				note.Properties[j] = Property{
+					Type:        Named{uint64(v[j].Type), types.GetGNUPropertyType(ehdr.E_machine, v[j].Type)},
+					Value:       v[j].Value,
+					Description: types.GetGNUPropertyValue(ehdr.E_machine, &v[j]),
+					Data:        hex.EncodeToString(v[j].Data),
+				}
+			}
+
+		case types.PackageMetadata:
+			// Embedded as JSON when it is valid, as a string otherwise
+			if json.Valid([]byte(v)) {
+				note.PackageMetadata = json.RawMessage(v)
+			} else {
+				note.PackageMetadata, _ = json.Marshal(string(v))
+			}
+
+		case *types.ProcessStatus:
+			note.ProcessStatus = &ProcessStatus{Pid: v.Pid, Signal: v.Signal}
+
+		case *types.ProcessInfo:
+			note.ProcessInfo = &ProcessInfo{
+				Pid:   v.Pid,
+				Name:  v.Name,
+				Args:  v.Args,
+				State: string(rune(v.SState)),
+			}
+
+		case types.AuxVector:
+			note.Auxv = make([]AuxEntry, len(v))
+			for j := range v {
+				note.Auxv[j] = AuxEntry{
+					Type:  Named{v[j].Type, types.GetAuxType(v[j].Type)},
+					Value: v[j].Value,
+				}
+			}
+
+		case *types.SignalInfo:
+			note.SignalInfo = &SignalInfo{Signo: v.Signo, Errno: v.Errno, Code: v.Code}
+
+		case types.MappedFiles:
+			note.Files = make([]MappedFile, len(v))
+			for j := range v {
+				note.Files[j] = MappedFile(v[j])
+			}
+
+		default:
+			note.Desc = hex.EncodeToString(n.Desc)
+		}
+
+		out.Notes[i] = note
+	}
+
+	return out
+}
+
+// Table lists one row per note, with the decoded descriptor summed up on a single line.
+func (v *Notes) Table() *Table {
+	t := &Table{Columns: []string{"source", "index", "owner", "type", "size", "offset", "description"}}
+
+	for i := range v.Notes {
+		n := &v.Notes[i]
+		t.Rows = append(t.Rows, []string{
+			n.Source,
+			decCell(n.Index),
+			n.Owner,
+			n.Type.Name,
+			decCell(n.Size),
+			hexCell(n.Offset),
+			n.summary(),
+		})
+	}
+
+	return t
+}
+
+// summary renders the decoded descriptor on a single line, or the raw hex when there is none.
+func (n *Note) summary() string {
+	var parts []string
+
+	switch {
+	case n.BuildID != "":
+		return n.BuildID
+
+	case n.GoBuildID != "":
+		return n.GoBuildID
+
+	case n.ABITag != nil:
+		return n.ABITag.OS.Name + " " + n.ABITag.ABI
+
+	case n.Properties != nil:
+		for i := range n.Properties {
+			parts = append(parts, n.Properties[i].Type.Name+": "+n.Properties[i].Description)
+		}
+
+	case n.PackageMetadata != nil:
+		return string(n.PackageMetadata)
+
+	case n.ProcessStatus != nil:
+		return fmt.Sprintf("pid %d, signal %d", n.ProcessStatus.Pid, n.ProcessStatus.Signal)
+
+	case n.ProcessInfo != nil:
+		return fmt.Sprintf("pid %d, name %s, state %s, args %s", n.ProcessInfo.Pid, n.ProcessInfo.Name, n.ProcessInfo.State, n.ProcessInfo.Args)
+
+	case n.SignalInfo != nil:
+		return fmt.Sprintf("signal %d, errno %d, code %d", n.SignalInfo.Signo, n.SignalInfo.Errno, n.SignalInfo.Code)
+
+	case n.Auxv != nil:
+		for i := range n.Auxv {
+			parts = append(parts, fmt.Sprintf("%s=%#x", n.Auxv[i].Type.Name, n.Auxv[i].Value))
+		}
+
+	case n.Files != nil:
+		return fmt.Sprintf("%d mapped files", len(n.Files))
+
+	default:
+		return n.Desc
+	}
+
+	return strings.Join(parts, "; ")
+}
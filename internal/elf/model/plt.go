@@ -0,0 +1,91 @@
+package model
+
+import (
+	"fmt"
+
+	"github.com/yourpwnguy/strix/internal/elf/plt"
+	"github.com/yourpwnguy/strix/pkg/elf/types"
+)
+
+// PLTEntry is one PLT stub and the function it calls. Lazy stubs only hand their slot to
+// the resolver and are never the target of a call, Landing marks an endbr64 or bti c pad.
+type PLTEntry struct {
+	Address uint64 `json:"address"`
+	Size    uint64 `json:"size"`
+	Section string `json:"section"`
+	Slot    uint64 `json:"slot"`
+	Type    *Named `json:"type,omitempty"`
+	Symbol  string `json:"symbol,omitempty"`
+	Version string `json:"version,omitempty"`
+	Lazy    bool   `json:"lazy"`
+	Landing bool   `json:"landing_pad"`
+}
+
+// PLT is the list of PLT stubs of a file (plt command).
+type PLT struct {
+	Entries []PLTEntry `json:"entries"`
+}
+
+// NewPLT builds the PLT view.
+func NewPLT(report *plt.Report) *PLT {
+	out := &PLT{Entries: make([]PLTEntry, len(report.Entries))}
+
+	for i := range report.Entries {
+		e := &report.Entries[i]
+
+		entry := PLTEntry{
+			Address: e.Address,
+			Size:    e.Size,
+			Section: e.Section,
+			Slot:    e.Slot,
+			Symbol:  e.Name,
+			Version: e.Version,
+			Lazy:    e.Lazy,
+			Landing: e.Landing,
+		}
+		if e.RelocType != 0 {
+			entry.Type = &Named{uint64(e.RelocType), types.GetRelocType(report.Machine, e.RelocType)}
+		}
+
+		out.Entries[i] = entry
+	}
+
+	return out
+}
+
+// Table lists one row per stub.
+func (v *PLT) Table() *Table {
+	t := &Table{Columns: []string{"address", "size", "section", "slot", "type", "symbol", "version", "lazy", "landing_pad"}}
+
+	for i := range v.Entries {
+		e := &v.Entries[i]
+
+		relocType := ""
+		if e.Type != nil {
+			relocType = e.Type.Name
+		}
+
+		t.Rows = append(t.Rows, []string{
+			hexCell(e.Address),
+			decCell(e.Size),
+			e.Section,
+			hexCell(e.Slot),
+			relocType,
+			e.Symbol,
+			e.Version,
+			fmt.Sprintf("%t", e.Lazy),
+			fmt.Sprintf("%t", e.Landing),
+		})
+	}
+
+	return t
+}
+
+// Demangle rewrites the names of the imported functions.
+func (v *PLT) Demangle(fn func(string) string) {
+	for i := range v.Entries {
+		if v.Entries[i].Symbol != "" {
+			v.Entries[i].Symbol = fn(v.Entries[i].Symbol)
+		}
+	}
+}
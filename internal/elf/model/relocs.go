@@ -0,0 +1,126 @@
+package model
+
+import (
+	"github.com/yourpwnguy/strix/pkg/elf/types"
+)
+
+// Relocation is a single relocation entry. Addend is the explicit addend for RELA entries
+// and the value stored at the patched location otherwise. Target is only present when it
+// can be computed (see types.Relocation.Target).
+type Relocation struct {
+	Index          int     `json:"index"`
+	Offset         uint64  `json:"offset"`
+	Type           Named   `json:"type"`
+	SymbolIndex    uint32  `json:"symbol_index"`
+	Symbol         string  `json:"symbol,omitempty"`
+	SymbolValue    *uint64 `json:"symbol_value,omitempty"`
+	Addend         int64   `json:"addend"`
+	ExplicitAddend bool    `json:"explicit_addend"`
+	Target         *uint64 `json:"target,omitempty"`
+}
+
+// RelocationTable is one REL, RELA or RELR table.
+type RelocationTable struct {
+	Name        string       `json:"name"`
+	Kind        Named        `json:"kind"`
+	Offset      uint64       `json:"offset"`
+	Relocations []Relocation `json:"relocations"`
+}
+
+// Relocations is the list of relocation tables (relocs command).
+type Relocations struct {
+	Tables []RelocationTable `json:"tables"`
+}
+
+// NewRelocations builds the relocation view. Unnamed section symbols are labelled with
+// the name of their section.
+func NewRelocations(ehdr *types.Elf64_Ehdr, tables []types.RelocationTable, shdr []types.Elf64_Shdr, shstrtab []byte) *Relocations {
+	out := &Relocations{Tables: make([]RelocationTable, len(tables))}
+
+	for t := range tables {
+		table := &tables[t]
+
+		relocs := make([]Relocation, len(table.Relocs))
+		for i := range table.Relocs {
+			r := &table.Relocs[i]
+
+			reloc := Relocation{
+				Index:          i,
+				Offset:         r.Offset,
+				Type:           Named{uint64(r.Type), types.GetRelocType(ehdr.E_machine, r.Type)},
+				SymbolIndex:    r.SymIndex,
+				Addend:         r.Addend,
+				ExplicitAddend: r.HasAddend,
+			}
+
+			if r.Symbol != nil {
+				value := r.Symbol.St_value
+				reloc.SymbolValue = &value
+
+				reloc.Symbol = r.Symbol.Name
+				if reloc.Symbol == "" && r.Symbol.Type() == types.STT_SECTION && r.Symbol.Shndx < uint32(len(shdr)) {
+					reloc.Symbol = types.GetString(shstrtab, shdr[r.Symbol.Shndx].Sh_name)
+				}
+			}
+
+			if target, ok := r.Target(ehdr); ok {
+				reloc.Target = &target
+			}
+
+			relocs[i] = reloc
+		}
+
+		out.Tables[t] = RelocationTable{
+			Name:        table.Name,
+			Kind:        Named{uint64(table.Kind), types.GetShType(table.Kind)},
+			Offset:      table.Offset,
+			Relocations: relocs,
+		}
+	}
+
+	return out
+}
+
+// Table lists the relocations of every table, each row naming the table it comes from.
+// Symbol value and target are left empty when they are absent.
+func (v *Relocations) Table() *Table {
+	t := &Table{Columns: []string{"table", "index", "offset", "type", "symbol_value", "symbol", "addend", "target"}}
+
+	for i := range v.Tables {
+		table := &v.Tables[i]
+		for j := range table.Relocations {
+			r := &table.Relocations[j]
+
+			var value, target string
+			if r.SymbolValue != nil {
+				value = hexCell(*r.SymbolValue)
+			}
+			if r.Target != nil {
+				target = hexCell(*r.Target)
+			}
+
+			t.Rows = append(t.Rows, []string{
+				table.Name,
+				decCell(r.Index),
+				hexCell(r.Offset),
+				r.Type.Name,
+				value,
+				r.Symbol,
+				decCell(r.Addend),
+				target,
+			})
+		}
+	}
+
+	return t
+}
+
+// Demangle rewrites the names of the symbols the relocations refer to.
+func (v *Relocations) Demangle(fn func(string) string) {
+	for i := range v.Tables {
+		for j := range v.Tables[i].Relocations {
+			r := &v.Tables[i].Relocations[j]
+			r.Symbol = fn(r.Symbol)
+		}
+	}
+}
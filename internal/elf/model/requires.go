@@ -0,0 +1,88 @@
+package model
+
+import (
+	"strings"
+
+	"github.com/yourpwnguy/strix/internal/elf/requires"
+)
+
+// Requirement is the newest version of one family required from a library. Limit is the
+// highest version allowed by the command line, and Exceeds is set when Version is above it.
+type Requirement struct {
+	Library string   `json:"library"`
+	Family  string   `json:"family"`
+	Version string   `json:"version"`
+	Name    string   `json:"name"`
+	Symbols []string `json:"symbols"`
+	Limit   string   `json:"limit,omitempty"`
+	Exceeds bool     `json:"exceeds"`
+}
+
+// Requires is the list of newest required versions of one file (requires command).
+type Requires struct {
+	Requirements []Requirement `json:"requirements"`
+	Exceeded     bool          `json:"exceeded"`
+}
+
+// NewRequires builds the requirement view, checking every family against limits
+// (family name to highest allowed version).
+func NewRequires(report *requires.Report, limits map[string]string) *Requires {
+	out := &Requires{Requirements: make([]Requirement, len(report.Requirements))}
+
+	for i := range report.Requirements {
+		r := &report.Requirements[i]
+
+		symbols := r.Symbols
+		if symbols == nil {
+			symbols = []string{}
+		}
+
+		req := Requirement{
+			Library: r.Library,
+			Family:  r.Family,
+			Version: r.Version,
+			Name:    r.Name,
+			Symbols: symbols,
+		}
+
+		if limit, ok := limits[r.Family]; ok && r.Version != "" {
+			req.Limit = limit
+			req.Exceeds = requires.Compare(r.Version, limit) > 0
+			out.Exceeded = out.Exceeded || req.Exceeds
+		}
+
+		out.Requirements[i] = req
+	}
+
+	return out
+}
+
+// Table lists one row per library and family.
+func (v *Requires) Table() *Table {
+	t := &Table{Columns: []string{"library", "family", "version", "name", "limit", "exceeds", "symbols"}}
+
+	for i := range v.Requirements {
+		r := &v.Requirements[i]
+
+		exceeds := ""
+		if r.Limit != "" {
+			exceeds = "false"
+			if r.Exceeds {
+				exceeds = "true"
+			}
+		}
+
+		t.Rows = append(t.Rows, []string{r.Library, r.Family, r.Version, r.Name, r.Limit, exceeds, strings.Join(r.Symbols, " ")})
+	}
+
+	return t
+}
+
+// Demangle rewrites the names of the symbols behind every requirement.
+func (v *Requires) Demangle(fn func(string) string) {
+	for i := range v.Requirements {
+		for j, name := range v.Requirements[i].Symbols {
+			v.Requirements[i].Symbols[j] = fn(name)
+		}
+	}
+}
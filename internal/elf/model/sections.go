@@ -0,0 +1,88 @@
+package model
+
+import (
+	"strings"
+
+	"github.com/yourpwnguy/strix/pkg/elf/types"
+)
+
+// Section is a single section header.
+type Section struct {
+	Index     int    `json:"index"`
+	Name      string `json:"name"`
+	Type      Named  `json:"type"`
+	Flags     Flags  `json:"flags"`
+	Addr      uint64 `json:"addr"`
+	Offset    uint64 `json:"offset"`
+	Size      uint64 `json:"size"`
+	Entsize   uint64 `json:"entsize"`
+	Link      uint32 `json:"link"`
+	Info      uint32 `json:"info"`
+	Addralign uint64 `json:"addralign"`
+}
+
+// SectionHeaders is the section header table (shdr command).
+type SectionHeaders struct {
+	Offset             uint64    `json:"offset"`
+	StringTableIndex   uint16    `json:"string_table_index"`   // e_shstrndx, SHN_XINDEX when it does not fit
+	StringTableSection uint32    `json:"string_table_section"` // Section index e_shstrndx resolves to
+	Sections           []Section `json:"sections"`
+}
+
+// NewSectionHeaders builds the section header view with names resolved through .shstrtab.
+func NewSectionHeaders(ehdr *types.Elf64_Ehdr, shdr []types.Elf64_Shdr, shstrtab []byte) *SectionHeaders {
+	out := &SectionHeaders{
+		Offset:             ehdr.E_shoff,
+		StringTableIndex:   ehdr.E_shstrndx,
+		StringTableSection: uint32(ehdr.E_shstrndx),
+		Sections:           make([]Section, len(shdr)),
+	}
+
+	// An index past SHN_LORESERVE is kept in sh_link of section 0
+	if ehdr.E_shstrndx == types.SHN_XINDEX && len(shdr) > 0 {
+		out.StringTableSection = shdr[0].Sh_link
+	}
+
+	for i := range shdr {
+		sh := &shdr[i]
+		out.Sections[i] = Section{
+			Index:     i,
+			Name:      types.GetString(shstrtab, sh.Sh_name),
+			Type:      Named{uint64(sh.Sh_type), types.GetShType(sh.Sh_type)},
+			Flags:     newFlags(sh.Sh_flags, types.ShFlagNames(sh.Sh_flags)),
+			Addr:      sh.Sh_addr,
+			Offset:    sh.Sh_offset,
+			Size:      sh.Sh_size,
+			Entsize:   sh.Sh_entsize,
+			Link:      sh.Sh_link,
+			Info:      sh.Sh_info,
+			Addralign: sh.Sh_addralign,
+		}
+	}
+
+	return out
+}
+
+// Table lists one row per section.
+func (v *SectionHeaders) Table() *Table {
+	t := &Table{Columns: []string{"index", "name", "type", "flags", "addr", "offset", "size", "entsize", "link", "info", "addralign"}}
+
+	for i := range v.Sections {
+		s := &v.Sections[i]
+		t.Rows = append(t.Rows, []string{
+			decCell(s.Index),
+			s.Name,
+			s.Type.Name,
+			strings.Join(s.Flags.Names, " "),
+			hexCell(s.Addr),
+			hexCell(s.Offset),
+			hexCell(s.Size),
+			hexCell(s.Entsize),
+			decCell(s.Link),
+			decCell(s.Info),
+			decCell(s.Addralign),
+		})
+	}
+
+	return t
+}
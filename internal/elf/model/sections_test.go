@@ -0,0 +1,27 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/yourpwnguy/strix/pkg/elf/types"
+)
+
+func TestNewSectionHeadersStringTable(t *testing.T) {
+	shdr := []types.Elf64_Shdr{{Sh_link: 3}, {}, {}, {Sh_type: types.SHT_STRTAB}}
+
+	tests := []struct {
+		name     string
+		shstrndx uint16
+		want     uint32
+	}{
+		{"plain", 2, 2},
+		{"escaped into section 0", types.SHN_XINDEX, 3},
+	}
+
+	for _, tt := range tests {
+		v := NewSectionHeaders(&types.Elf64_Ehdr{E_shstrndx: tt.shstrndx}, shdr, nil)
+		if v.StringTableIndex != tt.shstrndx || v.StringTableSection != tt.want {
+			t.Errorf("%s: string table index %d (%d), want %d (%d)", tt.name, v.StringTableIndex, v.StringTableSection, tt.shstrndx, tt.want)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package model
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/yourpwnguy/strix/pkg/elf/types"
+)
+
+// Segment is a single program header with the sections it maps. Sections without a name
+// are listed by index, as <index>.
+type Segment struct {
+	Index    int      `json:"index"`
+	Type     Named    `json:"type"`
+	Flags    Flags    `json:"flags"`
+	Offset   uint64   `json:"offset"`
+	Vaddr    uint64   `json:"vaddr"`
+	Paddr    uint64   `json:"paddr"`
+	Filesz   uint64   `json:"filesz"`
+	Memsz    uint64   `json:"memsz"`
+	Align    uint64   `json:"align"`
+	Sections []string `json:"sections"`
+}
+
+// ProgramHeaders is the program header table (phdr command), along with the file type,
+// entry point and interpreter the segments describe.
+type ProgramHeaders struct {
+	Type        Named     `json:"type"`
+	Entry       uint64    `json:"entry"`
+	Interpreter string    `json:"interpreter,omitempty"`
+	Offset      uint64    `json:"offset"`
+	Segments    []Segment `json:"segments"`
+}
+
+// NewProgramHeaders builds the program header view, resolving the section to segment mapping.
+func NewProgramHeaders(ehdr *types.Elf64_Ehdr, phdr []types.Elf64_Phdr, shdr []types.Elf64_Shdr, shstrtab []byte, data []byte) *ProgramHeaders {
+	mapping := types.MapSectionsToSegments(phdr, shdr)
+
+	out := &ProgramHeaders{
+		Type:     Named{uint64(ehdr.E_type), types.GetEType(ehdr.E_type, types.HasInterpreter(phdr))},
+		Entry:    ehdr.E_entry,
+		Offset:   ehdr.E_phoff,
+		Segments: make([]Segment, len(phdr)),
+	}
+
+	if types.HasInterpreter(phdr) {
+		out.Interpreter = types.GetInterpreter(phdr, data)
+	}
+
+	for i := range phdr {
+		ph := &phdr[i]
+
+		sections := make([]string, 0, len(mapping[i]))
+		for _, idx := range mapping[i] {
+			name := types.GetString(shstrtab, shdr[idx].Sh_name)
+			if name == "" {
+				name = fmt.Sprintf("<%d>", idx)
+			}
+			sections = append(sections, name)
+		}
+
+		out.Segments[i] = Segment{
+			Index:    i,
+			Type:     Named{uint64(ph.P_type), types.GetPType(ph.P_type)},
+			Flags:    newFlags(uint64(ph.P_flags), types.PFlagNames(ph.P_flags)),
+			Offset:   ph.P_offset,
+			Vaddr:    ph.P_vaddr,
+			Paddr:    ph.P_paddr,
+			Filesz:   ph.P_filesz,
+			Memsz:    ph.P_memsz,
+			Align:    ph.P_align,
+			Sections: sections,
+		}
+	}
+
+	return out
+}
+
+// Table lists one row per segment.
+func (v *ProgramHeaders) Table() *Table {
+	t := &Table{Columns: []string{"index", "type", "flags", "offset", "vaddr", "paddr", "filesz", "memsz", "align", "sections"}}
+
+	for i := range v.Segments {
+		s := &v.Segments[i]
+		t.Rows = append(t.Rows, []string{
+			decCell(s.Index),
+			s.Type.Name,
+			strings.Join(s.Flags.Names, ""),
+			hexCell(s.Offset),
+			hexCell(s.Vaddr),
+			hexCell(s.Paddr),
+			hexCell(s.Filesz),
+			hexCell(s.Memsz),
+			hexCell(s.Align),
+			strings.Join(s.Sections, " "),
+		})
+	}
+
+	return t
+}
@@ -0,0 +1,37 @@
+package model
+
+// FoundString is a printable string found in the file. Address is only present when a PT_LOAD
+// segment maps it, and Section is empty when no section header covers it.
+type FoundString struct {
+	Offset   uint64  `json:"offset"`
+	Address  *uint64 `json:"address,omitempty"`
+	Section  string  `json:"section"`
+	Encoding string  `json:"encoding"`
+	Value    string  `json:"value"`
+}
+
+// Strings is the list of printable strings extracted from the file (strings command).
+// Regions names the sections or segments that were scanned.
+type Strings struct {
+	MinLength int           `json:"min_length"`
+	Regions   []string      `json:"regions"`
+	Strings   []FoundString `json:"strings"`
+}
+
+// Table lists one row per string.
+func (v *Strings) Table() *Table {
+	t := &Table{Columns: []string{"offset", "address", "section", "encoding", "value"}}
+
+	for i := range v.Strings {
+		s := &v.Strings[i]
+
+		var address string
+		if s.Address != nil {
+			address = hexCell(*s.Address)
+		}
+
+		t.Rows = append(t.Rows, []string{hexCell(s.Offset), address, s.Section, s.Encoding, s.Value})
+	}
+
+	return t
+}
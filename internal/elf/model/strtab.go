@@ -0,0 +1,65 @@
+package model
+
+import (
+	"github.com/yourpwnguy/strix/pkg/elf/types"
+)
+
+// StringEntry is one NUL-terminated string of a string table. Index is its byte offset in the
+// table, the value sh_name, st_name and the string-valued dynamic tags refer to it by.
+type StringEntry struct {
+	Index uint64 `json:"index"`
+	Value string `json:"value"`
+}
+
+// StringTable is the content of one string table section.
+type StringTable struct {
+	Section int           `json:"section"`
+	Name    string        `json:"name"`
+	Offset  uint64        `json:"offset"`
+	Size    uint64        `json:"size"`
+	Entries []StringEntry `json:"entries"`
+}
+
+// StringTables is the list of dumped string tables (strtab command).
+type StringTables struct {
+	Tables []StringTable `json:"tables"`
+}
+
+// NewStringTable splits the bytes of a string table into its entries. Empty strings, such as
+// the one every table starts with, are left out. A final string missing its NUL is still listed.
+func NewStringTable(idx int, name string, sh *types.Elf64_Shdr, data []byte) StringTable {
+	out := StringTable{
+		Section: idx,
+		Name:    name,
+		Offset:  sh.Sh_offset,
+		Size:    sh.Sh_size,
+		Entries: []StringEntry{},
+	}
+
+	start := 0
+	for i := 0; i <= len(data); i++ {
+		if i < len(data) && data[i] != 0 {
+			continue
+		}
+		if i > start {
+			out.Entries = append(out.Entries, StringEntry{uint64(start), string(data[start:i])})
+		}
+		start = i + 1
+	}
+
+	return out
+}
+
+// Table lists the entries of every table, each row naming the table it comes from.
+func (v *StringTables) Table() *Table {
+	t := &Table{Columns: []string{"table", "index", "value"}}
+
+	for i := range v.Tables {
+		table := &v.Tables[i]
+		for _, e := range table.Entries {
+			t.Rows = append(t.Rows, []string{table.Name, decCell(e.Index), e.Value})
+		}
+	}
+
+	return t
+}
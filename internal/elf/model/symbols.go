@@ -0,0 +1,128 @@
+package model
+
+import (
+	"github.com/yourpwnguy/strix/pkg/elf/types"
+)
+
+// Symbol is a single symbol table entry. Ndx is the section index column as readelf shows it.
+// Version is the symbol version of a dynamic symbol; DefaultVersion marks a definition that
+// is the default one (name@@VER), references and hidden definitions are shown as name@VER.
+type Symbol struct {
+	Index      uint32 `json:"index"`
+	Name       string `json:"name"`
+	Value      uint64 `json:"value"`
+	Size       uint64 `json:"size"`
+	Type       Named  `json:"type"`
+	Bind       Named  `json:"bind"`
+	Visibility Named  `json:"visibility"`
+	Shndx      uint32 `json:"shndx"`
+	Ndx        string `json:"ndx"`
+	Section    string `json:"section"`
+
+	Version        string `json:"version,omitempty"`
+	VersionFile    string `json:"version_file,omitempty"`
+	DefaultVersion bool   `json:"default_version,omitempty"`
+}
+
+// VersionedName is the name with its version suffix, as the dynamic linker matches it.
+func (s *Symbol) VersionedName() string {
+	switch {
+	case s.Version == "":
+		return s.Name
+	case s.DefaultVersion:
+		return s.Name + "@@" + s.Version
+	default:
+		return s.Name + "@" + s.Version
+	}
+}
+
+// SymbolTable is one symbol table. Total counts every entry, even those a filter left out.
+type SymbolTable struct {
+	Name    string   `json:"name"`
+	Total   int      `json:"total"`
+	Symbols []Symbol `json:"symbols"`
+}
+
+// Symbols is the list of symbol tables (syms command).
+type Symbols struct {
+	Tables []SymbolTable `json:"tables"`
+}
+
+// NewSymbolTable builds the view of one symbol table. Section is the name of the section the
+// symbol is defined in, or the readelf label of the special index (UND, ABS, COM). Versions
+// are only given for the dynamic symbol table, which .gnu.version is indexed by.
+func NewSymbolTable(name string, total int, syms []types.Symbol, versions *types.Versions, shdr []types.Elf64_Shdr, shstrtab []byte) SymbolTable {
+	out := SymbolTable{
+		Name:    name,
+		Total:   total,
+		Symbols: make([]Symbol, len(syms)),
+	}
+
+	for i := range syms {
+		sym := &syms[i]
+
+		// Regular and extended indices name a real section
+		section := types.GetSymSectionIndex(sym.St_shndx, sym.Shndx)
+		regular := sym.St_shndx < types.SHN_LORESERVE || sym.St_shndx == types.SHN_XINDEX
+		if !sym.IsUndefined() && regular && sym.Shndx < uint32(len(shdr)) {
+			section = types.GetString(shstrtab, shdr[sym.Shndx].Sh_name)
+		}
+
+		out.Symbols[i] = Symbol{
+			Index:      sym.Index,
+			Name:       sym.Name,
+			Value:      sym.St_value,
+			Size:       sym.St_size,
+			Type:       Named{uint64(sym.Type()), types.GetSymType(sym.Type())},
+			Bind:       Named{uint64(sym.Bind()), types.GetSymBind(sym.Bind())},
+			Visibility: Named{uint64(sym.Visibility()), types.GetSymVisibility(sym.Visibility())},
+			Shndx:      sym.Shndx,
+			Ndx:        types.GetSymSectionIndex(sym.St_shndx, sym.Shndx),
+			Section:    section,
+		}
+
+		// The absolute symbol naming a version definition is not itself versioned
+		if ver, ok := versions.Symbol(sym.Index); ok && (ver.Name != sym.Name || sym.St_shndx != types.SHN_ABS) {
+			out.Symbols[i].Version = ver.Name
+			out.Symbols[i].VersionFile = ver.File
+			out.Symbols[i].DefaultVersion = !ver.Hidden && !sym.IsUndefined()
+		}
+	}
+
+	return out
+}
+
+// Table lists the symbols of every table, each row naming the table it comes from.
+func (v *Symbols) Table() *Table {
+	t := &Table{Columns: []string{"table", "index", "value", "size", "type", "bind", "visibility", "ndx", "name"}}
+
+	for i := range v.Tables {
+		table := &v.Tables[i]
+		for j := range table.Symbols {
+			s := &table.Symbols[j]
+			t.Rows = append(t.Rows, []string{
+				table.Name,
+				decCell(s.Index),
+				hexCell(s.Value),
+				decCell(s.Size),
+				s.Type.Name,
+				s.Bind.Name,
+				s.Visibility.Name,
+				s.Ndx,
+				s.VersionedName(),
+			})
+		}
+	}
+
+	return t
+}
+
+// Demangle rewrites the names of every symbol.
+func (v *Symbols) Demangle(fn func(string) string) {
+	for i := range v.Tables {
+		for j := range v.Tables[i].Symbols {
+			s := &v.Tables[i].Symbols[j]
+			s.Name = fn(s.Name)
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package model
+
+import (
+	"fmt"
+)
+
+// Table is the flat tabular form of a view, used by the CSV and Markdown renderers.
+// Cells are preformatted: addresses and offsets in hex, counts in decimal and enumerations
+// by name, since the raw values are only a JSON output away.
+type Table struct {
+	Columns []string
+	Rows    [][]string
+}
+
+// Tabular is implemented by every view that has a tabular form.
+type Tabular interface {
+	Table() *Table
+}
+
+// hexCell formats an address, offset or size.
+func hexCell(v uint64) string {
+	return fmt.Sprintf("%#x", v)
+}
+
+// decCell formats a count or an index.
+func decCell[T ~int | ~int32 | ~int64 | ~uint8 | ~uint16 | ~uint32 | ~uint64](v T) string {
+	return fmt.Sprintf("%d", v)
+}
@@ -0,0 +1,120 @@
+package model
+
+import (
+	"strings"
+
+	"github.com/yourpwnguy/strix/pkg/elf/types"
+)
+
+// VersionDefinition is a version node defined by the file. The BASE entry names the file itself.
+type VersionDefinition struct {
+	Index   uint16   `json:"index"`
+	Flags   Flags    `json:"flags"`
+	Hash    uint32   `json:"hash"`
+	Name    string   `json:"name"`
+	Parents []string `json:"parents"`
+}
+
+// VersionRequirement is a version node required from a dependency.
+type VersionRequirement struct {
+	Index uint16 `json:"index"`
+	Flags Flags  `json:"flags"`
+	Hash  uint32 `json:"hash"`
+	Name  string `json:"name"`
+}
+
+// VersionNeed lists the version nodes required from one library.
+type VersionNeed struct {
+	File     string               `json:"file"`
+	Versions []VersionRequirement `json:"versions"`
+}
+
+// Versions is the symbol versioning information of the file (versions command).
+// Symbols counts the entries of .gnu.version.
+type Versions struct {
+	Definitions []VersionDefinition `json:"definitions"`
+	Needs       []VersionNeed       `json:"needs"`
+	Symbols     int                 `json:"symbols"`
+}
+
+// NewVersions builds the symbol versioning view.
+func NewVersions(v *types.Versions) *Versions {
+	out := &Versions{
+		Definitions: make([]VersionDefinition, len(v.Definitions)),
+		Needs:       make([]VersionNeed, len(v.Needs)),
+		Symbols:     len(v.Symbols),
+	}
+
+	for i := range v.Definitions {
+		def := &v.Definitions[i]
+
+		parents := def.Parents
+		if parents == nil {
+			parents = []string{}
+		}
+
+		out.Definitions[i] = VersionDefinition{
+			Index:   def.Index,
+			Flags:   newFlags(uint64(def.Flags), types.VerFlagNames(def.Flags)),
+			Hash:    def.Hash,
+			Name:    def.Name,
+			Parents: parents,
+		}
+	}
+
+	for i := range v.Needs {
+		need := &v.Needs[i]
+
+		versions := make([]VersionRequirement, len(need.Versions))
+		for j := range need.Versions {
+			req := &need.Versions[j]
+			versions[j] = VersionRequirement{
+				Index: req.Index,
+				Flags: newFlags(uint64(req.Flags), types.VerFlagNames(req.Flags)),
+				Hash:  req.Hash,
+				Name:  req.Name,
+			}
+		}
+
+		out.Needs[i] = VersionNeed{File: need.File, Versions: versions}
+	}
+
+	return out
+}
+
+// Table lists one row per version node, defined ones first. The file column is empty for
+// the versions the file defines.
+func (v *Versions) Table() *Table {
+	t := &Table{Columns: []string{"kind", "library", "index", "flags", "hash", "name", "parents"}}
+
+	for i := range v.Definitions {
+		def := &v.Definitions[i]
+		t.Rows = append(t.Rows, []string{
+			"definition",
+			"",
+			decCell(def.Index),
+			strings.Join(def.Flags.Names, " "),
+			hexCell(uint64(def.Hash)),
+			def.Name,
+			strings.Join(def.Parents, " "),
+		})
+	}
+
+	for i := range v.Needs {
+		need := &v.Needs[i]
+		for j := range need.Versions {
+			req := &need.Versions[j]
+			t.Rows = append(t.Rows, []string{
+				"requirement",
+				need.File,
+				decCell(req.Index),
+				strings.Join(req.Flags.Names, " "),
+				hexCell(uint64(req.Hash)),
+				req.Name,
+				"",
+			})
+		}
+	}
+
+	return t
+}
@@ -0,0 +1,306 @@
+// Package plt maps the procedure linkage table stubs of a file to the functions they call.
+// Each stub jumps through a GOT slot, and the JUMP_SLOT, GLOB_DAT or IRELATIVE relocation of
+// that slot names the imported symbol, the way objdump builds its name@plt symbols.
+package plt
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/yourpwnguy/strix/pkg/elf"
+	"github.com/yourpwnguy/strix/pkg/elf/types"
+)
+
+// ErrUnsupported is returned for machines whose stubs are not decoded.
+var ErrUnsupported = errors.New("PLT stubs are only decoded on x86-64 and AArch64")
+
+// sections holds the sections PLT stubs live in. .plt.sec (IBT) and .plt.bnd (MPX) hold the
+// stubs called when the lazy ones in .plt only push a relocation index, and .plt.got those
+// of functions whose address is also taken, bound through GLOB_DAT.
+var sections = []string{".plt", ".plt.sec", ".plt.bnd", ".plt.got"}
+
+// Entry is one PLT stub.
+type Entry struct {
+	Address   uint64 // Address of the stub
+	Size      uint64 // Size of the stub
+	Section   string // Section holding it
+	Shndx     uint32 // Index of that section
+	Slot      uint64 // GOT slot the stub jumps through, or the one it hands the resolver
+	Lazy      bool   // Lazy binding stub that pushes a relocation index, never called directly
+	Landing   bool   // Starts with a landing pad (endbr64, bti c)
+	RelocType uint32 // Relocation of the slot, 0 when none was found
+	Name      string // Imported symbol, "*ABS*+0x..." for IRELATIVE slots, empty when unknown
+	Version   string // Version of the imported symbol
+}
+
+// Report lists the PLT stubs of a file in address order. Its strings are
+// copies, so the report outlives the parser it was computed with.
+type Report struct {
+	Machine uint16
+	Entries []Entry
+}
+
+// Analyze decodes the stubs of every PLT section. Files without section headers have no PLT
+// to report.
+func Analyze(p *elf.Parser) (*Report, error) {
+	f, err := p.File()
+	if err != nil {
+		return nil, err
+	}
+
+	machine := f.Header.E_machine
+	if machine != types.EM_X86_64 && machine != types.EM_AARCH64 {
+		return nil, fmt.Errorf("%w, not %s", ErrUnsupported, types.GetEMachine(machine))
+	}
+
+	slots, jmprel, err := slotRelocations(p)
+	if err != nil {
+		return nil, err
+	}
+
+	versions, err := p.Versions()
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{Machine: machine}
+	for _, name := range sections {
+		for i := range f.Sections {
+			sh := &f.Sections[i]
+			if f.SectionName(sh) != name || sh.Sh_type != types.SHT_PROGBITS {
+				continue
+			}
+
+			data, err := p.SectionData(sh)
+			if err != nil {
+				return nil, err
+			}
+
+			var entries []Entry
+			if machine == types.EM_X86_64 {
+				entries = decodeX86(data, sh, name, jmprel)
+			} else {
+				entries = decodeAArch64(data, sh, f.Header.E_ident.Ei_class == types.ELFCLASS64)
+			}
+
+			for j := range entries {
+				e := &entries[j]
+				e.Section, e.Shndx = name, uint32(i)
+
+				if rel, ok := slots[e.Slot]; ok {
+					e.RelocType = rel.Type
+					e.Name, e.Version = relocName(rel, versions)
+				}
+			}
+			report.Entries = append(report.Entries, entries...)
+		}
+	}
+
+	slices.SortStableFunc(report.Entries, func(a, b Entry) int { return cmp.Compare(a.Address, b.Address) })
+	return report, nil
+}
+
+// Symbols returns the synthetic name@plt symbols of the stubs calls land on, as STT_FUNC
+// symbols of their section so that they label addresses like regular ones.
+func (r *Report) Symbols() []types.Symbol {
+	var out []types.Symbol
+	for i := range r.Entries {
+		e := &r.Entries[i]
+		if e.Lazy || e.Name == "" {
+			continue
+		}
+
+		sym := types.Symbol{Name: e.Name + "@plt", Shndx: e.Shndx}
+		sym.St_value = e.Address
+		sym.St_size = e.Size
+		sym.St_info = types.STB_GLOBAL<<4 | types.STT_FUNC
+		sym.St_shndx = uint16(e.Shndx)
+		if e.Shndx >= uint32(types.SHN_LORESERVE) {
+			sym.St_shndx = types.SHN_XINDEX
+		}
+		out = append(out, sym)
+	}
+	return out
+}
+
+// slotRelocations indexes the GOT slot relocations by the slot they patch, and returns the
+// DT_JMPREL table whose index the lazy stubs push.
+func slotRelocations(p *elf.Parser) (map[uint64]*types.Relocation, []types.Relocation, error) {
+	ehdr, err := p.ELFHeader()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tables, err := p.Relocations()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	dyn, err := p.DynamicEntries()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	jmprelOffset, hasJmprel := uint64(0), false
+	if addr, ok := types.DynamicValue(dyn, types.DT_JMPREL); ok {
+		jmprelOffset, hasJmprel = p.VaddrToOffset(addr)
+	}
+
+	slots := make(map[uint64]*types.Relocation)
+	var jmprel []types.Relocation
+	for i := range tables {
+		t := &tables[i]
+		if hasJmprel && t.Offset == jmprelOffset {
+			jmprel = t.Relocs
+		}
+
+		for j := range t.Relocs {
+			rel := &t.Relocs[j]
+			if isSlot(ehdr.E_machine, rel.Type) {
+				slots[rel.Offset] = rel
+			}
+		}
+	}
+
+	return slots, jmprel, nil
+}
+
+// isSlot reports whether a relocation fills a GOT slot a PLT stub can jump through.
+func isSlot(machine uint16, r_type uint32) bool {
+	switch machine {
+	case types.EM_X86_64:
+		return r_type == types.R_X86_64_JUMP_SLOT || r_type == types.R_X86_64_GLOB_DAT || r_type == types.R_X86_64_IRELATIVE
+	case types.EM_AARCH64:
+		return r_type == types.R_AARCH64_JUMP_SLOT || r_type == types.R_AARCH64_GLOB_DAT || r_type == types.R_AARCH64_IRELATIVE
+	}
+	return false
+}
+
+// relocName names the function a slot relocation binds, the resolver address for IFUNCs.
+func relocName(rel *types.Relocation, versions *types.Versions) (name, version string) {
+	if rel.Symbol == nil || rel.Symbol.Name == "" {
+		return fmt.Sprintf("*ABS*+%#x", rel.Addend), ""
+	}
+
+	if ver, ok := versions.Symbol(rel.Symbol.Index); ok {
+		version = strings.Clone(ver.Name)
+	}
+	return strings.Clone(rel.Symbol.Name), version
+}
+
+// decodeX86 walks the fixed size stubs of an x86-64 PLT section. Stubs jump through their
+// slot with a RIP relative jmp, optionally behind endbr64 and a bnd prefix. Lazy stubs of the
+// IBT and MPX layouts instead push the index of their DT_JMPREL relocation. PLT0 and padding
+// match neither and are skipped.
+func decodeX86(data []byte, sh *types.Elf64_Shdr, name string, jmprel []types.Relocation) []Entry {
+	size := sh.Sh_entsize
+	if size == 0 || size > 32 {
+		size = 16
+		// Without endbr64, a .plt.got stub is a jmp padded to 8 bytes, bnd prefix or not
+		if name == ".plt.got" && (bytes.HasPrefix(data, []byte{0xff, 0x25}) || bytes.HasPrefix(data, []byte{0xf2, 0xff, 0x25})) {
+			size = 8
+		}
+	}
+
+	var out []Entry
+	for off := uint64(0); off+size <= uint64(len(data)); off += size {
+		stub := data[off : off+size]
+		e := Entry{Address: sh.Sh_addr + off, Size: size}
+
+		at := 0
+		if len(stub) >= 4 && binary.LittleEndian.Uint32(stub) == 0xfa1e0ff3 {
+			e.Landing, at = true, 4
+		}
+		if at < len(stub) && stub[at] == 0xf2 {
+			at++
+		}
+
+		switch {
+		case at+6 <= len(stub) && stub[at] == 0xff && stub[at+1] == 0x25:
+			// jmp *disp32(%rip), relative to the end of the instruction
+			disp := int64(int32(binary.LittleEndian.Uint32(stub[at+2:])))
+			e.Slot = e.Address + uint64(at) + 6 + uint64(disp)
+
+		case at+5 <= len(stub) && stub[at] == 0x68:
+			// push $index
+			index := binary.LittleEndian.Uint32(stub[at+1:])
+			if uint64(index) >= uint64(len(jmprel)) {
+				continue
+			}
+			e.Lazy, e.Slot = true, jmprel[index].Offset
+
+		default:
+			continue
+		}
+
+		out = append(out, e)
+	}
+
+	return out
+}
+
+// decodeAArch64 finds the stubs of an AArch64 PLT section by their adrp x16 / ldr x17 pair,
+// which loads the slot. A bti c right before the pair belongs to the stub, and PLT0 is told
+// apart by the stp that saves x16 and x30 in front of it.
+func decodeAArch64(data []byte, sh *types.Elf64_Shdr, lp64 bool) []Entry {
+	const (
+		btiC = 0xd503245f
+		stp  = 0xa9bf7bf0 // stp x16, x30, [sp, #-16]!
+	)
+
+	word := func(i int) uint32 { return binary.LittleEndian.Uint32(data[i*4:]) }
+
+	var out []Entry
+	count := len(data) / 4
+	for i := 0; i+1 < count; i++ {
+		adrp, ldr := word(i), word(i+1)
+		if adrp&0x9f00001f != 0x90000010 {
+			continue
+		}
+
+		// ldr x17, [x16, #imm] for LP64, ldr w17 for ILP32
+		var scale uint64
+		switch {
+		case lp64 && ldr&0xffc003ff == 0xf9400211:
+			scale = 8
+		case !lp64 && ldr&0xffc003ff == 0xb9400211:
+			scale = 4
+		default:
+			continue
+		}
+
+		if i > 0 && word(i-1) == stp {
+			continue
+		}
+
+		pc := sh.Sh_addr + uint64(i)*4
+		imm := int64(adrp>>29&3|adrp>>3&0x1ffffc) << 43 >> 31 // immhi:immlo, sign extended, shifted by 12
+		page := pc&^0xfff + uint64(imm)
+
+		e := Entry{Address: pc, Slot: page + uint64(ldr>>10&0xfff)*scale}
+		if i > 0 && word(i-1) == btiC {
+			e.Address -= 4
+			e.Landing = true
+		}
+		out = append(out, e)
+	}
+
+	// Stubs run up to the next one, the last one is as long as the one before it
+	for i := range out {
+		switch {
+		case i+1 < len(out):
+			out[i].Size = out[i+1].Address - out[i].Address
+		case i > 0:
+			out[i].Size = out[i-1].Size
+		default:
+			out[i].Size = 16
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,123 @@
+package plt
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/yourpwnguy/strix/pkg/elf/types"
+)
+
+// words encodes AArch64 instructions, which are little endian whatever the data encoding.
+func words(insns ...uint32) []byte {
+	out := make([]byte, 0, len(insns)*4)
+	for _, insn := range insns {
+		out = binary.LittleEndian.AppendUint32(out, insn)
+	}
+	return out
+}
+
+func TestDecodeAArch64(t *testing.T) {
+	const (
+		stp   = 0xa9bf7bf0 // stp x16, x30, [sp, #-16]!
+		adrp  = 0xb0000090 // adrp x16, pc page + 0x11000
+		ldr0  = 0xf9400a11 // ldr x17, [x16, #0x10]
+		ldr1  = 0xf9400e11 // ldr x17, [x16, #0x18]
+		add   = 0x91004210 // add x16, x16, #0x10
+		br    = 0xd61f0220 // br x17
+		nop   = 0xd503201f
+		btiC  = 0xd503245f
+		addr  = 0x10000
+		slot0 = 0x21010
+		slot1 = 0x21018
+	)
+
+	tests := []struct {
+		name string
+		data []byte
+		want []Entry
+	}{
+		{
+			name: "lazy",
+			data: words(
+				stp, adrp, ldr0, add, br, nop, nop, nop, // PLT0
+				adrp, ldr0, add, br,
+				adrp, ldr1, add, br,
+			),
+			want: []Entry{
+				{Address: addr + 32, Size: 16, Slot: slot0},
+				{Address: addr + 48, Size: 16, Slot: slot1},
+			},
+		},
+		{
+			name: "bti",
+			data: words(
+				btiC, stp, adrp, ldr0, add, br, nop, nop, // PLT0
+				btiC, adrp, ldr0, add, br, nop,
+				btiC, adrp, ldr1, add, br, nop,
+			),
+			want: []Entry{
+				{Address: addr + 32, Size: 24, Slot: slot0, Landing: true},
+				{Address: addr + 56, Size: 24, Slot: slot1, Landing: true},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		got := decodeAArch64(tt.data, &types.Elf64_Shdr{Sh_addr: addr}, true)
+		if len(got) != len(tt.want) {
+			t.Fatalf("%s: got %d entries, want %d: %+v", tt.name, len(got), len(tt.want), got)
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("%s: entry %d = %+v, want %+v", tt.name, i, got[i], tt.want[i])
+			}
+		}
+	}
+}
+
+func TestDecodeX86(t *testing.T) {
+	// .plt.sec stub: endbr64; bnd jmp *0x2f92(%rip); nop
+	stub := []byte{0xf3, 0x0f, 0x1e, 0xfa, 0xf2, 0xff, 0x25, 0x92, 0x2f, 0x00, 0x00, 0x0f, 0x1f, 0x44, 0x00, 0x00}
+	// .plt lazy stub: endbr64; push $1; bnd jmp PLT0; nop
+	lazy := []byte{0xf3, 0x0f, 0x1e, 0xfa, 0x68, 0x01, 0x00, 0x00, 0x00, 0xf2, 0xe9, 0xe1, 0xff, 0xff, 0xff, 0x90}
+	// .plt.got stubs without IBT: jmp *0x2fe2(%rip); xchg %ax,%ax, then jmp *0x2fe2(%rip) again
+	got8 := []byte{
+		0xff, 0x25, 0xe2, 0x2f, 0x00, 0x00, 0x66, 0x90,
+		0xff, 0x25, 0xe2, 0x2f, 0x00, 0x00, 0x66, 0x90,
+	}
+	jmprel := []types.Relocation{{Offset: 0x4000}, {Offset: 0x4008}}
+
+	tests := []struct {
+		name    string
+		data    []byte
+		section string
+		entsize uint64
+		want    []Entry
+	}{
+		{"plt.sec", append(stub, lazy...), ".plt.sec", 16, []Entry{
+			{Address: 0x1070, Size: 16, Slot: 0x1070 + 11 + 0x2f92, Landing: true},
+			{Address: 0x1080, Size: 16, Slot: 0x4008, Landing: true, Lazy: true},
+		}},
+		// An even number of 8 byte stubs fills whole 16 byte strides, only the encoding tells
+		{"plt.got without entsize", got8, ".plt.got", 0, []Entry{
+			{Address: 0x1070, Size: 8, Slot: 0x1070 + 6 + 0x2fe2},
+			{Address: 0x1078, Size: 8, Slot: 0x1078 + 6 + 0x2fe2},
+		}},
+		{"plt.got with endbr64", stub, ".plt.got", 0, []Entry{
+			{Address: 0x1070, Size: 16, Slot: 0x1070 + 11 + 0x2f92, Landing: true},
+		}},
+	}
+
+	for _, tt := range tests {
+		got := decodeX86(tt.data, &types.Elf64_Shdr{Sh_addr: 0x1070, Sh_entsize: tt.entsize}, tt.section, jmprel)
+		if len(got) != len(tt.want) {
+			t.Errorf("%s: got %d entries, want %d: %+v", tt.name, len(got), len(tt.want), got)
+			continue
+		}
+		for i := range got {
+			if got[i] != tt.want[i] {
+				t.Errorf("%s: entry %d = %+v, want %+v", tt.name, i, got[i], tt.want[i])
+			}
+		}
+	}
+}
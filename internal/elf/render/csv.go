@@ -0,0 +1,44 @@
+package render
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+
+	"github.com/yourpwnguy/strix/internal/elf/model"
+)
+
+// csvRenderer writes the tabular form of the results as CSV, under a single header row.
+// The first column names the file each row comes from.
+type csvRenderer struct {
+	opts Options
+}
+
+func (r *csvRenderer) Render(w io.Writer, results []*model.Envelope) error {
+	cw := csv.NewWriter(w)
+
+	header := false
+	for _, env := range results {
+		if env.Error != "" {
+			reportError(env, r.opts, true)
+			continue
+		}
+
+		tab, ok := env.Data.(model.Tabular)
+		if !ok {
+			return fmt.Errorf("no tabular form for %T", env.Data)
+		}
+		t := tab.Table()
+
+		if !header {
+			cw.Write(append([]string{"file"}, t.Columns...))
+			header = true
+		}
+		for _, row := range t.Rows {
+			cw.Write(append([]string{env.File}, row...))
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
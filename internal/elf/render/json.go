@@ -0,0 +1,39 @@
+package render
+
+import (
+	"encoding/json"
+	"io"
+
+	"github.com/yourpwnguy/strix/internal/elf/model"
+)
+
+// jsonRenderer writes indented JSON, a single document holding a list of envelopes when
+// the command takes several files. The ndjson variant writes one compact envelope per line.
+type jsonRenderer struct {
+	opts  Options
+	lines bool
+}
+
+func (r *jsonRenderer) Render(w io.Writer, results []*model.Envelope) error {
+	enc := json.NewEncoder(w)
+
+	if r.lines {
+		for _, env := range results {
+			if err := enc.Encode(env); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	enc.SetIndent("", "  ")
+	if r.opts.List {
+		return enc.Encode(results)
+	}
+	for _, env := range results {
+		if err := enc.Encode(env); err != nil {
+			return err
+		}
+	}
+	return nil
+}
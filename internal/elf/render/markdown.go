@@ -0,0 +1,59 @@
+package render
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/yourpwnguy/strix/internal/elf/model"
+)
+
+// markdownRenderer writes the tabular form of every result as a Markdown table under a
+// heading naming the command and the file.
+type markdownRenderer struct {
+	opts Options
+}
+
+func (r *markdownRenderer) Render(w io.Writer, results []*model.Envelope) error {
+	var sb strings.Builder
+
+	for _, env := range results {
+		if env.Error != "" {
+			reportError(env, r.opts, true)
+			continue
+		}
+
+		tab, ok := env.Data.(model.Tabular)
+		if !ok {
+			return fmt.Errorf("no tabular form for %T", env.Data)
+		}
+		t := tab.Table()
+
+		if sb.Len() > 0 {
+			sb.WriteByte('\n')
+		}
+		fmt.Fprintf(&sb, "## %s `%s`\n\n", env.Command, env.File)
+
+		writeRow(&sb, t.Columns)
+		sb.WriteString(strings.Repeat("| --- ", len(t.Columns)))
+		sb.WriteString("|\n")
+		for _, row := range t.Rows {
+			writeRow(&sb, row)
+		}
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// writeRow writes one table row, escaping the characters that would break the table.
+func writeRow(sb *strings.Builder, cells []string) {
+	for _, cell := range cells {
+		cell = strings.ReplaceAll(cell, `|`, `\|`)
+		cell = strings.ReplaceAll(cell, "\n", "<br>")
+		sb.WriteString("| ")
+		sb.WriteString(cell)
+		sb.WriteByte(' ')
+	}
+	sb.WriteString("|\n")
+}
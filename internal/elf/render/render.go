@@ -0,0 +1,67 @@
+// Package render turns the views of package model into output. Every output format is a
+// Renderer, so commands only build views and never deal with presentation.
+package render
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/yourpwnguy/strix/internal/elf/model"
+	"github.com/yourpwnguy/strix/internal/ui"
+)
+
+// Formats lists the accepted output formats, the first one being the default.
+var Formats = []string{"text", "plain", "json", "ndjson", "yaml", "csv", "markdown"}
+
+// Options tune how results are rendered.
+type Options struct {
+	List    bool // The command takes several files: json and yaml wrap the results in a list
+	Verbose bool // checksec: explain every check instead of the one line summary
+	Targets bool // relocs: add the target address column to the text layouts
+	Virtual bool // hexdump: label rows with virtual addresses instead of file offsets
+}
+
+// Renderer writes the results of one command run, one envelope per input file.
+// Results that carry an error are reported on stderr by the human oriented formats, and
+// written out like any other result by the machine readable ones.
+type Renderer interface {
+	Render(w io.Writer, results []*model.Envelope) error
+}
+
+// New returns the renderer of the named format.
+func New(format string, opts Options) (Renderer, error) {
+	switch format {
+	case "text":
+		return &textRenderer{opts: opts}, nil
+	case "plain":
+		return &textRenderer{opts: opts, plain: true}, nil
+	case "json":
+		return &jsonRenderer{opts: opts}, nil
+	case "ndjson":
+		return &jsonRenderer{opts: opts, lines: true}, nil
+	case "yaml":
+		return &yamlRenderer{opts: opts}, nil
+	case "csv":
+		return &csvRenderer{opts: opts}, nil
+	case "markdown":
+		return &markdownRenderer{opts: opts}, nil
+	}
+	return nil, fmt.Errorf("invalid output format %q: want one of %s", format, strings.Join(Formats, ", "))
+}
+
+// reportError writes a failed result on stderr behind the usual [ERR] prefix.
+// The file is named when the command takes several of them.
+func reportError(env *model.Envelope, opts Options, plain bool) {
+	msg := env.Error
+	if opts.List {
+		msg = env.File + ": " + msg
+	}
+
+	if plain {
+		fmt.Fprintf(os.Stderr, "[ERR] %s\n", msg)
+		return
+	}
+	fmt.Fprintf(os.Stderr, "%s %s\n", ui.ErrPrefix, ui.Red.Sprint(msg))
+}
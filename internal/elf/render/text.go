@@ -0,0 +1,110 @@
+package render
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/fatih/color"
+	"github.com/yourpwnguy/strix/internal/elf/format"
+	"github.com/yourpwnguy/strix/internal/elf/model"
+)
+
+// textRenderer writes the colored layouts of package format. The plain variant writes the
+// same layouts with colors turned off.
+type textRenderer struct {
+	opts  Options
+	plain bool
+}
+
+func (r *textRenderer) Render(w io.Writer, results []*model.Envelope) error {
+	if r.plain {
+		color.NoColor = true
+	}
+
+	// The checksec summary lines up the results of every file
+	width := 0
+	for _, env := range results {
+		width = max(width, len(env.File))
+	}
+
+	for _, env := range results {
+		if env.Error != "" {
+			reportError(env, r.opts, r.plain)
+			continue
+		}
+
+		switch v := env.Data.(type) {
+		case *model.Header:
+			format.PrintELFHeader(w, v)
+
+		case *model.ProgramHeaders:
+			format.PrintProgramHeaders(w, v)
+
+		case *model.SectionHeaders:
+			format.PrintSectionHeaders(w, v)
+
+		case *model.Symbols:
+			for i := range v.Tables {
+				format.PrintSymbols(w, &v.Tables[i])
+			}
+
+		case *model.Dynamic:
+			format.PrintDynamic(w, v)
+
+		case *model.Relocations:
+			for i := range v.Tables {
+				format.PrintRelocations(w, &v.Tables[i], r.opts.Targets)
+			}
+
+		case *model.Notes:
+			format.PrintNotes(w, v)
+
+		case *model.HexDump:
+			format.PrintHexDump(w, v, r.opts.Virtual)
+
+		case *model.StringTables:
+			for i := range v.Tables {
+				format.PrintStringTable(w, &v.Tables[i])
+			}
+
+		case *model.Strings:
+			format.PrintStrings(w, v)
+
+		case *model.Versions:
+			format.PrintVersions(w, v)
+
+		case *model.Requires:
+			format.PrintRequires(w, env.File, v)
+
+		case *model.Dependencies:
+			format.PrintDependencies(w, env.File, v)
+
+		case *model.LoaderCache:
+			format.PrintLoaderCache(w, env.File, v)
+
+		case *model.PLT:
+			format.PrintPLT(w, env.File, v)
+
+		case *model.Disassembly:
+			format.PrintDisassembly(w, env.File, v)
+
+		case *model.SymbolLookup:
+			format.PrintSymbolLookup(w, env.File, v)
+
+		case *model.Location:
+			format.PrintLocation(w, v)
+
+		case *model.Checksec:
+			if r.opts.Verbose {
+				format.PrintChecksecDetails(w, env.File, v)
+			} else {
+				format.PrintChecksecSummary(w, env.File, width, v)
+			}
+
+		default:
+			return fmt.Errorf("no text layout for %T", v)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,127 @@
+package render
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/yourpwnguy/strix/internal/elf/model"
+	"gopkg.in/yaml.v3"
+)
+
+// yamlRenderer writes YAML. The views go through their JSON encoding first, so both
+// formats share the field names and order of the versioned schema.
+type yamlRenderer struct {
+	opts Options
+}
+
+func (r *yamlRenderer) Render(w io.Writer, results []*model.Envelope) error {
+	enc := yaml.NewEncoder(w)
+	enc.SetIndent(2)
+	defer enc.Close()
+
+	if r.opts.List {
+		seq := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		for _, env := range results {
+			node, err := toNode(env)
+			if err != nil {
+				return err
+			}
+			seq.Content = append(seq.Content, node)
+		}
+		return enc.Encode(seq)
+	}
+
+	for _, env := range results {
+		node, err := toNode(env)
+		if err != nil {
+			return err
+		}
+		if err := enc.Encode(node); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// toNode converts v into a YAML node through its JSON encoding, keeping the key order.
+func toNode(v any) (*yaml.Node, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	return decodeNode(dec)
+}
+
+// decodeNode reads the next JSON value from dec as a YAML node.
+func decodeNode(dec *json.Decoder) (*yaml.Node, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch t := tok.(type) {
+	case json.Delim:
+		node := &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		if t == '[' {
+			node = &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		}
+
+		for dec.More() {
+			if node.Kind == yaml.MappingNode {
+				key, err := dec.Token()
+				if err != nil {
+					return nil, err
+				}
+				node.Content = append(node.Content, scalar("!!str", key.(string)))
+			}
+
+			value, err := decodeNode(dec)
+			if err != nil {
+				return nil, err
+			}
+			node.Content = append(node.Content, value)
+		}
+
+		// Closing delimiter
+		if _, err := dec.Token(); err != nil {
+			return nil, err
+		}
+		return node, nil
+
+	case string:
+		return scalar("!!str", t), nil
+
+	case json.Number:
+		if strings.ContainsAny(t.String(), ".eE") {
+			return scalar("!!float", t.String()), nil
+		}
+		return scalar("!!int", t.String()), nil
+
+	case bool:
+		return scalar("!!bool", fmt.Sprint(t)), nil
+
+	case nil:
+		return scalar("!!null", "null"), nil
+	}
+
+	return nil, fmt.Errorf("unexpected JSON token %v", tok)
+}
+
+// scalar builds a scalar node of the given tag. Strings that YAML 1.1 parsers read as
+// booleans (yes, no, on, off...) are quoted, which matters for the checksec values.
+func scalar(tag, value string) *yaml.Node {
+	node := &yaml.Node{Kind: yaml.ScalarNode, Tag: tag, Value: value}
+	if tag == "!!str" {
+		switch strings.ToLower(value) {
+		case "y", "n", "yes", "no", "on", "off":
+			node.Style = yaml.DoubleQuotedStyle
+		}
+	}
+	return node
+}
@@ -0,0 +1,139 @@
+// Package requires works out the newest symbol version a file needs from each library it
+// links against, which decides the oldest distribution it can run on.
+package requires
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/yourpwnguy/strix/pkg/elf"
+	"github.com/yourpwnguy/strix/pkg/elf/types"
+)
+
+// Requirement is the newest version of one family (GLIBC, GLIBCXX, ...) required from a library.
+type Requirement struct {
+	Library string   // Needed file (e.g. "libc.so.6")
+	Family  string   // Version family (e.g. "GLIBC"), the whole name for non numeric nodes
+	Version string   // Newest numeric version (e.g. "2.34"), empty for nodes like GLIBC_PRIVATE
+	Name    string   // Version node name (e.g. "GLIBC_2.34")
+	Symbols []string // Undefined symbols bound to exactly that version
+}
+
+// Report lists the requirements of one file, in .gnu.version_r order. Its strings are copies,
+// so the report outlives the parser it was computed with.
+type Report struct {
+	Requirements []Requirement
+}
+
+// Analyze computes the newest version of every family each library is required at.
+func Analyze(p *elf.Parser) (*Report, error) {
+	versions, err := p.Versions()
+	if err != nil {
+		return nil, err
+	}
+
+	dynsym, err := p.DynamicSymbols()
+	if err != nil {
+		return nil, err
+	}
+
+	// Undefined symbols by version index
+	symbols := make(map[uint16][]string)
+	for i := range dynsym {
+		sym := &dynsym[i]
+		if !sym.IsUndefined() || sym.Name == "" {
+			continue
+		}
+		if ver, ok := versions.Symbol(sym.Index); ok && ver.File != "" {
+			symbols[ver.Index] = append(symbols[ver.Index], strings.Clone(sym.Name))
+		}
+	}
+
+	report := &Report{}
+	for i := range versions.Needs {
+		need := &versions.Needs[i]
+
+		// Newest node of each family, families in order of first appearance
+		var newest []types.VersionRequirement
+		for _, req := range need.Versions {
+			family, version := SplitVersion(req.Name)
+
+			found := false
+			for j := range newest {
+				if f, v := SplitVersion(newest[j].Name); f == family {
+					if Compare(version, v) > 0 {
+						newest[j] = req
+					}
+					found = true
+					break
+				}
+			}
+			if !found {
+				newest = append(newest, req)
+			}
+		}
+
+		for _, req := range newest {
+			name := strings.Clone(req.Name)
+			family, version := SplitVersion(name)
+			report.Requirements = append(report.Requirements, Requirement{
+				Library: strings.Clone(need.File),
+				Family:  family,
+				Version: version,
+				Name:    name,
+				Symbols: symbols[req.Index],
+			})
+		}
+	}
+
+	return report, nil
+}
+
+// SplitVersion splits a version node name into its family and numeric version, at the last
+// underscore followed by a digit: GLIBC_2.34 is GLIBC and 2.34, GLIBCXX_3.4.29 is GLIBCXX and
+// 3.4.29. Names without a numeric version, such as GLIBC_PRIVATE, are their own family.
+func SplitVersion(name string) (family, version string) {
+	i := strings.LastIndexByte(name, '_')
+	if i <= 0 || i == len(name)-1 || name[i+1] < '0' || name[i+1] > '9' {
+		return name, ""
+	}
+	return name[:i], name[i+1:]
+}
+
+// Compare orders dotted numeric versions component by component, so that 2.10 comes after
+// 2.9 and 2.3.4 after 2.3. Missing components count as zero and non numeric ones as -1.
+func Compare(a, b string) int {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := range max(len(as), len(bs)) {
+		x, y := component(as, i), component(bs, i)
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// component returns the numeric value of the i-th component of a version.
+func component(parts []string, i int) int {
+	if i >= len(parts) {
+		return 0
+	}
+	n, err := strconv.Atoi(parts[i])
+	if err != nil {
+		return -1
+	}
+	return n
+}
+
+// ValidVersion reports whether v is a dotted numeric version such as 2.28.
+func ValidVersion(v string) bool {
+	for _, part := range strings.Split(v, ".") {
+		if _, err := strconv.Atoi(part); err != nil || part == "" || part[0] == '-' || part[0] == '+' {
+			return false
+		}
+	}
+	return true
+}
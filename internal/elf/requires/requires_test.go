@@ -0,0 +1,42 @@
+package requires
+
+import "testing"
+
+func TestSplitVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		family  string
+		version string
+	}{
+		{"GLIBC_2.34", "GLIBC", "2.34"},
+		{"GLIBCXX_3.4.29", "GLIBCXX", "3.4.29"},
+		{"GLIBC_PRIVATE", "GLIBC_PRIVATE", ""},
+		{"gssapi_krb5_2_MIT", "gssapi_krb5_2_MIT", ""},
+		{"OPENSSL_3.0.0", "OPENSSL", "3.0.0"},
+	}
+
+	for _, tt := range tests {
+		family, version := SplitVersion(tt.name)
+		if family != tt.family || version != tt.version {
+			t.Errorf("SplitVersion(%q) = %q, %q, want %q, %q", tt.name, family, version, tt.family, tt.version)
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"2.10", "2.9", 1},
+		{"2.3", "2.3.4", -1},
+		{"2.3.0", "2.3", 0},
+		{"3.4.29", "3.4.30", -1},
+	}
+
+	for _, tt := range tests {
+		if got := Compare(tt.a, tt.b); got != tt.want {
+			t.Errorf("Compare(%q, %q) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
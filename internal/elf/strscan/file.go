@@ -0,0 +1,68 @@
+package strscan
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/yourpwnguy/strix/pkg/elf"
+	"github.com/yourpwnguy/strix/pkg/elf/types"
+)
+
+// Region is a file range to scan, named after the section or segment it covers.
+type Region struct {
+	Name   string
+	Offset uint64
+	Size   uint64
+}
+
+// Located is a string placed in the file. Offset is a file offset, Address is only valid when
+// Mapped, that is when a PT_LOAD segment loads the string, and Section is empty when no
+// section header covers it.
+type Located struct {
+	Offset   uint64
+	Address  uint64
+	Mapped   bool
+	Section  string
+	Encoding Encoding
+	Value    string
+}
+
+// ScanFile scans each region of the file for each encoding. A region ends its strings, so
+// text running off the end of a section goes on as a string of the next one. The strings
+// are sorted by offset, and those found twice, by overlapping regions, are kept once.
+func ScanFile(p *elf.Parser, f *elf.File, regions []Region, minLen int, encodings []Encoding) ([]Located, error) {
+	var out []Located
+	for _, region := range regions {
+		data, err := p.Bytes(region.Name, region.Offset, region.Size)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, enc := range encodings {
+			for _, m := range Scan(data, minLen, enc) {
+				offset := region.Offset + uint64(m.Offset)
+				s := Located{Offset: offset, Section: sectionAt(f, offset), Encoding: m.Encoding, Value: m.Value}
+				s.Address, s.Mapped = p.OffsetToVaddr(offset)
+				out = append(out, s)
+			}
+		}
+	}
+
+	slices.SortStableFunc(out, func(a, b Located) int {
+		return cmp.Compare(a.Offset, b.Offset)
+	})
+	return slices.CompactFunc(out, func(a, b Located) bool {
+		return a.Offset == b.Offset && a.Encoding == b.Encoding
+	}), nil
+}
+
+// sectionAt names the section whose file contents hold offset, or returns "" when none does.
+func sectionAt(f *elf.File, offset uint64) string {
+	for i := range f.Sections {
+		sh := &f.Sections[i]
+		if sh.Sh_type != types.SHT_NULL && sh.Sh_type != types.SHT_NOBITS && offset >= sh.Sh_offset && offset-sh.Sh_offset < sh.Sh_size {
+			return f.SectionName(sh)
+		}
+	}
+	return ""
+}
@@ -0,0 +1,150 @@
+// Package strscan extracts runs of printable text from raw bytes, the way strings(1) does,
+// and places them in the sections and segments of an ELF file.
+package strscan
+
+import (
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Encoding is the character encoding a scan looks for.
+type Encoding int
+
+const (
+	ASCII   Encoding = iota // Printable 7-bit ASCII and tabs
+	UTF8                    // Printable UTF-8, a superset of ASCII
+	UTF16LE                 // Printable Latin-1 as UTF-16 little endian, on 2 byte boundaries
+)
+
+// encodingNames are the names of the encodings, as used on the command line.
+var encodingNames = [...]string{
+	ASCII:   "ascii",
+	UTF8:    "utf8",
+	UTF16LE: "utf16le",
+}
+
+// String returns the command line name of the encoding.
+func (e Encoding) String() string {
+	if int(e) < len(encodingNames) {
+		return encodingNames[e]
+	}
+	return fmt.Sprintf("Encoding(%d)", int(e))
+}
+
+// ParseEncoding looks up an encoding by name, ignoring case and dashes (utf-8, UTF16LE, ...).
+func ParseEncoding(name string) (Encoding, error) {
+	name = strings.ReplaceAll(strings.ToLower(strings.TrimSpace(name)), "-", "")
+	for e, n := range encodingNames {
+		if n == name {
+			return Encoding(e), nil
+		}
+	}
+	return 0, fmt.Errorf("unknown encoding %q (expected %s)", name, strings.Join(encodingNames[:], ", "))
+}
+
+// Match is one string found by a scan. Offset is relative to the scanned bytes.
+type Match struct {
+	Offset   int
+	Encoding Encoding
+	Value    string
+}
+
+// Scan returns every run of at least minLen printable characters in data, in order.
+func Scan(data []byte, minLen int, enc Encoding) []Match {
+	switch enc {
+	case UTF8:
+		return scanUTF8(data, minLen)
+	case UTF16LE:
+		return scanUTF16LE(data, minLen)
+	default:
+		return scanASCII(data, minLen)
+	}
+}
+
+// printable reports whether r can be part of a string. Tabs are allowed, other controls are not.
+func printable(r rune) bool {
+	return r == '\t' || unicode.IsPrint(r)
+}
+
+// scanASCII finds runs of printable ASCII bytes.
+func scanASCII(data []byte, minLen int) []Match {
+	var out []Match
+
+	start := -1
+	for i := 0; i <= len(data); i++ {
+		if i < len(data) && (data[i] == '\t' || (data[i] >= 0x20 && data[i] <= 0x7e)) {
+			if start < 0 {
+				start = i
+			}
+			continue
+		}
+
+		if start >= 0 && i-start >= minLen {
+			out = append(out, Match{start, ASCII, string(data[start:i])})
+		}
+		start = -1
+	}
+
+	return out
+}
+
+// scanUTF8 finds runs of printable characters in valid UTF-8. Lengths count characters, not bytes.
+func scanUTF8(data []byte, minLen int) []Match {
+	var out []Match
+
+	start, count := -1, 0
+	for i := 0; i <= len(data); {
+		r, size := utf8.RuneError, 1
+		if i < len(data) {
+			r, size = utf8.DecodeRune(data[i:])
+		}
+
+		if i < len(data) && r != utf8.RuneError && printable(r) {
+			if start < 0 {
+				start, count = i, 0
+			}
+			count++
+			i += size
+			continue
+		}
+
+		if start >= 0 && count >= minLen {
+			out = append(out, Match{start, UTF8, string(data[start:i])})
+		}
+		start = -1
+		i += size
+	}
+
+	return out
+}
+
+// scanUTF16LE finds runs of printable UTF-16LE characters on 2 byte aligned positions. Like
+// GNU strings -el, only characters of the Latin-1 range are accepted: any code unit reads as
+// some CJK or symbol character, so allowing the whole plane turns every table into "text".
+func scanUTF16LE(data []byte, minLen int) []Match {
+	var out []Match
+
+	var run []rune
+	start := -1
+	for i := 0; i <= len(data); i += 2 {
+		if i+2 <= len(data) {
+			if r := rune(binary.LittleEndian.Uint16(data[i:])); r < 0x100 && printable(r) {
+				if start < 0 {
+					start = i
+				}
+				run = append(run, r)
+				continue
+			}
+		}
+
+		if start >= 0 && len(run) >= minLen {
+			out = append(out, Match{start, UTF16LE, string(run)})
+		}
+		start, run = -1, run[:0]
+	}
+
+	return out
+}
@@ -0,0 +1,167 @@
+package strscan
+
+import (
+	"encoding/binary"
+	"reflect"
+	"testing"
+
+	"github.com/yourpwnguy/strix/internal/reader"
+	"github.com/yourpwnguy/strix/pkg/elf"
+	"github.com/yourpwnguy/strix/pkg/elf/types"
+)
+
+// utf16 encodes s as UTF-16LE, one code unit per rune.
+func utf16(s string) []byte {
+	var b []byte
+	for _, r := range s {
+		b = binary.LittleEndian.AppendUint16(b, uint16(r))
+	}
+	return b
+}
+
+func TestScan(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   []byte
+		minLen int
+		enc    Encoding
+		want   []Match
+	}{
+		{"shorter than minimum", []byte("abc\x00abcd\x00"), 4, ASCII, []Match{{4, ASCII, "abcd"}}},
+		{"minimum of one", []byte("a\x00\x01bc"), 1, ASCII, []Match{{0, ASCII, "a"}, {3, ASCII, "bc"}}},
+		{"runs to the end", []byte("\x00\x00hello"), 4, ASCII, []Match{{2, ASCII, "hello"}}},
+		{"tab is printable", []byte("a\tb c\n"), 4, ASCII, []Match{{0, ASCII, "a\tb c"}}},
+		{"empty", nil, 1, ASCII, nil},
+
+		// ASCII stops at the first byte of a multibyte character, UTF-8 counts characters
+		{"ascii splits utf8", []byte("naïveté"), 2, ASCII, []Match{{0, ASCII, "na"}, {4, ASCII, "vet"}}},
+		{"utf8", []byte("\x00naïveté\x00"), 7, UTF8, []Match{{1, UTF8, "naïveté"}}},
+		{"utf8 counts characters", []byte("ééé"), 4, UTF8, nil},
+		{"utf8 invalid byte", []byte("abcd\xffefgh"), 4, UTF8, []Match{{0, UTF8, "abcd"}, {5, UTF8, "efgh"}}},
+		{"utf8 control", []byte("abcd\x7fefgh"), 4, UTF8, []Match{{0, UTF8, "abcd"}, {5, UTF8, "efgh"}}},
+
+		// UTF-16LE keeps to even offsets and to Latin-1
+		{"utf16le", append([]byte{0xff, 0xff}, utf16("Wide é")...), 4, UTF16LE, []Match{{2, UTF16LE, "Wide é"}}},
+		{"utf16le odd offset", append([]byte{0xff}, utf16("Wide")...), 4, UTF16LE, nil},
+		{"utf16le outside latin1", utf16("abcd中efgh"), 4, UTF16LE, []Match{{0, UTF16LE, "abcd"}, {10, UTF16LE, "efgh"}}},
+		{"utf16le trailing byte", append(utf16("abcd"), 'e'), 4, UTF16LE, []Match{{0, UTF16LE, "abcd"}}},
+		{"utf16le ignores ascii", []byte("abcdefgh"), 2, UTF16LE, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Scan(tt.data, tt.minLen, tt.enc); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Scan(%q, %d, %s) = %v, want %v", tt.data, tt.minLen, tt.enc, got, tt.want)
+			}
+		})
+	}
+}
+
+// testFile builds a little endian ELF64 executable whose first 0x90 bytes are loaded at
+// 0x400000. Its sections are .rodata at 0x80, .data at 0x88 and .comment at 0x90, which no
+// segment loads. .rodata ends without a NUL, its text runs on into .data.
+func testFile(t *testing.T) (*elf.Parser, *elf.File) {
+	t.Helper()
+
+	const shstrtabOff, shoff = 0xa0, 0xc0
+	shstrtab := "\x00.rodata\x00.data\x00.comment\x00.shstrtab\x00"
+
+	buf := make([]byte, shoff+5*64)
+	copy(buf[0x80:], "abcdefgh")
+	copy(buf[0x88:], "ijkl\x00hi\x00")
+	copy(buf[0x90:], "GCC: 1.0\x00")
+	copy(buf[shstrtabOff:], shstrtab)
+
+	hdr := types.Elf64_Ehdr{
+		E_type: types.ET_EXEC, E_machine: types.EM_X86_64, E_version: 1,
+		E_phoff: 64, E_shoff: shoff, E_ehsize: 64, E_phentsize: 56, E_phnum: 1,
+		E_shentsize: 64, E_shnum: 5, E_shstrndx: 4,
+	}
+	hdr.E_ident.FileIdn = [4]uint8{0x7f, 'E', 'L', 'F'}
+	hdr.E_ident.Ei_class, hdr.E_ident.Ei_data, hdr.E_ident.Ei_version = types.ELFCLASS64, types.ELFDATA2LSB, 1
+	binary.Encode(buf, binary.LittleEndian, hdr)
+	binary.Encode(buf[64:], binary.LittleEndian, types.Elf64_Phdr{
+		P_type: types.PT_LOAD, P_flags: types.PF_R, P_vaddr: 0x400000, P_filesz: 0x90, P_memsz: 0x90, P_align: 0x1000,
+	})
+
+	sections := []types.Elf64_Shdr{
+		{},
+		{Sh_name: 1, Sh_type: types.SHT_PROGBITS, Sh_flags: types.SHF_ALLOC, Sh_addr: 0x400080, Sh_offset: 0x80, Sh_size: 8},
+		{Sh_name: 9, Sh_type: types.SHT_PROGBITS, Sh_flags: types.SHF_ALLOC | types.SHF_WRITE, Sh_addr: 0x400088, Sh_offset: 0x88, Sh_size: 8},
+		{Sh_name: 15, Sh_type: types.SHT_PROGBITS, Sh_offset: 0x90, Sh_size: 9},
+		{Sh_name: 24, Sh_type: types.SHT_STRTAB, Sh_offset: shstrtabOff, Sh_size: uint64(len(shstrtab))},
+	}
+	for i := range sections {
+		binary.Encode(buf[shoff+64*i:], binary.LittleEndian, sections[i])
+	}
+
+	p := elf.NewParser(reader.NewMemoryReader(buf))
+	if err := p.Load(""); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(p.Close)
+
+	f, err := p.File()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p, f
+}
+
+func TestScanFile(t *testing.T) {
+	p, f := testFile(t)
+
+	sections := []Region{
+		{Name: ".rodata", Offset: 0x80, Size: 8},
+		{Name: ".data", Offset: 0x88, Size: 8},
+		{Name: ".comment", Offset: 0x90, Size: 9},
+	}
+	segment := []Region{{Name: "segment 0 (LOAD)", Offset: 0, Size: 0x90}}
+
+	tests := []struct {
+		name    string
+		regions []Region
+		minLen  int
+		want    []Located
+	}{
+		// The string of .rodata runs off the end of the section and is cut there
+		{"sections", sections, 4, []Located{
+			{Offset: 0x80, Address: 0x400080, Mapped: true, Section: ".rodata", Value: "abcdefgh"},
+			{Offset: 0x88, Address: 0x400088, Mapped: true, Section: ".data", Value: "ijkl"},
+			{Offset: 0x90, Section: ".comment", Value: "GCC: 1.0"},
+		}},
+		{"minimum length", sections, 2, []Located{
+			{Offset: 0x80, Address: 0x400080, Mapped: true, Section: ".rodata", Value: "abcdefgh"},
+			{Offset: 0x88, Address: 0x400088, Mapped: true, Section: ".data", Value: "ijkl"},
+			{Offset: 0x8d, Address: 0x40008d, Mapped: true, Section: ".data", Value: "hi"},
+			{Offset: 0x90, Section: ".comment", Value: "GCC: 1.0"},
+		}},
+		// A segment does not stop at section boundaries, the string belongs to its first section
+		{"across sections", segment, 4, []Located{
+			{Offset: 0x80, Address: 0x400080, Mapped: true, Section: ".rodata", Value: "abcdefghijkl"},
+		}},
+		// Overlapping regions report a string once
+		{"overlapping regions", []Region{sections[1], sections[1]}, 4, []Located{
+			{Offset: 0x88, Address: 0x400088, Mapped: true, Section: ".data", Value: "ijkl"},
+		}},
+		{"no section", []Region{{Name: "header", Offset: 0, Size: 4}}, 3, []Located{
+			{Offset: 1, Address: 0x400001, Mapped: true, Value: "ELF"},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ScanFile(p, f, tt.regions, tt.minLen, []Encoding{ASCII})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ScanFile() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := ScanFile(p, f, []Region{{Name: "past the end", Offset: 0x100, Size: 0x1000}}, 4, []Encoding{ASCII}); err == nil {
+		t.Error("ScanFile() of a region past the end of the file succeeded")
+	}
+}
@@ -0,0 +1,398 @@
+// Package symhash replays the symbol lookups the dynamic linker performs through the
+// DT_GNU_HASH and DT_HASH tables, and measures how well those tables spread the symbols.
+package symhash
+
+import (
+	"math/bits"
+	"strings"
+
+	"github.com/yourpwnguy/strix/pkg/elf"
+	"github.com/yourpwnguy/strix/pkg/elf/types"
+)
+
+// Table kinds.
+const (
+	KindGNU  = "DT_GNU_HASH"
+	KindSysV = "DT_HASH"
+)
+
+// Reasons a probed symbol is not the one looked up.
+const (
+	ReasonHash        = "hash mismatch"
+	ReasonName        = "name mismatch"
+	ReasonMissing     = "no such symbol"
+	ReasonUndefined   = "undefined"
+	ReasonNoValue     = "no value"
+	ReasonType        = "type not exported"
+	ReasonLocal       = "local binding"
+	ReasonVersion     = "other version"
+	ReasonVersioned   = "versioned, no version asked for"
+	ReasonHiddenMatch = "non-default version"
+)
+
+// Symbol is the dynamic symbol a lookup ended on. Its strings are copies.
+type Symbol struct {
+	types.Symbol
+
+	Version string // Version node, empty for unversioned symbols
+	Hidden  bool   // Non-default version (name@VER rather than name@@VER)
+}
+
+// Probe is one chain entry the lookup compared against the name.
+type Probe struct {
+	Index  uint32 // Dynamic symbol index
+	Name   string
+	Hash   uint32 // Hash stored in the GNU chain, with the end of chain bit cleared
+	Reason string // Why the symbol was passed over, empty for the match
+}
+
+// Lookup is the walk through one table for one name.
+type Lookup struct {
+	Hash uint32 // GNU or SysV hash of the name
+
+	// Bloom filter test, GNU tables only. A miss ends the lookup before any bucket is read.
+	BloomWord uint32    // Index of the bloom filter word
+	BloomBits [2]uint32 // The two bits the hash sets in that word
+	BloomHit  bool
+
+	Bucket      uint32 // Bucket index
+	Start       uint32 // First symbol of the bucket, 0 for an empty bucket
+	ChainLength int    // Symbols in the bucket
+	Probes      []Probe
+
+	Symbol *Symbol // Resolved symbol, nil when not found
+}
+
+// Stats describes how evenly a table spreads its symbols.
+type Stats struct {
+	Buckets   int
+	Symbols   int   // Symbols reachable through the buckets
+	SymOffset int   // Dynamic symbols before the first hashed one, GNU tables only
+	Histogram []int // Histogram[n] is the number of buckets holding n symbols
+
+	BloomWords int // GNU tables only
+	BloomShift uint32
+	BloomSet   int // Bits set in the bloom filter
+	BloomSize  int // Bits in the bloom filter
+}
+
+// Longest returns the length of the longest chain.
+func (s *Stats) Longest() int {
+	return max(len(s.Histogram)-1, 0)
+}
+
+// Table is the analysis of one hash table.
+type Table struct {
+	Kind   string // KindGNU or KindSysV
+	Offset uint64 // File offset
+	Stats  Stats
+	Lookup *Lookup // nil when no symbol was looked up
+}
+
+// Report covers both hash tables of a file. The loader only uses the GNU table when both
+// are present.
+type Report struct {
+	Name    string // Symbol looked up, without its version
+	Version string // Version asked for, empty for a plain lookup
+	GNU     *Table // nil without DT_GNU_HASH
+	SysV    *Table // nil without DT_HASH
+}
+
+// Used returns the table the loader looks symbols up in, nil for files without one.
+func (r *Report) Used() *Table {
+	if r.GNU != nil {
+		return r.GNU
+	}
+	return r.SysV
+}
+
+// Tables returns the tables present, the one the loader uses first.
+func (r *Report) Tables() []*Table {
+	var out []*Table
+	for _, t := range []*Table{r.GNU, r.SysV} {
+		if t != nil {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// SplitName splits a "name@VERSION" or "name@@VERSION" lookup into the symbol name and version.
+func SplitName(s string) (name, version string) {
+	name, version, _ = strings.Cut(s, "@")
+	return name, strings.TrimPrefix(version, "@")
+}
+
+// Analyze measures the hash tables of a file and, when symbol is not empty, looks it up in
+// each of them. A version is given as in SplitName, the lookup then behaves like dlvsym,
+// otherwise like dlsym.
+func Analyze(p *elf.Parser, symbol string) (*Report, error) {
+	gnu, err := p.GNUHash()
+	if err != nil {
+		return nil, err
+	}
+
+	sysv, err := p.SysVHash()
+	if err != nil {
+		return nil, err
+	}
+
+	dynsym, err := p.DynamicSymbols()
+	if err != nil {
+		return nil, err
+	}
+
+	versions, err := p.Versions()
+	if err != nil {
+		return nil, err
+	}
+
+	name, version := SplitName(symbol)
+	m := &matcher{name: name, version: version, dynsym: dynsym, versions: versions}
+
+	report := &Report{Name: name, Version: version}
+	if gnu != nil {
+		report.GNU = &Table{Kind: KindGNU, Offset: gnu.Offset, Stats: GNUStats(gnu)}
+		if name != "" {
+			report.GNU.Lookup = m.gnu(gnu)
+		}
+	}
+	if sysv != nil {
+		report.SysV = &Table{Kind: KindSysV, Offset: sysv.Offset, Stats: SysVStats(sysv)}
+		if name != "" {
+			report.SysV.Lookup = m.sysv(sysv)
+		}
+	}
+
+	return report, nil
+}
+
+// matcher decides which symbols satisfy one lookup, following check_match in glibc.
+type matcher struct {
+	name     string
+	version  string
+	dynsym   []types.Symbol
+	versions *types.Versions
+
+	// Versioned symbols a plain lookup passed over. When exactly one of them is a default
+	// version, the loader falls back to it.
+	fallback *Symbol
+	defaults int
+}
+
+// gnu walks the GNU table the way do_lookup_x does: bloom filter, bucket, then the chain
+// until an entry has its end bit set.
+func (m *matcher) gnu(t *types.GNUHashTable) *Lookup {
+	m.fallback, m.defaults = nil, 0
+	l := &Lookup{Hash: types.GNUHash(m.name)}
+
+	if len(t.Bloom) > 0 {
+		words := uint32(len(t.Bloom))
+		l.BloomWord = (l.Hash / t.BloomBits) & (words - 1)
+		l.BloomBits = [2]uint32{l.Hash % t.BloomBits, (l.Hash >> (t.BloomShift & 31)) % t.BloomBits}
+
+		word := t.Bloom[l.BloomWord%words]
+		l.BloomHit = word>>l.BloomBits[0]&1 != 0 && word>>l.BloomBits[1]&1 != 0
+	}
+
+	if len(t.Buckets) == 0 {
+		return l
+	}
+
+	l.Bucket = l.Hash % uint32(len(t.Buckets))
+	l.Start = t.Buckets[l.Bucket]
+	if l.Start == 0 {
+		return l
+	}
+
+	for index := l.Start; index-t.SymOffset < uint32(len(t.Chains)); index++ {
+		stored := t.Chains[index-t.SymOffset]
+		l.ChainLength++
+
+		// Symbols past a bloom filter miss are counted, never compared
+		if l.BloomHit && l.Symbol == nil {
+			var probe Probe
+			if (stored^l.Hash)>>1 != 0 {
+				probe = Probe{Index: index, Reason: ReasonHash}
+				if index < uint32(len(m.dynsym)) {
+					probe.Name = strings.Clone(m.dynsym[index].Name)
+				}
+			} else {
+				probe = m.probe(index)
+			}
+			probe.Hash = stored &^ 1
+			l.Probes = append(l.Probes, probe)
+			if probe.Reason == "" {
+				l.Symbol = m.symbol(index)
+			}
+		}
+
+		if stored&1 != 0 {
+			break
+		}
+	}
+
+	if l.Symbol == nil && m.defaults == 1 {
+		l.Symbol = m.fallback
+	}
+	return l
+}
+
+// sysv walks the bucket chain of a DT_HASH table. Chains that loop are cut after visiting
+// every symbol once.
+func (m *matcher) sysv(t *types.SysVHashTable) *Lookup {
+	m.fallback, m.defaults = nil, 0
+	l := &Lookup{Hash: types.SysVHash(m.name)}
+
+	if len(t.Buckets) == 0 {
+		return l
+	}
+
+	l.Bucket = l.Hash % uint32(len(t.Buckets))
+	l.Start = t.Buckets[l.Bucket]
+
+	for index := l.Start; index != 0 && index < uint32(len(t.Chains)) && l.ChainLength < len(t.Chains); index = t.Chains[index] {
+		l.ChainLength++
+
+		if l.Symbol == nil {
+			probe := m.probe(index)
+			l.Probes = append(l.Probes, probe)
+			if probe.Reason == "" {
+				l.Symbol = m.symbol(index)
+			}
+		}
+	}
+
+	if l.Symbol == nil && m.defaults == 1 {
+		l.Symbol = m.fallback
+	}
+	return l
+}
+
+// probe compares the dynamic symbol at index against the lookup. Reason is empty on a match.
+func (m *matcher) probe(index uint32) Probe {
+	if index >= uint32(len(m.dynsym)) {
+		return Probe{Index: index, Reason: ReasonMissing}
+	}
+
+	sym := &m.dynsym[index]
+	probe := Probe{Index: index, Name: strings.Clone(sym.Name)}
+
+	switch {
+	case sym.Name != m.name:
+		probe.Reason = ReasonName
+	case sym.IsUndefined():
+		probe.Reason = ReasonUndefined
+	case sym.St_value == 0 && sym.Type() != types.STT_TLS:
+		probe.Reason = ReasonNoValue
+	case !exported(sym.Type()):
+		probe.Reason = ReasonType
+	case sym.Bind() != types.STB_GLOBAL && sym.Bind() != types.STB_WEAK && sym.Bind() != types.STB_GNU_UNIQUE:
+		probe.Reason = ReasonLocal
+	default:
+		probe.Reason = m.checkVersion(index)
+	}
+
+	return probe
+}
+
+// checkVersion applies the symbol versioning rules to a symbol whose name matched.
+func (m *matcher) checkVersion(index uint32) string {
+	if len(m.versions.Symbols) == 0 {
+		return ""
+	}
+
+	ver, versioned := m.versions.Symbol(index)
+	if m.version != "" {
+		if !versioned || ver.Name != m.version {
+			return ReasonVersion
+		}
+		return ""
+	}
+
+	// A plain lookup takes unversioned symbols and the base version of the file, and
+	// remembers the default versions it skips
+	if !versioned {
+		return ""
+	}
+	if ver.Hidden {
+		return ReasonHiddenMatch
+	}
+	if m.defaults++; m.defaults == 1 {
+		m.fallback = m.symbol(index)
+	}
+	return ReasonVersioned
+}
+
+// symbol copies the dynamic symbol at index with its version.
+func (m *matcher) symbol(index uint32) *Symbol {
+	sym := &m.dynsym[index]
+	out := &Symbol{Symbol: *sym}
+	out.Name = strings.Clone(sym.Name)
+	if ver, ok := m.versions.Symbol(index); ok {
+		out.Version, out.Hidden = strings.Clone(ver.Name), ver.Hidden
+	}
+	return out
+}
+
+// exported reports whether the loader binds references to symbols of this type (ALLOWED_STT).
+func exported(t uint8) bool {
+	switch t {
+	case types.STT_NOTYPE, types.STT_OBJECT, types.STT_FUNC, types.STT_COMMON, types.STT_TLS, types.STT_GNU_IFUNC:
+		return true
+	}
+	return false
+}
+
+// GNUStats measures the bucket chains and bloom filter of a GNU table.
+func GNUStats(t *types.GNUHashTable) Stats {
+	s := Stats{
+		Buckets:    len(t.Buckets),
+		SymOffset:  int(t.SymOffset),
+		BloomWords: len(t.Bloom),
+		BloomShift: t.BloomShift,
+		BloomSize:  len(t.Bloom) * int(t.BloomBits),
+	}
+	for _, w := range t.Bloom {
+		s.BloomSet += bits.OnesCount64(w)
+	}
+
+	for _, start := range t.Buckets {
+		length := 0
+		if start != 0 {
+			for index := start; index-t.SymOffset < uint32(len(t.Chains)); index++ {
+				length++
+				if t.Chains[index-t.SymOffset]&1 != 0 {
+					break
+				}
+			}
+		}
+		s.add(length)
+	}
+
+	return s
+}
+
+// SysVStats measures the bucket chains of a DT_HASH table.
+func SysVStats(t *types.SysVHashTable) Stats {
+	s := Stats{Buckets: len(t.Buckets)}
+
+	for _, start := range t.Buckets {
+		length := 0
+		for index := start; index != 0 && index < uint32(len(t.Chains)) && length < len(t.Chains); index = t.Chains[index] {
+			length++
+		}
+		s.add(length)
+	}
+
+	return s
+}
+
+// add records a bucket holding length symbols.
+func (s *Stats) add(length int) {
+	for len(s.Histogram) <= length {
+		s.Histogram = append(s.Histogram, 0)
+	}
+	s.Histogram[length]++
+	s.Symbols += length
+}
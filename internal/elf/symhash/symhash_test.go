@@ -0,0 +1,64 @@
+package symhash
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/yourpwnguy/strix/pkg/elf/types"
+)
+
+func TestHash(t *testing.T) {
+	tests := []struct {
+		name string
+		gnu  uint32
+		sysv uint32
+	}{
+		{"", 0x00001505, 0x00000000},
+		{"printf", 0x156b2bb8, 0x077905a6},
+		{"memcpy", 0x0d827590, 0x073c3a79},
+	}
+
+	for _, tt := range tests {
+		if got := types.GNUHash(tt.name); got != tt.gnu {
+			t.Errorf("GNUHash(%q) = %#08x, want %#08x", tt.name, got, tt.gnu)
+		}
+		if got := types.SysVHash(tt.name); got != tt.sysv {
+			t.Errorf("SysVHash(%q) = %#08x, want %#08x", tt.name, got, tt.sysv)
+		}
+	}
+}
+
+func TestGNUStats(t *testing.T) {
+	// Symbols 2 and 3 share bucket 0, symbol 4 is alone in bucket 2
+	table := &types.GNUHashTable{
+		SymOffset: 2,
+		BloomBits: 64,
+		Bloom:     []uint64{0b1011},
+		Buckets:   []uint32{2, 0, 4},
+		Chains:    []uint32{0x10, 0x21, 0x31},
+	}
+
+	s := GNUStats(table)
+	if s.Buckets != 3 || s.Symbols != 3 || s.Longest() != 2 || s.BloomSet != 3 || s.BloomSize != 64 {
+		t.Errorf("GNUStats = %+v", s)
+	}
+	if want := []int{1, 1, 1}; !slices.Equal(s.Histogram, want) {
+		t.Errorf("GNUStats histogram = %v, want %v", s.Histogram, want)
+	}
+}
+
+func TestSplitName(t *testing.T) {
+	tests := []struct {
+		in, name, version string
+	}{
+		{"printf", "printf", ""},
+		{"memcpy@GLIBC_2.2.5", "memcpy", "GLIBC_2.2.5"},
+		{"memcpy@@GLIBC_2.14", "memcpy", "GLIBC_2.14"},
+	}
+
+	for _, tt := range tests {
+		if name, version := SplitName(tt.in); name != tt.name || version != tt.version {
+			t.Errorf("SplitName(%q) = %q, %q, want %q, %q", tt.in, name, version, tt.name, tt.version)
+		}
+	}
+}
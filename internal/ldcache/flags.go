@@ -0,0 +1,179 @@
+package ldcache
+
+import (
+	"github.com/yourpwnguy/strix/pkg/elf/types"
+)
+
+// Entry flags (FLAG_* in glibc's ldconfig.h). The low byte is the file type, the second
+// byte the architecture variant the library requires.
+const (
+	FlagTypeMask     int32 = 0x00ff
+	FlagLibc4        int32 = 0x0000
+	FlagELF          int32 = 0x0001 // ELF without a libc, accepted by every loader
+	FlagELFLibc5     int32 = 0x0002
+	FlagELFLibc6     int32 = 0x0003
+	FlagRequiredMask int32 = 0xff00
+
+	FlagSparcLib64              int32 = 0x0100
+	FlagIA64Lib64               int32 = 0x0200
+	FlagX8664Lib64              int32 = 0x0300
+	FlagS390Lib64               int32 = 0x0400
+	FlagPowerPCLib64            int32 = 0x0500
+	FlagMIPS64LibN32            int32 = 0x0600
+	FlagMIPS64LibN64            int32 = 0x0700
+	FlagX8664LibX32             int32 = 0x0800
+	FlagARMLibHF                int32 = 0x0900
+	FlagAArch64Lib64            int32 = 0x0a00
+	FlagARMLibSF                int32 = 0x0b00
+	FlagMIPSLib32NaN2008        int32 = 0x0c00
+	FlagMIPS64LibN32NaN2008     int32 = 0x0d00
+	FlagMIPS64LibN64NaN2008     int32 = 0x0e00
+	FlagRISCVFloatABISoft       int32 = 0x0f00
+	FlagRISCVFloatABIDouble     int32 = 0x1000
+	FlagLoongArchFloatABISoft   int32 = 0x1100
+	FlagLoongArchFloatABIDouble int32 = 0x1200
+)
+
+// typeNames are the file type names ldconfig -p prints.
+var typeNames = map[int32]string{
+	FlagLibc4:    "libc4",
+	FlagELF:      "ELF",
+	FlagELFLibc5: "libc5",
+	FlagELFLibc6: "libc6",
+}
+
+// archNames are the architecture names ldconfig -p prints after the file type.
+var archNames = map[int32]string{
+	FlagSparcLib64:              "64bit",
+	FlagIA64Lib64:               "IA-64",
+	FlagX8664Lib64:              "x86-64",
+	FlagS390Lib64:               "64bit",
+	FlagPowerPCLib64:            "64bit",
+	FlagMIPS64LibN32:            "N32",
+	FlagMIPS64LibN64:            "64bit",
+	FlagX8664LibX32:             "x32",
+	FlagARMLibHF:                "hard-float",
+	FlagAArch64Lib64:            "AArch64",
+	FlagARMLibSF:                "soft-float",
+	FlagMIPSLib32NaN2008:        "nan2008",
+	FlagMIPS64LibN32NaN2008:     "N32,nan2008",
+	FlagMIPS64LibN64NaN2008:     "64bit,nan2008",
+	FlagRISCVFloatABISoft:       "soft-float",
+	FlagRISCVFloatABIDouble:     "double-float",
+	FlagLoongArchFloatABISoft:   "soft-float",
+	FlagLoongArchFloatABIDouble: "double-float",
+}
+
+// TypeName returns the file type of entry flags, e.g. "libc6".
+func TypeName(flags int32) string {
+	if name, ok := typeNames[flags&FlagTypeMask]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+// ArchName returns the architecture variant of entry flags, e.g. "x86-64". It is empty for
+// entries without one, such as 32-bit x86 libraries.
+func ArchName(flags int32) string {
+	required := flags & FlagRequiredMask
+	if required == 0 {
+		return ""
+	}
+	if name, ok := archNames[required]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+// FlagNames describes entry flags the way ldconfig -p does, e.g. "libc6,x86-64".
+func FlagNames(flags int32) string {
+	if arch := ArchName(flags); arch != "" {
+		return TypeName(flags) + "," + arch
+	}
+	return TypeName(flags)
+}
+
+// DefaultFlags returns the entry flags the loader of a file with this ELF header accepts
+// besides FlagELF (_DL_CACHE_DEFAULT_ID of its glibc port). The float ABI of ARM, MIPS,
+// RISC-V and LoongArch files is read from e_flags.
+func DefaultFlags(ehdr *types.Elf64_Ehdr) int32 {
+	is64 := ehdr.E_ident.Ei_class == types.ELFCLASS64
+	flags := ehdr.E_flags
+
+	switch ehdr.E_machine {
+	case types.EM_X86_64:
+		if is64 {
+			return FlagELFLibc6 | FlagX8664Lib64
+		}
+		return FlagELFLibc6 | FlagX8664LibX32
+	case types.EM_AARCH64:
+		return FlagELFLibc6 | FlagAArch64Lib64
+	case types.EM_IA_64:
+		return FlagELFLibc6 | FlagIA64Lib64
+	case types.EM_SPARCV9:
+		return FlagELFLibc6 | FlagSparcLib64
+	case types.EM_S390, types.EM_PPC64:
+		if !is64 {
+			return FlagELFLibc6
+		}
+		if ehdr.E_machine == types.EM_S390 {
+			return FlagELFLibc6 | FlagS390Lib64
+		}
+		return FlagELFLibc6 | FlagPowerPCLib64
+	case types.EM_ARM:
+		if flags&types.EF_ARM_ABI_FLOAT_HARD != 0 {
+			return FlagELFLibc6 | FlagARMLibHF
+		}
+		return FlagELFLibc6 | FlagARMLibSF
+	case types.EM_MIPS:
+		nan2008 := flags&types.EF_MIPS_NAN2008 != 0
+		switch {
+		case is64 && nan2008:
+			return FlagELFLibc6 | FlagMIPS64LibN64NaN2008
+		case is64:
+			return FlagELFLibc6 | FlagMIPS64LibN64
+		case flags&types.EF_MIPS_ABI2 != 0 && nan2008:
+			return FlagELFLibc6 | FlagMIPS64LibN32NaN2008
+		case flags&types.EF_MIPS_ABI2 != 0:
+			return FlagELFLibc6 | FlagMIPS64LibN32
+		case nan2008:
+			return FlagELFLibc6 | FlagMIPSLib32NaN2008
+		}
+		return FlagELFLibc6
+	case types.EM_RISCV:
+		if flags&types.EF_RISCV_FLOAT_ABI == types.EF_RISCV_FLOAT_ABI_DOUBLE {
+			return FlagELFLibc6 | FlagRISCVFloatABIDouble
+		}
+		return FlagELFLibc6 | FlagRISCVFloatABISoft
+	case types.EM_LOONGARCH:
+		if flags&types.EF_LARCH_ABI_MODIFIER_MASK == types.EF_LARCH_ABI_DOUBLE_FLOAT {
+			return FlagELFLibc6 | FlagLoongArchFloatABIDouble
+		}
+		return FlagELFLibc6 | FlagLoongArchFloatABISoft
+	}
+
+	return FlagELFLibc6
+}
+
+// Lookup returns the paths the cache maps name to for a file with this ELF header, in the
+// order the loader tries them. Baseline entries come first: which glibc-hwcaps subdirectory
+// the loader prefers depends on the CPU it runs on, while the baseline loads everywhere.
+func (c *Cache) Lookup(name string, ehdr *types.Elf64_Ehdr) []string {
+	want := DefaultFlags(ehdr)
+
+	var baseline, hwcaps []string
+	for i := range c.Entries {
+		e := &c.Entries[i]
+		if e.Name != name || (e.Flags != FlagELF && e.Flags != want) {
+			continue
+		}
+
+		if e.Hwcaps != "" {
+			hwcaps = append(hwcaps, e.Path)
+		} else {
+			baseline = append(baseline, e.Path)
+		}
+	}
+
+	return append(baseline, hwcaps...)
+}
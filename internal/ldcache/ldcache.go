@@ -0,0 +1,268 @@
+// Package ldcache reads the glibc loader cache (/etc/ld.so.cache) that ldconfig writes,
+// which maps library names to the files the loader opens for them.
+package ldcache
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/yourpwnguy/strix/internal/reader"
+)
+
+// Formats of the cache file.
+const (
+	FormatOld    = "ld.so-1.7.0"                        // libc5 era format, 32-bit hwcaps-less entries
+	FormatNew    = "glibc-ld.so.cache1.1"               // Current format, the only one written since glibc 2.32
+	FormatCompat = "ld.so-1.7.0 + glibc-ld.so.cache1.1" // Old entries followed by the new format
+)
+
+const (
+	magicOld = "ld.so-1.7.0"
+	magicNew = "glibc-ld.so.cache1.1"
+
+	oldHeaderSize = 16 // magic[11], pad, nlibs
+	oldEntrySize  = 12 // flags, key, value
+	newHeaderSize = 48 // magic[20], nlibs, len_strings, flags, pad[3], extension_offset, unused[3]
+	newEntrySize  = 24 // flags, key, value, osversion, hwcap
+
+	// Byte order recorded in the new header (cache_file_new_flags_endian_*)
+	endianMask   = 3
+	endianLittle = 2
+	endianBig    = 3
+
+	// Extension directory of the new format, which holds the glibc-hwcaps subdirectories
+	extensionMagic = 0xeaa42174
+	extensionSize  = 8  // magic, count
+	sectionSize    = 16 // tag, flags, offset, size
+	tagGenerator   = 0
+	tagHwcaps      = 1
+
+	// An entry with these top bits in hwcap lives in the glibc-hwcaps subdirectory indexed by
+	// the low 32 bits (DL_CACHE_HWCAP_EXTENSION)
+	hwcapExtension = 1 << 62
+)
+
+// Failures to read a cache file. They are wrapped with details, so match them with errors.Is.
+var (
+	ErrInvalidMagic = errors.New("not a loader cache")
+	ErrMalformed    = errors.New("malformed loader cache")
+)
+
+// Entry maps one library name to a file.
+type Entry struct {
+	Name      string // Name the loader looks up (e.g. "libc.so.6")
+	Path      string // File it maps to
+	Flags     int32  // File type and required architecture, see the Flag* constants
+	OSVersion uint32 // Minimum kernel version (OS in the top byte), 0 when unset
+	HWCap     uint64 // Legacy hwcap bits, or the hwcaps extension index
+	Hwcaps    string // glibc-hwcaps subdirectory (e.g. "x86-64-v3"), empty for the baseline
+}
+
+// Cache is a parsed loader cache. Entries are in file order, which ldconfig sorts by name
+// with the newest version first. Strings are copies, the cache does not hold the file open.
+type Cache struct {
+	Format    string
+	ByteOrder binary.ByteOrder
+	Generator string   // Program that wrote the cache, new format only
+	Hwcaps    []string // glibc-hwcaps subdirectories entries may refer to
+	Entries   []Entry
+}
+
+// Open memory maps and parses the cache file at path.
+func Open(path string) (*Cache, error) {
+	r := &reader.MmapReader{}
+	data, err := r.Read(path)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	return Parse(data)
+}
+
+// Parse decodes a cache image in either format. The result does not reference data.
+func Parse(data []byte) (*Cache, error) {
+	switch {
+	case bytes.HasPrefix(data, []byte(magicNew)):
+		return parseNew(data, 0, FormatNew)
+
+	case bytes.HasPrefix(data, []byte(magicOld)):
+		cache, err := parseOld(data)
+		if err != nil {
+			return nil, err
+		}
+
+		// The compat format appends a whole new format cache, aligned to 8 bytes,
+		// which the loader prefers
+		end := oldHeaderSize + uint64(len(cache.Entries))*oldEntrySize
+		start := (end + 7) &^ 7
+		if start < uint64(len(data)) && bytes.HasPrefix(data[start:], []byte(magicNew)) {
+			return parseNew(data, start, FormatCompat)
+		}
+		return cache, nil
+	}
+
+	return nil, fmt.Errorf("%w: %q", ErrInvalidMagic, data[:min(len(data), len(magicNew))])
+}
+
+// parseOld decodes the old format, whose strings follow the entries. It carries no byte
+// order, so the one that makes the entry count fit the file is used.
+func parseOld(data []byte) (*Cache, error) {
+	if len(data) < oldHeaderSize {
+		return nil, fmt.Errorf("%w: %d bytes is too small for the header", ErrMalformed, len(data))
+	}
+
+	order, nlibs := byteOrder(data, 12, oldHeaderSize, oldEntrySize)
+	if nlibs > (uint64(len(data))-oldHeaderSize)/oldEntrySize {
+		return nil, fmt.Errorf("%w: %d entries exceed file size %#x", ErrMalformed, nlibs, len(data))
+	}
+
+	cache := &Cache{Format: FormatOld, ByteOrder: order, Entries: make([]Entry, nlibs)}
+	strtab := data[oldHeaderSize+nlibs*oldEntrySize:]
+
+	for i := range cache.Entries {
+		raw := data[oldHeaderSize+uint64(i)*oldEntrySize:]
+
+		e := &cache.Entries[i]
+		e.Flags = int32(order.Uint32(raw[0:]))
+
+		var err error
+		if e.Name, err = cString(strtab, order.Uint32(raw[4:])); err != nil {
+			return nil, fmt.Errorf("%w: entry %d name: %w", ErrMalformed, i, err)
+		}
+		if e.Path, err = cString(strtab, order.Uint32(raw[8:])); err != nil {
+			return nil, fmt.Errorf("%w: entry %d path: %w", ErrMalformed, i, err)
+		}
+	}
+
+	return cache, nil
+}
+
+// parseNew decodes the new format, whose header is at start in the file. String offsets are
+// relative to the header, the extension directory and its sections are at file offsets.
+func parseNew(file []byte, start uint64, format string) (*Cache, error) {
+	data := file[start:]
+	if len(data) < newHeaderSize {
+		return nil, fmt.Errorf("%w: %d bytes is too small for the header", ErrMalformed, len(data))
+	}
+
+	var order binary.ByteOrder
+	var nlibs uint64
+	switch data[28] & endianMask {
+	case endianLittle:
+		order, nlibs = binary.LittleEndian, uint64(binary.LittleEndian.Uint32(data[20:]))
+	case endianBig:
+		order, nlibs = binary.BigEndian, uint64(binary.BigEndian.Uint32(data[20:]))
+	default:
+		// Written before the byte order was recorded (glibc < 2.33)
+		order, nlibs = byteOrder(data, 20, newHeaderSize, newEntrySize)
+	}
+
+	if nlibs > (uint64(len(data))-newHeaderSize)/newEntrySize {
+		return nil, fmt.Errorf("%w: %d entries exceed file size %#x", ErrMalformed, nlibs, len(data))
+	}
+
+	cache := &Cache{Format: format, ByteOrder: order, Entries: make([]Entry, nlibs)}
+	if err := cache.parseExtensions(file, data, order.Uint32(data[32:])); err != nil {
+		return nil, err
+	}
+
+	for i := range cache.Entries {
+		raw := data[newHeaderSize+uint64(i)*newEntrySize:]
+
+		e := &cache.Entries[i]
+		e.Flags = int32(order.Uint32(raw[0:]))
+		e.OSVersion = order.Uint32(raw[12:])
+		e.HWCap = order.Uint64(raw[16:])
+
+		var err error
+		if e.Name, err = cString(data, order.Uint32(raw[4:])); err != nil {
+			return nil, fmt.Errorf("%w: entry %d name: %w", ErrMalformed, i, err)
+		}
+		if e.Path, err = cString(data, order.Uint32(raw[8:])); err != nil {
+			return nil, fmt.Errorf("%w: entry %d path: %w", ErrMalformed, i, err)
+		}
+
+		if e.HWCap>>32 == hwcapExtension>>32 {
+			index := uint32(e.HWCap)
+			if uint64(index) >= uint64(len(cache.Hwcaps)) {
+				return nil, fmt.Errorf("%w: entry %d refers to hwcaps subdirectory %d of %d", ErrMalformed, i, index, len(cache.Hwcaps))
+			}
+			e.Hwcaps = cache.Hwcaps[index]
+		}
+	}
+
+	return cache, nil
+}
+
+// parseExtensions reads the extension directory at offset in the file, if any: the name of
+// the program that wrote the cache and the glibc-hwcaps subdirectories, whose names are in
+// the strings of the new format.
+func (c *Cache) parseExtensions(file, strtab []byte, offset uint32) error {
+	if offset == 0 {
+		return nil
+	}
+
+	order := c.ByteOrder
+	size := uint64(len(file))
+	if uint64(offset)+extensionSize > size || order.Uint32(file[offset:]) != extensionMagic {
+		return fmt.Errorf("%w: no extension directory at %#x", ErrMalformed, offset)
+	}
+
+	count := uint64(order.Uint32(file[offset+4:]))
+	if count > (size-uint64(offset)-extensionSize)/sectionSize {
+		return fmt.Errorf("%w: %d extension sections exceed file size %#x", ErrMalformed, count, size)
+	}
+
+	for i := range count {
+		raw := file[uint64(offset)+extensionSize+i*sectionSize:]
+		start, length := uint64(order.Uint32(raw[8:])), uint64(order.Uint32(raw[12:]))
+		if start+length > size {
+			return fmt.Errorf("%w: extension section %d at %#x exceeds file size %#x", ErrMalformed, i, start, size)
+		}
+		section := file[start : start+length]
+
+		switch order.Uint32(raw[0:]) {
+		case tagGenerator:
+			c.Generator = string(bytes.TrimRight(section, "\x00"))
+
+		case tagHwcaps:
+			for j := 0; j+4 <= len(section); j += 4 {
+				name, err := cString(strtab, order.Uint32(section[j:]))
+				if err != nil {
+					return fmt.Errorf("%w: hwcaps subdirectory %d: %w", ErrMalformed, j/4, err)
+				}
+				c.Hwcaps = append(c.Hwcaps, name)
+			}
+		}
+	}
+
+	return nil
+}
+
+// byteOrder picks the byte order under which the entry count at countOffset fits the file,
+// preferring little endian.
+func byteOrder(data []byte, countOffset int, header, entry uint64) (binary.ByteOrder, uint64) {
+	limit := (uint64(len(data)) - header) / entry
+	for _, order := range []binary.ByteOrder{binary.LittleEndian, binary.BigEndian} {
+		if n := uint64(order.Uint32(data[countOffset:])); n <= limit {
+			return order, n
+		}
+	}
+	return binary.LittleEndian, uint64(binary.LittleEndian.Uint32(data[countOffset:]))
+}
+
+// cString copies the NUL terminated string at offset in data, so it outlives the mapping.
+func cString(data []byte, offset uint32) (string, error) {
+	if uint64(offset) >= uint64(len(data)) {
+		return "", fmt.Errorf("string offset %#x out of bounds", offset)
+	}
+
+	end := bytes.IndexByte(data[offset:], 0)
+	if end < 0 {
+		return "", fmt.Errorf("unterminated string at %#x", offset)
+	}
+	return string(data[offset : offset+uint32(end)]), nil
+}
@@ -0,0 +1,94 @@
+package ldcache
+
+import (
+	"encoding/binary"
+	"slices"
+	"testing"
+
+	"github.com/yourpwnguy/strix/pkg/elf/types"
+)
+
+// newCache builds a new format cache with one library in the baseline directory and in
+// the x86-64-v3 glibc-hwcaps subdirectory.
+func newCache() []byte {
+	le := binary.LittleEndian
+	strtab := newHeaderSize + 2*newEntrySize
+
+	var strs []byte
+	str := func(s string) uint32 {
+		off := uint32(strtab + len(strs))
+		strs = append(append(strs, s...), 0)
+		return off
+	}
+	name := str("libf.so.1")
+	hwcapsPath := str("/lib/glibc-hwcaps/x86-64-v3/libf.so.1")
+	path := str("/lib/libf.so.1")
+	subdir := str("x86-64-v3")
+
+	data := make([]byte, strtab)
+	copy(data, magicNew)
+	le.PutUint32(data[20:], 2)
+	data[28] = endianLittle
+
+	entries := []struct {
+		path  uint32
+		hwcap uint64
+	}{{hwcapsPath, hwcapExtension}, {path, 0}}
+	for i, e := range entries {
+		raw := data[newHeaderSize+i*newEntrySize:]
+		le.PutUint32(raw[0:], uint32(FlagELFLibc6|FlagX8664Lib64))
+		le.PutUint32(raw[4:], name)
+		le.PutUint32(raw[8:], e.path)
+		le.PutUint64(raw[16:], e.hwcap)
+	}
+	data = append(data, strs...)
+
+	// Extension directory with the hwcaps subdirectory array right after it
+	ext := uint32(len(data))
+	le.PutUint32(data[32:], ext)
+	data = le.AppendUint32(data, extensionMagic)
+	data = le.AppendUint32(data, 1)
+	data = le.AppendUint32(data, tagHwcaps)
+	data = le.AppendUint32(data, 0)
+	data = le.AppendUint32(data, ext+extensionSize+sectionSize)
+	data = le.AppendUint32(data, 4)
+	data = le.AppendUint32(data, subdir)
+
+	return data
+}
+
+func TestParseNew(t *testing.T) {
+	cache, err := Parse(newCache())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if cache.Format != FormatNew || !slices.Equal(cache.Hwcaps, []string{"x86-64-v3"}) {
+		t.Fatalf("format %q, hwcaps %q", cache.Format, cache.Hwcaps)
+	}
+	if len(cache.Entries) != 2 || cache.Entries[0].Hwcaps != "x86-64-v3" || cache.Entries[1].Hwcaps != "" {
+		t.Fatalf("entries %+v", cache.Entries)
+	}
+
+	ehdr := &types.Elf64_Ehdr{E_machine: types.EM_X86_64}
+	ehdr.E_ident.Ei_class = types.ELFCLASS64
+	want := []string{"/lib/libf.so.1", "/lib/glibc-hwcaps/x86-64-v3/libf.so.1"}
+	if got := cache.Lookup("libf.so.1", ehdr); !slices.Equal(got, want) {
+		t.Errorf("Lookup = %q, want %q", got, want)
+	}
+
+	// x32 files need FLAG_X8664_LIBX32 entries
+	ehdr.E_ident.Ei_class = types.ELFCLASS32
+	if got := cache.Lookup("libf.so.1", ehdr); len(got) != 0 {
+		t.Errorf("Lookup for x32 = %q, want nothing", got)
+	}
+}
+
+func TestParseTruncated(t *testing.T) {
+	data := newCache()
+	for n := range len(data) {
+		if _, err := Parse(data[:n]); err == nil {
+			t.Errorf("Parse of %d of %d bytes succeeded", n, len(data))
+		}
+	}
+}
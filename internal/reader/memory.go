@@ -0,0 +1,21 @@
+package reader
+
+// Represents the Memory Reader for data that is already in memory (tests, fuzzing, embedded blobs)
+type MemoryReader struct {
+	data []byte
+}
+
+// NewMemoryReader returns a reader that serves data regardless of the path it is asked for.
+func NewMemoryReader(data []byte) *MemoryReader {
+	return &MemoryReader{data: data}
+}
+
+// Read returns the in-memory data. The path is ignored.
+func (m *MemoryReader) Read(path string) ([]byte, error) {
+	return m.data, nil
+}
+
+// Close drops the reference to the data, the caller still owns the underlying bytes.
+func (m *MemoryReader) Close() {
+	m.data = nil
+}
@@ -1,28 +1,126 @@
 package unsafe
 
 import (
+	"encoding/binary"
 	"unsafe"
 
-	"github.com/yourpwnguy/strix/internal/elf/types"
+	"github.com/yourpwnguy/strix/pkg/elf/types"
 )
 
+// castable reports whether count T values starting at offset lie inside data
+// at an address that is suitably aligned for T.
+func castable[T any](data []byte, count uint64, offset uint64) bool {
+	var zero T
+	size := uint64(unsafe.Sizeof(zero))
+
+	if count == 0 || offset >= uint64(len(data)) {
+		return false
+	}
+
+	if count > (uint64(len(data))-offset)/size {
+		return false
+	}
+
+	return uintptr(unsafe.Pointer(&data[offset]))%unsafe.Alignof(zero) == 0
+}
+
+// cast reinterprets the bytes at offset as a *T with zero copying.
+// Returns nil when the value does not fit in data or would be misaligned.
+func cast[T any](data []byte, offset uint64) *T {
+	if !castable[T](data, 1, offset) {
+		return nil
+	}
+	return (*T)(unsafe.Pointer(&data[offset]))
+}
+
+// castSlice reinterprets count consecutive T values starting at offset with zero copying.
+// Returns nil when the values do not fit in data or would be misaligned.
+func castSlice[T any](data []byte, count uint64, offset uint64) []T {
+	if !castable[T](data, count, offset) {
+		return nil
+	}
+	return unsafe.Slice((*T)(unsafe.Pointer(&data[offset])), count)
+}
+
 // CastHeader performs a zero-copy cast of raw bytes to an Elf64_Ehdr structure.
+// Like every cast in this package, it returns nil instead of reading out of bounds.
 func CastHeader(data []byte) *types.Elf64_Ehdr {
-	return (*types.Elf64_Ehdr)(unsafe.Pointer(&data[0]))
+	return cast[types.Elf64_Ehdr](data, 0)
+}
+
+// CastHeader32 performs a zero-copy cast of raw bytes to an Elf32_Ehdr structure.
+func CastHeader32(data []byte) *types.Elf32_Ehdr {
+	return cast[types.Elf32_Ehdr](data, 0)
 }
 
 // CastProgramHeaders casts raw bytes to []Elf64_Phdr with zero copying.
-func CastProgramHeaders(data []byte, count uint16, offset uint64) []types.Elf64_Phdr {
-	// Start of ELF program headers
-	ptr := unsafe.Pointer(&data[offset])
-	return unsafe.Slice((*types.Elf64_Phdr)(ptr), count)
+func CastProgramHeaders(data []byte, count uint64, offset uint64) []types.Elf64_Phdr {
+	return castSlice[types.Elf64_Phdr](data, count, offset)
+}
+
+// CastProgramHeaders32 casts raw bytes to []Elf32_Phdr with zero copying.
+func CastProgramHeaders32(data []byte, count uint64, offset uint64) []types.Elf32_Phdr {
+	return castSlice[types.Elf32_Phdr](data, count, offset)
+}
+
+// CastSectionHeaders casts raw bytes to []Elf64_Shdr with zero copying.
+func CastSectionHeaders(data []byte, count uint64, offset uint64) []types.Elf64_Shdr {
+	return castSlice[types.Elf64_Shdr](data, count, offset)
+}
+
+// CastSectionHeaders32 casts raw bytes to []Elf32_Shdr with zero copying.
+func CastSectionHeaders32(data []byte, count uint64, offset uint64) []types.Elf32_Shdr {
+	return castSlice[types.Elf32_Shdr](data, count, offset)
+}
+
+// CastSymbols casts raw bytes to []Elf64_Sym with zero copying.
+func CastSymbols(data []byte, count uint64, offset uint64) []types.Elf64_Sym {
+	return castSlice[types.Elf64_Sym](data, count, offset)
 }
 
-// castSectionHeaders casts raw bytes to []Elf64_Shdr with zero copying.
-func CastSectionHeaders(data []byte, count uint16, offset uint64) []types.Elf64_Shdr {
-	// Start of ELF section headers
-	ptr := unsafe.Pointer(&data[offset])
-	return unsafe.Slice((*types.Elf64_Shdr)(ptr), count)
+// CastSymbols32 casts raw bytes to []Elf32_Sym with zero copying.
+func CastSymbols32(data []byte, count uint64, offset uint64) []types.Elf32_Sym {
+	return castSlice[types.Elf32_Sym](data, count, offset)
+}
+
+// CastWords casts raw bytes to a []uint32 table (e.g. .symtab_shndx) with zero copying.
+func CastWords(data []byte, count uint64, offset uint64) []uint32 {
+	return castSlice[uint32](data, count, offset)
+}
+
+// CastDynamic casts raw bytes to []Elf64_Dyn with zero copying.
+func CastDynamic(data []byte, count uint64, offset uint64) []types.Elf64_Dyn {
+	return castSlice[types.Elf64_Dyn](data, count, offset)
+}
+
+// CastDynamic32 casts raw bytes to []Elf32_Dyn with zero copying.
+func CastDynamic32(data []byte, count uint64, offset uint64) []types.Elf32_Dyn {
+	return castSlice[types.Elf32_Dyn](data, count, offset)
+}
+
+// CastXwords casts raw bytes to a []uint64 table (e.g. .init_array of ELF64) with zero copying.
+func CastXwords(data []byte, count uint64, offset uint64) []uint64 {
+	return castSlice[uint64](data, count, offset)
+}
+
+// CastRel casts raw bytes to []Elf64_Rel with zero copying.
+func CastRel(data []byte, count uint64, offset uint64) []types.Elf64_Rel {
+	return castSlice[types.Elf64_Rel](data, count, offset)
+}
+
+// CastRel32 casts raw bytes to []Elf32_Rel with zero copying.
+func CastRel32(data []byte, count uint64, offset uint64) []types.Elf32_Rel {
+	return castSlice[types.Elf32_Rel](data, count, offset)
+}
+
+// CastRela casts raw bytes to []Elf64_Rela with zero copying.
+func CastRela(data []byte, count uint64, offset uint64) []types.Elf64_Rela {
+	return castSlice[types.Elf64_Rela](data, count, offset)
+}
+
+// CastRela32 casts raw bytes to []Elf32_Rela with zero copying.
+func CastRela32(data []byte, count uint64, offset uint64) []types.Elf32_Rela {
+	return castSlice[types.Elf32_Rela](data, count, offset)
 }
 
 // HasValidMagic checks if the data starts with the ELF magic number (0x7f 'E' 'L' 'F').
@@ -34,7 +132,20 @@ func HasValidMagic(data []byte) bool {
 		data[3] == 'F'
 }
 
-// HasMinimumSize checks if the data buffer is large enough to contain an ELF64 header.
-func HasMinimumSize(data []byte) bool {
+// HasMinimumSize checks if the data buffer is large enough to contain the ELF header of the given class.
+func HasMinimumSize(data []byte, class uint8) bool {
+	if class == types.ELFCLASS32 {
+		return len(data) >= int(unsafe.Sizeof(types.Elf32_Ehdr{}))
+	}
 	return len(data) >= int(unsafe.Sizeof(types.Elf64_Ehdr{}))
 }
+
+// HostByteOrder returns the byte order of the machine strix is running on.
+// Casting is only valid when the file byte order matches it.
+func HostByteOrder() binary.ByteOrder {
+	x := uint16(1)
+	if *(*byte)(unsafe.Pointer(&x)) == 1 {
+		return binary.LittleEndian
+	}
+	return binary.BigEndian
+}
@@ -0,0 +1,236 @@
+package elf
+
+import (
+	"encoding/binary"
+
+	"github.com/yourpwnguy/strix/internal/unsafe"
+	"github.com/yourpwnguy/strix/pkg/elf/types"
+)
+
+// decoder turns the on-disk tables of one ELF class and byte order into the class-neutral views.
+// Native-endian ELF64 tables are served straight from the mapping, everything else is decoded
+// with encoding/binary and ELF32 tables are widened into the ELF64 layout.
+//
+// The decoder never reads out of bounds, but callers are expected to validate tables with
+// checkTable first so that problems surface as typed errors instead of empty results.
+type decoder struct {
+	class  uint8
+	order  binary.ByteOrder
+	native bool
+}
+
+// newDecoder returns the decoder for the given ei_class and ei_data.
+func newDecoder(class uint8, data uint8) *decoder {
+	order := binary.ByteOrder(binary.LittleEndian)
+	if data == types.ELFDATA2MSB {
+		order = binary.BigEndian
+	}
+
+	return &decoder{
+		class:  class,
+		order:  order,
+		native: order == unsafe.HostByteOrder(),
+	}
+}
+
+// is64 reports whether the decoder handles ELFCLASS64 files.
+func (d *decoder) is64() bool {
+	return d.class == types.ELFCLASS64
+}
+
+// entrySize returns the on-disk size of the ELF64 or ELF32 structure, depending on the class.
+func (d *decoder) entrySize(v64, v32 any) uint64 {
+	if d.is64() {
+		return uint64(binary.Size(v64))
+	}
+	return uint64(binary.Size(v32))
+}
+
+// phentsize returns the expected e_phentsize for the class.
+func (d *decoder) phentsize() uint64 {
+	return d.entrySize(types.Elf64_Phdr{}, types.Elf32_Phdr{})
+}
+
+// shentsize returns the expected e_shentsize for the class.
+func (d *decoder) shentsize() uint64 {
+	return d.entrySize(types.Elf64_Shdr{}, types.Elf32_Shdr{})
+}
+
+// symentsize returns the expected sh_entsize of a symbol table for the class.
+func (d *decoder) symentsize() uint64 {
+	return d.entrySize(types.Elf64_Sym{}, types.Elf32_Sym{})
+}
+
+// dynentsize returns the size of a dynamic section entry for the class.
+func (d *decoder) dynentsize() uint64 {
+	return d.entrySize(types.Elf64_Dyn{}, types.Elf32_Dyn{})
+}
+
+// relentsize returns the size of a relocation entry without addend for the class.
+func (d *decoder) relentsize() uint64 {
+	return d.entrySize(types.Elf64_Rel{}, types.Elf32_Rel{})
+}
+
+// relaentsize returns the size of a relocation entry with addend for the class.
+func (d *decoder) relaentsize() uint64 {
+	return d.entrySize(types.Elf64_Rela{}, types.Elf32_Rela{})
+}
+
+// addrsize returns the size of an address (and of a pointer array slot) for the class.
+func (d *decoder) addrsize() uint64 {
+	if d.is64() {
+		return 8
+	}
+	return 4
+}
+
+// header decodes the ELF header at the start of data.
+func (d *decoder) header(data []byte) *types.Elf64_Ehdr {
+	if d.is64() {
+		if d.native {
+			if hdr := unsafe.CastHeader(data); hdr != nil {
+				return hdr
+			}
+		}
+		if hdr := decodeSlice[types.Elf64_Ehdr](data, 1, 0, d.order); hdr != nil {
+			return &hdr[0]
+		}
+		return nil
+	}
+
+	var raw *types.Elf32_Ehdr
+	if d.native {
+		raw = unsafe.CastHeader32(data)
+	}
+	if raw == nil {
+		if hdr := decodeSlice[types.Elf32_Ehdr](data, 1, 0, d.order); hdr != nil {
+			raw = &hdr[0]
+		}
+	}
+	if raw == nil {
+		return nil
+	}
+
+	hdr := raw.Widen()
+	return &hdr
+}
+
+// programHeaders decodes count program headers starting at offset.
+func (d *decoder) programHeaders(data []byte, count uint64, offset uint64) []types.Elf64_Phdr {
+	if d.is64() {
+		return entries(d, data, count, offset, unsafe.CastProgramHeaders)
+	}
+	return widen(entries(d, data, count, offset, unsafe.CastProgramHeaders32), (*types.Elf32_Phdr).Widen)
+}
+
+// sectionHeaders decodes count section headers starting at offset.
+func (d *decoder) sectionHeaders(data []byte, count uint64, offset uint64) []types.Elf64_Shdr {
+	if d.is64() {
+		return entries(d, data, count, offset, unsafe.CastSectionHeaders)
+	}
+	return widen(entries(d, data, count, offset, unsafe.CastSectionHeaders32), (*types.Elf32_Shdr).Widen)
+}
+
+// symbols decodes count symbol table entries starting at offset.
+func (d *decoder) symbols(data []byte, count uint64, offset uint64) []types.Elf64_Sym {
+	if d.is64() {
+		return entries(d, data, count, offset, unsafe.CastSymbols)
+	}
+	return widen(entries(d, data, count, offset, unsafe.CastSymbols32), (*types.Elf32_Sym).Widen)
+}
+
+// words decodes count 32 bit words starting at offset. Word tables have the same layout in both classes.
+func (d *decoder) words(data []byte, count uint64, offset uint64) []uint32 {
+	return entries(d, data, count, offset, unsafe.CastWords)
+}
+
+// dynamic decodes count dynamic section entries starting at offset.
+func (d *decoder) dynamic(data []byte, count uint64, offset uint64) []types.Elf64_Dyn {
+	if d.is64() {
+		return entries(d, data, count, offset, unsafe.CastDynamic)
+	}
+	return widen(entries(d, data, count, offset, unsafe.CastDynamic32), (*types.Elf32_Dyn).Widen)
+}
+
+// rel decodes count relocation entries without addend starting at offset.
+func (d *decoder) rel(data []byte, count uint64, offset uint64) []types.Elf64_Rel {
+	if d.is64() {
+		return entries(d, data, count, offset, unsafe.CastRel)
+	}
+	return widen(entries(d, data, count, offset, unsafe.CastRel32), (*types.Elf32_Rel).Widen)
+}
+
+// rela decodes count relocation entries with addend starting at offset.
+func (d *decoder) rela(data []byte, count uint64, offset uint64) []types.Elf64_Rela {
+	if d.is64() {
+		return entries(d, data, count, offset, unsafe.CastRela)
+	}
+	return widen(entries(d, data, count, offset, unsafe.CastRela32), (*types.Elf32_Rela).Widen)
+}
+
+// addresses decodes count class sized addresses starting at offset.
+func (d *decoder) addresses(data []byte, count uint64, offset uint64) []uint64 {
+	if d.is64() {
+		return entries(d, data, count, offset, unsafe.CastXwords)
+	}
+	return widen(entries(d, data, count, offset, unsafe.CastWords), func(w *uint32) uint64 { return uint64(*w) })
+}
+
+// address decodes a single class sized word at offset. Returns false when it does not fit in data.
+func (d *decoder) address(data []byte, offset uint64) (uint64, bool) {
+	size := d.addrsize()
+	if offset > uint64(len(data)) || size > uint64(len(data))-offset {
+		return 0, false
+	}
+
+	if d.is64() {
+		return d.order.Uint64(data[offset:]), true
+	}
+	return uint64(d.order.Uint32(data[offset:])), true
+}
+
+// entries returns count T values starting at offset. They are cast in place when the file
+// byte order matches the host and the table is aligned, and decoded into a copy otherwise.
+func entries[T any](d *decoder, data []byte, count uint64, offset uint64, cast func([]byte, uint64, uint64) []T) []T {
+	if count == 0 {
+		return nil
+	}
+
+	if d.native {
+		if out := cast(data, count, offset); out != nil {
+			return out
+		}
+	}
+
+	return decodeSlice[T](data, count, offset, d.order)
+}
+
+// decodeSlice decodes count consecutive T values starting at offset in the given byte order.
+// Returns nil when the values do not fit in data.
+func decodeSlice[T any](data []byte, count uint64, offset uint64, order binary.ByteOrder) []T {
+	var zero T
+	size := uint64(binary.Size(zero))
+
+	if count == 0 || offset > uint64(len(data)) || count > (uint64(len(data))-offset)/size {
+		return nil
+	}
+
+	out := make([]T, count)
+	if _, err := binary.Decode(data[offset:], order, out); err != nil {
+		return nil
+	}
+	return out
+}
+
+// widen converts a table of ELF32 entries into their ELF64 counterparts.
+func widen[T32, T64 any](raw []T32, fn func(*T32) T64) []T64 {
+	if raw == nil {
+		return nil
+	}
+
+	out := make([]T64, len(raw))
+	for i := range raw {
+		out[i] = fn(&raw[i])
+	}
+	return out
+}
@@ -0,0 +1,166 @@
+package elf
+
+import (
+	"github.com/yourpwnguy/strix/pkg/elf/types"
+)
+
+// vaddrToOffset translates a virtual address into a file offset through the PT_LOAD segments.
+// Addresses in the memory-only tail of a segment (.bss) have no file backing and are not found.
+func vaddrToOffset(phdr []types.Elf64_Phdr, addr uint64) (uint64, bool) {
+	for i := range phdr {
+		ph := &phdr[i]
+		if ph.P_type != types.PT_LOAD {
+			continue
+		}
+
+		if addr >= ph.P_vaddr && addr-ph.P_vaddr < ph.P_filesz {
+			offset := ph.P_offset + (addr - ph.P_vaddr)
+			if offset < ph.P_offset {
+				return 0, false
+			}
+			return offset, true
+		}
+	}
+	return 0, false
+}
+
+// offsetToVaddr translates a file offset into a virtual address through the PT_LOAD segments.
+func offsetToVaddr(phdr []types.Elf64_Phdr, offset uint64) (uint64, bool) {
+	for i := range phdr {
+		ph := &phdr[i]
+		if ph.P_type != types.PT_LOAD {
+			continue
+		}
+
+		if offset >= ph.P_offset && offset-ph.P_offset < ph.P_filesz {
+			return ph.P_vaddr + (offset - ph.P_offset), true
+		}
+	}
+	return 0, false
+}
+
+// dynamicLocation finds the file range of the dynamic table, preferring the PT_DYNAMIC
+// segment the loader uses and falling back to the .dynamic section.
+func dynamicLocation(phdr []types.Elf64_Phdr, shdr []types.Elf64_Shdr) (offset uint64, size uint64, found bool) {
+	for i := range phdr {
+		if phdr[i].P_type == types.PT_DYNAMIC {
+			return phdr[i].P_offset, phdr[i].P_filesz, true
+		}
+	}
+
+	if idx := findSection(shdr, types.SHT_DYNAMIC); idx >= 0 {
+		return shdr[idx].Sh_offset, shdr[idx].Sh_size, true
+	}
+
+	return 0, 0, false
+}
+
+// dynamicStringTable locates the string table behind DT_STRTAB/DT_STRSZ by virtual address.
+// When there are no PT_LOAD segments to translate through, the section linked from .dynamic is used.
+func dynamicStringTable(data []byte, raw []types.Elf64_Dyn, phdr []types.Elf64_Phdr, shdr []types.Elf64_Shdr) ([]byte, error) {
+	var addr, size uint64
+	var hasAddr bool
+	for i := range raw {
+		switch raw[i].D_tag {
+		case types.DT_STRTAB:
+			addr, hasAddr = raw[i].D_val, true
+		case types.DT_STRSZ:
+			size = raw[i].D_val
+		}
+	}
+
+	if hasAddr {
+		if offset, ok := vaddrToOffset(phdr, addr); ok && offset < uint64(len(data)) {
+			// Clamp to the file, a lying DT_STRSZ only loses the tail
+			if size == 0 || size > uint64(len(data))-offset {
+				size = uint64(len(data)) - offset
+			}
+			return data[offset : offset+size], nil
+		}
+	}
+
+	if idx := findSection(shdr, types.SHT_DYNAMIC); idx >= 0 {
+		if link := shdr[idx].Sh_link; link != 0 && link < uint32(len(shdr)) {
+			return parseSectionData(data, &shdr[link], "dynamic string table")
+		}
+	}
+
+	return nil, nil
+}
+
+// parseDynamic decodes the dynamic table up to DT_NULL, resolving string-valued tags
+// through DT_STRTAB and reading the contents of the init/fini arrays.
+func parseDynamic(data []byte, dec *decoder, phdr []types.Elf64_Phdr, shdr []types.Elf64_Shdr) ([]types.DynamicEntry, error) {
+	offset, size, found := dynamicLocation(phdr, shdr)
+	if !found {
+		return nil, nil
+	}
+
+	entsize := dec.dynentsize()
+	count := size / entsize
+	if err := checkTable("dynamic table", offset, count, entsize, len(data)); err != nil {
+		return nil, err
+	}
+
+	raw := dec.dynamic(data, count, offset)
+	for i := range raw {
+		if raw[i].D_tag == types.DT_NULL {
+			raw = raw[:i]
+			break
+		}
+	}
+
+	strtab, err := dynamicStringTable(data, raw, phdr, shdr)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]types.DynamicEntry, len(raw))
+	for i := range raw {
+		entries[i].Elf64_Dyn = raw[i]
+		if types.IsStringTag(raw[i].D_tag) && raw[i].D_val <= 0xffffffff {
+			entries[i].String = types.GetString(strtab, uint32(raw[i].D_val))
+		}
+	}
+
+	// Function pointer arrays, sized by their companion *_ARRAYSZ tag
+	arrays := map[int64]int64{
+		types.DT_INIT_ARRAY:    types.DT_INIT_ARRAYSZ,
+		types.DT_FINI_ARRAY:    types.DT_FINI_ARRAYSZ,
+		types.DT_PREINIT_ARRAY: types.DT_PREINIT_ARRAYSZ,
+	}
+	for i := range entries {
+		sizeTag, ok := arrays[entries[i].D_tag]
+		if !ok {
+			continue
+		}
+
+		arraySize, ok := dynamicTagValue(raw, sizeTag)
+		if !ok {
+			continue
+		}
+
+		arrayOffset, ok := vaddrToOffset(phdr, entries[i].D_val)
+		if !ok {
+			continue
+		}
+
+		slots := arraySize / dec.addrsize()
+		if checkTable("pointer array", arrayOffset, slots, dec.addrsize(), len(data)) != nil {
+			continue
+		}
+		entries[i].Array = dec.addresses(data, slots, arrayOffset)
+	}
+
+	return entries, nil
+}
+
+// dynamicTagValue returns the value of the first raw entry with the given tag.
+func dynamicTagValue(raw []types.Elf64_Dyn, tag int64) (uint64, bool) {
+	for i := range raw {
+		if raw[i].D_tag == tag {
+			return raw[i].D_val, true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,69 @@
+// Package elf is the importable API of the strix ELF parser.
+//
+// Open memory maps a file and NewFromBytes parses a buffer the caller already holds. Both
+// return a Parser whose methods decode the tables lazily and cache them, so asking for the
+// same table twice is free. Every table comes in the class-neutral ELF64 layout of package
+// types, whatever the class and byte order of the file.
+//
+// # Lifetime of returned data
+//
+// Parsing is zero copy. Data, string tables, note descriptors and every string the Parser
+// returns (section and symbol names, needed libraries...) point straight into the mapped
+// file, and so do the header tables when the file matches the host class and byte order.
+// They are only valid until Close: reading them afterwards faults, as the mapping is gone.
+// Copy anything that has to outlive the Parser, e.g. with strings.Clone.
+// For NewFromBytes the data is the caller's buffer, which must not be modified while the
+// Parser is in use.
+//
+// Returned tables are shared with the cache of the Parser and must be treated as read-only.
+// A Parser is not safe for concurrent use, parse one file per goroutine instead.
+//
+// # Errors
+//
+// Malformed files come back as errors, never as panics. Header failures wrap the Err*
+// values, match them with errors.Is. Table failures are typed (TruncatedTableError,
+// EntrySizeError, OverlapError, OffsetOverflowError, MalformedNoteError,
+// MalformedVersionError, MalformedHashError), match them with errors.As.
+package elf
+
+import (
+	"github.com/yourpwnguy/strix/internal/reader"
+)
+
+// BinaryReader is the data source of a Parser. Read returns the whole file and Close
+// releases it, which invalidates every slice handed out by the Parser.
+type BinaryReader = reader.BinaryReader
+
+// MmapReader is the BinaryReader behind Open, it memory maps the file read-only.
+type MmapReader = reader.MmapReader
+
+// MemoryReader is the BinaryReader behind NewFromBytes, it serves a buffer the caller owns.
+type MemoryReader = reader.MemoryReader
+
+// Open memory maps the file at path and validates its ELF header.
+// The caller must Close the Parser, after which the data it returned is no longer valid.
+func Open(path string) (*Parser, error) {
+	return open(&reader.MmapReader{}, path)
+}
+
+// NewFromBytes parses an ELF image held in memory and validates its header.
+// The Parser reads data in place, so data must stay unmodified while the Parser is in use.
+func NewFromBytes(data []byte) (*Parser, error) {
+	return open(reader.NewMemoryReader(data), "")
+}
+
+// open loads path through r and decodes the ELF header, so that non-ELF input fails early.
+func open(r BinaryReader, path string) (*Parser, error) {
+	p := NewParser(r)
+	if err := p.Load(path); err != nil {
+		p.Close()
+		return nil, err
+	}
+
+	if _, err := p.ELFHeader(); err != nil {
+		p.Close()
+		return nil, err
+	}
+
+	return p, nil
+}
@@ -0,0 +1,86 @@
+package elf_test
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/yourpwnguy/strix/pkg/elf"
+)
+
+// accessors calls every table decoder of p. The results are thrown away, only panics matter.
+func accessors(p *elf.Parser) {
+	p.ProgramHeaders()
+	p.SectionHeaders()
+	p.SectionStringTable()
+	p.File()
+	p.Symbols()
+	p.DynamicSymbols()
+	p.DynamicEntries()
+	p.Relocations()
+	p.Notes()
+	p.Versions()
+	p.GNUHash()
+	p.SysVHash()
+}
+
+// TestTruncated holds the package to its promise that malformed files come back as errors,
+// never as panics. Real binaries are cut short at every step, which loses the tables at the
+// end of the file, then hollowed out: the bytes between the cut and the section headers are
+// zeroed, so that the headers still point at tables whose contents are gone.
+func TestTruncated(t *testing.T) {
+	paths := []string{"/bin/ls", "/bin/sh", "/usr/lib/x86_64-linux-gnu/libc.so.6"}
+
+	tested := 0
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		tested++
+
+		// Hollowing keeps the ELF and program headers at the start of the file and the
+		// section headers at its end
+		p, err := elf.NewFromBytes(data)
+		if err != nil {
+			t.Fatalf("%s: %v", path, err)
+		}
+		ehdr, _ := p.ELFHeader()
+		keep := int(ehdr.E_phoff) + int(ehdr.E_phnum)*int(ehdr.E_phentsize)
+		shoff := int(min(ehdr.E_shoff, uint64(len(data))))
+		p.Close()
+
+		step := max(len(data)/2048, 1)
+		for cut := 0; cut < len(data); cut += step {
+			try(t, fmt.Sprintf("%s cut at %#x", path, cut), data[:cut])
+
+			if keep <= cut && cut < shoff {
+				hollow := bytes.Clone(data)
+				clear(hollow[cut:shoff])
+				try(t, fmt.Sprintf("%s hollowed from %#x", path, cut), hollow)
+			}
+		}
+	}
+
+	if tested == 0 {
+		t.Skip("no system binaries to truncate")
+	}
+}
+
+// try runs the accessors over data, reporting a panic as a failure of the named input.
+func try(t *testing.T, name string, data []byte) {
+	t.Helper()
+	defer func() {
+		if r := recover(); r != nil {
+			t.Fatalf("%s: panic: %v", name, r)
+		}
+	}()
+
+	p, err := elf.NewFromBytes(data)
+	if err != nil {
+		return
+	}
+	defer p.Close()
+	accessors(p)
+}
@@ -0,0 +1,161 @@
+package elf
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Header level failures. They are wrapped with details, so match them with errors.Is.
+var (
+	ErrFileTooSmall        = errors.New("file too small to be ELF")
+	ErrInvalidMagic        = errors.New("invalid ELF magic value")
+	ErrUnsupportedClass    = errors.New("unsupported ELF class")
+	ErrUnsupportedEncoding = errors.New("unsupported ELF data encoding")
+)
+
+// TruncatedTableError reports a table that does not fit inside the file.
+type TruncatedTableError struct {
+	Table    string // Table name (e.g. "program header table")
+	Offset   uint64 // File offset of the table
+	Size     uint64 // Size of the table in bytes
+	FileSize uint64 // Size of the whole file in bytes
+}
+
+func (e *TruncatedTableError) Error() string {
+	return fmt.Sprintf("truncated %s: %#x bytes at offset %#x exceed file size %#x",
+		e.Table,
+		e.Size,
+		e.Offset,
+		e.FileSize,
+	)
+}
+
+// EntrySizeError reports a table whose declared entry size does not match the structure for the file class.
+type EntrySizeError struct {
+	Table string // Table name (e.g. "section header table")
+	Got   uint64 // Entry size declared by the file
+	Want  uint64 // Entry size of the structure for the file class
+}
+
+func (e *EntrySizeError) Error() string {
+	return fmt.Sprintf("bad %s entry size: got %d bytes, want %d",
+		e.Table,
+		e.Got,
+		e.Want,
+	)
+}
+
+// OverlapError reports two tables that share file bytes.
+type OverlapError struct {
+	First  string // Name of the first table
+	Second string // Name of the second table
+	Offset uint64 // Start of the overlapping range
+	Size   uint64 // Length of the overlapping range
+}
+
+func (e *OverlapError) Error() string {
+	return fmt.Sprintf("%s overlaps %s: %#x bytes at offset %#x",
+		e.First,
+		e.Second,
+		e.Size,
+		e.Offset,
+	)
+}
+
+// OffsetOverflowError reports a table whose offset plus size wraps around the 64 bit range.
+type OffsetOverflowError struct {
+	Table  string // Table name
+	Offset uint64 // File offset of the table
+	Count  uint64 // Number of entries
+	Size   uint64 // Size of a single entry
+}
+
+func (e *OffsetOverflowError) Error() string {
+	return fmt.Sprintf("%s overflows: %d entries of %d bytes at offset %#x",
+		e.Table,
+		e.Count,
+		e.Size,
+		e.Offset,
+	)
+}
+
+// MalformedNoteError reports a note whose name or descriptor runs past the end of its section or segment.
+type MalformedNoteError struct {
+	Source string // Section name, or "PT_NOTE"
+	Offset uint64 // File offset of the note header
+}
+
+func (e *MalformedNoteError) Error() string {
+	return fmt.Sprintf("malformed note in %s at offset %#x",
+		e.Source,
+		e.Offset,
+	)
+}
+
+// MalformedVersionError reports a symbol versioning entry that runs past the end of its section.
+type MalformedVersionError struct {
+	Table  string // "version definition table" or "version requirement table"
+	Offset uint64 // File offset of the entry
+}
+
+func (e *MalformedVersionError) Error() string {
+	return fmt.Sprintf("malformed %s: entry at offset %#x runs past the end of its section",
+		e.Table,
+		e.Offset,
+	)
+}
+
+// MalformedHashError reports a GNU hash table bucket that refers to a symbol below the
+// first hashed one, which the loader would read before the start of the chains.
+type MalformedHashError struct {
+	Offset uint64 // File offset of the table
+	Bucket uint32 // Bucket index
+	Symbol uint32 // Symbol index the bucket refers to
+}
+
+func (e *MalformedHashError) Error() string {
+	return fmt.Sprintf("malformed GNU hash table at offset %#x: bucket %d refers to symbol %d before the hashed symbols",
+		e.Offset,
+		e.Bucket,
+		e.Symbol,
+	)
+}
+
+// checkTable validates that count entries of size bytes starting at offset fit inside a file of fileSize bytes.
+func checkTable(table string, offset, count, size uint64, fileSize int) error {
+	if count == 0 {
+		return nil
+	}
+
+	total := count * size
+	if size != 0 && total/size != count {
+		return &OffsetOverflowError{Table: table, Offset: offset, Count: count, Size: size}
+	}
+
+	end := offset + total
+	if end < offset {
+		return &OffsetOverflowError{Table: table, Offset: offset, Count: count, Size: size}
+	}
+
+	if end > uint64(fileSize) {
+		return &TruncatedTableError{Table: table, Offset: offset, Size: total, FileSize: uint64(fileSize)}
+	}
+
+	return nil
+}
+
+// checkOverlap validates that the byte ranges [aOff, aOff+aSize) and [bOff, bOff+bSize) are disjoint.
+// Both ranges must already have passed checkTable.
+func checkOverlap(a string, aOff, aSize uint64, b string, bOff, bSize uint64) error {
+	if aSize == 0 || bSize == 0 {
+		return nil
+	}
+
+	start := max(aOff, bOff)
+	end := min(aOff+aSize, bOff+bSize)
+	if start < end {
+		return &OverlapError{First: a, Second: b, Offset: start, Size: end - start}
+	}
+
+	return nil
+}
@@ -0,0 +1,144 @@
+package elf_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/yourpwnguy/strix/pkg/elf"
+	"github.com/yourpwnguy/strix/pkg/elf/types"
+)
+
+// This example builds a bare ELF header in memory and decodes it.
+func ExampleNewFromBytes() {
+	hdr := types.Elf64_Ehdr{
+		E_type:    types.ET_DYN,
+		E_machine: types.EM_AARCH64,
+		E_version: 1,
+		E_ehsize:  64,
+	}
+	hdr.E_ident.FileIdn = [4]uint8{0x7f, 'E', 'L', 'F'}
+	hdr.E_ident.Ei_class = types.ELFCLASS64
+	hdr.E_ident.Ei_data = types.ELFDATA2LSB
+	hdr.E_ident.Ei_version = 1
+
+	var buf bytes.Buffer
+	binary.Write(&buf, binary.LittleEndian, hdr)
+
+	p, err := elf.NewFromBytes(buf.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer p.Close()
+
+	ehdr, err := p.ELFHeader()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println(types.GetEiClass(ehdr.E_ident.Ei_class))
+	fmt.Println(types.GetEMachine(ehdr.E_machine))
+	// Output:
+	// ELF64
+	// ARM AARCH64
+}
+
+// Header failures wrap sentinel errors, which errors.Is can match.
+func ExampleNewFromBytes_notELF() {
+	_, err := elf.NewFromBytes([]byte(strings.Repeat("#!/bin/sh\n", 8)))
+	fmt.Println(errors.Is(err, elf.ErrInvalidMagic))
+	// Output:
+	// true
+}
+
+// This example lists the shared libraries a binary needs.
+func ExampleOpen() {
+	p, err := elf.Open("/bin/ls")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer p.Close()
+
+	entries, err := p.DynamicEntries()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for _, e := range entries {
+		if e.D_tag == types.DT_NEEDED {
+			fmt.Println(e.String)
+		}
+	}
+}
+
+// fileReader is a BinaryReader that reads the whole file into memory instead of mapping it.
+type fileReader struct{}
+
+func (fileReader) Read(path string) ([]byte, error) { return os.ReadFile(path) }
+func (fileReader) Close()                           {}
+
+// This example parses a file through a custom reader. Unlike Open, Load does not decode
+// the ELF header, so non-ELF input only fails at the first table asked for.
+func ExampleNewParser() {
+	p := elf.NewParser(fileReader{})
+	if err := p.Load("/bin/ls"); err != nil {
+		log.Fatal(err)
+	}
+	defer p.Close()
+
+	class, err := p.Class()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	fmt.Println(types.GetEiClass(class))
+}
+
+// Symbol names point into the mapped file, so the ones kept past Close are cloned.
+func ExampleParser_Symbols() {
+	p, err := elf.Open("/usr/lib/x86_64-linux-gnu/libc.so.6")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	syms, err := p.DynamicSymbols()
+	if err != nil {
+		p.Close()
+		log.Fatal(err)
+	}
+
+	var funcs []string
+	for i := range syms {
+		if syms[i].Type() == types.STT_FUNC && !syms[i].IsUndefined() {
+			funcs = append(funcs, strings.Clone(syms[i].Name))
+		}
+	}
+	p.Close()
+
+	fmt.Println(len(funcs), "functions")
+}
+
+// This example walks the sections through the class-neutral view of the file.
+func ExampleParser_File() {
+	p, err := elf.Open("/bin/ls")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer p.Close()
+
+	f, err := p.File()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for i := range f.Sections {
+		sh := &f.Sections[i]
+		if sh.Sh_flags&types.SHF_EXECINSTR != 0 {
+			fmt.Printf("%-12s %#x %d bytes\n", f.SectionName(sh), sh.Sh_addr, sh.Sh_size)
+		}
+	}
+}
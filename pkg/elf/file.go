@@ -0,0 +1,78 @@
+package elf
+
+import (
+	"github.com/yourpwnguy/strix/pkg/elf/types"
+)
+
+// File is a class-neutral view of an ELF image. Header, segments and sections are
+// normalized to the ELF64 layout whatever the on-disk class, so consumers never
+// have to care whether they are looking at an ELF32 or ELF64 file.
+type File struct {
+	Class    uint8              // ELFCLASS32 or ELFCLASS64, from e_ident
+	Header   *types.Elf64_Ehdr  // ELF header
+	Segments []types.Elf64_Phdr // Program headers
+	Sections []types.Elf64_Shdr // Section headers
+
+	// Section name string table (.shstrtab)
+	shstrtab []byte
+}
+
+// SectionName returns the name of a section as stored in .shstrtab.
+func (f *File) SectionName(sh *types.Elf64_Shdr) string {
+	return types.GetString(f.shstrtab, sh.Sh_name)
+}
+
+// SectionNames returns the raw section name string table (.shstrtab).
+func (f *File) SectionNames() []byte {
+	return f.shstrtab
+}
+
+// SectionByName returns the first section with the given name, or nil if there is none.
+func (f *File) SectionByName(name string) *types.Elf64_Shdr {
+	for i := range f.Sections {
+		if f.SectionName(&f.Sections[i]) == name {
+			return &f.Sections[i]
+		}
+	}
+	return nil
+}
+
+// SectionByType returns the first section of the given type, or nil if there is none.
+func (f *File) SectionByType(sh_type uint32) *types.Elf64_Shdr {
+	if idx := findSection(f.Sections, sh_type); idx >= 0 {
+		return &f.Sections[idx]
+	}
+	return nil
+}
+
+// File returns the class-neutral view of the loaded ELF image.
+// The underlying tables are cached by the parser, so repeated calls are cheap.
+func (p *Parser) File() (*File, error) {
+	ehdr, err := p.ELFHeader()
+	if err != nil {
+		return nil, err
+	}
+
+	phdr, err := p.ProgramHeaders()
+	if err != nil {
+		return nil, err
+	}
+
+	shdr, err := p.SectionHeaders()
+	if err != nil {
+		return nil, err
+	}
+
+	shstrtab, err := p.SectionStringTable()
+	if err != nil {
+		return nil, err
+	}
+
+	return &File{
+		Class:    p.dec.class,
+		Header:   ehdr,
+		Segments: phdr,
+		Sections: shdr,
+		shstrtab: shstrtab,
+	}, nil
+}
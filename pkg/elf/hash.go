@@ -0,0 +1,128 @@
+package elf
+
+import (
+	"github.com/yourpwnguy/strix/pkg/elf/types"
+)
+
+// hashLocation finds the file offset of a hash table through its dynamic tag, as the loader
+// does, falling back to the first section of the given type.
+func hashLocation(dyn []types.DynamicEntry, phdr []types.Elf64_Phdr, shdr []types.Elf64_Shdr, tag int64, sh_type uint32) (uint64, bool) {
+	if addr, ok := types.DynamicValue(dyn, tag); ok {
+		if offset, ok := vaddrToOffset(phdr, addr); ok {
+			return offset, true
+		}
+	}
+
+	if idx := findSection(shdr, sh_type); idx >= 0 {
+		return shdr[idx].Sh_offset, true
+	}
+
+	return 0, false
+}
+
+// parseGNUHash decodes the DT_GNU_HASH table at offset. The table does not record how many
+// chain entries it holds, so they are counted by walking the chain of the highest bucket up
+// to its end marker, as readelf does.
+func parseGNUHash(data []byte, dec *decoder, offset uint64) (*types.GNUHashTable, error) {
+	const table = "GNU hash table"
+
+	if err := checkTable(table, offset, 4, 4, len(data)); err != nil {
+		return nil, err
+	}
+	header := dec.words(data, 4, offset)
+	nbuckets, symoffset, bloomSize := uint64(header[0]), header[1], uint64(header[2])
+
+	out := &types.GNUHashTable{
+		Offset:     offset,
+		SymOffset:  symoffset,
+		BloomShift: header[3],
+		BloomBits:  uint32(dec.addrsize() * 8),
+	}
+
+	bloomOffset := offset + 16
+	if err := checkTable("GNU hash bloom filter", bloomOffset, bloomSize, dec.addrsize(), len(data)); err != nil {
+		return nil, err
+	}
+	out.Bloom = dec.addresses(data, bloomSize, bloomOffset)
+
+	bucketOffset := bloomOffset + bloomSize*dec.addrsize()
+	if err := checkTable("GNU hash buckets", bucketOffset, nbuckets, 4, len(data)); err != nil {
+		return nil, err
+	}
+	out.Buckets = dec.words(data, nbuckets, bucketOffset)
+
+	// Highest symbol a bucket starts at, its chain is the last one of the table
+	last := uint32(0)
+	for i, start := range out.Buckets {
+		if start == 0 {
+			continue
+		}
+		if start < symoffset {
+			return nil, &MalformedHashError{Offset: offset, Bucket: uint32(i), Symbol: start}
+		}
+		last = max(last, start)
+	}
+	if last == 0 {
+		return out, nil
+	}
+
+	chainOffset := bucketOffset + nbuckets*4
+	count := uint64(last-symoffset) + 1
+	for {
+		if err := checkTable("GNU hash chains", chainOffset, count, 4, len(data)); err != nil {
+			return nil, err
+		}
+		if dec.order.Uint32(data[chainOffset+(count-1)*4:])&1 != 0 {
+			break
+		}
+		count++
+	}
+	out.Chains = dec.words(data, count, chainOffset)
+
+	return out, nil
+}
+
+// parseSysVHash decodes the DT_HASH table at offset. Its entries are 32 bit words, except on
+// 64-bit s390 where they are 64 bits wide.
+func parseSysVHash(data []byte, dec *decoder, ehdr *types.Elf64_Ehdr, offset uint64) (*types.SysVHashTable, error) {
+	const table = "hash table"
+
+	size := uint64(4)
+	if ehdr.E_machine == types.EM_S390 && dec.is64() {
+		size = 8
+	}
+
+	if err := checkTable(table, offset, 2, size, len(data)); err != nil {
+		return nil, err
+	}
+
+	var header []uint64
+	if size == 8 {
+		header = decodeSlice[uint64](data, 2, offset, dec.order)
+	} else {
+		header = widen(dec.words(data, 2, offset), func(w *uint32) uint64 { return uint64(*w) })
+	}
+	nbucket, nchain := header[0], header[1]
+
+	if nbucket+nchain < nbucket {
+		return nil, &OffsetOverflowError{Table: table, Offset: offset, Count: nbucket, Size: size}
+	}
+	if err := checkTable(table, offset+2*size, nbucket+nchain, size, len(data)); err != nil {
+		return nil, err
+	}
+
+	var words []uint32
+	if size == 8 {
+		for _, w := range decodeSlice[uint64](data, nbucket+nchain, offset+2*size, dec.order) {
+			words = append(words, uint32(w))
+		}
+	} else {
+		words = dec.words(data, nbucket+nchain, offset+2*size)
+	}
+
+	return &types.SysVHashTable{
+		Offset:  offset,
+		Buckets: words[:nbucket:nbucket],
+		Chains:  words[nbucket:],
+	}, nil
+}
@@ -0,0 +1,54 @@
+package elf
+
+import (
+	"fmt"
+
+	"github.com/yourpwnguy/strix/internal/unsafe"
+	"github.com/yourpwnguy/strix/pkg/elf/types"
+)
+
+// parseHeader validates and parses the ELF header from the provided byte slice.
+// The returned decoder matches the file class and byte order and is used for every other table.
+// Returns an error if the magic number, class or data encoding is invalid or the data is too small.
+func parseELFHeader(data []byte) (*types.Elf64_Ehdr, *decoder, error) {
+	if len(data) < types.EI_NIDENT {
+		return nil, nil, fmt.Errorf("%w: %d bytes",
+			ErrFileTooSmall,
+			len(data),
+		)
+	}
+
+	if !unsafe.HasValidMagic(data[:4]) {
+		return nil, nil, fmt.Errorf("%w: %#04x",
+			ErrInvalidMagic,
+			data[:4],
+		)
+	}
+
+	class := data[types.EI_CLASS]
+	if class != types.ELFCLASS32 && class != types.ELFCLASS64 {
+		return nil, nil, fmt.Errorf("%w: %d",
+			ErrUnsupportedClass,
+			class,
+		)
+	}
+
+	encoding := data[types.EI_DATA]
+	if encoding != types.ELFDATA2LSB && encoding != types.ELFDATA2MSB {
+		return nil, nil, fmt.Errorf("%w: %d",
+			ErrUnsupportedEncoding,
+			encoding,
+		)
+	}
+
+	if !unsafe.HasMinimumSize(data, class) {
+		return nil, nil, fmt.Errorf("%w: %d bytes for %s",
+			ErrFileTooSmall,
+			len(data),
+			types.GetEiClass(class),
+		)
+	}
+
+	dec := newDecoder(class, encoding)
+	return dec.header(data), dec, nil
+}
@@ -0,0 +1,295 @@
+package elf
+
+import (
+	"strings"
+
+	"github.com/yourpwnguy/strix/pkg/elf/types"
+)
+
+// noteHeaderSize is the size of n_namesz, n_descsz and n_type, which are 32 bit words in both classes.
+const noteHeaderSize = 12
+
+// noteAlign returns the alignment of the name and descriptor fields inside a note container.
+// 8 byte aligned containers (GNU property notes of ELF64) use 8, everything else uses 4.
+func noteAlign(align uint64) uint64 {
+	if align == 8 {
+		return 8
+	}
+	return 4
+}
+
+// alignUp rounds v up to a multiple of align, which must be a power of two.
+func alignUp(v, align uint64) uint64 {
+	return (v + align - 1) &^ (align - 1)
+}
+
+// parseNotes walks every SHT_NOTE section and then every PT_NOTE segment, skipping notes
+// already seen through a section so that each note is reported once.
+func parseNotes(data []byte, dec *decoder, ehdr *types.Elf64_Ehdr, phdr []types.Elf64_Phdr, shdr []types.Elf64_Shdr, shstrtab []byte) ([]types.Note, error) {
+	var notes []types.Note
+	seen := make(map[uint64]bool)
+
+	add := func(source string, offset, size, align uint64) error {
+		found, err := parseNoteRange(data, dec, source, offset, size, noteAlign(align))
+		if err != nil {
+			return err
+		}
+
+		for i := range found {
+			if seen[found[i].Offset] {
+				continue
+			}
+			seen[found[i].Offset] = true
+
+			decodeNote(&found[i], dec, ehdr)
+			notes = append(notes, found[i])
+		}
+		return nil
+	}
+
+	for i := range shdr {
+		if shdr[i].Sh_type != types.SHT_NOTE {
+			continue
+		}
+
+		sh := &shdr[i]
+		if err := add(types.GetString(shstrtab, sh.Sh_name), sh.Sh_offset, sh.Sh_size, sh.Sh_addralign); err != nil {
+			return nil, err
+		}
+	}
+
+	for i := range phdr {
+		if phdr[i].P_type != types.PT_NOTE {
+			continue
+		}
+
+		ph := &phdr[i]
+		if err := add("PT_NOTE", ph.P_offset, ph.P_filesz, ph.P_align); err != nil {
+			return nil, err
+		}
+	}
+
+	return notes, nil
+}
+
+// parseNoteRange splits size bytes of notes starting at offset into individual entries.
+// Names and descriptors are sliced from data without copying.
+func parseNoteRange(data []byte, dec *decoder, source string, offset, size, align uint64) ([]types.Note, error) {
+	if err := checkTable(source, offset, 1, size, len(data)); err != nil {
+		return nil, err
+	}
+
+	var notes []types.Note
+	end := offset + size
+	for pos := offset; end-pos >= noteHeaderSize; {
+		namesz := uint64(dec.order.Uint32(data[pos:]))
+		descsz := uint64(dec.order.Uint32(data[pos+4:]))
+		n_type := dec.order.Uint32(data[pos+8:])
+
+		// Offsets are relative to the container, which is itself aligned
+		nameStart := pos + noteHeaderSize
+		descStart := offset + alignUp(nameStart+namesz-offset, align)
+		next := offset + alignUp(descStart+descsz-offset, align)
+		if descStart+descsz > end {
+			return nil, &MalformedNoteError{Source: source, Offset: pos}
+		}
+
+		notes = append(notes, types.Note{
+			Owner:  strings.TrimRight(string(data[nameStart:nameStart+namesz]), "\x00"),
+			Type:   n_type,
+			Desc:   data[descStart : descStart+descsz],
+			Source: source,
+			Offset: pos,
+		})
+
+		// The final note may omit its trailing padding
+		if next >= end {
+			break
+		}
+		pos = next
+	}
+
+	return notes, nil
+}
+
+// decodeNote fills in the Value of the notes strix understands.
+func decodeNote(n *types.Note, dec *decoder, ehdr *types.Elf64_Ehdr) {
+	switch n.Owner {
+	case "GNU":
+		switch n.Type {
+		case types.NT_GNU_BUILD_ID:
+			n.Value = types.BuildID(n.Desc)
+		case types.NT_GNU_ABI_TAG:
+			if len(n.Desc) >= 16 {
+				n.Value = &types.ABITag{
+					OS:    dec.order.Uint32(n.Desc[0:]),
+					Major: dec.order.Uint32(n.Desc[4:]),
+					Minor: dec.order.Uint32(n.Desc[8:]),
+					Patch: dec.order.Uint32(n.Desc[12:]),
+				}
+			}
+		case types.NT_GNU_PROPERTY_TYPE_0:
+			n.Value = decodeGNUProperties(n.Desc, dec)
+		}
+
+	case "Go":
+		if n.Type == types.NT_GO_BUILD_ID {
+			n.Value = types.GoBuildID(strings.TrimRight(string(n.Desc), "\x00"))
+		}
+
+	case "FDO":
+		if n.Type == types.NT_FDO_PACKAGING_METADATA {
+			n.Value = types.PackageMetadata(strings.TrimRight(string(n.Desc), "\x00"))
+		}
+
+	case "CORE", "LINUX":
+		if ehdr.E_type == types.ET_CORE {
+			n.Value = decodeCoreNote(n, dec)
+		}
+	}
+}
+
+// decodeGNUProperties splits a NT_GNU_PROPERTY_TYPE_0 descriptor into its properties.
+// Each pr_data is padded to the address size of the class.
+func decodeGNUProperties(desc []byte, dec *decoder) types.GNUProperties {
+	var props types.GNUProperties
+	for pos := uint64(0); uint64(len(desc))-pos >= 8; {
+		pr_type := dec.order.Uint32(desc[pos:])
+		datasz := uint64(dec.order.Uint32(desc[pos+4:]))
+
+		start := pos + 8
+		if datasz > uint64(len(desc))-start {
+			break
+		}
+
+		p := types.GNUProperty{
+			Type: pr_type,
+			Data: desc[start : start+datasz],
+		}
+		switch datasz {
+		case 4:
+			p.Value = uint64(dec.order.Uint32(p.Data))
+		case 8:
+			p.Value = dec.order.Uint64(p.Data)
+		}
+		props = append(props, p)
+
+		pos = alignUp(start+datasz, dec.addrsize())
+	}
+	return props
+}
+
+// decodeCoreNote decodes the core file notes whose layout does not depend on the register set.
+func decodeCoreNote(n *types.Note, dec *decoder) any {
+	desc := n.Desc
+	word := dec.addrsize()
+
+	switch n.Type {
+	case types.NT_PRSTATUS:
+		// pr_info (12 bytes), pr_cursig and padding, then pr_sigpend and pr_sighold words
+		pid := 12 + 4 + 2*word
+		if uint64(len(desc)) < pid+4 {
+			return nil
+		}
+		return &types.ProcessStatus{
+			Signal: dec.order.Uint16(desc[12:]),
+			Pid:    int32(dec.order.Uint32(desc[pid:])),
+		}
+
+	case types.NT_PRPSINFO:
+		// The layout is told apart by its size: 64 bit, 32 bit with 16 bit ids (i386, ARM),
+		// and 32 bit with 32 bit ids
+		var pid, fname uint64
+		switch len(desc) {
+		case 136:
+			pid, fname = 24, 40
+		case 124:
+			pid, fname = 12, 28
+		case 128:
+			pid, fname = 16, 32
+		default:
+			return nil
+		}
+		return &types.ProcessInfo{
+			Pid:    int32(dec.order.Uint32(desc[pid:])),
+			Name:   cString(desc[fname : fname+16]),
+			Args:   strings.TrimSpace(cString(desc[fname+16 : fname+16+80])),
+			State:  desc[0],
+			SState: desc[1],
+		}
+
+	case types.NT_AUXV:
+		var auxv types.AuxVector
+		for pos := uint64(0); pos+2*word <= uint64(len(desc)); pos += 2 * word {
+			a_type, _ := dec.address(desc, pos)
+			a_val, _ := dec.address(desc, pos+word)
+			if a_type == types.AT_NULL {
+				break
+			}
+			auxv = append(auxv, types.AuxEntry{Type: a_type, Value: a_val})
+		}
+		return auxv
+
+	case types.NT_SIGINFO:
+		if len(desc) < 12 {
+			return nil
+		}
+		return &types.SignalInfo{
+			Signo: int32(dec.order.Uint32(desc[0:])),
+			Errno: int32(dec.order.Uint32(desc[4:])),
+			Code:  int32(dec.order.Uint32(desc[8:])),
+		}
+
+	case types.NT_FILE:
+		return decodeMappedFiles(desc, dec)
+	}
+
+	return nil
+}
+
+// decodeMappedFiles decodes a NT_FILE descriptor: a count and page size, count (start, end, page offset)
+// triples, then count NUL terminated paths.
+func decodeMappedFiles(desc []byte, dec *decoder) types.MappedFiles {
+	word := dec.addrsize()
+
+	// The count and page size must both be there before the triples are measured
+	tableEnd := 2 * word
+	if uint64(len(desc)) < tableEnd {
+		return nil
+	}
+	count, _ := dec.address(desc, 0)
+	pageSize, _ := dec.address(desc, word)
+
+	if count > (uint64(len(desc))-tableEnd)/(3*word) {
+		return nil
+	}
+	tableEnd += count * 3 * word
+
+	paths := strings.Split(string(desc[tableEnd:]), "\x00")
+
+	files := make(types.MappedFiles, count)
+	for i := range files {
+		pos := 2*word + uint64(i)*3*word
+		start, _ := dec.address(desc, pos)
+		end, _ := dec.address(desc, pos+word)
+		pgoff, _ := dec.address(desc, pos+2*word)
+
+		files[i] = types.MappedFile{
+			Start:  start,
+			End:    end,
+			Offset: pgoff * pageSize,
+		}
+		if i < len(paths) {
+			files[i].Path = paths[i]
+		}
+	}
+	return files
+}
+
+// cString returns the bytes of b up to the first NUL.
+func cString(b []byte) string {
+	if i := strings.IndexByte(string(b), 0); i >= 0 {
+		return string(b[:i])
+	}
+	return string(b)
+}
@@ -0,0 +1,62 @@
+package elf
+
+import (
+	"encoding/binary"
+	"reflect"
+	"testing"
+
+	"github.com/yourpwnguy/strix/pkg/elf/types"
+)
+
+// words encodes each value as a 64 bit little endian word.
+func words(v ...uint64) []byte {
+	var b []byte
+	for _, w := range v {
+		b = binary.LittleEndian.AppendUint64(b, w)
+	}
+	return b
+}
+
+func TestDecodeCoreNote(t *testing.T) {
+	dec := newDecoder(types.ELFCLASS64, types.ELFDATA2LSB)
+
+	prstatus := make([]byte, 36)
+	binary.LittleEndian.PutUint16(prstatus[12:], 11)
+	binary.LittleEndian.PutUint32(prstatus[32:], 4242)
+
+	mapped := append(words(2, 0x1000, 0x400000, 0x401000, 0, 0x7f0000, 0x7f2000, 3), "/bin/true\x00/lib/libc.so.6\x00"...)
+
+	tests := []struct {
+		name  string
+		ntype uint32
+		desc  []byte
+		want  any
+	}{
+		{"prstatus", types.NT_PRSTATUS, prstatus, &types.ProcessStatus{Signal: 11, Pid: 4242}},
+		{"prstatus short", types.NT_PRSTATUS, prstatus[:35], nil},
+		{"prpsinfo unknown size", types.NT_PRPSINFO, make([]byte, 100), nil},
+		{"auxv", types.NT_AUXV, words(types.AT_PAGESZ, 0x1000, types.AT_NULL, 0, 7, 7), types.AuxVector{{Type: types.AT_PAGESZ, Value: 0x1000}}},
+		{"auxv partial entry", types.NT_AUXV, words(types.AT_PAGESZ, 0x1000)[:12], types.AuxVector(nil)},
+		{"siginfo", types.NT_SIGINFO, []byte{11, 0, 0, 0, 0, 0, 0, 0, 1, 0, 0, 0}, &types.SignalInfo{Signo: 11, Code: 1}},
+		{"siginfo short", types.NT_SIGINFO, make([]byte, 11), nil},
+		{"file", types.NT_FILE, mapped, types.MappedFiles{
+			{Start: 0x400000, End: 0x401000, Offset: 0, Path: "/bin/true"},
+			{Start: 0x7f0000, End: 0x7f2000, Offset: 0x3000, Path: "/lib/libc.so.6"},
+		}},
+		{"file no paths", types.NT_FILE, words(1, 0x1000, 1, 2, 0), types.MappedFiles{{Start: 1, End: 2}}},
+		{"file empty", types.NT_FILE, nil, types.MappedFiles(nil)},
+		{"file count only", types.NT_FILE, []byte{1, 0, 0, 0}, types.MappedFiles(nil)},
+		{"file no page size", types.NT_FILE, words(1, 0x1000)[:12], types.MappedFiles(nil)},
+		{"file count too large", types.NT_FILE, words(3, 0x1000, 1, 2, 0), types.MappedFiles(nil)},
+		{"unknown type", 0x4b1d, words(1), nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n := types.Note{Owner: "CORE", Type: tt.ntype, Desc: tt.desc}
+			if got := decodeCoreNote(&n, dec); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("decodeCoreNote() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,498 @@
+package elf
+
+import (
+	"encoding/binary"
+
+	"github.com/yourpwnguy/strix/internal/reader"
+	"github.com/yourpwnguy/strix/pkg/elf/types"
+)
+
+// Parser decodes one ELF file, caching each table for repeated access. See the package
+// documentation for the lifetime rules of the data it returns.
+type Parser struct {
+	reader reader.BinaryReader
+
+	// Raw data (mmap'd or in-memory)
+	data []byte
+
+	// Class specific table decoder, chosen from the ELF header
+	dec *decoder
+
+	// Tables are cached once parsed. A table the file does not have is nil, so each has
+	// a flag telling it apart from one not parsed yet.
+
+	// ELF specific information
+	ehdr       *types.Elf64_Ehdr
+	phdr       []types.Elf64_Phdr
+	phdrParsed bool
+	shdr       []types.Elf64_Shdr
+	shdrParsed bool
+
+	// Section name string table (.shstrtab)
+	shstrtab       []byte
+	shstrtabParsed bool
+
+	// Symbol tables (.symtab and .dynsym)
+	symtab       []types.Symbol
+	symtabParsed bool
+	dynsym       []types.Symbol
+	dynsymParsed bool
+
+	// Dynamic section entries
+	dynamic       []types.DynamicEntry
+	dynamicParsed bool
+
+	// Relocation tables (REL, RELA and RELR)
+	relocs       []types.RelocationTable
+	relocsParsed bool
+
+	// Notes from SHT_NOTE sections and PT_NOTE segments
+	notes       []types.Note
+	notesParsed bool
+
+	// Symbol versioning tables (.gnu.version, .gnu.version_d and .gnu.version_r)
+	versions       *types.Versions
+	versionsParsed bool
+
+	// Symbol hash tables (DT_GNU_HASH and DT_HASH)
+	gnuHash        *types.GNUHashTable
+	gnuHashParsed  bool
+	sysvHash       *types.SysVHashTable
+	sysvHashParsed bool
+}
+
+// NewParser returns a Parser reading through reader. Call Load with the path to hand to
+// reader, or use Open and NewFromBytes, which also validate the ELF header.
+func NewParser(reader reader.BinaryReader) *Parser {
+	return &Parser{
+		reader: reader,
+	}
+}
+
+// Load reads the ELF binary file into memory using the configured reader.
+func (p *Parser) Load(path string) error {
+	data, err := p.reader.Read(path)
+	if err != nil {
+		return err
+	}
+	p.data = data
+	return nil
+}
+
+// Close releases all resources held by the parser, including the underlying reader.
+func (p *Parser) Close() {
+	if p.reader != nil {
+		p.reader.Close()
+	}
+}
+
+// ELFHeader returns the ELF header in the class-neutral layout. Results are cached after the first call.
+func (p *Parser) ELFHeader() (*types.Elf64_Ehdr, error) {
+	if p.ehdr != nil {
+		return p.ehdr, nil
+	}
+
+	hdr, dec, err := parseELFHeader(p.data)
+	if err != nil {
+		return nil, err
+	}
+
+	p.ehdr = hdr
+	p.dec = dec
+	return hdr, err
+}
+
+// Class returns the ELF class (ELFCLASS32 or ELFCLASS64) of the loaded file.
+func (p *Parser) Class() (uint8, error) {
+	if _, err := p.ELFHeader(); err != nil {
+		return types.ELFCLASSNONE, err
+	}
+	return p.dec.class, nil
+}
+
+// ByteOrder returns the byte order of the loaded file, taken from e_ident[EI_DATA].
+func (p *Parser) ByteOrder() (binary.ByteOrder, error) {
+	if _, err := p.ELFHeader(); err != nil {
+		return nil, err
+	}
+	return p.dec.order, nil
+}
+
+// ProgramHeaders returns all program headers. Results are cached after the first call.
+func (p *Parser) ProgramHeaders() ([]types.Elf64_Phdr, error) {
+	if p.phdrParsed {
+		return p.phdr, nil
+	}
+
+	ehdr, err := p.ELFHeader()
+	if err != nil {
+		return nil, err
+	}
+
+	phdr, err := parseProgramHeaders(p.data, ehdr, p.dec)
+	if err != nil {
+		return nil, err
+	}
+
+	p.phdr, p.phdrParsed = phdr, true
+	return phdr, err
+}
+
+// SectionHeaders returns all section headers. Results are cached after the first call.
+func (p *Parser) SectionHeaders() ([]types.Elf64_Shdr, error) {
+	if p.shdrParsed {
+		return p.shdr, nil
+	}
+
+	ehdr, err := p.ELFHeader()
+	if err != nil {
+		return nil, err
+	}
+
+	shdr, err := parseSectionHeaders(p.data, ehdr, p.dec)
+	if err != nil {
+		return nil, err
+	}
+
+	p.shdr, p.shdrParsed = shdr, true
+	return shdr, nil
+}
+
+// SectionStringTable returns the section name string table referenced by e_shstrndx.
+// Files without one yield an empty table. Results are cached after the first call.
+func (p *Parser) SectionStringTable() ([]byte, error) {
+	if p.shstrtabParsed {
+		return p.shstrtab, nil
+	}
+
+	ehdr, err := p.ELFHeader()
+	if err != nil {
+		return nil, err
+	}
+
+	shdr, err := p.SectionHeaders()
+	if err != nil {
+		return nil, err
+	}
+
+	idx := sectionStringIndex(ehdr, shdr)
+	if idx == uint32(types.SHN_UNDEF) || idx >= uint32(len(shdr)) {
+		p.shstrtabParsed = true
+		return nil, nil
+	}
+
+	strtab, err := parseSectionData(p.data, &shdr[idx], "section name string table")
+	if err != nil {
+		return nil, err
+	}
+
+	p.shstrtab, p.shstrtabParsed = strtab, true
+	return strtab, nil
+}
+
+// Symbols returns the static symbol table (.symtab). Files without one (e.g. stripped binaries)
+// yield an empty table. Results are cached after the first call.
+func (p *Parser) Symbols() ([]types.Symbol, error) {
+	if p.symtabParsed {
+		return p.symtab, nil
+	}
+
+	syms, err := p.symbolTable(types.SHT_SYMTAB)
+	if err != nil {
+		return nil, err
+	}
+
+	p.symtab, p.symtabParsed = syms, true
+	return syms, nil
+}
+
+// DynamicSymbols returns the dynamic symbol table (.dynsym). Results are cached after the first call.
+func (p *Parser) DynamicSymbols() ([]types.Symbol, error) {
+	if p.dynsymParsed {
+		return p.dynsym, nil
+	}
+
+	syms, err := p.symbolTable(types.SHT_DYNSYM)
+	if err != nil {
+		return nil, err
+	}
+
+	p.dynsym, p.dynsymParsed = syms, true
+	return syms, nil
+}
+
+// symbolTable locates the first section of the given symbol table type and decodes it.
+func (p *Parser) symbolTable(sh_type uint32) ([]types.Symbol, error) {
+	if _, err := p.ELFHeader(); err != nil {
+		return nil, err
+	}
+
+	shdr, err := p.SectionHeaders()
+	if err != nil {
+		return nil, err
+	}
+
+	idx := findSection(shdr, sh_type)
+	if idx < 0 {
+		return nil, nil
+	}
+
+	return parseSymbols(p.data, p.dec, shdr, idx)
+}
+
+// DynamicEntries returns the dynamic table located through PT_DYNAMIC (or .dynamic),
+// with string-valued tags resolved. Static binaries yield an empty table.
+// Results are cached after the first call.
+func (p *Parser) DynamicEntries() ([]types.DynamicEntry, error) {
+	if p.dynamicParsed {
+		return p.dynamic, nil
+	}
+
+	if _, err := p.ELFHeader(); err != nil {
+		return nil, err
+	}
+
+	phdr, err := p.ProgramHeaders()
+	if err != nil {
+		return nil, err
+	}
+
+	shdr, err := p.SectionHeaders()
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := parseDynamic(p.data, p.dec, phdr, shdr)
+	if err != nil {
+		return nil, err
+	}
+
+	p.dynamic, p.dynamicParsed = entries, true
+	return entries, nil
+}
+
+// Relocations returns every REL, RELA and RELR table with symbols resolved. Tables come from
+// the section headers, or from the dynamic section when those are missing.
+// Results are cached after the first call.
+func (p *Parser) Relocations() ([]types.RelocationTable, error) {
+	if p.relocsParsed {
+		return p.relocs, nil
+	}
+
+	ehdr, err := p.ELFHeader()
+	if err != nil {
+		return nil, err
+	}
+
+	phdr, err := p.ProgramHeaders()
+	if err != nil {
+		return nil, err
+	}
+
+	shdr, err := p.SectionHeaders()
+	if err != nil {
+		return nil, err
+	}
+
+	shstrtab, err := p.SectionStringTable()
+	if err != nil {
+		return nil, err
+	}
+
+	dyn, err := p.DynamicEntries()
+	if err != nil {
+		return nil, err
+	}
+
+	tables, err := parseRelocations(p.data, p.dec, ehdr, phdr, shdr, shstrtab, dyn)
+	if err != nil {
+		return nil, err
+	}
+
+	p.relocs, p.relocsParsed = tables, true
+	return tables, nil
+}
+
+// Notes returns the notes of every SHT_NOTE section and PT_NOTE segment, each reported once.
+// Build IDs, ABI tags, GNU properties, package metadata and core file notes come decoded in Value.
+// Results are cached after the first call.
+func (p *Parser) Notes() ([]types.Note, error) {
+	if p.notesParsed {
+		return p.notes, nil
+	}
+
+	ehdr, err := p.ELFHeader()
+	if err != nil {
+		return nil, err
+	}
+
+	phdr, err := p.ProgramHeaders()
+	if err != nil {
+		return nil, err
+	}
+
+	shdr, err := p.SectionHeaders()
+	if err != nil {
+		return nil, err
+	}
+
+	shstrtab, err := p.SectionStringTable()
+	if err != nil {
+		return nil, err
+	}
+
+	notes, err := parseNotes(p.data, p.dec, ehdr, phdr, shdr, shstrtab)
+	if err != nil {
+		return nil, err
+	}
+
+	p.notes, p.notesParsed = notes, true
+	return notes, nil
+}
+
+// Versions returns the symbol versioning tables: the versions the file defines, those it
+// requires from its dependencies and the version index of every dynamic symbol. Files without
+// versioning yield empty tables. Results are cached after the first call.
+func (p *Parser) Versions() (*types.Versions, error) {
+	if p.versionsParsed {
+		return p.versions, nil
+	}
+
+	if _, err := p.ELFHeader(); err != nil {
+		return nil, err
+	}
+
+	shdr, err := p.SectionHeaders()
+	if err != nil {
+		return nil, err
+	}
+
+	versions, err := parseVersions(p.data, p.dec, shdr)
+	if err != nil {
+		return nil, err
+	}
+
+	p.versions, p.versionsParsed = versions, true
+	return versions, nil
+}
+
+// GNUHash returns the DT_GNU_HASH table, located through the dynamic section like the loader
+// does or through the SHT_GNU_HASH section. Files without one yield nil.
+// Results are cached after the first call.
+func (p *Parser) GNUHash() (*types.GNUHashTable, error) {
+	if p.gnuHashParsed {
+		return p.gnuHash, nil
+	}
+
+	offset, found, err := p.hashLocation(types.DT_GNU_HASH, types.SHT_GNU_HASH)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		p.gnuHashParsed = true
+		return nil, nil
+	}
+
+	table, err := parseGNUHash(p.data, p.dec, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	p.gnuHash, p.gnuHashParsed = table, true
+	return table, nil
+}
+
+// SysVHash returns the DT_HASH table, located through the dynamic section like the loader
+// does or through the SHT_HASH section. Files without one yield nil.
+// Results are cached after the first call.
+func (p *Parser) SysVHash() (*types.SysVHashTable, error) {
+	if p.sysvHashParsed {
+		return p.sysvHash, nil
+	}
+
+	offset, found, err := p.hashLocation(types.DT_HASH, types.SHT_HASH)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		p.sysvHashParsed = true
+		return nil, nil
+	}
+
+	table, err := parseSysVHash(p.data, p.dec, p.ehdr, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	p.sysvHash, p.sysvHashParsed = table, true
+	return table, nil
+}
+
+// hashLocation finds the file offset of the hash table with the given tag and section type.
+func (p *Parser) hashLocation(tag int64, sh_type uint32) (uint64, bool, error) {
+	if _, err := p.ELFHeader(); err != nil {
+		return 0, false, err
+	}
+
+	phdr, err := p.ProgramHeaders()
+	if err != nil {
+		return 0, false, err
+	}
+
+	shdr, err := p.SectionHeaders()
+	if err != nil {
+		return 0, false, err
+	}
+
+	dyn, err := p.DynamicEntries()
+	if err != nil {
+		return 0, false, err
+	}
+
+	offset, found := hashLocation(dyn, phdr, shdr, tag, sh_type)
+	return offset, found, nil
+}
+
+// VaddrToOffset translates a virtual address into a file offset through the PT_LOAD segments.
+// The second result is false when the address is not backed by file contents.
+func (p *Parser) VaddrToOffset(addr uint64) (uint64, bool) {
+	phdr, err := p.ProgramHeaders()
+	if err != nil {
+		return 0, false
+	}
+	return vaddrToOffset(phdr, addr)
+}
+
+// OffsetToVaddr translates a file offset into a virtual address through the PT_LOAD segments.
+// The second result is false when the offset is not loaded at runtime.
+func (p *Parser) OffsetToVaddr(offset uint64) (uint64, bool) {
+	phdr, err := p.ProgramHeaders()
+	if err != nil {
+		return 0, false
+	}
+	return offsetToVaddr(phdr, offset)
+}
+
+// SectionData returns the file bytes backing a section, as a slice of the loaded data.
+// NOBITS sections occupy no file space and yield an empty slice.
+func (p *Parser) SectionData(sh *types.Elf64_Shdr) ([]byte, error) {
+	shstrtab, err := p.SectionStringTable()
+	if err != nil {
+		return nil, err
+	}
+	return parseSectionData(p.data, sh, "section "+types.GetString(shstrtab, sh.Sh_name))
+}
+
+// Bytes returns size bytes of the file starting at offset, as a slice of the loaded data.
+// Ranges that do not fit inside the file come back as a TruncatedTableError naming what.
+func (p *Parser) Bytes(what string, offset, size uint64) ([]byte, error) {
+	if err := checkTable(what, offset, 1, size, len(p.data)); err != nil {
+		return nil, err
+	}
+	return p.data[offset : offset+size], nil
+}
+
+// Data returns the raw file bytes loaded by the parser.
+func (p *Parser) Data() []byte {
+	return p.data
+}
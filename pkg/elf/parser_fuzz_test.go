@@ -0,0 +1,152 @@
+package elf
+
+import (
+	"encoding/binary"
+	"testing"
+
+	"github.com/yourpwnguy/strix/internal/reader"
+	"github.com/yourpwnguy/strix/pkg/elf/types"
+)
+
+// seedELF builds a small but well formed ELF image with one PT_LOAD segment,
+// a NULL section and a .shstrtab section, in the requested class and byte order.
+func seedELF(class uint8, order binary.ByteOrder) []byte {
+	var ehsize, phentsize, shentsize int
+	if class == types.ELFCLASS64 {
+		ehsize, phentsize, shentsize = 64, 56, 64
+	} else {
+		ehsize, phentsize, shentsize = 52, 32, 40
+	}
+
+	strtab := []byte("\x00.shstrtab\x00")
+	phoff := ehsize
+	stroff := phoff + phentsize
+	shoff := (stroff + len(strtab) + 7) &^ 7
+	buf := make([]byte, shoff+2*shentsize)
+
+	copy(buf, []byte{0x7f, 'E', 'L', 'F', class, types.ELFDATA2LSB, 1})
+	if order == binary.BigEndian {
+		buf[types.EI_DATA] = types.ELFDATA2MSB
+	}
+	copy(buf[stroff:], strtab)
+
+	if class == types.ELFCLASS64 {
+		hdr := types.Elf64_Ehdr{
+			E_type: types.ET_EXEC, E_machine: types.EM_X86_64, E_version: 1,
+			E_entry: 0x401000, E_phoff: uint64(phoff), E_shoff: uint64(shoff),
+			E_ehsize: uint16(ehsize), E_phentsize: uint16(phentsize), E_phnum: 1,
+			E_shentsize: uint16(shentsize), E_shnum: 2, E_shstrndx: 1,
+		}
+		hdr.E_ident.FileIdn = [4]uint8{0x7f, 'E', 'L', 'F'}
+		hdr.E_ident.Ei_class, hdr.E_ident.Ei_data, hdr.E_ident.Ei_version = class, buf[types.EI_DATA], 1
+		binary.Encode(buf, order, hdr)
+		binary.Encode(buf[phoff:], order, types.Elf64_Phdr{
+			P_type: types.PT_LOAD, P_flags: types.PF_R | types.PF_X, P_vaddr: 0x400000,
+			P_filesz: uint64(len(buf)), P_memsz: uint64(len(buf)), P_align: 0x1000,
+		})
+		binary.Encode(buf[shoff+shentsize:], order, types.Elf64_Shdr{
+			Sh_name: 1, Sh_type: types.SHT_STRTAB, Sh_offset: uint64(stroff),
+			Sh_size: uint64(len(strtab)), Sh_addralign: 1,
+		})
+		return buf
+	}
+
+	hdr := types.Elf32_Ehdr{
+		E_type: types.ET_EXEC, E_machine: types.EM_386, E_version: 1,
+		E_entry: 0x8049000, E_phoff: uint32(phoff), E_shoff: uint32(shoff),
+		E_ehsize: uint16(ehsize), E_phentsize: uint16(phentsize), E_phnum: 1,
+		E_shentsize: uint16(shentsize), E_shnum: 2, E_shstrndx: 1,
+	}
+	hdr.E_ident.FileIdn = [4]uint8{0x7f, 'E', 'L', 'F'}
+	hdr.E_ident.Ei_class, hdr.E_ident.Ei_data, hdr.E_ident.Ei_version = class, buf[types.EI_DATA], 1
+	binary.Encode(buf, order, hdr)
+	binary.Encode(buf[phoff:], order, types.Elf32_Phdr{
+		P_type: types.PT_LOAD, P_flags: types.PF_R | types.PF_X, P_vaddr: 0x8048000,
+		P_filesz: uint32(len(buf)), P_memsz: uint32(len(buf)), P_align: 0x1000,
+	})
+	binary.Encode(buf[shoff+shentsize:], order, types.Elf32_Shdr{
+		Sh_name: 1, Sh_type: types.SHT_STRTAB, Sh_offset: uint32(stroff),
+		Sh_size: uint32(len(strtab)), Sh_addralign: 1,
+	})
+	return buf
+}
+
+// seedCore builds a 64 bit little endian core file with no sections and a single PT_NOTE
+// segment holding one CORE note of the given type and descriptor.
+func seedCore(n_type uint32, desc []byte) []byte {
+	const ehsize, phentsize = 64, 56
+
+	note := binary.LittleEndian.AppendUint32(nil, 5)
+	note = binary.LittleEndian.AppendUint32(note, uint32(len(desc)))
+	note = binary.LittleEndian.AppendUint32(note, n_type)
+	note = append(note, "CORE\x00\x00\x00\x00"...)
+	note = append(note, desc...)
+
+	noteoff := ehsize + phentsize
+	buf := make([]byte, noteoff+len(note))
+	copy(buf[noteoff:], note)
+
+	hdr := types.Elf64_Ehdr{
+		E_type: types.ET_CORE, E_machine: types.EM_X86_64, E_version: 1,
+		E_phoff: ehsize, E_ehsize: ehsize, E_phentsize: phentsize, E_phnum: 1,
+	}
+	hdr.E_ident.FileIdn = [4]uint8{0x7f, 'E', 'L', 'F'}
+	hdr.E_ident.Ei_class, hdr.E_ident.Ei_data, hdr.E_ident.Ei_version = types.ELFCLASS64, types.ELFDATA2LSB, 1
+	binary.Encode(buf, binary.LittleEndian, hdr)
+	binary.Encode(buf[ehsize:], binary.LittleEndian, types.Elf64_Phdr{
+		P_type: types.PT_NOTE, P_offset: uint64(noteoff), P_filesz: uint64(len(note)), P_align: 4,
+	})
+	return buf
+}
+
+// FuzzParser feeds arbitrary bytes through every Parser entry point. Malformed input must
+// come back as an error, never as a panic or an out of bounds read.
+//
+//	go test ./pkg/elf -run '^$' -fuzz FuzzParser
+func FuzzParser(f *testing.F) {
+	f.Add(seedELF(types.ELFCLASS64, binary.LittleEndian))
+	f.Add(seedELF(types.ELFCLASS64, binary.BigEndian))
+	f.Add(seedELF(types.ELFCLASS32, binary.LittleEndian))
+	f.Add(seedELF(types.ELFCLASS32, binary.BigEndian))
+	f.Add([]byte("\x7fELF"))
+	// NT_FILE descriptors shorter than their count and page size
+	f.Add(seedCore(types.NT_FILE, []byte{1, 0, 0, 0}))
+	f.Add(seedCore(types.NT_FILE, []byte{1, 0, 0, 0, 0, 0, 0, 0, 0, 0x10, 0, 0}))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		p := NewParser(reader.NewMemoryReader(data))
+		if err := p.Load("fuzz"); err != nil {
+			t.Fatal(err)
+		}
+		defer p.Close()
+
+		if _, err := p.ELFHeader(); err != nil {
+			return
+		}
+
+		phdr, phErr := p.ProgramHeaders()
+		shdr, shErr := p.SectionHeaders()
+		shstrtab, _ := p.SectionStringTable()
+		_, _ = p.File()
+		_, _ = p.Symbols()
+		_, _ = p.DynamicSymbols()
+		_, _ = p.DynamicEntries()
+		_, _ = p.Relocations()
+		_, _ = p.Notes()
+		_, _ = p.Versions()
+		_, _ = p.GNUHash()
+		_, _ = p.SysVHash()
+
+		if phErr == nil {
+			types.HasInterpreter(phdr)
+			types.GetInterpreter(phdr, p.Data())
+		}
+
+		if shErr == nil {
+			for i := range shdr {
+				types.GetString(shstrtab, shdr[i].Sh_name)
+			}
+			types.MapSectionsToSegments(phdr, shdr)
+		}
+	})
+}
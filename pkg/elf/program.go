@@ -0,0 +1,46 @@
+package elf
+
+import (
+	"github.com/yourpwnguy/strix/pkg/elf/types"
+)
+
+// programHeaderCount resolves the number of program headers, following the PN_XNUM escape
+// into sh_info of the initial section header.
+func programHeaderCount(data []byte, ehdr *types.Elf64_Ehdr, dec *decoder) (uint64, error) {
+	if ehdr.E_phnum != types.PN_XNUM {
+		return uint64(ehdr.E_phnum), nil
+	}
+
+	first, err := initialSectionHeader(data, ehdr, dec)
+	if err != nil || first == nil {
+		return uint64(ehdr.E_phnum), err
+	}
+	return uint64(first.Sh_info), nil
+}
+
+// parseProgramHeaders validates and parses the program headers from the provided byte slice.
+func parseProgramHeaders(data []byte, ehdr *types.Elf64_Ehdr, dec *decoder) ([]types.Elf64_Phdr, error) {
+	count, err := programHeaderCount(data, ehdr, dec)
+	if err != nil {
+		return nil, err
+	}
+
+	if count == 0 || ehdr.E_phoff == 0 {
+		return nil, nil
+	}
+
+	size := dec.phentsize()
+	if uint64(ehdr.E_phentsize) != size {
+		return nil, &EntrySizeError{
+			Table: "program header table",
+			Got:   uint64(ehdr.E_phentsize),
+			Want:  size,
+		}
+	}
+
+	if err := checkTable("program header table", ehdr.E_phoff, count, size, len(data)); err != nil {
+		return nil, err
+	}
+
+	return dec.programHeaders(data, count, ehdr.E_phoff), nil
+}
@@ -0,0 +1,296 @@
+package elf
+
+import (
+	"github.com/yourpwnguy/strix/pkg/elf/types"
+)
+
+// relocSource describes where a relocation table lives before it is decoded.
+type relocSource struct {
+	name    string
+	kind    uint32 // SHT_REL, SHT_RELA or SHT_RELR
+	offset  uint64
+	size    uint64
+	entsize uint64
+	symtab  int  // Section index of the linked symbol table, -1 when there is none
+	dynsym  bool // Symbols come from DT_SYMTAB, the table has no section header
+	place   int  // Section the entries patch in relocatable files, -1 when r_offset is a vaddr
+}
+
+// sectionRelocSources collects the SHT_REL, SHT_RELA and SHT_RELR sections.
+func sectionRelocSources(ehdr *types.Elf64_Ehdr, shdr []types.Elf64_Shdr, shstrtab []byte) []relocSource {
+	var sources []relocSource
+	for i := range shdr {
+		sh := &shdr[i]
+		if sh.Sh_type != types.SHT_REL && sh.Sh_type != types.SHT_RELA && sh.Sh_type != types.SHT_RELR {
+			continue
+		}
+
+		src := relocSource{
+			name:    types.GetString(shstrtab, sh.Sh_name),
+			kind:    sh.Sh_type,
+			offset:  sh.Sh_offset,
+			size:    sh.Sh_size,
+			entsize: sh.Sh_entsize,
+			symtab:  -1,
+			place:   -1,
+		}
+
+		if link := sh.Sh_link; link != 0 && link < uint32(len(shdr)) {
+			if t := shdr[link].Sh_type; t == types.SHT_SYMTAB || t == types.SHT_DYNSYM {
+				src.symtab = int(link)
+			}
+		}
+
+		// In relocatable files r_offset is relative to the section named by sh_info
+		if ehdr.E_type == types.ET_REL && sh.Sh_info != 0 && sh.Sh_info < uint32(len(shdr)) {
+			src.place = int(sh.Sh_info)
+		}
+
+		sources = append(sources, src)
+	}
+	return sources
+}
+
+// dynamicRelocSources collects the relocation tables named by the dynamic section.
+// They are only used when the section headers are missing, so their symbols are looked up
+// in the table DT_SYMTAB names.
+func dynamicRelocSources(dyn []types.DynamicEntry, phdr []types.Elf64_Phdr) []relocSource {
+	raw := make([]types.Elf64_Dyn, len(dyn))
+	for i := range dyn {
+		raw[i] = dyn[i].Elf64_Dyn
+	}
+
+	pltKind := types.SHT_RELA
+	if v, ok := dynamicTagValue(raw, types.DT_PLTREL); ok && int64(v) == types.DT_REL {
+		pltKind = types.SHT_REL
+	}
+
+	tables := []struct {
+		name                string
+		kind                uint32
+		addr, size, entsize int64
+	}{
+		{"DT_RELA", types.SHT_RELA, types.DT_RELA, types.DT_RELASZ, types.DT_RELAENT},
+		{"DT_REL", types.SHT_REL, types.DT_REL, types.DT_RELSZ, types.DT_RELENT},
+		{"DT_RELR", types.SHT_RELR, types.DT_RELR, types.DT_RELRSZ, types.DT_RELRENT},
+		{"DT_JMPREL", pltKind, types.DT_JMPREL, types.DT_PLTRELSZ, 0},
+	}
+
+	var sources []relocSource
+	for _, t := range tables {
+		addr, ok := dynamicTagValue(raw, t.addr)
+		if !ok {
+			continue
+		}
+
+		offset, ok := vaddrToOffset(phdr, addr)
+		if !ok {
+			continue
+		}
+
+		size, _ := dynamicTagValue(raw, t.size)
+		entsize, _ := dynamicTagValue(raw, t.entsize)
+
+		sources = append(sources, relocSource{
+			name:    t.name,
+			kind:    t.kind,
+			offset:  offset,
+			size:    size,
+			entsize: entsize,
+			symtab:  -1,
+			dynsym:  true,
+			place:   -1,
+		})
+	}
+	return sources
+}
+
+// parseRelocations decodes every relocation table, preferring the section headers and falling
+// back to the dynamic section. Symbols are resolved through each table's linked symbol table,
+// and REL/RELR addends are read from the patched location.
+func parseRelocations(data []byte, dec *decoder, ehdr *types.Elf64_Ehdr, phdr []types.Elf64_Phdr, shdr []types.Elf64_Shdr, shstrtab []byte, dyn []types.DynamicEntry) ([]types.RelocationTable, error) {
+	sources := sectionRelocSources(ehdr, shdr, shstrtab)
+	if len(sources) == 0 {
+		sources = dynamicRelocSources(dyn, phdr)
+	}
+
+	symtabs := make(map[int][]types.Symbol)
+	tables := make([]types.RelocationTable, 0, len(sources))
+
+	// Tables without a section header are resolved once they are all decoded, since the
+	// highest symbol index they use is the only bound on DT_SYMTAB
+	var dynamic []int
+	var dynCount uint64
+
+	for _, src := range sources {
+		var want uint64
+		switch src.kind {
+		case types.SHT_REL:
+			want = dec.relentsize()
+		case types.SHT_RELA:
+			want = dec.relaentsize()
+		case types.SHT_RELR:
+			want = dec.addrsize()
+		}
+
+		// DT_JMPREL has no entry size tag of its own
+		if src.entsize == 0 {
+			src.entsize = want
+		}
+		if src.entsize != want {
+			return nil, &EntrySizeError{
+				Table: "relocation table " + src.name,
+				Got:   src.entsize,
+				Want:  want,
+			}
+		}
+
+		count := src.size / want
+		if err := checkTable("relocation table "+src.name, src.offset, count, want, len(data)); err != nil {
+			return nil, err
+		}
+
+		var syms []types.Symbol
+		if src.symtab >= 0 {
+			if cached, ok := symtabs[src.symtab]; ok {
+				syms = cached
+			} else {
+				parsed, err := parseSymbols(data, dec, shdr, src.symtab)
+				if err != nil {
+					return nil, err
+				}
+				symtabs[src.symtab] = parsed
+				syms = parsed
+			}
+		}
+
+		table := types.RelocationTable{
+			Name:   src.name,
+			Kind:   src.kind,
+			Offset: src.offset,
+		}
+
+		switch src.kind {
+		case types.SHT_RELA:
+			raw := dec.rela(data, count, src.offset)
+			table.Relocs = make([]types.Relocation, len(raw))
+			for i := range raw {
+				table.Relocs[i] = types.Relocation{
+					Offset:    raw[i].R_offset,
+					Type:      raw[i].Type(),
+					SymIndex:  raw[i].Sym(),
+					Addend:    raw[i].R_addend,
+					HasAddend: true,
+				}
+			}
+
+		case types.SHT_REL:
+			raw := dec.rel(data, count, src.offset)
+			table.Relocs = make([]types.Relocation, len(raw))
+			for i := range raw {
+				table.Relocs[i] = types.Relocation{
+					Offset:   raw[i].R_offset,
+					Type:     raw[i].Type(),
+					SymIndex: raw[i].Sym(),
+				}
+			}
+
+		case types.SHT_RELR:
+			relative := types.RelativeType(ehdr.E_machine)
+			for _, addr := range expandRelr(dec.addresses(data, count, src.offset), dec.addrsize()) {
+				table.Relocs = append(table.Relocs, types.Relocation{
+					Offset: addr,
+					Type:   relative,
+				})
+			}
+		}
+
+		for i := range table.Relocs {
+			r := &table.Relocs[i]
+			if src.dynsym {
+				dynCount = max(dynCount, uint64(r.SymIndex)+1)
+			} else if r.SymIndex != 0 && r.SymIndex < uint32(len(syms)) {
+				r.Symbol = &syms[r.SymIndex]
+			}
+			if !r.HasAddend {
+				r.Addend = implicitAddend(data, dec, phdr, shdr, src.place, r.Offset)
+			}
+		}
+
+		if src.dynsym {
+			dynamic = append(dynamic, len(tables))
+		}
+		tables = append(tables, table)
+	}
+
+	if len(dynamic) > 0 {
+		syms, err := parseDynamicSymbols(data, dec, dyn, phdr, dynCount)
+		if err != nil {
+			return nil, err
+		}
+		for _, t := range dynamic {
+			for i := range tables[t].Relocs {
+				r := &tables[t].Relocs[i]
+				if r.SymIndex != 0 && r.SymIndex < uint32(len(syms)) {
+					r.Symbol = &syms[r.SymIndex]
+				}
+			}
+		}
+	}
+
+	return tables, nil
+}
+
+// expandRelr unpacks a SHT_RELR table into the addresses it relocates. An even word is an
+// address, an odd word is a bitmap of the following wordSize*8-1 words after the last address.
+func expandRelr(words []uint64, wordSize uint64) []uint64 {
+	bits := wordSize*8 - 1
+
+	var addrs []uint64
+	var next uint64
+	for _, w := range words {
+		if w&1 == 0 {
+			addrs = append(addrs, w)
+			next = w + wordSize
+			continue
+		}
+
+		for i := uint64(0); i < bits; i++ {
+			if w>>(i+1)&1 != 0 {
+				addrs = append(addrs, next+i*wordSize)
+			}
+		}
+		next += bits * wordSize
+	}
+	return addrs
+}
+
+// implicitAddend reads the word stored at the location a REL or RELR entry patches.
+// place is the patched section in relocatable files, otherwise r_offset is a vaddr.
+// Locations without file backing yield 0.
+func implicitAddend(data []byte, dec *decoder, phdr []types.Elf64_Phdr, shdr []types.Elf64_Shdr, place int, r_offset uint64) int64 {
+	var offset uint64
+	if place >= 0 {
+		sh := &shdr[place]
+		if sh.Sh_type == types.SHT_NOBITS || r_offset >= sh.Sh_size {
+			return 0
+		}
+		offset = sh.Sh_offset + r_offset
+	} else {
+		var ok bool
+		if offset, ok = vaddrToOffset(phdr, r_offset); !ok {
+			return 0
+		}
+	}
+
+	val, ok := dec.address(data, offset)
+	if !ok {
+		return 0
+	}
+
+	// Sign-extend like the explicit ELF32 addends
+	if !dec.is64() {
+		return int64(int32(val))
+	}
+	return int64(val)
+}
@@ -0,0 +1,82 @@
+package elf
+
+import (
+	"github.com/yourpwnguy/strix/pkg/elf/types"
+)
+
+// initialSectionHeader returns section header 0, which carries the real e_shnum, e_shstrndx
+// and e_phnum values when they do not fit in the ELF header. Returns nil if there is no table.
+func initialSectionHeader(data []byte, ehdr *types.Elf64_Ehdr, dec *decoder) (*types.Elf64_Shdr, error) {
+	if ehdr.E_shoff == 0 {
+		return nil, nil
+	}
+
+	size := dec.shentsize()
+	if uint64(ehdr.E_shentsize) != size {
+		return nil, &EntrySizeError{
+			Table: "section header table",
+			Got:   uint64(ehdr.E_shentsize),
+			Want:  size,
+		}
+	}
+
+	if err := checkTable("section header table", ehdr.E_shoff, 1, size, len(data)); err != nil {
+		return nil, err
+	}
+
+	return &dec.sectionHeaders(data, 1, ehdr.E_shoff)[0], nil
+}
+
+// parseSectionHeaders parses section headers, zero-copy for native ELF64 files.
+// When e_shnum is zero but a table is present, the real count lives in sh_size of the initial entry.
+func parseSectionHeaders(data []byte, ehdr *types.Elf64_Ehdr, dec *decoder) ([]types.Elf64_Shdr, error) {
+	first, err := initialSectionHeader(data, ehdr, dec)
+	if err != nil || first == nil {
+		return nil, err
+	}
+
+	count := uint64(ehdr.E_shnum)
+	if count == 0 {
+		count = first.Sh_size
+	}
+
+	size := dec.shentsize()
+	if err := checkTable("section header table", ehdr.E_shoff, count, size, len(data)); err != nil {
+		return nil, err
+	}
+
+	// The program header table is validated on its own, only its extent matters here
+	if phnum, err := programHeaderCount(data, ehdr, dec); err == nil && ehdr.E_phoff != 0 &&
+		checkTable("program header table", ehdr.E_phoff, phnum, dec.phentsize(), len(data)) == nil {
+		if err := checkOverlap(
+			"section header table", ehdr.E_shoff, count*size,
+			"program header table", ehdr.E_phoff, phnum*dec.phentsize(),
+		); err != nil {
+			return nil, err
+		}
+	}
+
+	return dec.sectionHeaders(data, count, ehdr.E_shoff), nil
+}
+
+// sectionStringIndex resolves e_shstrndx, following the SHN_XINDEX escape into sh_link of the initial entry.
+func sectionStringIndex(ehdr *types.Elf64_Ehdr, shdr []types.Elf64_Shdr) uint32 {
+	if ehdr.E_shstrndx == types.SHN_XINDEX && len(shdr) > 0 {
+		return shdr[0].Sh_link
+	}
+	return uint32(ehdr.E_shstrndx)
+}
+
+// parseSectionData returns the file bytes backing a section.
+// NOBITS sections occupy no file space and yield an empty slice.
+func parseSectionData(data []byte, sh *types.Elf64_Shdr, table string) ([]byte, error) {
+	if sh.Sh_type == types.SHT_NOBITS {
+		return nil, nil
+	}
+
+	if err := checkTable(table, sh.Sh_offset, 1, sh.Sh_size, len(data)); err != nil {
+		return nil, err
+	}
+
+	return data[sh.Sh_offset : sh.Sh_offset+sh.Sh_size], nil
+}
@@ -0,0 +1,122 @@
+package elf
+
+import (
+	"github.com/yourpwnguy/strix/pkg/elf/types"
+)
+
+// findSection returns the index of the first section of the given type, or -1 if there is none.
+func findSection(shdr []types.Elf64_Shdr, sh_type uint32) int {
+	for i := range shdr {
+		if shdr[i].Sh_type == sh_type {
+			return i
+		}
+	}
+	return -1
+}
+
+// parseSymbols decodes the symbol table held by section idx. Names are resolved through the
+// string table in sh_link, and SHN_XINDEX entries through the SHT_SYMTAB_SHNDX section linked to it.
+func parseSymbols(data []byte, dec *decoder, shdr []types.Elf64_Shdr, idx int) ([]types.Symbol, error) {
+	sh := &shdr[idx]
+
+	size := dec.symentsize()
+	if sh.Sh_entsize != size {
+		return nil, &EntrySizeError{
+			Table: "symbol table",
+			Got:   sh.Sh_entsize,
+			Want:  size,
+		}
+	}
+
+	count := sh.Sh_size / size
+	if err := checkTable("symbol table", sh.Sh_offset, count, size, len(data)); err != nil {
+		return nil, err
+	}
+	raw := dec.symbols(data, count, sh.Sh_offset)
+
+	// Linked string table
+	var strtab []byte
+	if sh.Sh_link != 0 && sh.Sh_link < uint32(len(shdr)) {
+		var err error
+		strtab, err = parseSectionData(data, &shdr[sh.Sh_link], "symbol string table")
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Extended section indices, one word per symbol
+	var xindex []uint32
+	for i := range shdr {
+		if shdr[i].Sh_type != types.SHT_SYMTAB_SHNDX || shdr[i].Sh_link != uint32(idx) {
+			continue
+		}
+
+		words := shdr[i].Sh_size / 4
+		if err := checkTable("extended section index table", shdr[i].Sh_offset, words, 4, len(data)); err != nil {
+			return nil, err
+		}
+		xindex = dec.words(data, words, shdr[i].Sh_offset)
+		break
+	}
+
+	syms := make([]types.Symbol, len(raw))
+	for i := range raw {
+		shndx := uint32(raw[i].St_shndx)
+		if raw[i].St_shndx == types.SHN_XINDEX && i < len(xindex) {
+			shndx = xindex[i]
+		}
+
+		syms[i] = types.Symbol{
+			Elf64_Sym: raw[i],
+			Index:     uint32(i),
+			Name:      types.GetString(strtab, raw[i].St_name),
+			Shndx:     shndx,
+		}
+	}
+
+	return syms, nil
+}
+
+// parseDynamicSymbols decodes the first count entries of the symbol table named by DT_SYMTAB,
+// with names from DT_STRTAB, for files whose section headers are missing. The table records
+// no size of its own, so entries past the end of the file are dropped rather than reported.
+func parseDynamicSymbols(data []byte, dec *decoder, dyn []types.DynamicEntry, phdr []types.Elf64_Phdr, count uint64) ([]types.Symbol, error) {
+	addr, ok := types.DynamicValue(dyn, types.DT_SYMTAB)
+	if !ok || count == 0 {
+		return nil, nil
+	}
+	offset, ok := vaddrToOffset(phdr, addr)
+	if !ok || offset >= uint64(len(data)) {
+		return nil, nil
+	}
+
+	size := dec.symentsize()
+	if entsize, ok := types.DynamicValue(dyn, types.DT_SYMENT); ok && entsize != size {
+		return nil, &EntrySizeError{Table: "dynamic symbol table", Got: entsize, Want: size}
+	}
+	count = min(count, (uint64(len(data))-offset)/size)
+	raw := dec.symbols(data, count, offset)
+
+	// The string table is cut short at the end of the file
+	var strtab []byte
+	if addr, ok := types.DynamicValue(dyn, types.DT_STRTAB); ok {
+		if start, ok := vaddrToOffset(phdr, addr); ok && start < uint64(len(data)) {
+			end := uint64(len(data))
+			if n, ok := types.DynamicValue(dyn, types.DT_STRSZ); ok && n < end-start {
+				end = start + n
+			}
+			strtab = data[start:end]
+		}
+	}
+
+	syms := make([]types.Symbol, len(raw))
+	for i := range raw {
+		syms[i] = types.Symbol{
+			Elf64_Sym: raw[i],
+			Index:     uint32(i),
+			Name:      types.GetString(strtab, raw[i].St_name),
+			Shndx:     uint32(raw[i].St_shndx),
+		}
+	}
+	return syms, nil
+}
@@ -0,0 +1,663 @@
+package types
+
+const (
+	// Magic number validation for ELF
+	// We will be fucked up if we try it in Big-endian OS
+	ELF_MAGIC Elf64_Word = 0x464c457f
+
+	// Indexes into e_ident
+	EI_CLASS  = 4  /* File class byte index */
+	EI_DATA   = 5  /* Data encoding byte index */
+	EI_NIDENT = 16 /* Size of e_ident */
+
+	// ELF Class (ei_class)
+	ELFCLASSNONE uint8 = 0
+	ELFCLASS32   uint8 = 1
+	ELFCLASS64   uint8 = 2
+
+	// Data encoding (ei_data)
+	ELFDATANONE uint8 = 0
+	ELFDATA2LSB uint8 = 1
+	ELFDATA2MSB uint8 = 2
+
+	// OS/ABI (ei_osabi)
+	ELFOSABI_NONE       uint8 = 0
+	ELFOSABI_LINUX      uint8 = 3
+	ELFOSABI_FREEBSD    uint8 = 9
+	ELFOSABI_OPENBSD    uint8 = 12
+	ELFOSABI_STANDALONE uint8 = 255
+
+	// Legal Values for e_type (Object file type)
+	ET_NONE   uint16 = 0
+	ET_REL    uint16 = 1
+	ET_EXEC   uint16 = 2
+	ET_DYN    uint16 = 3
+	ET_CORE   uint16 = 4
+	ET_LOOS   uint16 = 0xfe00
+	ET_HIOS   uint16 = 0xfeff
+	ET_LOPROC uint16 = 0xff00
+	ET_HIPROC uint16 = 0xffff
+
+	// Legal Values for e_machine (architecture)
+	EM_NONE        = 0  /* No machine */
+	EM_M32         = 1  /* AT&T WE 32100 */
+	EM_SPARC       = 2  /* SUN SPARC */
+	EM_386         = 3  /* Intel 80386 */
+	EM_68K         = 4  /* Motorola m68k family */
+	EM_88K         = 5  /* Motorola m88k family */
+	EM_IAMCU       = 6  /* Intel MCU */
+	EM_860         = 7  /* Intel 80860 */
+	EM_MIPS        = 8  /* MIPS R3000 big-endian */
+	EM_S370        = 9  /* IBM System/370 */
+	EM_MIPS_RS3_LE = 10 /* MIPS R3000 little-endian */
+	/* reserved 11-14 */
+	EM_PARISC = 15 /* HPPA */
+	/* reserved 16 */
+	EM_VPP500      = 17 /* Fujitsu VPP500 */
+	EM_SPARC32PLUS = 18 /* Sun's "v8plus" */
+	EM_960         = 19 /* Intel 80960 */
+	EM_PPC         = 20 /* PowerPC */
+	EM_PPC64       = 21 /* PowerPC 64-bit */
+	EM_S390        = 22 /* IBM S390 */
+	EM_SPU         = 23 /* IBM SPU/SPC */
+	/* reserved 24-35 */
+	EM_V800         = 36  /* NEC V800 series */
+	EM_FR20         = 37  /* Fujitsu FR20 */
+	EM_RH32         = 38  /* TRW RH-32 */
+	EM_RCE          = 39  /* Motorola RCE */
+	EM_ARM          = 40  /* ARM */
+	EM_FAKE_ALPHA   = 41  /* Digital Alpha */
+	EM_SH           = 42  /* Hitachi SH */
+	EM_SPARCV9      = 43  /* SPARC v9 64-bit */
+	EM_TRICORE      = 44  /* Siemens Tricore */
+	EM_ARC          = 45  /* Argonaut RISC Core */
+	EM_H8_300       = 46  /* Hitachi H8/300 */
+	EM_H8_300H      = 47  /* Hitachi H8/300H */
+	EM_H8S          = 48  /* Hitachi H8S */
+	EM_H8_500       = 49  /* Hitachi H8/500 */
+	EM_IA_64        = 50  /* Intel Merced */
+	EM_MIPS_X       = 51  /* Stanford MIPS-X */
+	EM_COLDFIRE     = 52  /* Motorola Coldfire */
+	EM_68HC12       = 53  /* Motorola M68HC12 */
+	EM_MMA          = 54  /* Fujitsu MMA Multimedia Accelerator */
+	EM_PCP          = 55  /* Siemens PCP */
+	EM_NCPU         = 56  /* Sony nCPU embedded RISC */
+	EM_NDR1         = 57  /* Denso NDR1 microprocessor */
+	EM_STARCORE     = 58  /* Motorola Start*Core processor */
+	EM_ME16         = 59  /* Toyota ME16 processor */
+	EM_ST100        = 60  /* STMicroelectronic ST100 processor */
+	EM_TINYJ        = 61  /* Advanced Logic Corp. Tinyj emb.fam */
+	EM_X86_64       = 62  /* AMD x86-64 architecture */
+	EM_PDSP         = 63  /* Sony DSP Processor */
+	EM_PDP10        = 64  /* Digital PDP-10 */
+	EM_PDP11        = 65  /* Digital PDP-11 */
+	EM_FX66         = 66  /* Siemens FX66 microcontroller */
+	EM_ST9PLUS      = 67  /* STMicroelectronics ST9+ 8/16 mc */
+	EM_ST7          = 68  /* STmicroelectronics ST7 8 bit mc */
+	EM_68HC16       = 69  /* Motorola MC68HC16 microcontroller */
+	EM_68HC11       = 70  /* Motorola MC68HC11 microcontroller */
+	EM_68HC08       = 71  /* Motorola MC68HC08 microcontroller */
+	EM_68HC05       = 72  /* Motorola MC68HC05 microcontroller */
+	EM_SVX          = 73  /* Silicon Graphics SVx */
+	EM_ST19         = 74  /* STMicroelectronics ST19 8 bit mc */
+	EM_VAX          = 75  /* Digital VAX */
+	EM_CRIS         = 76  /* Axis Communications 32-bit emb.proc */
+	EM_JAVELIN      = 77  /* Infineon Technologies 32-bit emb.proc */
+	EM_FIREPATH     = 78  /* Element 14 64-bit DSP Processor */
+	EM_ZSP          = 79  /* LSI Logic 16-bit DSP Processor */
+	EM_MMIX         = 80  /* Donald Knuth's educational 64-bit proc */
+	EM_HUANY        = 81  /* Harvard University machine-independent object files */
+	EM_PRISM        = 82  /* SiTera Prism */
+	EM_AVR          = 83  /* Atmel AVR 8-bit microcontroller */
+	EM_FR30         = 84  /* Fujitsu FR30 */
+	EM_D10V         = 85  /* Mitsubishi D10V */
+	EM_D30V         = 86  /* Mitsubishi D30V */
+	EM_V850         = 87  /* NEC v850 */
+	EM_M32R         = 88  /* Mitsubishi M32R */
+	EM_MN10300      = 89  /* Matsushita MN10300 */
+	EM_MN10200      = 90  /* Matsushita MN10200 */
+	EM_PJ           = 91  /* picoJava */
+	EM_OPENRISC     = 92  /* OpenRISC 32-bit embedded processor */
+	EM_ARC_COMPACT  = 93  /* ARC International ARCompact */
+	EM_XTENSA       = 94  /* Tensilica Xtensa Architecture */
+	EM_VIDEOCORE    = 95  /* Alphamosaic VideoCore */
+	EM_TMM_GPP      = 96  /* Thompson Multimedia General Purpose Proc */
+	EM_NS32K        = 97  /* National Semi. 32000 */
+	EM_TPC          = 98  /* Tenor Network TPC */
+	EM_SNP1K        = 99  /* Trebia SNP 1000 */
+	EM_ST200        = 100 /* STMicroelectronics ST200 */
+	EM_IP2K         = 101 /* Ubicom IP2xxx */
+	EM_MAX          = 102 /* MAX processor */
+	EM_CR           = 103 /* National Semi. CompactRISC */
+	EM_F2MC16       = 104 /* Fujitsu F2MC16 */
+	EM_MSP430       = 105 /* Texas Instruments msp430 */
+	EM_BLACKFIN     = 106 /* Analog Devices Blackfin DSP */
+	EM_SE_C33       = 107 /* Seiko Epson S1C33 family */
+	EM_SEP          = 108 /* Sharp embedded microprocessor */
+	EM_ARCA         = 109 /* Arca RISC */
+	EM_UNICORE      = 110 /* PKU-Unity & MPRC Peking Uni. mc series */
+	EM_EXCESS       = 111 /* eXcess configurable cpu */
+	EM_DXP          = 112 /* Icera Semi. Deep Execution Processor */
+	EM_ALTERA_NIOS2 = 113 /* Altera Nios II */
+	EM_CRX          = 114 /* National Semi. CompactRISC CRX */
+	EM_XGATE        = 115 /* Motorola XGATE */
+	EM_C166         = 116 /* Infineon C16x/XC16x */
+	EM_M16C         = 117 /* Renesas M16C */
+	EM_DSPIC30F     = 118 /* Microchip Technology dsPIC30F */
+	EM_CE           = 119 /* Freescale Communication Engine RISC */
+	EM_M32C         = 120 /* Renesas M32C */
+	/* reserved 121-130 */
+	EM_TSK3000       = 131 /* Altium TSK3000 */
+	EM_RS08          = 132 /* Freescale RS08 */
+	EM_SHARC         = 133 /* Analog Devices SHARC family */
+	EM_ECOG2         = 134 /* Cyan Technology eCOG2 */
+	EM_SCORE7        = 135 /* Sunplus S+core7 RISC */
+	EM_DSP24         = 136 /* New Japan Radio (NJR) 24-bit DSP */
+	EM_VIDEOCORE3    = 137 /* Broadcom VideoCore III */
+	EM_LATTICEMICO32 = 138 /* RISC for Lattice FPGA */
+	EM_SE_C17        = 139 /* Seiko Epson C17 */
+	EM_TI_C6000      = 140 /* Texas Instruments TMS320C6000 DSP */
+	EM_TI_C2000      = 141 /* Texas Instruments TMS320C2000 DSP */
+	EM_TI_C5500      = 142 /* Texas Instruments TMS320C55x DSP */
+	EM_TI_ARP32      = 143 /* Texas Instruments App. Specific RISC */
+	EM_TI_PRU        = 144 /* Texas Instruments Prog. Realtime Unit */
+	/* reserved 145-159 */
+	EM_MMDSP_PLUS  = 160 /* STMicroelectronics 64bit VLIW DSP */
+	EM_CYPRESS_M8C = 161 /* Cypress M8C */
+	EM_R32C        = 162 /* Renesas R32C */
+	EM_TRIMEDIA    = 163 /* NXP Semi. TriMedia */
+	EM_QDSP6       = 164 /* QUALCOMM DSP6 */
+	EM_8051        = 165 /* Intel 8051 and variants */
+	EM_STXP7X      = 166 /* STMicroelectronics STxP7x */
+	EM_NDS32       = 167 /* Andes Tech. compact code emb. RISC */
+	EM_ECOG1X      = 168 /* Cyan Technology eCOG1X */
+	EM_MAXQ30      = 169 /* Dallas Semi. MAXQ30 mc */
+	EM_XIMO16      = 170 /* New Japan Radio (NJR) 16-bit DSP */
+	EM_MANIK       = 171 /* M2000 Reconfigurable RISC */
+	EM_CRAYNV2     = 172 /* Cray NV2 vector architecture */
+	EM_RX          = 173 /* Renesas RX */
+	EM_METAG       = 174 /* Imagination Tech. META */
+	EM_MCST_ELBRUS = 175 /* MCST Elbrus */
+	EM_ECOG16      = 176 /* Cyan Technology eCOG16 */
+	EM_CR16        = 177 /* National Semi. CompactRISC CR16 */
+	EM_ETPU        = 178 /* Freescale Extended Time Processing Unit */
+	EM_SLE9X       = 179 /* Infineon Tech. SLE9X */
+	EM_L10M        = 180 /* Intel L10M */
+	EM_K10M        = 181 /* Intel K10M */
+	/* reserved 182 */
+	EM_AARCH64 = 183 /* ARM AARCH64 */
+	/* reserved 184 */
+	EM_AVR32       = 185 /* Amtel 32-bit microprocessor */
+	EM_STM8        = 186 /* STMicroelectronics STM8 */
+	EM_TILE64      = 187 /* Tilera TILE64 */
+	EM_TILEPRO     = 188 /* Tilera TILEPro */
+	EM_MICROBLAZE  = 189 /* Xilinx MicroBlaze */
+	EM_CUDA        = 190 /* NVIDIA CUDA */
+	EM_TILEGX      = 191 /* Tilera TILE-Gx */
+	EM_CLOUDSHIELD = 192 /* CloudShield */
+	EM_COREA_1ST   = 193 /* KIPO-KAIST Core-A 1st gen. */
+	EM_COREA_2ND   = 194 /* KIPO-KAIST Core-A 2nd gen. */
+	EM_ARCV2       = 195 /* Synopsys ARCv2 ISA.  */
+	EM_OPEN8       = 196 /* Open8 RISC */
+	EM_RL78        = 197 /* Renesas RL78 */
+	EM_VIDEOCORE5  = 198 /* Broadcom VideoCore V */
+	EM_78KOR       = 199 /* Renesas 78KOR */
+	EM_56800EX     = 200 /* Freescale 56800EX DSC */
+	EM_BA1         = 201 /* Beyond BA1 */
+	EM_BA2         = 202 /* Beyond BA2 */
+	EM_XCORE       = 203 /* XMOS xCORE */
+	EM_MCHP_PIC    = 204 /* Microchip 8-bit PIC(r) */
+	EM_INTELGT     = 205 /* Intel Graphics Technology */
+	/* reserved 206-209 */
+	EM_KM32        = 210 /* KM211 KM32 */
+	EM_KMX32       = 211 /* KM211 KMX32 */
+	EM_EMX16       = 212 /* KM211 KMX16 */
+	EM_EMX8        = 213 /* KM211 KMX8 */
+	EM_KVARC       = 214 /* KM211 KVARC */
+	EM_CDP         = 215 /* Paneve CDP */
+	EM_COGE        = 216 /* Cognitive Smart Memory Processor */
+	EM_COOL        = 217 /* Bluechip CoolEngine */
+	EM_NORC        = 218 /* Nanoradio Optimized RISC */
+	EM_CSR_KALIMBA = 219 /* CSR Kalimba */
+	EM_Z80         = 220 /* Zilog Z80 */
+	EM_VISIUM      = 221 /* Controls and Data Services VISIUMcore */
+	EM_FT32        = 222 /* FTDI Chip FT32 */
+	EM_MOXIE       = 223 /* Moxie processor */
+	EM_AMDGPU      = 224 /* AMD GPU */
+	/* reserved 225-242 */
+	EM_RISCV = 243 /* RISC-V */
+
+	EM_BPF       = 247 /* Linux BPF -- in-kernel virtual machine */
+	EM_CSKY      = 252 /* C-SKY */
+	EM_LOONGARCH = 258 /* LoongArch */
+
+	// Processor specific e_flags bits that select the ABI
+	EF_MIPS_ABI2               uint32 = 0x20  /* N32 ABI */
+	EF_MIPS_NAN2008            uint32 = 0x400 /* IEEE 754-2008 NaN encoding */
+	EF_ARM_ABI_FLOAT_SOFT      uint32 = 0x200 /* Soft-float calling convention */
+	EF_ARM_ABI_FLOAT_HARD      uint32 = 0x400 /* VFP (hard-float) calling convention */
+	EF_RISCV_FLOAT_ABI         uint32 = 0x6   /* Mask of the floating point ABI */
+	EF_RISCV_FLOAT_ABI_SOFT    uint32 = 0x0
+	EF_RISCV_FLOAT_ABI_SINGLE  uint32 = 0x2
+	EF_RISCV_FLOAT_ABI_DOUBLE  uint32 = 0x4
+	EF_LARCH_ABI_MODIFIER_MASK uint32 = 0x7 /* Mask of the floating point ABI */
+	EF_LARCH_ABI_SOFT_FLOAT    uint32 = 0x1
+	EF_LARCH_ABI_SINGLE_FLOAT  uint32 = 0x2
+	EF_LARCH_ABI_DOUBLE_FLOAT  uint32 = 0x3
+
+	// Program Header related consts
+	PN_XNUM uint16 = 0xffff /* e_phnum escape, real count is in sh_info of section 0 */
+
+	// Legal values for p_type (segment type)
+	PT_NULL         uint32 = 0          /* Program header table entry unused */
+	PT_LOAD         uint32 = 1          /* Loadable program segment */
+	PT_DYNAMIC      uint32 = 2          /* Dynamic linking information */
+	PT_INTERP       uint32 = 3          /* Program interpreter */
+	PT_NOTE         uint32 = 4          /* Auxiliary information */
+	PT_SHLIB        uint32 = 5          /* Reserved */
+	PT_PHDR         uint32 = 6          /* Entry for header table itself */
+	PT_TLS          uint32 = 7          /* Thread-local storage segment */
+	PT_NUM          uint32 = 8          /* Number of defined types */
+	PT_LOOS         uint32 = 0x60000000 /* Start of OS-specific */
+	PT_GNU_EH_FRAME uint32 = 0x6474e550 /* GCC .eh_frame_hdr segment */
+	PT_GNU_STACK    uint32 = 0x6474e551 /* Indicates stack executability */
+	PT_GNU_RELRO    uint32 = 0x6474e552 /* Read-only after relocation */
+	PT_GNU_PROPERTY uint32 = 0x6474e553 /* GNU property */
+	PT_GNU_SFRAME   uint32 = 0x6474e554 /* SFrame segment.  */
+	PT_GNU_MBIND_LO uint32 = 0x6474e555 /* Start of GNU memory binding segments */
+	PT_GNU_MBIND_HI uint32 = 0x6474f554 /* End of GNU memory binding segments */
+	PT_LOSUNW       uint32 = 0x6ffffffa
+	PT_SUNWBSS      uint32 = 0x6ffffffa /* Sun Specific segment */
+	PT_SUNWSTACK    uint32 = 0x6ffffffb /* Stack segment */
+	PT_HISUNW       uint32 = 0x6fffffff
+	PT_HIOS         uint32 = 0x6fffffff /* End of OS-specific */
+	PT_LOPROC       uint32 = 0x70000000 /* Start of processor-specific */
+	PT_HIPROC       uint32 = 0x7fffffff /* End of processor-specific */
+
+	// Legal values for p_flags (segment flags)
+	PF_X uint32 = 1
+	PF_W uint32 = 2
+	PF_R uint32 = 4
+
+	// Special section indices
+	SHN_UNDEF     uint16 = 0      /* Undefined section */
+	SHN_LORESERVE uint16 = 0xff00 /* Start of reserved indices */
+	SHN_LOPROC    uint16 = 0xff00 /* Start of processor-specific */
+	SHN_HIPROC    uint16 = 0xff1f /* End of processor-specific */
+	SHN_LOOS      uint16 = 0xff20 /* Start of OS-specific */
+	SHN_HIOS      uint16 = 0xff3f /* End of OS-specific */
+	SHN_ABS       uint16 = 0xfff1 /* Associated symbol is absolute */
+	SHN_COMMON    uint16 = 0xfff2 /* Associated symbol is common */
+	SHN_XINDEX    uint16 = 0xffff /* Index is in extra table */
+	SHN_HIRESERVE uint16 = 0xffff /* End of reserved indices */
+
+	// Legal values for sh_type (section type)
+	SHT_NULL           uint32 = 0          /* Section header table entry unused */
+	SHT_PROGBITS       uint32 = 1          /* Program data */
+	SHT_SYMTAB         uint32 = 2          /* Symbol table */
+	SHT_STRTAB         uint32 = 3          /* String table */
+	SHT_RELA           uint32 = 4          /* Relocation entries with addends */
+	SHT_HASH           uint32 = 5          /* Symbol hash table */
+	SHT_DYNAMIC        uint32 = 6          /* Dynamic linking information */
+	SHT_NOTE           uint32 = 7          /* Notes */
+	SHT_NOBITS         uint32 = 8          /* Program space with no data (bss) */
+	SHT_REL            uint32 = 9          /* Relocation entries, no addends */
+	SHT_SHLIB          uint32 = 10         /* Reserved */
+	SHT_DYNSYM         uint32 = 11         /* Dynamic linker symbol table */
+	SHT_INIT_ARRAY     uint32 = 14         /* Array of constructors */
+	SHT_FINI_ARRAY     uint32 = 15         /* Array of destructors */
+	SHT_PREINIT_ARRAY  uint32 = 16         /* Array of pre-constructors */
+	SHT_GROUP          uint32 = 17         /* Section group */
+	SHT_SYMTAB_SHNDX   uint32 = 18         /* Extended section indices */
+	SHT_RELR           uint32 = 19         /* RELR relative relocations */
+	SHT_NUM            uint32 = 20         /* Number of defined types */
+	SHT_LOOS           uint32 = 0x60000000 /* Start OS-specific */
+	SHT_GNU_ATTRIBUTES uint32 = 0x6ffffff5 /* Object attributes */
+	SHT_GNU_HASH       uint32 = 0x6ffffff6 /* GNU-style hash table */
+	SHT_GNU_LIBLIST    uint32 = 0x6ffffff7 /* Prelink library list */
+	SHT_CHECKSUM       uint32 = 0x6ffffff8 /* Checksum for DSO content */
+	SHT_SUNW_move      uint32 = 0x6ffffffa
+	SHT_SUNW_COMDAT    uint32 = 0x6ffffffb
+	SHT_SUNW_syminfo   uint32 = 0x6ffffffc
+	SHT_GNU_verdef     uint32 = 0x6ffffffd /* Version definition section */
+	SHT_GNU_verneed    uint32 = 0x6ffffffe /* Version needs section */
+	SHT_GNU_versym     uint32 = 0x6fffffff /* Version symbol table */
+	SHT_HIOS           uint32 = 0x6fffffff /* End OS-specific type */
+	SHT_LOPROC         uint32 = 0x70000000 /* Start of processor-specific */
+	SHT_HIPROC         uint32 = 0x7fffffff /* End of processor-specific */
+	SHT_LOUSER         uint32 = 0x80000000 /* Start of application-specific */
+	SHT_HIUSER         uint32 = 0x8fffffff /* End of application-specific */
+
+	// Legal values for sh_flags (section flags)
+	SHF_WRITE            uint64 = (1 << 0)   // Writable
+	SHF_ALLOC            uint64 = (1 << 1)   // Occupies memory during execution
+	SHF_EXECINSTR        uint64 = (1 << 2)   // Executable
+	SHF_MERGE            uint64 = (1 << 4)   // Might be merged
+	SHF_STRINGS          uint64 = (1 << 5)   // Contains nul-terminated strings
+	SHF_INFO_LINK        uint64 = (1 << 6)   // `sh_info' contains SHT index
+	SHF_LINK_ORDER       uint64 = (1 << 7)   // Preserve order after combining
+	SHF_OS_NONCONFORMING uint64 = (1 << 8)   // Non-standard OS specific handling required
+	SHF_GROUP            uint64 = (1 << 9)   // Section is member of a group.
+	SHF_TLS              uint64 = (1 << 10)  // Section hold thread-local data.
+	SHF_COMPRESSED       uint64 = (1 << 11)  // Section with compressed data.
+	SHF_MASKOS           uint64 = 0x0ff00000 // OS-specific.
+	SHF_MASKPROC         uint64 = 0xf0000000 // Processor-specific
+	SHF_GNU_RETAIN       uint64 = (1 << 21)  // Not to be GCed by linker.
+	SHF_ORDERED          uint64 = (1 << 30)  // Special ordering requirement
+	SHF_EXCLUDE          uint64 = (1 << 31)  // Excluded unless referenced or allocated
+
+	// Legal values for ST_BIND subfield of st_info (symbol binding)
+	STB_LOCAL      uint8 = 0  /* Local symbol */
+	STB_GLOBAL     uint8 = 1  /* Global symbol */
+	STB_WEAK       uint8 = 2  /* Weak symbol */
+	STB_GNU_UNIQUE uint8 = 10 /* Unique symbol */
+	STB_LOOS       uint8 = 10 /* Start of OS-specific */
+	STB_HIOS       uint8 = 12 /* End of OS-specific */
+	STB_LOPROC     uint8 = 13 /* Start of processor-specific */
+	STB_HIPROC     uint8 = 15 /* End of processor-specific */
+
+	// Legal values for ST_TYPE subfield of st_info (symbol type)
+	STT_NOTYPE    uint8 = 0  /* Symbol type is unspecified */
+	STT_OBJECT    uint8 = 1  /* Symbol is a data object */
+	STT_FUNC      uint8 = 2  /* Symbol is a code object */
+	STT_SECTION   uint8 = 3  /* Symbol associated with a section */
+	STT_FILE      uint8 = 4  /* Symbol's name is file name */
+	STT_COMMON    uint8 = 5  /* Symbol is a common data object */
+	STT_TLS       uint8 = 6  /* Symbol is thread-local data object */
+	STT_GNU_IFUNC uint8 = 10 /* Symbol is indirect code object */
+	STT_LOOS      uint8 = 10 /* Start of OS-specific */
+	STT_HIOS      uint8 = 12 /* End of OS-specific */
+	STT_LOPROC    uint8 = 13 /* Start of processor-specific */
+	STT_HIPROC    uint8 = 15 /* End of processor-specific */
+
+	// Symbol visibility specification encoded in the st_other field
+	STV_DEFAULT   uint8 = 0 /* Default symbol visibility rules */
+	STV_INTERNAL  uint8 = 1 /* Processor specific hidden class */
+	STV_HIDDEN    uint8 = 2 /* Sym unavailable in other modules */
+	STV_PROTECTED uint8 = 3 /* Not preemptible, not exported */
+
+	// Legal values for d_tag (dynamic entry type)
+	DT_NULL            int64 = 0          /* Marks end of dynamic section */
+	DT_NEEDED          int64 = 1          /* Name of needed library */
+	DT_PLTRELSZ        int64 = 2          /* Size in bytes of PLT relocs */
+	DT_PLTGOT          int64 = 3          /* Processor defined value */
+	DT_HASH            int64 = 4          /* Address of symbol hash table */
+	DT_STRTAB          int64 = 5          /* Address of string table */
+	DT_SYMTAB          int64 = 6          /* Address of symbol table */
+	DT_RELA            int64 = 7          /* Address of Rela relocs */
+	DT_RELASZ          int64 = 8          /* Total size of Rela relocs */
+	DT_RELAENT         int64 = 9          /* Size of one Rela reloc */
+	DT_STRSZ           int64 = 10         /* Size of string table */
+	DT_SYMENT          int64 = 11         /* Size of one symbol table entry */
+	DT_INIT            int64 = 12         /* Address of init function */
+	DT_FINI            int64 = 13         /* Address of termination function */
+	DT_SONAME          int64 = 14         /* Name of shared object */
+	DT_RPATH           int64 = 15         /* Library search path (deprecated) */
+	DT_SYMBOLIC        int64 = 16         /* Start symbol search here */
+	DT_REL             int64 = 17         /* Address of Rel relocs */
+	DT_RELSZ           int64 = 18         /* Total size of Rel relocs */
+	DT_RELENT          int64 = 19         /* Size of one Rel reloc */
+	DT_PLTREL          int64 = 20         /* Type of reloc in PLT */
+	DT_DEBUG           int64 = 21         /* For debugging; unspecified */
+	DT_TEXTREL         int64 = 22         /* Reloc might modify .text */
+	DT_JMPREL          int64 = 23         /* Address of PLT relocs */
+	DT_BIND_NOW        int64 = 24         /* Process relocations of object */
+	DT_INIT_ARRAY      int64 = 25         /* Array with addresses of init fct */
+	DT_FINI_ARRAY      int64 = 26         /* Array with addresses of fini fct */
+	DT_INIT_ARRAYSZ    int64 = 27         /* Size in bytes of DT_INIT_ARRAY */
+	DT_FINI_ARRAYSZ    int64 = 28         /* Size in bytes of DT_FINI_ARRAY */
+	DT_RUNPATH         int64 = 29         /* Library search path */
+	DT_FLAGS           int64 = 30         /* Flags for the object being loaded */
+	DT_PREINIT_ARRAY   int64 = 32         /* Array with addresses of preinit fct*/
+	DT_PREINIT_ARRAYSZ int64 = 33         /* size in bytes of DT_PREINIT_ARRAY */
+	DT_SYMTAB_SHNDX    int64 = 34         /* Address of SYMTAB_SHNDX section */
+	DT_RELRSZ          int64 = 35         /* Total size of RELR relative relocations */
+	DT_RELR            int64 = 36         /* Address of RELR relative relocations */
+	DT_RELRENT         int64 = 37         /* Size of one RELR relative relocaction */
+	DT_LOOS            int64 = 0x6000000d /* Start of OS-specific */
+	DT_GNU_PRELINKED   int64 = 0x6ffffdf5 /* Prelinking timestamp */
+	DT_GNU_CONFLICTSZ  int64 = 0x6ffffdf6 /* Size of conflict section */
+	DT_GNU_LIBLISTSZ   int64 = 0x6ffffdf7 /* Size of library list */
+	DT_CHECKSUM        int64 = 0x6ffffdf8
+	DT_PLTPADSZ        int64 = 0x6ffffdf9
+	DT_MOVEENT         int64 = 0x6ffffdfa
+	DT_MOVESZ          int64 = 0x6ffffdfb
+	DT_FEATURE_1       int64 = 0x6ffffdfc /* Feature selection (DTF_*) */
+	DT_POSFLAG_1       int64 = 0x6ffffdfd /* Flags for DT_* entries, effecting the following DT_* entry */
+	DT_SYMINSZ         int64 = 0x6ffffdfe /* Size of syminfo table (in bytes) */
+	DT_SYMINENT        int64 = 0x6ffffdff /* Entry size of syminfo */
+	DT_GNU_HASH        int64 = 0x6ffffef5 /* GNU-style hash table */
+	DT_TLSDESC_PLT     int64 = 0x6ffffef6
+	DT_TLSDESC_GOT     int64 = 0x6ffffef7
+	DT_GNU_CONFLICT    int64 = 0x6ffffef8 /* Start of conflict section */
+	DT_GNU_LIBLIST     int64 = 0x6ffffef9 /* Library list */
+	DT_CONFIG          int64 = 0x6ffffefa /* Configuration information */
+	DT_DEPAUDIT        int64 = 0x6ffffefb /* Dependency auditing */
+	DT_AUDIT           int64 = 0x6ffffefc /* Object auditing */
+	DT_PLTPAD          int64 = 0x6ffffefd /* PLT padding */
+	DT_MOVETAB         int64 = 0x6ffffefe /* Move table */
+	DT_SYMINFO         int64 = 0x6ffffeff /* Syminfo table */
+	DT_VERSYM          int64 = 0x6ffffff0 /* Address of .gnu.version */
+	DT_RELACOUNT       int64 = 0x6ffffff9 /* Number of RELATIVE Rela relocs */
+	DT_RELCOUNT        int64 = 0x6ffffffa /* Number of RELATIVE Rel relocs */
+	DT_FLAGS_1         int64 = 0x6ffffffb /* State flags, see DF_1_* below */
+	DT_VERDEF          int64 = 0x6ffffffc /* Address of version definition table */
+	DT_VERDEFNUM       int64 = 0x6ffffffd /* Number of version definitions */
+	DT_VERNEED         int64 = 0x6ffffffe /* Address of table with needed versions */
+	DT_VERNEEDNUM      int64 = 0x6fffffff /* Number of needed versions */
+	DT_HIOS            int64 = 0x6ffff000 /* End of OS-specific */
+	DT_LOPROC          int64 = 0x70000000 /* Start of processor-specific */
+	DT_AUXILIARY       int64 = 0x7ffffffd /* Shared object to load before self */
+	DT_FILTER          int64 = 0x7fffffff /* Shared object to get values from */
+	DT_HIPROC          int64 = 0x7fffffff /* End of processor-specific */
+
+	// Values of DT_FLAGS
+	DF_ORIGIN     uint64 = 0x00000001 /* Object may use DF_ORIGIN */
+	DF_SYMBOLIC   uint64 = 0x00000002 /* Symbol resolutions starts here */
+	DF_TEXTREL    uint64 = 0x00000004 /* Object contains text relocations */
+	DF_BIND_NOW   uint64 = 0x00000008 /* No lazy binding for this object */
+	DF_STATIC_TLS uint64 = 0x00000010 /* Module uses the static TLS model */
+
+	// Values of DT_FLAGS_1
+	DF_1_NOW        uint64 = 0x00000001 /* Set RTLD_NOW for this object */
+	DF_1_GLOBAL     uint64 = 0x00000002 /* Set RTLD_GLOBAL for this object */
+	DF_1_GROUP      uint64 = 0x00000004 /* Set RTLD_GROUP for this object */
+	DF_1_NODELETE   uint64 = 0x00000008 /* Set RTLD_NODELETE for this object */
+	DF_1_LOADFLTR   uint64 = 0x00000010 /* Trigger filtee loading at runtime */
+	DF_1_INITFIRST  uint64 = 0x00000020 /* Set RTLD_INITFIRST for this object */
+	DF_1_NOOPEN     uint64 = 0x00000040 /* Set RTLD_NOOPEN for this object */
+	DF_1_ORIGIN     uint64 = 0x00000080 /* $ORIGIN must be handled */
+	DF_1_DIRECT     uint64 = 0x00000100 /* Direct binding enabled */
+	DF_1_TRANS      uint64 = 0x00000200
+	DF_1_INTERPOSE  uint64 = 0x00000400 /* Object is used to interpose */
+	DF_1_NODEFLIB   uint64 = 0x00000800 /* Ignore default lib search path */
+	DF_1_NODUMP     uint64 = 0x00001000 /* Object can't be dldump'ed */
+	DF_1_CONFALT    uint64 = 0x00002000 /* Configuration alternative created */
+	DF_1_ENDFILTEE  uint64 = 0x00004000 /* Filtee terminates filters search */
+	DF_1_DISPRELDNE uint64 = 0x00008000 /* Disp reloc applied at build time */
+	DF_1_DISPRELPND uint64 = 0x00010000 /* Disp reloc applied at run-time */
+	DF_1_NODIRECT   uint64 = 0x00020000 /* Object has no-direct binding */
+	DF_1_IGNMULDEF  uint64 = 0x00040000
+	DF_1_NOKSYMS    uint64 = 0x00080000
+	DF_1_NOHDR      uint64 = 0x00100000
+	DF_1_EDITED     uint64 = 0x00200000 /* Object is modified after built */
+	DF_1_NORELOC    uint64 = 0x00400000
+	DF_1_SYMINTPOSE uint64 = 0x00800000 /* Object has individual interposers */
+	DF_1_GLOBAUDIT  uint64 = 0x01000000 /* Global auditing required */
+	DF_1_SINGLETON  uint64 = 0x02000000 /* Singleton symbols are used */
+	DF_1_STUB       uint64 = 0x04000000
+	DF_1_PIE        uint64 = 0x08000000
+	DF_1_KMOD       uint64 = 0x10000000
+	DF_1_WEAKFILTER uint64 = 0x20000000
+	DF_1_NOCOMMON   uint64 = 0x40000000
+)
+
+// Symbol versioning: version definition flags and special .gnu.version indices.
+const (
+	VER_FLG_BASE uint16 = 0x1 /* Version definition of file itself */
+	VER_FLG_WEAK uint16 = 0x2 /* Weak version identifier */
+	VER_FLG_INFO uint16 = 0x4 /* Reference exists for informational purposes */
+
+	VER_NDX_LOCAL     uint16 = 0      /* Symbol is local */
+	VER_NDX_GLOBAL    uint16 = 1      /* Symbol is global */
+	VER_NDX_LORESERVE uint16 = 0xff00 /* Beginning of reserved entries */
+	VER_NDX_ELIMINATE uint16 = 0xff01 /* Symbol is to be eliminated */
+
+	VERSYM_HIDDEN  uint16 = 0x8000 /* Symbol is not the default version */
+	VERSYM_VERSION uint16 = 0x7fff /* Version index mask */
+)
+
+// Relocation types that matter to the dynamic linker, per architecture.
+// The full name tables used for display live in helpers.go.
+const (
+	R_X86_64_NONE      uint32 = 0  /* No reloc */
+	R_X86_64_64        uint32 = 1  /* Direct 64 bit */
+	R_X86_64_COPY      uint32 = 5  /* Copy symbol at runtime */
+	R_X86_64_GLOB_DAT  uint32 = 6  /* Create GOT entry */
+	R_X86_64_JUMP_SLOT uint32 = 7  /* Create PLT entry */
+	R_X86_64_RELATIVE  uint32 = 8  /* Adjust by program base */
+	R_X86_64_32        uint32 = 10 /* Direct 32 bit zero extended */
+	R_X86_64_IRELATIVE uint32 = 37 /* Adjust indirectly by program base */
+
+	R_386_NONE      uint32 = 0  /* No reloc */
+	R_386_32        uint32 = 1  /* Direct 32 bit */
+	R_386_COPY      uint32 = 5  /* Copy symbol at runtime */
+	R_386_GLOB_DAT  uint32 = 6  /* Create GOT entry */
+	R_386_JMP_SLOT  uint32 = 7  /* Create PLT entry */
+	R_386_RELATIVE  uint32 = 8  /* Adjust by program base */
+	R_386_IRELATIVE uint32 = 42 /* Adjust indirectly by program base */
+
+	R_AARCH64_NONE      uint32 = 0    /* No relocation */
+	R_AARCH64_ABS64     uint32 = 257  /* Direct 64 bit */
+	R_AARCH64_ABS32     uint32 = 258  /* Direct 32 bit */
+	R_AARCH64_COPY      uint32 = 1024 /* Copy symbol at runtime */
+	R_AARCH64_GLOB_DAT  uint32 = 1025 /* Create GOT entry */
+	R_AARCH64_JUMP_SLOT uint32 = 1026 /* Create PLT entry */
+	R_AARCH64_RELATIVE  uint32 = 1027 /* Adjust by program base */
+	R_AARCH64_IRELATIVE uint32 = 1032 /* STT_GNU_IFUNC relocation */
+
+	R_RISCV_NONE      uint32 = 0  /* No reloc */
+	R_RISCV_32        uint32 = 1  /* Direct 32 bit */
+	R_RISCV_64        uint32 = 2  /* Direct 64 bit */
+	R_RISCV_RELATIVE  uint32 = 3  /* Adjust by program base */
+	R_RISCV_COPY      uint32 = 4  /* Copy symbol at runtime */
+	R_RISCV_JUMP_SLOT uint32 = 5  /* Create PLT entry */
+	R_RISCV_IRELATIVE uint32 = 58 /* STT_GNU_IFUNC relocation */
+
+	R_ARM_NONE      uint32 = 0   /* No reloc */
+	R_ARM_ABS32     uint32 = 2   /* Direct 32 bit */
+	R_ARM_COPY      uint32 = 20  /* Copy symbol at runtime */
+	R_ARM_GLOB_DAT  uint32 = 21  /* Create GOT entry */
+	R_ARM_JUMP_SLOT uint32 = 22  /* Create PLT entry */
+	R_ARM_RELATIVE  uint32 = 23  /* Adjust by program base */
+	R_ARM_IRELATIVE uint32 = 160 /* STT_GNU_IFUNC relocation */
+)
+
+// Note types. The meaning of n_type depends on the note owner (n_name).
+const (
+	// Owner "GNU"
+	NT_GNU_ABI_TAG         uint32 = 1 /* ABI information */
+	NT_GNU_HWCAP           uint32 = 2 /* Synthetic hwcap information */
+	NT_GNU_BUILD_ID        uint32 = 3 /* Build ID bits as generated by ld --build-id */
+	NT_GNU_GOLD_VERSION    uint32 = 4 /* Version note generated by GNU gold */
+	NT_GNU_PROPERTY_TYPE_0 uint32 = 5 /* Program property */
+
+	// Owner "Go"
+	NT_GO_BUILD_ID uint32 = 4 /* Go toolchain build ID */
+
+	// Owner "FDO"
+	NT_FDO_PACKAGING_METADATA uint32 = 0xcafe1a7e /* Package metadata as JSON */
+
+	// Owner "stapsdt"
+	NT_STAPSDT uint32 = 3 /* SystemTap USDT probe */
+
+	// Owners "CORE" and "LINUX", in core files
+	NT_PRSTATUS         uint32 = 1          /* Contains copy of prstatus struct */
+	NT_PRFPREG          uint32 = 2          /* Contains copy of fpregset struct */
+	NT_PRPSINFO         uint32 = 3          /* Contains copy of prpsinfo struct */
+	NT_TASKSTRUCT       uint32 = 4          /* Contains copy of task structure */
+	NT_AUXV             uint32 = 6          /* Contains copy of auxv array */
+	NT_PRXFPREG         uint32 = 0x46e62b7f /* Contains copy of user_fxsr_struct */
+	NT_X86_XSTATE       uint32 = 0x202      /* x86 extended state using xsave */
+	NT_X86_SHSTK        uint32 = 0x204      /* x86 shadow stack state */
+	NT_X86_XSAVE_LAYOUT uint32 = 0x205      /* x86 xsave layout description */
+	NT_ARM_VFP          uint32 = 0x400      /* ARM VFP/NEON registers */
+	NT_ARM_TLS          uint32 = 0x401      /* ARM TLS register */
+	NT_ARM_HW_BREAK     uint32 = 0x402      /* ARM hardware breakpoint registers */
+	NT_ARM_HW_WATCH     uint32 = 0x403      /* ARM hardware watchpoint registers */
+	NT_ARM_SVE          uint32 = 0x405      /* ARM Scalable Vector Extension registers */
+	NT_ARM_PAC_MASK     uint32 = 0x406      /* ARM pointer authentication code masks */
+	NT_SIGINFO          uint32 = 0x53494749 /* Contains copy of siginfo_t */
+	NT_FILE             uint32 = 0x46494c45 /* Contains information about mapped files */
+)
+
+// OS values of the NT_GNU_ABI_TAG note.
+const (
+	ELF_NOTE_OS_LINUX    uint32 = 0
+	ELF_NOTE_OS_GNU      uint32 = 1
+	ELF_NOTE_OS_SOLARIS2 uint32 = 2
+	ELF_NOTE_OS_FREEBSD  uint32 = 3
+)
+
+// Program property types carried by NT_GNU_PROPERTY_TYPE_0 notes.
+const (
+	GNU_PROPERTY_STACK_SIZE            uint32 = 1
+	GNU_PROPERTY_NO_COPY_ON_PROTECTED  uint32 = 2
+	GNU_PROPERTY_1_NEEDED              uint32 = 0xb0008000
+	GNU_PROPERTY_AARCH64_FEATURE_1_AND uint32 = 0xc0000000
+	GNU_PROPERTY_X86_FEATURE_1_AND     uint32 = 0xc0000002
+	GNU_PROPERTY_X86_ISA_1_NEEDED      uint32 = 0xc0008002
+	GNU_PROPERTY_X86_FEATURE_2_NEEDED  uint32 = 0xc0008001
+	GNU_PROPERTY_X86_ISA_1_USED        uint32 = 0xc0010002
+	GNU_PROPERTY_X86_FEATURE_2_USED    uint32 = 0xc0010001
+
+	GNU_PROPERTY_1_NEEDED_INDIRECT_EXTERN_ACCESS uint32 = 1 << 0
+
+	GNU_PROPERTY_X86_FEATURE_1_IBT     uint32 = 1 << 0
+	GNU_PROPERTY_X86_FEATURE_1_SHSTK   uint32 = 1 << 1
+	GNU_PROPERTY_X86_FEATURE_1_LAM_U48 uint32 = 1 << 2
+	GNU_PROPERTY_X86_FEATURE_1_LAM_U57 uint32 = 1 << 3
+
+	GNU_PROPERTY_X86_ISA_1_BASELINE uint32 = 1 << 0
+	GNU_PROPERTY_X86_ISA_1_V2       uint32 = 1 << 1
+	GNU_PROPERTY_X86_ISA_1_V3       uint32 = 1 << 2
+	GNU_PROPERTY_X86_ISA_1_V4       uint32 = 1 << 3
+
+	GNU_PROPERTY_AARCH64_FEATURE_1_BTI uint32 = 1 << 0
+	GNU_PROPERTY_AARCH64_FEATURE_1_PAC uint32 = 1 << 1
+	GNU_PROPERTY_AARCH64_FEATURE_1_GCS uint32 = 1 << 2
+)
+
+// Auxiliary vector entry types, found in NT_AUXV notes of core files.
+const (
+	AT_NULL              uint64 = 0  /* End of vector */
+	AT_IGNORE            uint64 = 1  /* Entry should be ignored */
+	AT_EXECFD            uint64 = 2  /* File descriptor of program */
+	AT_PHDR              uint64 = 3  /* Program headers for program */
+	AT_PHENT             uint64 = 4  /* Size of program header entry */
+	AT_PHNUM             uint64 = 5  /* Number of program headers */
+	AT_PAGESZ            uint64 = 6  /* System page size */
+	AT_BASE              uint64 = 7  /* Base address of interpreter */
+	AT_FLAGS             uint64 = 8  /* Flags */
+	AT_ENTRY             uint64 = 9  /* Entry point of program */
+	AT_NOTELF            uint64 = 10 /* Program is not ELF */
+	AT_UID               uint64 = 11 /* Real uid */
+	AT_EUID              uint64 = 12 /* Effective uid */
+	AT_GID               uint64 = 13 /* Real gid */
+	AT_EGID              uint64 = 14 /* Effective gid */
+	AT_PLATFORM          uint64 = 15 /* String identifying platform */
+	AT_HWCAP             uint64 = 16 /* Machine-dependent hints about processor capabilities */
+	AT_CLKTCK            uint64 = 17 /* Frequency of times() */
+	AT_SECURE            uint64 = 23 /* Boolean, was exec setuid-like? */
+	AT_BASE_PLATFORM     uint64 = 24 /* String identifying real platform */
+	AT_RANDOM            uint64 = 25 /* Address of 16 random bytes */
+	AT_HWCAP2            uint64 = 26 /* More machine-dependent hints about processor capabilities */
+	AT_RSEQ_FEATURE_SIZE uint64 = 27 /* rseq supported feature size */
+	AT_RSEQ_ALIGN        uint64 = 28 /* rseq allocation alignment */
+	AT_HWCAP3            uint64 = 29 /* Extension of AT_HWCAP */
+	AT_HWCAP4            uint64 = 30 /* Extension of AT_HWCAP */
+	AT_EXECFN            uint64 = 31 /* Filename of executable */
+	AT_SYSINFO           uint64 = 32 /* Entry point of the vsyscall page */
+	AT_SYSINFO_EHDR      uint64 = 33 /* Address of the vDSO */
+	AT_MINSIGSTKSZ       uint64 = 51 /* Minimal stack size for signal delivery */
+)
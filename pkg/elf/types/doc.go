@@ -0,0 +1,8 @@
+// Package types holds the ELF structures, constants and name tables shared by the parser
+// and its callers.
+//
+// The Elf64_* structures double as the class-neutral layout: the parser widens every ELF32
+// table into them, so callers handle both classes with the same code. Field names follow
+// the ELF specification (E_type, Sh_flags, St_value...) to keep them easy to look up.
+// The Get* helpers decode raw values into the names readelf prints.
+package types
@@ -0,0 +1,42 @@
+package types
+
+// DynamicEntry is a decoded dynamic section entry. String-valued tags
+// (DT_NEEDED, DT_SONAME, DT_RPATH, DT_RUNPATH, ...) carry their resolved string,
+// and DT_INIT_ARRAY/DT_FINI_ARRAY/DT_PREINIT_ARRAY carry the function pointers they hold.
+type DynamicEntry struct {
+	Elf64_Dyn // Class-neutral raw entry
+
+	String string   // Value resolved through DT_STRTAB, for string-valued tags
+	Array  []uint64 // Array contents, for the *_ARRAY tags
+}
+
+// IsStringTag reports whether the d_val of a tag is an offset into DT_STRTAB.
+func IsStringTag(tag int64) bool {
+	switch tag {
+	case DT_NEEDED, DT_SONAME, DT_RPATH, DT_RUNPATH,
+		DT_AUXILIARY, DT_FILTER, DT_CONFIG, DT_DEPAUDIT, DT_AUDIT:
+		return true
+	}
+	return false
+}
+
+// DynamicValue returns the value of the first entry with the given tag.
+func DynamicValue(entries []DynamicEntry, tag int64) (uint64, bool) {
+	for i := range entries {
+		if entries[i].D_tag == tag {
+			return entries[i].D_val, true
+		}
+	}
+	return 0, false
+}
+
+// DynamicStrings returns the resolved strings of every entry with the given tag, in order.
+func DynamicStrings(entries []DynamicEntry, tag int64) []string {
+	var out []string
+	for i := range entries {
+		if entries[i].D_tag == tag {
+			out = append(out, entries[i].String)
+		}
+	}
+	return out
+}
@@ -0,0 +1,195 @@
+package types
+
+// Types of addresses
+type (
+	Elf32_Addr = uint32
+)
+
+// Types of offsets
+type (
+	Elf32_Off = uint32
+)
+
+// Type for a 16-bit quantity.
+type (
+	Elf32_Half = uint16
+)
+
+// Types for signed and unsigned 32-bit quantities.
+type (
+	Elf32_Word  = uint32
+	Elf32_Sword = int32
+)
+
+// Type for section indices, which are 16-bit quantities.
+type (
+	Elf32_Section = uint16
+)
+
+// Type for version symbol information, one 16-bit entry per dynamic symbol.
+type (
+	Elf32_Versym = uint16
+)
+
+// Representing ELF Header for 32 Bit Executables
+type Elf32_Ehdr struct {
+	E_ident     e_ident_t  // Contains magic number and basic information about the ELF
+	E_type      Elf32_Half // Object file type
+	E_machine   Elf32_Half // Architecture
+	E_version   Elf32_Word // Object file version
+	E_entry     Elf32_Addr // Entry Point Virtual Address
+	E_phoff     Elf32_Off  // Program header table file offset
+	E_shoff     Elf32_Off  // Section header table file offset
+	E_flags     Elf32_Word // Processor specific flags
+	E_ehsize    Elf32_Half // ELF header size in bytes
+	E_phentsize Elf32_Half // Program header table entry size
+	E_phnum     Elf32_Half // Program header table entry count
+	E_shentsize Elf32_Half // Section header table entry size
+	E_shnum     Elf32_Half // Section header table entry count
+	E_shstrndx  Elf32_Half // Section header string table index
+}
+
+// Representing ELF program header for 32 Bit Executables.
+// Note that p_flags comes after p_memsz here, unlike the 64 bit layout.
+type Elf32_Phdr struct {
+	P_type   Elf32_Word
+	P_offset Elf32_Off
+	P_vaddr  Elf32_Addr
+	P_paddr  Elf32_Addr
+	P_filesz Elf32_Word
+	P_memsz  Elf32_Word
+	P_flags  Elf32_Word
+	P_align  Elf32_Word
+}
+
+// Representing ELF Section Header for 32 Bit Executables
+type Elf32_Shdr struct {
+	Sh_name      Elf32_Word // Section name (string tbl index)
+	Sh_type      Elf32_Word // Section type
+	Sh_flags     Elf32_Word // Section flags
+	Sh_addr      Elf32_Addr // Section virtual addr at execution
+	Sh_offset    Elf32_Off  // Section file offset
+	Sh_size      Elf32_Word // Section size in bytes
+	Sh_link      Elf32_Word // Link to another section
+	Sh_info      Elf32_Word // Addtional section information
+	Sh_addralign Elf32_Word // Section alignment
+	Sh_entsize   Elf32_Word // Entry size if section holds table
+}
+
+// Representing ELF Symbol Table entry for 32 Bit Executables
+type Elf32_Sym struct {
+	St_name  Elf32_Word    // Symbol name (string tbl index)
+	St_value Elf32_Addr    // Symbol value
+	St_size  Elf32_Word    // Symbol size
+	St_info  uint8         // Symbol type and binding
+	St_other uint8         // Symbol visibility
+	St_shndx Elf32_Section // Section index
+}
+
+// Representing ELF Dynamic section entry for 32 Bit Executables
+type Elf32_Dyn struct {
+	D_tag Elf32_Sword // Dynamic entry type
+	D_val Elf32_Word  // Integer value or address
+}
+
+// Representing ELF Relocation entry without addend for 32 Bit Executables
+type Elf32_Rel struct {
+	R_offset Elf32_Addr // Address (or section offset in relocatable files)
+	R_info   Elf32_Word // Relocation type and symbol index
+}
+
+// Representing ELF Relocation entry with addend for 32 Bit Executables
+type Elf32_Rela struct {
+	R_offset Elf32_Addr  // Address (or section offset in relocatable files)
+	R_info   Elf32_Word  // Relocation type and symbol index
+	R_addend Elf32_Sword // Addend
+}
+
+// Widen converts the header into the class-neutral ELF64 layout.
+func (h *Elf32_Ehdr) Widen() Elf64_Ehdr {
+	return Elf64_Ehdr{
+		E_ident:     h.E_ident,
+		E_type:      h.E_type,
+		E_machine:   h.E_machine,
+		E_version:   h.E_version,
+		E_entry:     Elf64_Addr(h.E_entry),
+		E_phoff:     Elf64_Off(h.E_phoff),
+		E_shoff:     Elf64_Off(h.E_shoff),
+		E_flags:     h.E_flags,
+		E_ehsize:    h.E_ehsize,
+		E_phentsize: h.E_phentsize,
+		E_phnum:     h.E_phnum,
+		E_shentsize: h.E_shentsize,
+		E_shnum:     h.E_shnum,
+		E_shstrndx:  h.E_shstrndx,
+	}
+}
+
+// Widen converts the program header into the class-neutral ELF64 layout.
+func (ph *Elf32_Phdr) Widen() Elf64_Phdr {
+	return Elf64_Phdr{
+		P_type:   ph.P_type,
+		P_flags:  ph.P_flags,
+		P_offset: Elf64_Off(ph.P_offset),
+		P_vaddr:  Elf64_Addr(ph.P_vaddr),
+		P_paddr:  Elf64_Addr(ph.P_paddr),
+		P_filesz: Elf64_Xword(ph.P_filesz),
+		P_memsz:  Elf64_Xword(ph.P_memsz),
+		P_align:  Elf64_Xword(ph.P_align),
+	}
+}
+
+// Widen converts the section header into the class-neutral ELF64 layout.
+func (sh *Elf32_Shdr) Widen() Elf64_Shdr {
+	return Elf64_Shdr{
+		Sh_name:      sh.Sh_name,
+		Sh_type:      sh.Sh_type,
+		Sh_flags:     Elf64_Xword(sh.Sh_flags),
+		Sh_addr:      Elf64_Addr(sh.Sh_addr),
+		Sh_offset:    Elf64_Off(sh.Sh_offset),
+		Sh_size:      Elf64_Xword(sh.Sh_size),
+		Sh_link:      sh.Sh_link,
+		Sh_info:      sh.Sh_info,
+		Sh_addralign: Elf64_Xword(sh.Sh_addralign),
+		Sh_entsize:   Elf64_Xword(sh.Sh_entsize),
+	}
+}
+
+// Widen converts the symbol into the class-neutral ELF64 layout.
+func (sym *Elf32_Sym) Widen() Elf64_Sym {
+	return Elf64_Sym{
+		St_name:  sym.St_name,
+		St_info:  sym.St_info,
+		St_other: sym.St_other,
+		St_shndx: sym.St_shndx,
+		St_value: Elf64_Addr(sym.St_value),
+		St_size:  Elf64_Xword(sym.St_size),
+	}
+}
+
+// Widen converts the dynamic entry into the class-neutral ELF64 layout, sign-extending the tag.
+func (dyn *Elf32_Dyn) Widen() Elf64_Dyn {
+	return Elf64_Dyn{
+		D_tag: Elf64_Sxword(dyn.D_tag),
+		D_val: Elf64_Xword(dyn.D_val),
+	}
+}
+
+// Widen converts the relocation into the class-neutral ELF64 layout.
+// r_info is repacked from the ELF32 split (sym<<8 | type) into the ELF64 one (sym<<32 | type).
+func (r *Elf32_Rel) Widen() Elf64_Rel {
+	return Elf64_Rel{
+		R_offset: Elf64_Addr(r.R_offset),
+		R_info:   Elf64_Xword(r.R_info>>8)<<32 | Elf64_Xword(r.R_info&0xff),
+	}
+}
+
+// Widen converts the relocation into the class-neutral ELF64 layout, sign-extending the addend.
+// r_info is repacked from the ELF32 split (sym<<8 | type) into the ELF64 one (sym<<32 | type).
+func (r *Elf32_Rela) Widen() Elf64_Rela {
+	return Elf64_Rela{
+		R_offset: Elf64_Addr(r.R_offset),
+		R_info:   Elf64_Xword(r.R_info>>8)<<32 | Elf64_Xword(r.R_info&0xff),
+		R_addend: Elf64_Sxword(r.R_addend),
+	}
+}
@@ -0,0 +1,180 @@
+package types
+
+// Types of addresses
+type (
+	Elf64_Addr = uint64
+)
+
+// Types of offsets
+type (
+	Elf64_Off = uint64
+)
+
+// Type for a 16-bit quantity.
+type (
+	Elf64_Half = uint16
+)
+
+// Types for signed and unsigned 32-bit quantities.
+type (
+	Elf64_Word  = uint32
+	Elf64_Sword = int32
+)
+
+// Types for signed and unsigned 64-bit quantities.
+type (
+	Elf64_Xword  = uint64
+	Elf64_Sxword = int64
+)
+
+// Type for section indices, which are 16-bit quantities.
+type (
+	Elf64_Section = uint16
+)
+
+// Type for version symbol information, one 16-bit entry per dynamic symbol.
+type (
+	Elf64_Versym = uint16
+)
+
+// Representing ELF identification
+type e_ident_t struct {
+	FileIdn       [4]uint8 // Magic bytes
+	Ei_class      uint8    // ELF Class (32/64 bits)
+	Ei_data       uint8    // ELF Data encoding (LSB/MSB)
+	Ei_version    uint8    // ELF Version
+	Ei_osabi      uint8    // OS ABI identification
+	Ei_abiversion uint8    // OS ABI version
+	Ei_pad        [7]uint8 // Padding
+}
+
+// Representing ELF Header for 64 Bit Executables.
+// The ELF64 structures double as the class-neutral view handed out by the parser,
+// ELF32 files are widened into them since every 32 bit field fits.
+type Elf64_Ehdr struct {
+	E_ident     e_ident_t  // Contains magic number and basic information about the ELF
+	E_type      Elf64_Half // Object file type
+	E_machine   Elf64_Half // Architecture
+	E_version   Elf64_Word // Object file version
+	E_entry     Elf64_Addr // Entry Point Virtual Address
+	E_phoff     Elf64_Off  // Program header table file offset
+	E_shoff     Elf64_Off  // Section header table file offset
+	E_flags     Elf64_Word // Processor specific flags
+	E_ehsize    Elf64_Half // ELF header size in bytes
+	E_phentsize Elf64_Half // Program header table entry size
+	E_phnum     Elf64_Half // Program header table entry count
+	E_shentsize Elf64_Half // Section header table entry size
+	E_shnum     Elf64_Half // Section header table entry count
+	E_shstrndx  Elf64_Half // Section header string table index
+}
+
+// Representing ELF program header
+type Elf64_Phdr struct {
+	P_type   Elf64_Word
+	P_flags  Elf64_Word
+	P_offset Elf64_Off
+	P_vaddr  Elf64_Addr
+	P_paddr  Elf64_Addr
+	P_filesz Elf64_Xword
+	P_memsz  Elf64_Xword
+	P_align  Elf64_Xword
+}
+
+// Representing ELF Section Header
+type Elf64_Shdr struct {
+	Sh_name      Elf64_Word  // Section name (string tbl index)
+	Sh_type      Elf64_Word  // Section type
+	Sh_flags     Elf64_Xword // Section flags
+	Sh_addr      Elf64_Addr  // Section virtual addr at execution
+	Sh_offset    Elf64_Off   // Section file offset
+	Sh_size      Elf64_Xword // Section size in bytes
+	Sh_link      Elf64_Word  // Link to another section
+	Sh_info      Elf64_Word  // Addtional section information
+	Sh_addralign Elf64_Xword // Section alignment
+	Sh_entsize   Elf64_Xword // Entry size if section holds table
+}
+
+// Representing ELF Symbol Table entry
+type Elf64_Sym struct {
+	St_name  Elf64_Word    // Symbol name (string tbl index)
+	St_info  uint8         // Symbol type and binding
+	St_other uint8         // Symbol visibility
+	St_shndx Elf64_Section // Section index
+	St_value Elf64_Addr    // Symbol value
+	St_size  Elf64_Xword   // Symbol size
+}
+
+// Representing ELF Dynamic section entry. D_val holds both d_val and d_ptr of the C union.
+type Elf64_Dyn struct {
+	D_tag Elf64_Sxword // Dynamic entry type
+	D_val Elf64_Xword  // Integer value or address
+}
+
+// Representing ELF Relocation entry without addend
+type Elf64_Rel struct {
+	R_offset Elf64_Addr  // Address (or section offset in relocatable files)
+	R_info   Elf64_Xword // Relocation type and symbol index
+}
+
+// Representing ELF Relocation entry with addend
+type Elf64_Rela struct {
+	R_offset Elf64_Addr   // Address (or section offset in relocatable files)
+	R_info   Elf64_Xword  // Relocation type and symbol index
+	R_addend Elf64_Sxword // Addend
+}
+
+// Sym returns the symbol table index of the relocation (ELF64_R_SYM).
+func (r *Elf64_Rel) Sym() uint32 {
+	return uint32(r.R_info >> 32)
+}
+
+// Type returns the relocation type (ELF64_R_TYPE).
+func (r *Elf64_Rel) Type() uint32 {
+	return uint32(r.R_info)
+}
+
+// Sym returns the symbol table index of the relocation (ELF64_R_SYM).
+func (r *Elf64_Rela) Sym() uint32 {
+	return uint32(r.R_info >> 32)
+}
+
+// Type returns the relocation type (ELF64_R_TYPE).
+func (r *Elf64_Rela) Type() uint32 {
+	return uint32(r.R_info)
+}
+
+// Representing a version definition entry (.gnu.version_d).
+// The version structures have the same layout in ELF32 and ELF64 files.
+type Elf64_Verdef struct {
+	Vd_version Elf64_Half // Version revision
+	Vd_flags   Elf64_Half // Version information
+	Vd_ndx     Elf64_Half // Version index, as found in .gnu.version
+	Vd_cnt     Elf64_Half // Number of associated aux entries
+	Vd_hash    Elf64_Word // Version name hash value
+	Vd_aux     Elf64_Word // Offset in bytes to verdaux array
+	Vd_next    Elf64_Word // Offset in bytes to next verdef entry
+}
+
+// Representing an auxiliary version definition entry, naming the version or a parent.
+type Elf64_Verdaux struct {
+	Vda_name Elf64_Word // Version or dependency name (string tbl index)
+	Vda_next Elf64_Word // Offset in bytes to next verdaux entry
+}
+
+// Representing a version requirement entry (.gnu.version_r), one per needed file.
+type Elf64_Verneed struct {
+	Vn_version Elf64_Half // Version of structure
+	Vn_cnt     Elf64_Half // Number of associated aux entries
+	Vn_file    Elf64_Word // Offset of filename for this dependency
+	Vn_aux     Elf64_Word // Offset in bytes to vernaux array
+	Vn_next    Elf64_Word // Offset in bytes to next verneed entry
+}
+
+// Representing an auxiliary version requirement entry, one per version needed from the file.
+type Elf64_Vernaux struct {
+	Vna_hash  Elf64_Word // Hash value of dependency name
+	Vna_flags Elf64_Half // Dependency specific information
+	Vna_other Elf64_Half // Version index, as found in .gnu.version
+	Vna_name  Elf64_Word // Dependency name string offset
+	Vna_next  Elf64_Word // Offset in bytes to next vernaux entry
+}
@@ -0,0 +1,43 @@
+package types
+
+// GNUHashTable is a decoded DT_GNU_HASH table. Only the dynamic symbols from SymOffset on are
+// hashed, sorted by bucket, and Chains holds the hash of each of them with the low bit set on
+// the last symbol of a bucket.
+type GNUHashTable struct {
+	Offset     uint64   // File offset of the table
+	SymOffset  uint32   // Index of the first dynamic symbol in the table
+	BloomShift uint32   // Shift giving the second bloom filter bit
+	BloomBits  uint32   // Bits per bloom filter word, the address size of the class
+	Bloom      []uint64 // Bloom filter words, widened for ELF32
+	Buckets    []uint32 // Lowest symbol index of each bucket, 0 for empty buckets
+	Chains     []uint32 // Hashes of symbols SymOffset onwards
+}
+
+// SysVHashTable is a decoded DT_HASH table. Chains has one entry per dynamic symbol, so its
+// length is the symbol count the loader assumes.
+type SysVHashTable struct {
+	Offset  uint64   // File offset of the table
+	Buckets []uint32 // First symbol index of each bucket, STN_UNDEF for empty buckets
+	Chains  []uint32 // Next symbol index in the chain of each symbol, STN_UNDEF at the end
+}
+
+// GNUHash is the hash function of DT_GNU_HASH tables (dl_new_hash).
+func GNUHash(name string) uint32 {
+	h := uint32(5381)
+	for i := 0; i < len(name); i++ {
+		h = h*33 + uint32(name[i])
+	}
+	return h
+}
+
+// SysVHash is the hash function of DT_HASH tables and version entries (elf_hash).
+func SysVHash(name string) uint32 {
+	var h uint32
+	for i := 0; i < len(name); i++ {
+		h = h<<4 + uint32(name[i])
+		g := h & 0xf0000000
+		h ^= g >> 24
+		h &^= g
+	}
+	return h
+}
@@ -0,0 +1,1741 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+	"unsafe"
+)
+
+// GetEiClass returns a human-readable string representation of the EI_CLASS field,
+// indicating whether the ELF file is a 32-bit or 64-bit executable.
+func GetEiClass(ei_class uint8) string {
+	switch ei_class {
+	case ELFCLASS32:
+		return "ELF32"
+	case ELFCLASS64:
+		return "ELF64"
+	default:
+		return "<Unknown>"
+	}
+}
+
+// GetEiData returns a human-readable string representation of the EI_DATA field,
+// indicating the data encoding (endianness) of the ELF file's processor-specific data.
+func GetEiData(ei_data uint8) string {
+	switch ei_data {
+	case ELFDATA2LSB:
+		return "2's complement, Little endian"
+	case ELFDATA2MSB:
+		return "2's complement, Big endian"
+	default:
+		return "<Unknown>"
+	}
+}
+
+// GetEiOSABI returns a human-readable string representation of the EI_OSABI field,
+// identifying the target operating system and ABI for which the ELF file is intended.
+func GetEiOSABI(ei_osabi uint8) string {
+	switch ei_osabi {
+	case ELFOSABI_NONE:
+		return "UNIX - System V"
+	case ELFOSABI_LINUX:
+		return "UNIX - GNU"
+	case ELFOSABI_FREEBSD:
+		return "UNIX - FREEBSD"
+	case ELFOSABI_OPENBSD:
+		return "UNIX - OPENBSD"
+	case ELFOSABI_STANDALONE:
+		return "Standalone"
+	default:
+		return "<Unknown>"
+	}
+}
+
+// GetEType returns the ELF object file type as a human-readable string.
+// For ET_DYN, it checks for PT_INTERP to distinguish PIE executables from shared objects.
+func GetEType(e_type uint16, hasInterp bool) string {
+	switch e_type {
+	case ET_NONE:
+		return "NONE (No file type)"
+	case ET_REL:
+		return "REL (Relocatable file)"
+	case ET_EXEC:
+		return "EXEC (Executable file)"
+	case ET_DYN:
+		if hasInterp {
+			return "DYN (Position-Independent Executable file)"
+		}
+		return "DYN (Shared object file)"
+	case ET_CORE:
+		return "CORE (Core file)"
+	default:
+		if e_type >= ET_LOOS && e_type <= ET_HIOS {
+			return fmt.Sprintf("OS-specific (0x%04x)", e_type)
+		}
+		if e_type >= ET_LOPROC && e_type <= ET_HIPROC {
+			return fmt.Sprintf("Processor-specific (0x%04x)", e_type)
+		}
+		return fmt.Sprintf("<unknown: 0x%04x>", e_type)
+	}
+}
+
+// HasInterpreter checks if any program header has PT_INTERP type.
+func HasInterpreter(phdr []Elf64_Phdr) bool {
+	for i := range phdr {
+		if phdr[i].P_type == PT_INTERP {
+			return true
+		}
+	}
+	return false
+}
+
+// GetEMachine returns a human-readable string representation of the e_machine field,
+// identifying the target instruction set architecture required by the ELF file.
+func GetEMachine(e_machine uint16) string {
+	switch e_machine {
+	case EM_NONE:
+		return "No machine"
+	case EM_M32:
+		return "AT&T WE 32100"
+	case EM_SPARC:
+		return "SUN SPARC"
+	case EM_386:
+		return "Intel 80386"
+	case EM_68K:
+		return "Motorola m68k family"
+	case EM_88K:
+		return "Motorola m88k family"
+	case EM_IAMCU:
+		return "Intel MCU"
+	case EM_860:
+		return "Intel 80860"
+	case EM_MIPS:
+		return "MIPS R3000 big-endian"
+	case EM_S370:
+		return "IBM System/370"
+	case EM_MIPS_RS3_LE:
+		return "MIPS R3000 little-endian"
+	case EM_PARISC:
+		return "HPPA"
+	case EM_VPP500:
+		return "Fujitsu VPP500"
+	case EM_SPARC32PLUS:
+		return "Sun's v8plus"
+	case EM_960:
+		return "Intel 80960"
+	case EM_PPC:
+		return "PowerPC"
+	case EM_PPC64:
+		return "PowerPC 64-bit"
+	case EM_S390:
+		return "IBM S390"
+	case EM_SPU:
+		return "IBM SPU/SPC"
+	case EM_V800:
+		return "NEC V800 series"
+	case EM_FR20:
+		return "Fujitsu FR20"
+	case EM_RH32:
+		return "TRW RH-32"
+	case EM_RCE:
+		return "Motorola RCE"
+	case EM_ARM:
+		return "ARM"
+	case EM_FAKE_ALPHA:
+		return "Digital Alpha"
+	case EM_SH:
+		return "Hitachi SH"
+	case EM_SPARCV9:
+		return "SPARC v9 64-bit"
+	case EM_TRICORE:
+		return "Siemens Tricore"
+	case EM_ARC:
+		return "Argonaut RISC Core"
+	case EM_H8_300:
+		return "Hitachi H8/300"
+	case EM_H8_300H:
+		return "Hitachi H8/300H"
+	case EM_H8S:
+		return "Hitachi H8S"
+	case EM_H8_500:
+		return "Hitachi H8/500"
+	case EM_IA_64:
+		return "Intel Merced"
+	case EM_MIPS_X:
+		return "Stanford MIPS-X"
+	case EM_COLDFIRE:
+		return "Motorola Coldfire"
+	case EM_68HC12:
+		return "Motorola M68HC12"
+	case EM_MMA:
+		return "Fujitsu MMA Multimedia Accelerator"
+	case EM_PCP:
+		return "Siemens PCP"
+	case EM_NCPU:
+		return "Sony nCPU embedded RISC"
+	case EM_NDR1:
+		return "Denso NDR1 microprocessor"
+	case EM_STARCORE:
+		return "Motorola Start*Core processor"
+	case EM_ME16:
+		return "Toyota ME16 processor"
+	case EM_ST100:
+		return "STMicroelectronic ST100 processor"
+	case EM_TINYJ:
+		return "Advanced Logic Corp. Tinyj emb.fam"
+	case EM_X86_64:
+		return "Advanced Micro Devices X86-64"
+	case EM_PDSP:
+		return "Sony DSP Processor"
+	case EM_PDP10:
+		return "Digital PDP-10"
+	case EM_PDP11:
+		return "Digital PDP-11"
+	case EM_FX66:
+		return "Siemens FX66 microcontroller"
+	case EM_ST9PLUS:
+		return "STMicroelectronics ST9+ 8/16 mc"
+	case EM_ST7:
+		return "STmicroelectronics ST7 8 bit mc"
+	case EM_68HC16:
+		return "Motorola MC68HC16 microcontroller"
+	case EM_68HC11:
+		return "Motorola MC68HC11 microcontroller"
+	case EM_68HC08:
+		return "Motorola MC68HC08 microcontroller"
+	case EM_68HC05:
+		return "Motorola MC68HC05 microcontroller"
+	case EM_SVX:
+		return "Silicon Graphics SVx"
+	case EM_ST19:
+		return "STMicroelectronics ST19 8 bit mc"
+	case EM_VAX:
+		return "Digital VAX"
+	case EM_CRIS:
+		return "Axis Communications 32-bit emb.proc"
+	case EM_JAVELIN:
+		return "Infineon Technologies 32-bit emb.proc"
+	case EM_FIREPATH:
+		return "Element 14 64-bit DSP Processor"
+	case EM_ZSP:
+		return "LSI Logic 16-bit DSP Processor"
+	case EM_MMIX:
+		return "Donald Knuth's educational 64-bit proc"
+	case EM_HUANY:
+		return "Harvard University machine-independent object files"
+	case EM_PRISM:
+		return "SiTera Prism"
+	case EM_AVR:
+		return "Atmel AVR 8-bit microcontroller"
+	case EM_FR30:
+		return "Fujitsu FR30"
+	case EM_D10V:
+		return "Mitsubishi D10V"
+	case EM_D30V:
+		return "Mitsubishi D30V"
+	case EM_V850:
+		return "NEC v850"
+	case EM_M32R:
+		return "Mitsubishi M32R"
+	case EM_MN10300:
+		return "Matsushita MN10300"
+	case EM_MN10200:
+		return "Matsushita MN10200"
+	case EM_PJ:
+		return "picoJava"
+	case EM_OPENRISC:
+		return "OpenRISC 32-bit embedded processor"
+	case EM_ARC_COMPACT:
+		return "ARC International ARCompact"
+	case EM_XTENSA:
+		return "Tensilica Xtensa Architecture"
+	case EM_VIDEOCORE:
+		return "Alphamosaic VideoCore"
+	case EM_TMM_GPP:
+		return "Thompson Multimedia General Purpose Proc"
+	case EM_NS32K:
+		return "National Semi. 32000"
+	case EM_TPC:
+		return "Tenor Network TPC"
+	case EM_SNP1K:
+		return "Trebia SNP 1000"
+	case EM_ST200:
+		return "STMicroelectronics ST200"
+	case EM_IP2K:
+		return "Ubicom IP2xxx"
+	case EM_MAX:
+		return "MAX processor"
+	case EM_CR:
+		return "National Semi. CompactRISC"
+	case EM_F2MC16:
+		return "Fujitsu F2MC16"
+	case EM_MSP430:
+		return "Texas Instruments msp430"
+	case EM_BLACKFIN:
+		return "Analog Devices Blackfin DSP"
+	case EM_SE_C33:
+		return "Seiko Epson S1C33 family"
+	case EM_SEP:
+		return "Sharp embedded microprocessor"
+	case EM_ARCA:
+		return "Arca RISC"
+	case EM_UNICORE:
+		return "PKU-Unity & MPRC Peking Uni. mc series"
+	case EM_EXCESS:
+		return "eXcess configurable cpu"
+	case EM_DXP:
+		return "Icera Semi. Deep Execution Processor"
+	case EM_ALTERA_NIOS2:
+		return "Altera Nios II"
+	case EM_CRX:
+		return "National Semi. CompactRISC CRX"
+	case EM_XGATE:
+		return "Motorola XGATE"
+	case EM_C166:
+		return "Infineon C16x/XC16x"
+	case EM_M16C:
+		return "Renesas M16C"
+	case EM_DSPIC30F:
+		return "Microchip Technology dsPIC30F"
+	case EM_CE:
+		return "Freescale Communication Engine RISC"
+	case EM_M32C:
+		return "Renesas M32C"
+	case EM_TSK3000:
+		return "Altium TSK3000"
+	case EM_RS08:
+		return "Freescale RS08"
+	case EM_SHARC:
+		return "Analog Devices SHARC family"
+	case EM_ECOG2:
+		return "Cyan Technology eCOG2"
+	case EM_SCORE7:
+		return "Sunplus S+core7 RISC"
+	case EM_DSP24:
+		return "New Japan Radio (NJR 24-bit DSP)"
+	case EM_VIDEOCORE3:
+		return "Broadcom VideoCore III"
+	case EM_LATTICEMICO32:
+		return "RISC for Lattice FPGA"
+	case EM_SE_C17:
+		return "Seiko Epson C17"
+	case EM_TI_C6000:
+		return "Texas Instruments TMS320C6000 DSP"
+	case EM_TI_C2000:
+		return "Texas Instruments TMS320C2000 DSP"
+	case EM_TI_C5500:
+		return "Texas Instruments TMS320C55x DSP"
+	case EM_TI_ARP32:
+		return "Texas Instruments App. Specific RISC"
+	case EM_TI_PRU:
+		return "Texas Instruments Prog. Realtime Unit"
+	case EM_MMDSP_PLUS:
+		return "STMicroelectronics 64bit VLIW DSP"
+	case EM_CYPRESS_M8C:
+		return "Cypress M8C"
+	case EM_R32C:
+		return "Renesas R32C"
+	case EM_TRIMEDIA:
+		return "NXP Semi. TriMedia"
+	case EM_QDSP6:
+		return "QUALCOMM DSP6"
+	case EM_8051:
+		return "Intel 8051 and variants"
+	case EM_STXP7X:
+		return "STMicroelectronics STxP7x"
+	case EM_NDS32:
+		return "Andes Tech. compact code emb. RISC"
+	case EM_ECOG1X:
+		return "Cyan Technology eCOG1X"
+	case EM_MAXQ30:
+		return "Dallas Semi. MAXQ30 mc"
+	case EM_XIMO16:
+		return "New Japan Radio (NJR 16-bit DSP)"
+	case EM_MANIK:
+		return "M2000 Reconfigurable RISC"
+	case EM_CRAYNV2:
+		return "Cray NV2 vector architecture"
+	case EM_RX:
+		return "Renesas RX"
+	case EM_METAG:
+		return "Imagination Tech. META"
+	case EM_MCST_ELBRUS:
+		return "MCST Elbrus"
+	case EM_ECOG16:
+		return "Cyan Technology eCOG16"
+	case EM_CR16:
+		return "National Semi. CompactRISC CR16"
+	case EM_ETPU:
+		return "Freescale Extended Time Processing Unit"
+	case EM_SLE9X:
+		return "Infineon Tech. SLE9X"
+	case EM_L10M:
+		return "Intel L10M"
+	case EM_K10M:
+		return "Intel K10M"
+	case EM_AARCH64:
+		return "ARM AARCH64"
+	case EM_AVR32:
+		return "Amtel 32-bit microprocessor"
+	case EM_STM8:
+		return "STMicroelectronics STM8"
+	case EM_TILE64:
+		return "Tilera TILE64"
+	case EM_TILEPRO:
+		return "Tilera TILEPro"
+	case EM_MICROBLAZE:
+		return "Xilinx MicroBlaze"
+	case EM_CUDA:
+		return "NVIDIA CUDA"
+	case EM_TILEGX:
+		return "Tilera TILE-Gx"
+	case EM_CLOUDSHIELD:
+		return "CloudShield"
+	case EM_COREA_1ST:
+		return "KIPO-KAIST Core-A 1st gen."
+	case EM_COREA_2ND:
+		return "KIPO-KAIST Core-A 2nd gen."
+	case EM_ARCV2:
+		return "Synopsys ARCv2 ISA"
+	case EM_OPEN8:
+		return "Open8 RISC"
+	case EM_RL78:
+		return "Renesas RL78"
+	case EM_VIDEOCORE5:
+		return "Broadcom VideoCore V"
+	case EM_78KOR:
+		return "Renesas 78KOR"
+	case EM_56800EX:
+		return "Freescale 56800EX DSC"
+	case EM_BA1:
+		return "Beyond BA1"
+	case EM_BA2:
+		return "Beyond BA2"
+	case EM_XCORE:
+		return "XMOS xCORE"
+	case EM_MCHP_PIC:
+		return "Microchip 8-bit PIC(r)"
+	case EM_INTELGT:
+		return "Intel Graphics Technology"
+	case EM_KM32:
+		return "KM211 KM32"
+	case EM_KMX32:
+		return "KM211 KMX32"
+	case EM_EMX16:
+		return "KM211 KMX16"
+	case EM_EMX8:
+		return "KM211 KMX8"
+	case EM_KVARC:
+		return "KM211 KVARC"
+	case EM_CDP:
+		return "Paneve CDP"
+	case EM_COGE:
+		return "Cognitive Smart Memory Processor"
+	case EM_COOL:
+		return "Bluechip CoolEngine"
+	case EM_NORC:
+		return "Nanoradio Optimized RISC"
+	case EM_CSR_KALIMBA:
+		return "CSR Kalimba"
+	case EM_Z80:
+		return "Zilog Z80"
+	case EM_VISIUM:
+		return "Controls and Data Services VISIUMcore"
+	case EM_FT32:
+		return "FTDI Chip FT32"
+	case EM_MOXIE:
+		return "Moxie processor"
+	case EM_AMDGPU:
+		return "AMD GPU"
+	case EM_RISCV:
+		return "RISC-V"
+	case EM_BPF:
+		return "Linux BPF"
+	case EM_CSKY:
+		return "C-SKY"
+	case EM_LOONGARCH:
+		return "LoongArch"
+	default:
+		return "<Unknown>"
+	}
+}
+
+// Getter for getting interpreter from ELF File
+func GetInterpreter(phdr []Elf64_Phdr, data []byte) string {
+	for i := range phdr {
+		if phdr[i].P_type != PT_INTERP {
+			continue
+		}
+		start := phdr[i].P_offset
+		end := start + phdr[i].P_filesz
+
+		if end < start || end > uint64(len(data)) || start >= end {
+			return "<Invalid interpreter offset>"
+		}
+
+		// Strip null terminator
+		interp := data[start:end]
+		if interp[len(interp)-1] == 0 {
+			interp = interp[:len(interp)-1]
+		}
+
+		return unsafe.String(&interp[0], len(interp))
+	}
+
+	return "<Interpreter not available>"
+}
+
+// GetPType returns a human-readable string for the program header type (p_type field).
+// It handles standard, OS-specific, and processor-specific segment types.
+func GetPType(p_type uint32) string {
+	// Fast path: common types (0-8)
+	if p_type <= PT_NUM {
+		switch p_type {
+		case PT_NULL:
+			return "NULL"
+		case PT_LOAD:
+			return "LOAD"
+		case PT_DYNAMIC:
+			return "DYNAMIC"
+		case PT_INTERP:
+			return "INTERP"
+		case PT_NOTE:
+			return "NOTE"
+		case PT_SHLIB:
+			return "SHLIB"
+		case PT_PHDR:
+			return "PHDR"
+		case PT_TLS:
+			return "TLS"
+		}
+	}
+
+	// GNU extensions (most common in practice)
+	switch p_type {
+	case PT_GNU_EH_FRAME:
+		return "GNU_EH_FRAME"
+	case PT_GNU_STACK:
+		return "GNU_STACK"
+	case PT_GNU_RELRO:
+		return "GNU_RELRO"
+	case PT_GNU_PROPERTY:
+		return "GNU_PROPERTY"
+	case PT_GNU_SFRAME:
+		return "GNU_SFRAME"
+	}
+
+	// Range-based detection (order matters due to overlap)
+	if p_type >= PT_LOPROC && p_type <= PT_HIPROC {
+		return fmt.Sprintf("PROC_SPECIFIC: 0x%x", p_type)
+	}
+
+	if p_type >= PT_LOOS && p_type <= PT_HIOS {
+		// Check specific Sun values
+		switch p_type {
+		case PT_SUNWBSS:
+			return "SUNWBSS"
+		case PT_SUNWSTACK:
+			return "SUNWSTACK"
+		}
+		return fmt.Sprintf("OS_SPECIFIC: 0x%x", p_type)
+	}
+
+	return fmt.Sprintf("<Unknown: 0x%x>", p_type)
+}
+
+// Getter for retrieving segment flags (p_flags) in the readelf layout, e.g. "R E" or "RW ".
+func GetPFlags(p_flags uint32) string {
+	flags := []byte("   ")
+	if p_flags&PF_R != 0 {
+		flags[0] = 'R'
+	}
+	if p_flags&PF_W != 0 {
+		flags[1] = 'W'
+	}
+	if p_flags&PF_X != 0 {
+		flags[2] = 'E'
+	}
+	return string(flags)
+}
+
+// isTbssSpecial reports whether a section is .tbss-like (TLS + NOBITS) and the
+// segment is not PT_TLS. Such sections take no space in any other segment.
+func isTbssSpecial(sh *Elf64_Shdr, ph *Elf64_Phdr) bool {
+	return sh.Sh_flags&SHF_TLS != 0 &&
+		sh.Sh_type == SHT_NOBITS &&
+		ph.P_type != PT_TLS
+}
+
+// SectionInSegment reports whether a section belongs to a segment, using the same
+// strict rules as binutils' ELF_SECTION_IN_SEGMENT_STRICT (file offsets and VMAs both checked).
+func SectionInSegment(sh *Elf64_Shdr, ph *Elf64_Phdr) bool {
+	// .tbss only occupies space in PT_TLS
+	if isTbssSpecial(sh, ph) {
+		return false
+	}
+
+	tls := sh.Sh_flags&SHF_TLS != 0
+	alloc := sh.Sh_flags&SHF_ALLOC != 0
+	nobits := sh.Sh_type == SHT_NOBITS
+
+	// Only PT_LOAD, PT_GNU_RELRO and PT_TLS segments can contain SHF_TLS sections,
+	// PT_TLS contains only SHF_TLS sections and PT_PHDR no sections at all.
+	if tls {
+		if ph.P_type != PT_TLS && ph.P_type != PT_GNU_RELRO && ph.P_type != PT_LOAD {
+			return false
+		}
+	} else if ph.P_type == PT_TLS || ph.P_type == PT_PHDR {
+		return false
+	}
+
+	// PT_LOAD and similar segments only have SHF_ALLOC sections
+	if !alloc {
+		switch {
+		case ph.P_type == PT_LOAD,
+			ph.P_type == PT_DYNAMIC,
+			ph.P_type == PT_GNU_EH_FRAME,
+			ph.P_type == PT_GNU_STACK,
+			ph.P_type == PT_GNU_RELRO,
+			ph.P_type == PT_GNU_SFRAME,
+			ph.P_type >= PT_GNU_MBIND_LO && ph.P_type <= PT_GNU_MBIND_HI:
+			return false
+		}
+	}
+
+	size := sh.Sh_size
+
+	// Any section besides NOBITS must have its file offsets within the segment
+	if !nobits {
+		if sh.Sh_offset < ph.P_offset ||
+			sh.Sh_offset-ph.P_offset > ph.P_filesz-1 ||
+			sh.Sh_offset-ph.P_offset+size > ph.P_filesz {
+			return false
+		}
+	}
+
+	// SHF_ALLOC sections must have VMAs within the segment
+	if alloc {
+		if sh.Sh_addr < ph.P_vaddr ||
+			sh.Sh_addr-ph.P_vaddr > ph.P_memsz-1 ||
+			sh.Sh_addr-ph.P_vaddr+size > ph.P_memsz {
+			return false
+		}
+	}
+
+	// No zero size sections at start or end of PT_DYNAMIC nor PT_NOTE
+	if (ph.P_type == PT_DYNAMIC || ph.P_type == PT_NOTE) && size == 0 && ph.P_memsz != 0 {
+		inFile := nobits || (sh.Sh_offset > ph.P_offset && sh.Sh_offset-ph.P_offset < ph.P_filesz)
+		inMem := !alloc || (sh.Sh_addr > ph.P_vaddr && sh.Sh_addr-ph.P_vaddr < ph.P_memsz)
+		return inFile && inMem
+	}
+
+	return true
+}
+
+// MapSectionsToSegments returns, for every program header, the indices of the
+// sections it contains. The null section at index 0 is never mapped.
+func MapSectionsToSegments(phdr []Elf64_Phdr, shdr []Elf64_Shdr) [][]int {
+	mapping := make([][]int, len(phdr))
+	for i := range phdr {
+		for j := 1; j < len(shdr); j++ {
+			if SectionInSegment(&shdr[j], &phdr[i]) {
+				mapping[i] = append(mapping[i], j)
+			}
+		}
+	}
+	return mapping
+}
+
+// GetShType returns a human-readable string for the section type (sh_type field).
+// It handles standard, GNU/Sun extensions, and the reserved OS/processor/user ranges.
+func GetShType(sh_type uint32) string {
+	switch sh_type {
+	case SHT_NULL:
+		return "NULL"
+	case SHT_PROGBITS:
+		return "PROGBITS"
+	case SHT_SYMTAB:
+		return "SYMTAB"
+	case SHT_STRTAB:
+		return "STRTAB"
+	case SHT_RELA:
+		return "RELA"
+	case SHT_HASH:
+		return "HASH"
+	case SHT_DYNAMIC:
+		return "DYNAMIC"
+	case SHT_NOTE:
+		return "NOTE"
+	case SHT_NOBITS:
+		return "NOBITS"
+	case SHT_REL:
+		return "REL"
+	case SHT_SHLIB:
+		return "SHLIB"
+	case SHT_DYNSYM:
+		return "DYNSYM"
+	case SHT_INIT_ARRAY:
+		return "INIT_ARRAY"
+	case SHT_FINI_ARRAY:
+		return "FINI_ARRAY"
+	case SHT_PREINIT_ARRAY:
+		return "PREINIT_ARRAY"
+	case SHT_GROUP:
+		return "GROUP"
+	case SHT_SYMTAB_SHNDX:
+		return "SYMTAB_SHNDX"
+	case SHT_RELR:
+		return "RELR"
+	case SHT_GNU_ATTRIBUTES:
+		return "GNU_ATTRIBUTES"
+	case SHT_GNU_HASH:
+		return "GNU_HASH"
+	case SHT_GNU_LIBLIST:
+		return "GNU_LIBLIST"
+	case SHT_CHECKSUM:
+		return "CHECKSUM"
+	case SHT_SUNW_move:
+		return "SUNW_move"
+	case SHT_SUNW_COMDAT:
+		return "SUNW_COMDAT"
+	case SHT_SUNW_syminfo:
+		return "SUNW_syminfo"
+	case SHT_GNU_verdef:
+		return "VERDEF"
+	case SHT_GNU_verneed:
+		return "VERNEED"
+	case SHT_GNU_versym:
+		return "VERSYM"
+	}
+
+	switch {
+	case sh_type >= SHT_LOPROC && sh_type <= SHT_HIPROC:
+		return fmt.Sprintf("LOPROC+%#x", sh_type-SHT_LOPROC)
+	case sh_type >= SHT_LOUSER && sh_type <= SHT_HIUSER:
+		return fmt.Sprintf("LOUSER+%#x", sh_type-SHT_LOUSER)
+	case sh_type >= SHT_LOOS && sh_type <= SHT_HIOS:
+		return fmt.Sprintf("LOOS+%#x", sh_type-SHT_LOOS)
+	}
+
+	return fmt.Sprintf("<Unknown: 0x%x>", sh_type)
+}
+
+// GetShFlags returns the readelf style key letters for the section flags (sh_flags field).
+func GetShFlags(sh_flags uint64) string {
+	result := make([]byte, 0, 16)
+
+	if sh_flags&SHF_WRITE != 0 {
+		result = append(result, 'W')
+	}
+	if sh_flags&SHF_ALLOC != 0 {
+		result = append(result, 'A')
+	}
+	if sh_flags&SHF_EXECINSTR != 0 {
+		result = append(result, 'X')
+	}
+	if sh_flags&SHF_MERGE != 0 {
+		result = append(result, 'M')
+	}
+	if sh_flags&SHF_STRINGS != 0 {
+		result = append(result, 'S')
+	}
+	if sh_flags&SHF_INFO_LINK != 0 {
+		result = append(result, 'I')
+	}
+	if sh_flags&SHF_LINK_ORDER != 0 {
+		result = append(result, 'L')
+	}
+	if sh_flags&SHF_OS_NONCONFORMING != 0 {
+		result = append(result, 'O')
+	}
+	if sh_flags&SHF_GROUP != 0 {
+		result = append(result, 'G')
+	}
+	if sh_flags&SHF_TLS != 0 {
+		result = append(result, 'T')
+	}
+	if sh_flags&SHF_COMPRESSED != 0 {
+		result = append(result, 'C')
+	}
+	if sh_flags&SHF_GNU_RETAIN != 0 {
+		result = append(result, 'R')
+	}
+	if sh_flags&SHF_EXCLUDE != 0 {
+		result = append(result, 'E')
+	}
+
+	// OS-specific bits that are not already covered by SHF_GNU_RETAIN
+	if sh_flags&SHF_MASKOS&^SHF_GNU_RETAIN != 0 {
+		result = append(result, 'o')
+	}
+
+	// Processor-specific bits (SHF_EXCLUDE and SHF_ORDERED live in this range)
+	if sh_flags&SHF_MASKPROC&^(SHF_EXCLUDE|SHF_ORDERED) != 0 {
+		result = append(result, 'p')
+	}
+
+	return string(result)
+}
+
+// GetString extracts the NUL-terminated string starting at offset in a string table.
+// The returned string aliases the table bytes, so no copy is made.
+func GetString(strtab []byte, offset uint32) string {
+	if uint64(offset) >= uint64(len(strtab)) {
+		return ""
+	}
+
+	end := offset
+	for end < uint32(len(strtab)) && strtab[end] != 0 {
+		end++
+	}
+
+	if end == offset {
+		return ""
+	}
+	return unsafe.String(&strtab[offset], end-offset)
+}
+
+// GetSymType returns a human-readable string for the symbol type (ST_TYPE of st_info).
+func GetSymType(st_type uint8) string {
+	switch st_type {
+	case STT_NOTYPE:
+		return "NOTYPE"
+	case STT_OBJECT:
+		return "OBJECT"
+	case STT_FUNC:
+		return "FUNC"
+	case STT_SECTION:
+		return "SECTION"
+	case STT_FILE:
+		return "FILE"
+	case STT_COMMON:
+		return "COMMON"
+	case STT_TLS:
+		return "TLS"
+	case STT_GNU_IFUNC:
+		return "IFUNC"
+	}
+
+	switch {
+	case st_type >= STT_LOPROC && st_type <= STT_HIPROC:
+		return fmt.Sprintf("PROC+%d", st_type-STT_LOPROC)
+	case st_type >= STT_LOOS && st_type <= STT_HIOS:
+		return fmt.Sprintf("OS+%d", st_type-STT_LOOS)
+	}
+
+	return fmt.Sprintf("<Unknown: %d>", st_type)
+}
+
+// GetSymBind returns a human-readable string for the symbol binding (ST_BIND of st_info).
+func GetSymBind(st_bind uint8) string {
+	switch st_bind {
+	case STB_LOCAL:
+		return "LOCAL"
+	case STB_GLOBAL:
+		return "GLOBAL"
+	case STB_WEAK:
+		return "WEAK"
+	case STB_GNU_UNIQUE:
+		return "UNIQUE"
+	}
+
+	switch {
+	case st_bind >= STB_LOPROC && st_bind <= STB_HIPROC:
+		return fmt.Sprintf("PROC+%d", st_bind-STB_LOPROC)
+	case st_bind >= STB_LOOS && st_bind <= STB_HIOS:
+		return fmt.Sprintf("OS+%d", st_bind-STB_LOOS)
+	}
+
+	return fmt.Sprintf("<Unknown: %d>", st_bind)
+}
+
+// GetSymVisibility returns a human-readable string for the symbol visibility (ST_VISIBILITY of st_other).
+func GetSymVisibility(st_visibility uint8) string {
+	switch st_visibility {
+	case STV_DEFAULT:
+		return "DEFAULT"
+	case STV_INTERNAL:
+		return "INTERNAL"
+	case STV_HIDDEN:
+		return "HIDDEN"
+	case STV_PROTECTED:
+		return "PROTECTED"
+	default:
+		return fmt.Sprintf("<Unknown: %d>", st_visibility)
+	}
+}
+
+// GetSymSectionIndex returns the readelf style Ndx column for a symbol. Reserved indices are
+// named from st_shndx, and SHN_XINDEX entries show the index resolved from .symtab_shndx.
+func GetSymSectionIndex(st_shndx uint16, resolved uint32) string {
+	switch {
+	case st_shndx == SHN_XINDEX:
+		return fmt.Sprintf("%d", resolved)
+	case st_shndx == SHN_UNDEF:
+		return "UND"
+	case st_shndx == SHN_ABS:
+		return "ABS"
+	case st_shndx == SHN_COMMON:
+		return "COM"
+	case st_shndx >= SHN_LOPROC && st_shndx <= SHN_HIPROC:
+		return fmt.Sprintf("PRC[%#04x]", st_shndx)
+	case st_shndx >= SHN_LOOS && st_shndx <= SHN_HIOS:
+		return fmt.Sprintf("OS [%#04x]", st_shndx)
+	default:
+		return fmt.Sprintf("%d", st_shndx)
+	}
+}
+
+// GetDynTag returns the name of a dynamic entry tag (d_tag field) without the DT_ prefix.
+func GetDynTag(d_tag int64) string {
+	switch d_tag {
+	case DT_NULL:
+		return "NULL"
+	case DT_NEEDED:
+		return "NEEDED"
+	case DT_PLTRELSZ:
+		return "PLTRELSZ"
+	case DT_PLTGOT:
+		return "PLTGOT"
+	case DT_HASH:
+		return "HASH"
+	case DT_STRTAB:
+		return "STRTAB"
+	case DT_SYMTAB:
+		return "SYMTAB"
+	case DT_RELA:
+		return "RELA"
+	case DT_RELASZ:
+		return "RELASZ"
+	case DT_RELAENT:
+		return "RELAENT"
+	case DT_STRSZ:
+		return "STRSZ"
+	case DT_SYMENT:
+		return "SYMENT"
+	case DT_INIT:
+		return "INIT"
+	case DT_FINI:
+		return "FINI"
+	case DT_SONAME:
+		return "SONAME"
+	case DT_RPATH:
+		return "RPATH"
+	case DT_SYMBOLIC:
+		return "SYMBOLIC"
+	case DT_REL:
+		return "REL"
+	case DT_RELSZ:
+		return "RELSZ"
+	case DT_RELENT:
+		return "RELENT"
+	case DT_PLTREL:
+		return "PLTREL"
+	case DT_DEBUG:
+		return "DEBUG"
+	case DT_TEXTREL:
+		return "TEXTREL"
+	case DT_JMPREL:
+		return "JMPREL"
+	case DT_BIND_NOW:
+		return "BIND_NOW"
+	case DT_INIT_ARRAY:
+		return "INIT_ARRAY"
+	case DT_FINI_ARRAY:
+		return "FINI_ARRAY"
+	case DT_INIT_ARRAYSZ:
+		return "INIT_ARRAYSZ"
+	case DT_FINI_ARRAYSZ:
+		return "FINI_ARRAYSZ"
+	case DT_RUNPATH:
+		return "RUNPATH"
+	case DT_FLAGS:
+		return "FLAGS"
+	case DT_PREINIT_ARRAY:
+		return "PREINIT_ARRAY"
+	case DT_PREINIT_ARRAYSZ:
+		return "PREINIT_ARRAYSZ"
+	case DT_SYMTAB_SHNDX:
+		return "SYMTAB_SHNDX"
+	case DT_RELRSZ:
+		return "RELRSZ"
+	case DT_RELR:
+		return "RELR"
+	case DT_RELRENT:
+		return "RELRENT"
+	case DT_GNU_PRELINKED:
+		return "GNU_PRELINKED"
+	case DT_GNU_CONFLICTSZ:
+		return "GNU_CONFLICTSZ"
+	case DT_GNU_LIBLISTSZ:
+		return "GNU_LIBLISTSZ"
+	case DT_CHECKSUM:
+		return "CHECKSUM"
+	case DT_PLTPADSZ:
+		return "PLTPADSZ"
+	case DT_MOVEENT:
+		return "MOVEENT"
+	case DT_MOVESZ:
+		return "MOVESZ"
+	case DT_FEATURE_1:
+		return "FEATURE_1"
+	case DT_POSFLAG_1:
+		return "POSFLAG_1"
+	case DT_SYMINSZ:
+		return "SYMINSZ"
+	case DT_SYMINENT:
+		return "SYMINENT"
+	case DT_GNU_HASH:
+		return "GNU_HASH"
+	case DT_TLSDESC_PLT:
+		return "TLSDESC_PLT"
+	case DT_TLSDESC_GOT:
+		return "TLSDESC_GOT"
+	case DT_GNU_CONFLICT:
+		return "GNU_CONFLICT"
+	case DT_GNU_LIBLIST:
+		return "GNU_LIBLIST"
+	case DT_CONFIG:
+		return "CONFIG"
+	case DT_DEPAUDIT:
+		return "DEPAUDIT"
+	case DT_AUDIT:
+		return "AUDIT"
+	case DT_PLTPAD:
+		return "PLTPAD"
+	case DT_MOVETAB:
+		return "MOVETAB"
+	case DT_SYMINFO:
+		return "SYMINFO"
+	case DT_VERSYM:
+		return "VERSYM"
+	case DT_RELACOUNT:
+		return "RELACOUNT"
+	case DT_RELCOUNT:
+		return "RELCOUNT"
+	case DT_FLAGS_1:
+		return "FLAGS_1"
+	case DT_VERDEF:
+		return "VERDEF"
+	case DT_VERDEFNUM:
+		return "VERDEFNUM"
+	case DT_VERNEED:
+		return "VERNEED"
+	case DT_VERNEEDNUM:
+		return "VERNEEDNUM"
+	case DT_AUXILIARY:
+		return "AUXILIARY"
+	case DT_FILTER:
+		return "FILTER"
+	}
+
+	switch {
+	case d_tag >= DT_LOPROC && d_tag <= DT_HIPROC:
+		return fmt.Sprintf("LOPROC+%#x", d_tag-DT_LOPROC)
+	case d_tag >= DT_LOOS && d_tag <= DT_HIOS:
+		return fmt.Sprintf("LOOS+%#x", d_tag-DT_LOOS)
+	}
+
+	return fmt.Sprintf("<Unknown: %#x>", d_tag)
+}
+
+// flagName pairs a single bit with its name, for decoding flag words.
+type flagName struct {
+	bit  uint64
+	name string
+}
+
+// dynFlags are the names of the DT_FLAGS bits.
+var dynFlags = []flagName{
+	{DF_ORIGIN, "ORIGIN"},
+	{DF_SYMBOLIC, "SYMBOLIC"},
+	{DF_TEXTREL, "TEXTREL"},
+	{DF_BIND_NOW, "BIND_NOW"},
+	{DF_STATIC_TLS, "STATIC_TLS"},
+}
+
+// dynFlags1 are the names of the DT_FLAGS_1 bits.
+var dynFlags1 = []flagName{
+	{DF_1_NOW, "NOW"},
+	{DF_1_GLOBAL, "GLOBAL"},
+	{DF_1_GROUP, "GROUP"},
+	{DF_1_NODELETE, "NODELETE"},
+	{DF_1_LOADFLTR, "LOADFLTR"},
+	{DF_1_INITFIRST, "INITFIRST"},
+	{DF_1_NOOPEN, "NOOPEN"},
+	{DF_1_ORIGIN, "ORIGIN"},
+	{DF_1_DIRECT, "DIRECT"},
+	{DF_1_TRANS, "TRANS"},
+	{DF_1_INTERPOSE, "INTERPOSE"},
+	{DF_1_NODEFLIB, "NODEFLIB"},
+	{DF_1_NODUMP, "NODUMP"},
+	{DF_1_CONFALT, "CONFALT"},
+	{DF_1_ENDFILTEE, "ENDFILTEE"},
+	{DF_1_DISPRELDNE, "DISPRELDNE"},
+	{DF_1_DISPRELPND, "DISPRELPND"},
+	{DF_1_NODIRECT, "NODIRECT"},
+	{DF_1_IGNMULDEF, "IGNMULDEF"},
+	{DF_1_NOKSYMS, "NOKSYMS"},
+	{DF_1_NOHDR, "NOHDR"},
+	{DF_1_EDITED, "EDITED"},
+	{DF_1_NORELOC, "NORELOC"},
+	{DF_1_SYMINTPOSE, "SYMINTPOSE"},
+	{DF_1_GLOBAUDIT, "GLOBAUDIT"},
+	{DF_1_SINGLETON, "SINGLETON"},
+	{DF_1_STUB, "STUB"},
+	{DF_1_PIE, "PIE"},
+	{DF_1_KMOD, "KMOD"},
+	{DF_1_WEAKFILTER, "WEAKFILTER"},
+	{DF_1_NOCOMMON, "NOCOMMON"},
+}
+
+// flagNames names every set bit of a flag word, leftover unknown bits are appended in hex.
+func flagNames(val uint64, names []flagName) []string {
+	var parts []string
+	for _, f := range names {
+		if val&f.bit != 0 {
+			parts = append(parts, f.name)
+			val &^= f.bit
+		}
+	}
+
+	if val != 0 {
+		parts = append(parts, fmt.Sprintf("%#x", val))
+	}
+
+	return parts
+}
+
+// decodeFlags is flagNames joined with spaces.
+func decodeFlags(val uint64, names []flagName) string {
+	return strings.Join(flagNames(val, names), " ")
+}
+
+// GetDynFlags decodes the DT_FLAGS bitmask into space separated flag names.
+func GetDynFlags(val uint64) string {
+	return decodeFlags(val, dynFlags)
+}
+
+// GetDynFlags1 decodes the DT_FLAGS_1 bitmask into space separated flag names.
+func GetDynFlags1(val uint64) string {
+	return decodeFlags(val, dynFlags1)
+}
+
+// DynFlagNames decodes the DT_FLAGS bitmask into a list of flag names.
+func DynFlagNames(val uint64) []string {
+	return flagNames(val, dynFlags)
+}
+
+// DynFlags1Names decodes the DT_FLAGS_1 bitmask into a list of flag names.
+func DynFlags1Names(val uint64) []string {
+	return flagNames(val, dynFlags1)
+}
+
+// Segment flag names, as used by PFlagNames.
+var segmentFlags = []flagName{
+	{uint64(PF_R), "R"},
+	{uint64(PF_W), "W"},
+	{uint64(PF_X), "X"},
+}
+
+// PFlagNames decodes the segment flags (p_flags field) into a list of flag names without colors.
+func PFlagNames(p_flags uint32) []string {
+	return flagNames(uint64(p_flags), segmentFlags)
+}
+
+// Section flag names, as used by ShFlagNames.
+var sectionFlags = []flagName{
+	{SHF_WRITE, "WRITE"},
+	{SHF_ALLOC, "ALLOC"},
+	{SHF_EXECINSTR, "EXECINSTR"},
+	{SHF_MERGE, "MERGE"},
+	{SHF_STRINGS, "STRINGS"},
+	{SHF_INFO_LINK, "INFO_LINK"},
+	{SHF_LINK_ORDER, "LINK_ORDER"},
+	{SHF_OS_NONCONFORMING, "OS_NONCONFORMING"},
+	{SHF_GROUP, "GROUP"},
+	{SHF_TLS, "TLS"},
+	{SHF_COMPRESSED, "COMPRESSED"},
+	{SHF_GNU_RETAIN, "GNU_RETAIN"},
+	{SHF_EXCLUDE, "EXCLUDE"},
+}
+
+// ShFlagNames decodes the section flags (sh_flags field) into a list of SHF_ names without the prefix.
+func ShFlagNames(sh_flags uint64) []string {
+	return flagNames(sh_flags, sectionFlags)
+}
+
+// Version flag names, as used by VerFlagNames.
+var versionFlags = []flagName{
+	{uint64(VER_FLG_BASE), "BASE"},
+	{uint64(VER_FLG_WEAK), "WEAK"},
+	{uint64(VER_FLG_INFO), "INFO"},
+}
+
+// VerFlagNames decodes the vd_flags or vna_flags bitmask of a version entry into a list of flag names.
+func VerFlagNames(flags uint16) []string {
+	return flagNames(uint64(flags), versionFlags)
+}
+
+// Relocation type names, indexed by r_type. Gaps are types the psABI reserves or retired.
+
+// relocX86_64 names the x86-64 relocation types.
+var relocX86_64 = map[uint32]string{
+	0:  "R_X86_64_NONE",
+	1:  "R_X86_64_64",
+	2:  "R_X86_64_PC32",
+	3:  "R_X86_64_GOT32",
+	4:  "R_X86_64_PLT32",
+	5:  "R_X86_64_COPY",
+	6:  "R_X86_64_GLOB_DAT",
+	7:  "R_X86_64_JUMP_SLOT",
+	8:  "R_X86_64_RELATIVE",
+	9:  "R_X86_64_GOTPCREL",
+	10: "R_X86_64_32",
+	11: "R_X86_64_32S",
+	12: "R_X86_64_16",
+	13: "R_X86_64_PC16",
+	14: "R_X86_64_8",
+	15: "R_X86_64_PC8",
+	16: "R_X86_64_DTPMOD64",
+	17: "R_X86_64_DTPOFF64",
+	18: "R_X86_64_TPOFF64",
+	19: "R_X86_64_TLSGD",
+	20: "R_X86_64_TLSLD",
+	21: "R_X86_64_DTPOFF32",
+	22: "R_X86_64_GOTTPOFF",
+	23: "R_X86_64_TPOFF32",
+	24: "R_X86_64_PC64",
+	25: "R_X86_64_GOTOFF64",
+	26: "R_X86_64_GOTPC32",
+	27: "R_X86_64_GOT64",
+	28: "R_X86_64_GOTPCREL64",
+	29: "R_X86_64_GOTPC64",
+	30: "R_X86_64_GOTPLT64",
+	31: "R_X86_64_PLTOFF64",
+	32: "R_X86_64_SIZE32",
+	33: "R_X86_64_SIZE64",
+	34: "R_X86_64_GOTPC32_TLSDESC",
+	35: "R_X86_64_TLSDESC_CALL",
+	36: "R_X86_64_TLSDESC",
+	37: "R_X86_64_IRELATIVE",
+	38: "R_X86_64_RELATIVE64",
+	41: "R_X86_64_GOTPCRELX",
+	42: "R_X86_64_REX_GOTPCRELX",
+}
+
+// reloc386 names the i386 relocation types.
+var reloc386 = map[uint32]string{
+	0:  "R_386_NONE",
+	1:  "R_386_32",
+	2:  "R_386_PC32",
+	3:  "R_386_GOT32",
+	4:  "R_386_PLT32",
+	5:  "R_386_COPY",
+	6:  "R_386_GLOB_DAT",
+	7:  "R_386_JMP_SLOT",
+	8:  "R_386_RELATIVE",
+	9:  "R_386_GOTOFF",
+	10: "R_386_GOTPC",
+	11: "R_386_32PLT",
+	14: "R_386_TLS_TPOFF",
+	15: "R_386_TLS_IE",
+	16: "R_386_TLS_GOTIE",
+	17: "R_386_TLS_LE",
+	18: "R_386_TLS_GD",
+	19: "R_386_TLS_LDM",
+	20: "R_386_16",
+	21: "R_386_PC16",
+	22: "R_386_8",
+	23: "R_386_PC8",
+	24: "R_386_TLS_GD_32",
+	25: "R_386_TLS_GD_PUSH",
+	26: "R_386_TLS_GD_CALL",
+	27: "R_386_TLS_GD_POP",
+	28: "R_386_TLS_LDM_32",
+	29: "R_386_TLS_LDM_PUSH",
+	30: "R_386_TLS_LDM_CALL",
+	31: "R_386_TLS_LDM_POP",
+	32: "R_386_TLS_LDO_32",
+	33: "R_386_TLS_IE_32",
+	34: "R_386_TLS_LE_32",
+	35: "R_386_TLS_DTPMOD32",
+	36: "R_386_TLS_DTPOFF32",
+	37: "R_386_TLS_TPOFF32",
+	38: "R_386_SIZE32",
+	39: "R_386_TLS_GOTDESC",
+	40: "R_386_TLS_DESC_CALL",
+	41: "R_386_TLS_DESC",
+	42: "R_386_IRELATIVE",
+	43: "R_386_GOT32X",
+}
+
+// relocAArch64 names the AArch64 relocation types.
+var relocAArch64 = map[uint32]string{
+	0:    "R_AARCH64_NONE",
+	257:  "R_AARCH64_ABS64",
+	258:  "R_AARCH64_ABS32",
+	259:  "R_AARCH64_ABS16",
+	260:  "R_AARCH64_PREL64",
+	261:  "R_AARCH64_PREL32",
+	262:  "R_AARCH64_PREL16",
+	263:  "R_AARCH64_MOVW_UABS_G0",
+	264:  "R_AARCH64_MOVW_UABS_G0_NC",
+	265:  "R_AARCH64_MOVW_UABS_G1",
+	266:  "R_AARCH64_MOVW_UABS_G1_NC",
+	267:  "R_AARCH64_MOVW_UABS_G2",
+	268:  "R_AARCH64_MOVW_UABS_G2_NC",
+	269:  "R_AARCH64_MOVW_UABS_G3",
+	270:  "R_AARCH64_MOVW_SABS_G0",
+	271:  "R_AARCH64_MOVW_SABS_G1",
+	272:  "R_AARCH64_MOVW_SABS_G2",
+	273:  "R_AARCH64_LD_PREL_LO19",
+	274:  "R_AARCH64_ADR_PREL_LO21",
+	275:  "R_AARCH64_ADR_PREL_PG_HI21",
+	276:  "R_AARCH64_ADR_PREL_PG_HI21_NC",
+	277:  "R_AARCH64_ADD_ABS_LO12_NC",
+	278:  "R_AARCH64_LDST8_ABS_LO12_NC",
+	279:  "R_AARCH64_TSTBR14",
+	280:  "R_AARCH64_CONDBR19",
+	282:  "R_AARCH64_JUMP26",
+	283:  "R_AARCH64_CALL26",
+	284:  "R_AARCH64_LDST16_ABS_LO12_NC",
+	285:  "R_AARCH64_LDST32_ABS_LO12_NC",
+	286:  "R_AARCH64_LDST64_ABS_LO12_NC",
+	299:  "R_AARCH64_LDST128_ABS_LO12_NC",
+	309:  "R_AARCH64_GOT_LD_PREL19",
+	311:  "R_AARCH64_ADR_GOT_PAGE",
+	312:  "R_AARCH64_LD64_GOT_LO12_NC",
+	512:  "R_AARCH64_TLSGD_ADR_PREL21",
+	513:  "R_AARCH64_TLSGD_ADR_PAGE21",
+	514:  "R_AARCH64_TLSGD_ADD_LO12_NC",
+	541:  "R_AARCH64_TLSIE_ADR_GOTTPREL_PAGE21",
+	542:  "R_AARCH64_TLSIE_LD64_GOTTPREL_LO12_NC",
+	549:  "R_AARCH64_TLSLE_ADD_TPREL_HI12",
+	550:  "R_AARCH64_TLSLE_ADD_TPREL_LO12",
+	551:  "R_AARCH64_TLSLE_ADD_TPREL_LO12_NC",
+	560:  "R_AARCH64_TLSDESC_LD_PREL19",
+	561:  "R_AARCH64_TLSDESC_ADR_PREL21",
+	562:  "R_AARCH64_TLSDESC_ADR_PAGE21",
+	563:  "R_AARCH64_TLSDESC_LD64_LO12",
+	564:  "R_AARCH64_TLSDESC_ADD_LO12",
+	569:  "R_AARCH64_TLSDESC_CALL",
+	1024: "R_AARCH64_COPY",
+	1025: "R_AARCH64_GLOB_DAT",
+	1026: "R_AARCH64_JUMP_SLOT",
+	1027: "R_AARCH64_RELATIVE",
+	1028: "R_AARCH64_TLS_DTPMOD",
+	1029: "R_AARCH64_TLS_DTPREL",
+	1030: "R_AARCH64_TLS_TPREL",
+	1031: "R_AARCH64_TLSDESC",
+	1032: "R_AARCH64_IRELATIVE",
+}
+
+// relocRISCV names the RISC-V relocation types.
+var relocRISCV = map[uint32]string{
+	0:  "R_RISCV_NONE",
+	1:  "R_RISCV_32",
+	2:  "R_RISCV_64",
+	3:  "R_RISCV_RELATIVE",
+	4:  "R_RISCV_COPY",
+	5:  "R_RISCV_JUMP_SLOT",
+	6:  "R_RISCV_TLS_DTPMOD32",
+	7:  "R_RISCV_TLS_DTPMOD64",
+	8:  "R_RISCV_TLS_DTPREL32",
+	9:  "R_RISCV_TLS_DTPREL64",
+	10: "R_RISCV_TLS_TPREL32",
+	11: "R_RISCV_TLS_TPREL64",
+	12: "R_RISCV_TLSDESC",
+	16: "R_RISCV_BRANCH",
+	17: "R_RISCV_JAL",
+	18: "R_RISCV_CALL",
+	19: "R_RISCV_CALL_PLT",
+	20: "R_RISCV_GOT_HI20",
+	21: "R_RISCV_TLS_GOT_HI20",
+	22: "R_RISCV_TLS_GD_HI20",
+	23: "R_RISCV_PCREL_HI20",
+	24: "R_RISCV_PCREL_LO12_I",
+	25: "R_RISCV_PCREL_LO12_S",
+	26: "R_RISCV_HI20",
+	27: "R_RISCV_LO12_I",
+	28: "R_RISCV_LO12_S",
+	29: "R_RISCV_TPREL_HI20",
+	30: "R_RISCV_TPREL_LO12_I",
+	31: "R_RISCV_TPREL_LO12_S",
+	32: "R_RISCV_TPREL_ADD",
+	33: "R_RISCV_ADD8",
+	34: "R_RISCV_ADD16",
+	35: "R_RISCV_ADD32",
+	36: "R_RISCV_ADD64",
+	37: "R_RISCV_SUB8",
+	38: "R_RISCV_SUB16",
+	39: "R_RISCV_SUB32",
+	40: "R_RISCV_SUB64",
+	41: "R_RISCV_GOT32_PCREL",
+	43: "R_RISCV_ALIGN",
+	44: "R_RISCV_RVC_BRANCH",
+	45: "R_RISCV_RVC_JUMP",
+	51: "R_RISCV_RELAX",
+	52: "R_RISCV_SUB6",
+	53: "R_RISCV_SET6",
+	54: "R_RISCV_SET8",
+	55: "R_RISCV_SET16",
+	56: "R_RISCV_SET32",
+	57: "R_RISCV_32_PCREL",
+	58: "R_RISCV_IRELATIVE",
+	59: "R_RISCV_PLT32",
+	60: "R_RISCV_SET_ULEB128",
+	61: "R_RISCV_SUB_ULEB128",
+}
+
+// relocARM names the 32 bit ARM relocation types.
+var relocARM = map[uint32]string{
+	0:   "R_ARM_NONE",
+	1:   "R_ARM_PC24",
+	2:   "R_ARM_ABS32",
+	3:   "R_ARM_REL32",
+	4:   "R_ARM_LDR_PC_G0",
+	5:   "R_ARM_ABS16",
+	6:   "R_ARM_ABS12",
+	7:   "R_ARM_THM_ABS5",
+	8:   "R_ARM_ABS8",
+	9:   "R_ARM_SBREL32",
+	10:  "R_ARM_THM_CALL",
+	11:  "R_ARM_THM_PC8",
+	12:  "R_ARM_BREL_ADJ",
+	13:  "R_ARM_TLS_DESC",
+	17:  "R_ARM_TLS_DTPMOD32",
+	18:  "R_ARM_TLS_DTPOFF32",
+	19:  "R_ARM_TLS_TPOFF32",
+	20:  "R_ARM_COPY",
+	21:  "R_ARM_GLOB_DAT",
+	22:  "R_ARM_JUMP_SLOT",
+	23:  "R_ARM_RELATIVE",
+	24:  "R_ARM_GOTOFF32",
+	25:  "R_ARM_BASE_PREL",
+	26:  "R_ARM_GOT_BREL",
+	27:  "R_ARM_PLT32",
+	28:  "R_ARM_CALL",
+	29:  "R_ARM_JUMP24",
+	30:  "R_ARM_THM_JUMP24",
+	31:  "R_ARM_BASE_ABS",
+	38:  "R_ARM_TARGET1",
+	40:  "R_ARM_V4BX",
+	41:  "R_ARM_TARGET2",
+	42:  "R_ARM_PREL31",
+	43:  "R_ARM_MOVW_ABS_NC",
+	44:  "R_ARM_MOVT_ABS",
+	45:  "R_ARM_MOVW_PREL_NC",
+	46:  "R_ARM_MOVT_PREL",
+	47:  "R_ARM_THM_MOVW_ABS_NC",
+	48:  "R_ARM_THM_MOVT_ABS",
+	49:  "R_ARM_THM_MOVW_PREL_NC",
+	50:  "R_ARM_THM_MOVT_PREL",
+	51:  "R_ARM_THM_JUMP19",
+	96:  "R_ARM_GOT_PREL",
+	102: "R_ARM_THM_JUMP11",
+	103: "R_ARM_THM_JUMP8",
+	104: "R_ARM_TLS_GD32",
+	105: "R_ARM_TLS_LDM32",
+	106: "R_ARM_TLS_LDO32",
+	107: "R_ARM_TLS_IE32",
+	108: "R_ARM_TLS_LE32",
+	160: "R_ARM_IRELATIVE",
+}
+
+// GetRelocType returns the name of a relocation type (r_type) for the given machine.
+func GetRelocType(e_machine uint16, r_type uint32) string {
+	var names map[uint32]string
+	switch e_machine {
+	case EM_X86_64:
+		names = relocX86_64
+	case EM_386:
+		names = reloc386
+	case EM_AARCH64:
+		names = relocAArch64
+	case EM_RISCV:
+		names = relocRISCV
+	case EM_ARM:
+		names = relocARM
+	}
+
+	if name, ok := names[r_type]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("<Unknown: 0x%x>", r_type)
+}
+
+// GetNoteType returns the name of a note type (n_type), which depends on the note owner.
+// Core file notes are only recognized in ET_CORE files.
+func GetNoteType(owner string, n_type uint32, e_type uint16) string {
+	switch owner {
+	case "GNU":
+		switch n_type {
+		case NT_GNU_ABI_TAG:
+			return "NT_GNU_ABI_TAG"
+		case NT_GNU_HWCAP:
+			return "NT_GNU_HWCAP"
+		case NT_GNU_BUILD_ID:
+			return "NT_GNU_BUILD_ID"
+		case NT_GNU_GOLD_VERSION:
+			return "NT_GNU_GOLD_VERSION"
+		case NT_GNU_PROPERTY_TYPE_0:
+			return "NT_GNU_PROPERTY_TYPE_0"
+		}
+	case "Go":
+		if n_type == NT_GO_BUILD_ID {
+			return "NT_GO_BUILD_ID"
+		}
+	case "FDO":
+		if n_type == NT_FDO_PACKAGING_METADATA {
+			return "NT_FDO_PACKAGING_METADATA"
+		}
+	case "stapsdt":
+		if n_type == NT_STAPSDT {
+			return "NT_STAPSDT"
+		}
+	case "CORE", "LINUX":
+		if e_type != ET_CORE {
+			break
+		}
+
+		switch n_type {
+		case NT_PRSTATUS:
+			return "NT_PRSTATUS"
+		case NT_PRFPREG:
+			return "NT_FPREGSET"
+		case NT_PRPSINFO:
+			return "NT_PRPSINFO"
+		case NT_TASKSTRUCT:
+			return "NT_TASKSTRUCT"
+		case NT_AUXV:
+			return "NT_AUXV"
+		case NT_PRXFPREG:
+			return "NT_PRXFPREG"
+		case NT_X86_XSTATE:
+			return "NT_X86_XSTATE"
+		case NT_X86_SHSTK:
+			return "NT_X86_SHSTK"
+		case NT_X86_XSAVE_LAYOUT:
+			return "NT_X86_XSAVE_LAYOUT"
+		case NT_ARM_VFP:
+			return "NT_ARM_VFP"
+		case NT_ARM_TLS:
+			return "NT_ARM_TLS"
+		case NT_ARM_HW_BREAK:
+			return "NT_ARM_HW_BREAK"
+		case NT_ARM_HW_WATCH:
+			return "NT_ARM_HW_WATCH"
+		case NT_ARM_SVE:
+			return "NT_ARM_SVE"
+		case NT_ARM_PAC_MASK:
+			return "NT_ARM_PAC_MASK"
+		case NT_SIGINFO:
+			return "NT_SIGINFO"
+		case NT_FILE:
+			return "NT_FILE"
+		}
+	}
+
+	return fmt.Sprintf("<Unknown: 0x%x>", n_type)
+}
+
+// GetABITagOS returns the operating system named by a NT_GNU_ABI_TAG note.
+func GetABITagOS(os uint32) string {
+	switch os {
+	case ELF_NOTE_OS_LINUX:
+		return "Linux"
+	case ELF_NOTE_OS_GNU:
+		return "Hurd"
+	case ELF_NOTE_OS_SOLARIS2:
+		return "Solaris"
+	case ELF_NOTE_OS_FREEBSD:
+		return "FreeBSD"
+	default:
+		return fmt.Sprintf("<Unknown: 0x%x>", os)
+	}
+}
+
+// GetGNUPropertyType returns the name of a program property type (pr_type).
+// Processor specific types are only recognized for their machine.
+func GetGNUPropertyType(e_machine uint16, pr_type uint32) string {
+	switch pr_type {
+	case GNU_PROPERTY_STACK_SIZE:
+		return "stack size"
+	case GNU_PROPERTY_NO_COPY_ON_PROTECTED:
+		return "no copy on protected"
+	case GNU_PROPERTY_1_NEEDED:
+		return "1_needed"
+	}
+
+	switch e_machine {
+	case EM_X86_64, EM_386:
+		switch pr_type {
+		case GNU_PROPERTY_X86_FEATURE_1_AND:
+			return "x86 feature"
+		case GNU_PROPERTY_X86_ISA_1_NEEDED:
+			return "x86 ISA needed"
+		case GNU_PROPERTY_X86_ISA_1_USED:
+			return "x86 ISA used"
+		case GNU_PROPERTY_X86_FEATURE_2_NEEDED:
+			return "x86 feature needed"
+		case GNU_PROPERTY_X86_FEATURE_2_USED:
+			return "x86 feature used"
+		}
+	case EM_AARCH64:
+		if pr_type == GNU_PROPERTY_AARCH64_FEATURE_1_AND {
+			return "AArch64 feature"
+		}
+	}
+
+	return fmt.Sprintf("<Unknown: 0x%x>", pr_type)
+}
+
+// Bit names of the bitmask program properties.
+var (
+	x86Feature1 = []flagName{
+		{uint64(GNU_PROPERTY_X86_FEATURE_1_IBT), "IBT"},
+		{uint64(GNU_PROPERTY_X86_FEATURE_1_SHSTK), "SHSTK"},
+		{uint64(GNU_PROPERTY_X86_FEATURE_1_LAM_U48), "LAM_U48"},
+		{uint64(GNU_PROPERTY_X86_FEATURE_1_LAM_U57), "LAM_U57"},
+	}
+
+	x86ISA1 = []flagName{
+		{uint64(GNU_PROPERTY_X86_ISA_1_BASELINE), "x86-64-baseline"},
+		{uint64(GNU_PROPERTY_X86_ISA_1_V2), "x86-64-v2"},
+		{uint64(GNU_PROPERTY_X86_ISA_1_V3), "x86-64-v3"},
+		{uint64(GNU_PROPERTY_X86_ISA_1_V4), "x86-64-v4"},
+	}
+
+	x86Feature2 = []flagName{
+		{1 << 0, "x86"},
+		{1 << 1, "x87"},
+		{1 << 2, "MMX"},
+		{1 << 3, "XMM"},
+		{1 << 4, "YMM"},
+		{1 << 5, "ZMM"},
+		{1 << 6, "FXSR"},
+		{1 << 7, "XSAVE"},
+		{1 << 8, "XSAVEOPT"},
+		{1 << 9, "XSAVEC"},
+		{1 << 10, "TMM"},
+		{1 << 11, "MASK"},
+	}
+
+	aarch64Feature1 = []flagName{
+		{uint64(GNU_PROPERTY_AARCH64_FEATURE_1_BTI), "BTI"},
+		{uint64(GNU_PROPERTY_AARCH64_FEATURE_1_PAC), "PAC"},
+		{uint64(GNU_PROPERTY_AARCH64_FEATURE_1_GCS), "GCS"},
+	}
+
+	property1Needed = []flagName{
+		{uint64(GNU_PROPERTY_1_NEEDED_INDIRECT_EXTERN_ACCESS), "indirect external access"},
+	}
+)
+
+// GetGNUPropertyValue decodes the data of a program property into a readable string.
+func GetGNUPropertyValue(e_machine uint16, p *GNUProperty) string {
+	var names []flagName
+	switch p.Type {
+	case GNU_PROPERTY_STACK_SIZE:
+		return fmt.Sprintf("%#x", p.Value)
+	case GNU_PROPERTY_NO_COPY_ON_PROTECTED:
+		return ""
+	case GNU_PROPERTY_1_NEEDED:
+		names = property1Needed
+	}
+
+	switch e_machine {
+	case EM_X86_64, EM_386:
+		switch p.Type {
+		case GNU_PROPERTY_X86_FEATURE_1_AND:
+			names = x86Feature1
+		case GNU_PROPERTY_X86_ISA_1_NEEDED, GNU_PROPERTY_X86_ISA_1_USED:
+			names = x86ISA1
+		case GNU_PROPERTY_X86_FEATURE_2_NEEDED, GNU_PROPERTY_X86_FEATURE_2_USED:
+			names = x86Feature2
+		}
+	case EM_AARCH64:
+		if p.Type == GNU_PROPERTY_AARCH64_FEATURE_1_AND {
+			names = aarch64Feature1
+		}
+	}
+
+	if names == nil {
+		return fmt.Sprintf("%x", p.Data)
+	}
+
+	if p.Value == 0 {
+		return "<None>"
+	}
+	return decodeFlags(p.Value, names)
+}
+
+// GetAuxType returns the name of an auxiliary vector entry type.
+func GetAuxType(a_type uint64) string {
+	switch a_type {
+	case AT_NULL:
+		return "AT_NULL"
+	case AT_IGNORE:
+		return "AT_IGNORE"
+	case AT_EXECFD:
+		return "AT_EXECFD"
+	case AT_PHDR:
+		return "AT_PHDR"
+	case AT_PHENT:
+		return "AT_PHENT"
+	case AT_PHNUM:
+		return "AT_PHNUM"
+	case AT_PAGESZ:
+		return "AT_PAGESZ"
+	case AT_BASE:
+		return "AT_BASE"
+	case AT_FLAGS:
+		return "AT_FLAGS"
+	case AT_ENTRY:
+		return "AT_ENTRY"
+	case AT_NOTELF:
+		return "AT_NOTELF"
+	case AT_UID:
+		return "AT_UID"
+	case AT_EUID:
+		return "AT_EUID"
+	case AT_GID:
+		return "AT_GID"
+	case AT_EGID:
+		return "AT_EGID"
+	case AT_PLATFORM:
+		return "AT_PLATFORM"
+	case AT_HWCAP:
+		return "AT_HWCAP"
+	case AT_CLKTCK:
+		return "AT_CLKTCK"
+	case AT_SECURE:
+		return "AT_SECURE"
+	case AT_BASE_PLATFORM:
+		return "AT_BASE_PLATFORM"
+	case AT_RANDOM:
+		return "AT_RANDOM"
+	case AT_HWCAP2:
+		return "AT_HWCAP2"
+	case AT_RSEQ_FEATURE_SIZE:
+		return "AT_RSEQ_FEATURE_SIZE"
+	case AT_RSEQ_ALIGN:
+		return "AT_RSEQ_ALIGN"
+	case AT_HWCAP3:
+		return "AT_HWCAP3"
+	case AT_HWCAP4:
+		return "AT_HWCAP4"
+	case AT_EXECFN:
+		return "AT_EXECFN"
+	case AT_SYSINFO:
+		return "AT_SYSINFO"
+	case AT_SYSINFO_EHDR:
+		return "AT_SYSINFO_EHDR"
+	case AT_MINSIGSTKSZ:
+		return "AT_MINSIGSTKSZ"
+	default:
+		return fmt.Sprintf("<Unknown: %d>", a_type)
+	}
+}
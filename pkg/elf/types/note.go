@@ -0,0 +1,102 @@
+package types
+
+import (
+	"encoding/hex"
+)
+
+// Note is a single entry of a SHT_NOTE section or PT_NOTE segment.
+// Value holds the decoded descriptor for the notes strix understands, and is nil otherwise.
+// It is one of BuildID, GoBuildID, *ABITag, GNUProperties, PackageMetadata, *ProcessStatus,
+// *ProcessInfo, AuxVector, *SignalInfo or MappedFiles.
+type Note struct {
+	Owner  string // Note owner (n_name) without the trailing NUL
+	Type   uint32 // Owner specific note type (n_type)
+	Desc   []byte // Raw descriptor bytes
+	Source string // Section name, or "PT_NOTE" for notes only reachable through a segment
+	Offset uint64 // File offset of the note header
+	Value  any    // Decoded descriptor
+}
+
+// BuildID is the unique identifier of a build (NT_GNU_BUILD_ID or the Go toolchain build ID).
+type BuildID []byte
+
+// String returns the build ID as lowercase hex.
+func (id BuildID) String() string {
+	return hex.EncodeToString(id)
+}
+
+// GoBuildID is the build ID the Go toolchain records in its "Go" note, a slash separated string of hashes.
+type GoBuildID string
+
+// ABITag is the minimum kernel ABI recorded by NT_GNU_ABI_TAG.
+type ABITag struct {
+	OS    uint32 // ELF_NOTE_OS_* value
+	Major uint32
+	Minor uint32
+	Patch uint32
+}
+
+// GNUProperty is a single program property of a NT_GNU_PROPERTY_TYPE_0 note.
+type GNUProperty struct {
+	Type  uint32 // GNU_PROPERTY_* type
+	Data  []byte // Raw property data (pr_data)
+	Value uint64 // pr_data as an integer in file byte order, when it is 4 or 8 bytes long
+}
+
+// GNUProperties is the list of program properties carried by NT_GNU_PROPERTY_TYPE_0.
+type GNUProperties []GNUProperty
+
+// Find returns the property of the given type, or nil if there is none.
+func (ps GNUProperties) Find(pr_type uint32) *GNUProperty {
+	for i := range ps {
+		if ps[i].Type == pr_type {
+			return &ps[i]
+		}
+	}
+	return nil
+}
+
+// PackageMetadata is the JSON document of a NT_FDO_PACKAGING_METADATA note.
+type PackageMetadata string
+
+// ProcessStatus holds the fields of NT_PRSTATUS that do not depend on the register layout.
+type ProcessStatus struct {
+	Signal uint16 // Current signal (pr_cursig)
+	Pid    int32  // Thread ID (pr_pid)
+}
+
+// ProcessInfo holds the process name and arguments from NT_PRPSINFO.
+type ProcessInfo struct {
+	Pid    int32  // Process ID (pr_pid)
+	Name   string // Executable name (pr_fname)
+	Args   string // Initial part of the argument list (pr_psargs)
+	State  byte   // Numeric process state (pr_state)
+	SState byte   // Process state as a character (pr_sname)
+}
+
+// AuxEntry is a single auxiliary vector entry.
+type AuxEntry struct {
+	Type  uint64 // AT_* type
+	Value uint64
+}
+
+// AuxVector is the auxiliary vector copied into NT_AUXV, without the AT_NULL terminator.
+type AuxVector []AuxEntry
+
+// SignalInfo holds the fields of NT_SIGINFO that are common to every signal.
+type SignalInfo struct {
+	Signo int32 // Signal number
+	Errno int32 // Errno value
+	Code  int32 // Signal code
+}
+
+// MappedFile is a file backed mapping recorded by NT_FILE.
+type MappedFile struct {
+	Start  uint64 // Start of the mapping
+	End    uint64 // End of the mapping
+	Offset uint64 // File offset of the mapping, in bytes
+	Path   string // Mapped file
+}
+
+// MappedFiles is the list of file backed mappings of a core file.
+type MappedFiles []MappedFile
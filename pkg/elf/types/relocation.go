@@ -0,0 +1,151 @@
+package types
+
+// Relocation is a decoded REL, RELA or RELR entry with its symbol resolved.
+type Relocation struct {
+	Offset    uint64  // Location to patch (r_offset)
+	Type      uint32  // Machine specific relocation type
+	SymIndex  uint32  // Index into the linked symbol table, 0 when there is no symbol
+	Addend    int64   // Explicit addend (RELA), or the value stored at the location (REL, RELR)
+	HasAddend bool    // Whether the addend comes from the entry itself
+	Symbol    *Symbol // Resolved symbol, nil when SymIndex is 0 or out of range
+}
+
+// RelocationTable is a single relocation table and its decoded entries.
+type RelocationTable struct {
+	Name   string // Section name, or the DT_ tag when found through the dynamic section
+	Kind   uint32 // SHT_REL, SHT_RELA or SHT_RELR
+	Offset uint64 // File offset of the table
+	Relocs []Relocation
+}
+
+// How a relocation computes the value it stores.
+const (
+	relocUnknown  = iota // Instruction or TLS relocation, no plain target
+	relocAbsolute        // S + A
+	relocSlot            // S, GOT and PLT slots ignore the addend
+	relocRelative        // B + A
+	relocIndirect        // B + A is the address of an IFUNC resolver
+	relocCopy            // Data copied from the shared object definition of S
+)
+
+// relocKind classifies a relocation type of the given machine.
+func relocKind(e_machine uint16, r_type uint32) int {
+	switch e_machine {
+	case EM_X86_64:
+		switch r_type {
+		case R_X86_64_64, R_X86_64_32:
+			return relocAbsolute
+		case R_X86_64_GLOB_DAT, R_X86_64_JUMP_SLOT:
+			return relocSlot
+		case R_X86_64_RELATIVE:
+			return relocRelative
+		case R_X86_64_IRELATIVE:
+			return relocIndirect
+		case R_X86_64_COPY:
+			return relocCopy
+		}
+	case EM_386:
+		switch r_type {
+		case R_386_32:
+			return relocAbsolute
+		case R_386_GLOB_DAT, R_386_JMP_SLOT:
+			return relocSlot
+		case R_386_RELATIVE:
+			return relocRelative
+		case R_386_IRELATIVE:
+			return relocIndirect
+		case R_386_COPY:
+			return relocCopy
+		}
+	case EM_AARCH64:
+		switch r_type {
+		case R_AARCH64_ABS64, R_AARCH64_ABS32:
+			return relocAbsolute
+		case R_AARCH64_GLOB_DAT, R_AARCH64_JUMP_SLOT:
+			return relocSlot
+		case R_AARCH64_RELATIVE:
+			return relocRelative
+		case R_AARCH64_IRELATIVE:
+			return relocIndirect
+		case R_AARCH64_COPY:
+			return relocCopy
+		}
+	case EM_RISCV:
+		switch r_type {
+		case R_RISCV_64, R_RISCV_32:
+			return relocAbsolute
+		case R_RISCV_JUMP_SLOT:
+			return relocSlot
+		case R_RISCV_RELATIVE:
+			return relocRelative
+		case R_RISCV_IRELATIVE:
+			return relocIndirect
+		case R_RISCV_COPY:
+			return relocCopy
+		}
+	case EM_ARM:
+		switch r_type {
+		case R_ARM_ABS32:
+			return relocAbsolute
+		case R_ARM_GLOB_DAT, R_ARM_JUMP_SLOT:
+			return relocSlot
+		case R_ARM_RELATIVE:
+			return relocRelative
+		case R_ARM_IRELATIVE:
+			return relocIndirect
+		case R_ARM_COPY:
+			return relocCopy
+		}
+	}
+	return relocUnknown
+}
+
+// RelativeType returns the R_*_RELATIVE type of the machine, which every RELR entry implies.
+func RelativeType(e_machine uint16) uint32 {
+	switch e_machine {
+	case EM_X86_64:
+		return R_X86_64_RELATIVE
+	case EM_386:
+		return R_386_RELATIVE
+	case EM_AARCH64:
+		return R_AARCH64_RELATIVE
+	case EM_RISCV:
+		return R_RISCV_RELATIVE
+	case EM_ARM:
+		return R_ARM_RELATIVE
+	}
+	return 0
+}
+
+// Target computes the address the relocation resolves to, taking the load base as 0.
+// The result is only known for data relocations (absolute words, GOT/PLT slots, relative
+// and IFUNC relocations) whose symbol, if any, is defined in this file.
+func (r *Relocation) Target(ehdr *Elf64_Ehdr) (uint64, bool) {
+	target, ok := r.target(ehdr.E_machine)
+
+	// Sign-extended ELF32 addends wrap around the 32 bit address space
+	if ehdr.E_ident.Ei_class == ELFCLASS32 {
+		target &= 0xffffffff
+	}
+	return target, ok
+}
+
+// target computes the unmasked result of Target.
+func (r *Relocation) target(e_machine uint16) (uint64, bool) {
+	kind := relocKind(e_machine, r.Type)
+
+	switch kind {
+	case relocRelative, relocIndirect:
+		return uint64(r.Addend), true
+	case relocAbsolute, relocSlot, relocCopy:
+		if r.Symbol == nil || r.Symbol.IsUndefined() {
+			return 0, false
+		}
+		if kind == relocAbsolute {
+			return r.Symbol.St_value + uint64(r.Addend), true
+		}
+		return r.Symbol.St_value, true
+	}
+
+	return 0, false
+}
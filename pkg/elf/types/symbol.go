@@ -0,0 +1,30 @@
+package types
+
+// Symbol is a decoded symbol table entry with its name and section index resolved.
+type Symbol struct {
+	Elf64_Sym // Class-neutral raw entry
+
+	Index uint32 // Index of the entry in its symbol table
+	Name  string // Name from the linked string table
+	Shndx uint32 // Section index, with SHN_XINDEX resolved through .symtab_shndx
+}
+
+// Bind returns the symbol binding (ST_BIND of st_info).
+func (s *Symbol) Bind() uint8 {
+	return s.St_info >> 4
+}
+
+// Type returns the symbol type (ST_TYPE of st_info).
+func (s *Symbol) Type() uint8 {
+	return s.St_info & 0xf
+}
+
+// Visibility returns the symbol visibility (ST_VISIBILITY of st_other).
+func (s *Symbol) Visibility() uint8 {
+	return s.St_other & 0x3
+}
+
+// IsUndefined reports whether the symbol is referenced but not defined in this file.
+func (s *Symbol) IsUndefined() bool {
+	return s.St_shndx == SHN_UNDEF
+}
@@ -0,0 +1,77 @@
+package types
+
+// VersionDefinition is a version defined by this file (.gnu.version_d). The entry flagged
+// VER_FLG_BASE names the file itself, and Parents lists the versions this one inherits from.
+type VersionDefinition struct {
+	Index   uint16   // Version index, as found in .gnu.version
+	Flags   uint16   // VER_FLG_* bits
+	Hash    uint32   // ELF hash of the name
+	Name    string   // Version name (first verdaux entry)
+	Parents []string // Names of the remaining verdaux entries
+}
+
+// VersionRequirement is a version this file needs from one of its dependencies (vernaux).
+type VersionRequirement struct {
+	Index uint16 // Version index, as found in .gnu.version
+	Flags uint16 // VER_FLG_* bits
+	Hash  uint32 // ELF hash of the name
+	Name  string // Version name (e.g. "GLIBC_2.34")
+}
+
+// VersionNeed lists the versions required from one file (.gnu.version_r).
+type VersionNeed struct {
+	File     string // Name of the needed file, as in DT_NEEDED
+	Versions []VersionRequirement
+}
+
+// SymbolVersion is the version bound to a dynamic symbol through .gnu.version.
+type SymbolVersion struct {
+	Index  uint16 // Version index, without the hidden bit
+	Hidden bool   // Not the default version of the symbol (name@VER rather than name@@VER)
+	Name   string // Version name, empty for VER_NDX_LOCAL and VER_NDX_GLOBAL
+	File   string // File the version is required from, empty for versions defined here
+}
+
+// Versions holds the symbol versioning tables of a file.
+// Symbols has one raw .gnu.version entry per .dynsym entry.
+type Versions struct {
+	Definitions []VersionDefinition
+	Needs       []VersionNeed
+	Symbols     []Elf64_Versym
+}
+
+// Symbol resolves the version of the dynamic symbol at index. The second result is false
+// when the file has no entry for it, or when the symbol is unversioned (local or global).
+func (v *Versions) Symbol(index uint32) (SymbolVersion, bool) {
+	if v == nil || index >= uint32(len(v.Symbols)) {
+		return SymbolVersion{}, false
+	}
+
+	raw := v.Symbols[index]
+	out := SymbolVersion{
+		Index:  raw & VERSYM_VERSION,
+		Hidden: raw&VERSYM_HIDDEN != 0,
+	}
+	if out.Index == VER_NDX_LOCAL || out.Index == VER_NDX_GLOBAL {
+		return out, false
+	}
+
+	for i := range v.Definitions {
+		if v.Definitions[i].Index == out.Index {
+			out.Name = v.Definitions[i].Name
+			return out, true
+		}
+	}
+
+	for i := range v.Needs {
+		for _, req := range v.Needs[i].Versions {
+			if req.Index == out.Index {
+				out.Name = req.Name
+				out.File = v.Needs[i].File
+				return out, true
+			}
+		}
+	}
+
+	return out, false
+}
@@ -0,0 +1,167 @@
+package elf
+
+import (
+	"github.com/yourpwnguy/strix/pkg/elf/types"
+)
+
+// parseVersions decodes the symbol versioning sections: .gnu.version (SHT_GNU_versym),
+// .gnu.version_d (SHT_GNU_verdef) and .gnu.version_r (SHT_GNU_verneed). Names are resolved
+// through the string table each section links to, normally .dynstr.
+func parseVersions(data []byte, dec *decoder, shdr []types.Elf64_Shdr) (*types.Versions, error) {
+	out := &types.Versions{}
+
+	for i := range shdr {
+		sh := &shdr[i]
+
+		switch sh.Sh_type {
+		case types.SHT_GNU_versym:
+			count := sh.Sh_size / 2
+			if err := checkTable("version symbol table", sh.Sh_offset, count, 2, len(data)); err != nil {
+				return nil, err
+			}
+			out.Symbols = decodeSlice[types.Elf64_Versym](data, count, sh.Sh_offset, dec.order)
+
+		case types.SHT_GNU_verdef:
+			strtab, err := linkedStringTable(data, shdr, sh)
+			if err != nil {
+				return nil, err
+			}
+
+			defs, err := parseVerdef(data, dec, sh, strtab)
+			if err != nil {
+				return nil, err
+			}
+			out.Definitions = append(out.Definitions, defs...)
+
+		case types.SHT_GNU_verneed:
+			strtab, err := linkedStringTable(data, shdr, sh)
+			if err != nil {
+				return nil, err
+			}
+
+			needs, err := parseVerneed(data, dec, sh, strtab)
+			if err != nil {
+				return nil, err
+			}
+			out.Needs = append(out.Needs, needs...)
+		}
+	}
+
+	return out, nil
+}
+
+// linkedStringTable returns the string table a version section names in sh_link.
+func linkedStringTable(data []byte, shdr []types.Elf64_Shdr, sh *types.Elf64_Shdr) ([]byte, error) {
+	if sh.Sh_link == 0 || sh.Sh_link >= uint32(len(shdr)) {
+		return nil, nil
+	}
+	return parseSectionData(data, &shdr[sh.Sh_link], "version string table")
+}
+
+// parseVerdef walks the sh_info version definitions of a SHT_GNU_verdef section. Entries and
+// their verdaux arrays are chained by byte offsets, which must stay inside the section.
+func parseVerdef(data []byte, dec *decoder, sh *types.Elf64_Shdr, strtab []byte) ([]types.VersionDefinition, error) {
+	const table = "version definition table"
+
+	sec, err := parseSectionData(data, sh, table)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []types.VersionDefinition
+
+	offset := uint64(0)
+	for range sh.Sh_info {
+		vd := decodeSlice[types.Elf64_Verdef](sec, 1, offset, dec.order)
+		if vd == nil {
+			return nil, &MalformedVersionError{Table: table, Offset: sh.Sh_offset + offset}
+		}
+
+		def := types.VersionDefinition{
+			Index: vd[0].Vd_ndx,
+			Flags: vd[0].Vd_flags,
+			Hash:  vd[0].Vd_hash,
+		}
+
+		aux := offset + uint64(vd[0].Vd_aux)
+		for j := range vd[0].Vd_cnt {
+			vda := decodeSlice[types.Elf64_Verdaux](sec, 1, aux, dec.order)
+			if vda == nil {
+				return nil, &MalformedVersionError{Table: table, Offset: sh.Sh_offset + aux}
+			}
+
+			name := types.GetString(strtab, vda[0].Vda_name)
+			if j == 0 {
+				def.Name = name
+			} else {
+				def.Parents = append(def.Parents, name)
+			}
+
+			if vda[0].Vda_next == 0 {
+				break
+			}
+			aux += uint64(vda[0].Vda_next)
+		}
+
+		out = append(out, def)
+
+		if vd[0].Vd_next == 0 {
+			break
+		}
+		offset += uint64(vd[0].Vd_next)
+	}
+
+	return out, nil
+}
+
+// parseVerneed walks the sh_info files of a SHT_GNU_verneed section and the versions needed
+// from each of them.
+func parseVerneed(data []byte, dec *decoder, sh *types.Elf64_Shdr, strtab []byte) ([]types.VersionNeed, error) {
+	const table = "version requirement table"
+
+	sec, err := parseSectionData(data, sh, table)
+	if err != nil {
+		return nil, err
+	}
+
+	var out []types.VersionNeed
+
+	offset := uint64(0)
+	for range sh.Sh_info {
+		vn := decodeSlice[types.Elf64_Verneed](sec, 1, offset, dec.order)
+		if vn == nil {
+			return nil, &MalformedVersionError{Table: table, Offset: sh.Sh_offset + offset}
+		}
+
+		need := types.VersionNeed{File: types.GetString(strtab, vn[0].Vn_file)}
+
+		aux := offset + uint64(vn[0].Vn_aux)
+		for range vn[0].Vn_cnt {
+			vna := decodeSlice[types.Elf64_Vernaux](sec, 1, aux, dec.order)
+			if vna == nil {
+				return nil, &MalformedVersionError{Table: table, Offset: sh.Sh_offset + aux}
+			}
+
+			need.Versions = append(need.Versions, types.VersionRequirement{
+				Index: vna[0].Vna_other,
+				Flags: vna[0].Vna_flags,
+				Hash:  vna[0].Vna_hash,
+				Name:  types.GetString(strtab, vna[0].Vna_name),
+			})
+
+			if vna[0].Vna_next == 0 {
+				break
+			}
+			aux += uint64(vna[0].Vna_next)
+		}
+
+		out = append(out, need)
+
+		if vn[0].Vn_next == 0 {
+			break
+		}
+		offset += uint64(vn[0].Vn_next)
+	}
+
+	return out, nil
+}